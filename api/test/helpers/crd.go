@@ -297,3 +297,412 @@ func (th *TestHelper) SimulateOVNControllerReady(name types.NamespacedName) {
 	}, th.Timeout, th.Interval).Should(gomega.Succeed())
 	th.Logger.Info("Simulated GetOVNController ready", "on", name)
 }
+
+// CreateOVNInterconnect creates a new OVNInterconnect instance with the
+// specified namespace in the Kubernetes cluster.
+//
+// Example usage:
+//
+//	ovnInterconnect := th.CreateOVNInterconnect(namespace, spec)
+//	DeferCleanup(th.DeleteOVNInterconnect, ovnInterconnect)
+func (th *TestHelper) CreateOVNInterconnect(namespace string, spec ovnv1.OVNInterconnectSpec) types.NamespacedName {
+	name := "ovninterconnect-" + uuid.New().String()
+	ovninterconnect := &ovnv1.OVNInterconnect{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "ovn.openstack.org/v1beta1",
+			Kind:       "OVNInterconnect",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+
+	gomega.Expect(th.K8sClient.Create(th.Ctx, ovninterconnect)).Should(gomega.Succeed())
+	th.Logger.Info("OVNInterconnect created", "OVNInterconnect", name)
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// DeleteOVNInterconnect deletes a OVNInterconnect resource from the
+// Kubernetes cluster.
+//
+// After the deletion, the function checks again if the OVNInterconnect is
+// successfully deleted.
+//
+// Example usage:
+//
+//	ovnInterconnect := th.CreateOVNInterconnect(namespace, spec)
+//	DeferCleanup(th.DeleteOVNInterconnect, ovnInterconnect)
+func (th *TestHelper) DeleteOVNInterconnect(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		ovninterconnect := &ovnv1.OVNInterconnect{}
+		err := th.K8sClient.Get(th.Ctx, name, ovninterconnect)
+		// if it is already gone that is OK
+		if k8s_errors.IsNotFound(err) {
+			return
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		g.Expect(th.K8sClient.Delete(th.Ctx, ovninterconnect)).Should(gomega.Succeed())
+
+		err = th.K8sClient.Get(th.Ctx, name, ovninterconnect)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+}
+
+// GetOVNInterconnect retrieves a OVNInterconnect resource.
+//
+// The function returns a pointer to the retrieved OVNInterconnect resource.
+//
+// Example usage:
+//
+//	ovnInterconnectName := th.CreateOVNInterconnect(namespace, spec)
+//	ovnInterconnect := th.GetOVNInterconnect(ovnInterconnectName)
+func (th *TestHelper) GetOVNInterconnect(name types.NamespacedName) *ovnv1.OVNInterconnect {
+	instance := &ovnv1.OVNInterconnect{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(th.K8sClient.Get(th.Ctx, name, instance)).Should(gomega.Succeed())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+	return instance
+}
+
+// CreateOVNTrace creates a new OVNTrace instance with the specified
+// namespace in the Kubernetes cluster.
+//
+// Example usage:
+//
+//	ovnTrace := th.CreateOVNTrace(namespace, spec)
+//	DeferCleanup(th.DeleteOVNTrace, ovnTrace)
+func (th *TestHelper) CreateOVNTrace(namespace string, spec ovnv1.OVNTraceSpec) types.NamespacedName {
+	name := "ovntrace-" + uuid.New().String()
+	ovntrace := &ovnv1.OVNTrace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "ovn.openstack.org/v1beta1",
+			Kind:       "OVNTrace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+
+	gomega.Expect(th.K8sClient.Create(th.Ctx, ovntrace)).Should(gomega.Succeed())
+	th.Logger.Info("OVNTrace created", "OVNTrace", name)
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// DeleteOVNTrace deletes a OVNTrace resource from the Kubernetes cluster.
+//
+// After the deletion, the function checks again if the OVNTrace is
+// successfully deleted.
+//
+// Example usage:
+//
+//	ovnTrace := th.CreateOVNTrace(namespace, spec)
+//	DeferCleanup(th.DeleteOVNTrace, ovnTrace)
+func (th *TestHelper) DeleteOVNTrace(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		ovntrace := &ovnv1.OVNTrace{}
+		err := th.K8sClient.Get(th.Ctx, name, ovntrace)
+		// if it is already gone that is OK
+		if k8s_errors.IsNotFound(err) {
+			return
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		g.Expect(th.K8sClient.Delete(th.Ctx, ovntrace)).Should(gomega.Succeed())
+
+		err = th.K8sClient.Get(th.Ctx, name, ovntrace)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+}
+
+// GetOVNTrace retrieves a OVNTrace resource.
+//
+// The function returns a pointer to the retrieved OVNTrace resource.
+//
+// Example usage:
+//
+//	ovnTraceName := th.CreateOVNTrace(namespace, spec)
+//	ovnTrace := th.GetOVNTrace(ovnTraceName)
+func (th *TestHelper) GetOVNTrace(name types.NamespacedName) *ovnv1.OVNTrace {
+	instance := &ovnv1.OVNTrace{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(th.K8sClient.Get(th.Ctx, name, instance)).Should(gomega.Succeed())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+	return instance
+}
+
+// CreateOVNCommand creates a new OVNCommand instance with the specified
+// namespace in the Kubernetes cluster.
+//
+// Example usage:
+//
+//	ovnCommand := th.CreateOVNCommand(namespace, spec)
+//	DeferCleanup(th.DeleteOVNCommand, ovnCommand)
+func (th *TestHelper) CreateOVNCommand(namespace string, spec ovnv1.OVNCommandSpec) types.NamespacedName {
+	name := "ovncommand-" + uuid.New().String()
+	ovncommand := &ovnv1.OVNCommand{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "ovn.openstack.org/v1beta1",
+			Kind:       "OVNCommand",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+
+	gomega.Expect(th.K8sClient.Create(th.Ctx, ovncommand)).Should(gomega.Succeed())
+	th.Logger.Info("OVNCommand created", "OVNCommand", name)
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// DeleteOVNCommand deletes a OVNCommand resource from the Kubernetes cluster.
+//
+// After the deletion, the function checks again if the OVNCommand is
+// successfully deleted.
+//
+// Example usage:
+//
+//	ovnCommand := th.CreateOVNCommand(namespace, spec)
+//	DeferCleanup(th.DeleteOVNCommand, ovnCommand)
+func (th *TestHelper) DeleteOVNCommand(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		ovncommand := &ovnv1.OVNCommand{}
+		err := th.K8sClient.Get(th.Ctx, name, ovncommand)
+		// if it is already gone that is OK
+		if k8s_errors.IsNotFound(err) {
+			return
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		g.Expect(th.K8sClient.Delete(th.Ctx, ovncommand)).Should(gomega.Succeed())
+
+		err = th.K8sClient.Get(th.Ctx, name, ovncommand)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+}
+
+// GetOVNCommand retrieves a OVNCommand resource.
+//
+// The function returns a pointer to the retrieved OVNCommand resource.
+//
+// Example usage:
+//
+//	ovnCommandName := th.CreateOVNCommand(namespace, spec)
+//	ovnCommand := th.GetOVNCommand(ovnCommandName)
+func (th *TestHelper) GetOVNCommand(name types.NamespacedName) *ovnv1.OVNCommand {
+	instance := &ovnv1.OVNCommand{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(th.K8sClient.Get(th.Ctx, name, instance)).Should(gomega.Succeed())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+	return instance
+}
+
+// CreateOVNDiagnostics creates a new OVNDiagnostics instance with the specified
+// namespace in the Kubernetes cluster.
+//
+// Example usage:
+//
+//	ovnDiagnostics := th.CreateOVNDiagnostics(namespace, spec)
+//	DeferCleanup(th.DeleteOVNDiagnostics, ovnDiagnostics)
+func (th *TestHelper) CreateOVNDiagnostics(namespace string, spec ovnv1.OVNDiagnosticsSpec) types.NamespacedName {
+	name := "ovndiagnostics-" + uuid.New().String()
+	ovndiagnostics := &ovnv1.OVNDiagnostics{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "ovn.openstack.org/v1beta1",
+			Kind:       "OVNDiagnostics",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+
+	gomega.Expect(th.K8sClient.Create(th.Ctx, ovndiagnostics)).Should(gomega.Succeed())
+	th.Logger.Info("OVNDiagnostics created", "OVNDiagnostics", name)
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// DeleteOVNDiagnostics deletes a OVNDiagnostics resource from the Kubernetes cluster.
+//
+// After the deletion, the function checks again if the OVNDiagnostics is
+// successfully deleted.
+//
+// Example usage:
+//
+//	ovnDiagnostics := th.CreateOVNDiagnostics(namespace, spec)
+//	DeferCleanup(th.DeleteOVNDiagnostics, ovnDiagnostics)
+func (th *TestHelper) DeleteOVNDiagnostics(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		ovndiagnostics := &ovnv1.OVNDiagnostics{}
+		err := th.K8sClient.Get(th.Ctx, name, ovndiagnostics)
+		// if it is already gone that is OK
+		if k8s_errors.IsNotFound(err) {
+			return
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		g.Expect(th.K8sClient.Delete(th.Ctx, ovndiagnostics)).Should(gomega.Succeed())
+
+		err = th.K8sClient.Get(th.Ctx, name, ovndiagnostics)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+}
+
+// GetOVNDiagnostics retrieves a OVNDiagnostics resource.
+//
+// The function returns a pointer to the retrieved OVNDiagnostics resource.
+//
+// Example usage:
+//
+//	ovnDiagnosticsName := th.CreateOVNDiagnostics(namespace, spec)
+//	ovnDiagnostics := th.GetOVNDiagnostics(ovnDiagnosticsName)
+func (th *TestHelper) GetOVNDiagnostics(name types.NamespacedName) *ovnv1.OVNDiagnostics {
+	instance := &ovnv1.OVNDiagnostics{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(th.K8sClient.Get(th.Ctx, name, instance)).Should(gomega.Succeed())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+	return instance
+}
+
+// CreateOVNCapture creates a new OVNCapture instance with the specified
+// namespace in the Kubernetes cluster.
+//
+// Example usage:
+//
+//	ovnCapture := th.CreateOVNCapture(namespace, spec)
+//	DeferCleanup(th.DeleteOVNCapture, ovnCapture)
+func (th *TestHelper) CreateOVNCapture(namespace string, spec ovnv1.OVNCaptureSpec) types.NamespacedName {
+	name := "ovncapture-" + uuid.New().String()
+	ovncapture := &ovnv1.OVNCapture{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "ovn.openstack.org/v1beta1",
+			Kind:       "OVNCapture",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+
+	gomega.Expect(th.K8sClient.Create(th.Ctx, ovncapture)).Should(gomega.Succeed())
+	th.Logger.Info("OVNCapture created", "OVNCapture", name)
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// DeleteOVNCapture deletes a OVNCapture resource from the Kubernetes cluster.
+//
+// After the deletion, the function checks again if the OVNCapture is
+// successfully deleted.
+//
+// Example usage:
+//
+//	ovnCapture := th.CreateOVNCapture(namespace, spec)
+//	DeferCleanup(th.DeleteOVNCapture, ovnCapture)
+func (th *TestHelper) DeleteOVNCapture(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		ovncapture := &ovnv1.OVNCapture{}
+		err := th.K8sClient.Get(th.Ctx, name, ovncapture)
+		// if it is already gone that is OK
+		if k8s_errors.IsNotFound(err) {
+			return
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		g.Expect(th.K8sClient.Delete(th.Ctx, ovncapture)).Should(gomega.Succeed())
+
+		err = th.K8sClient.Get(th.Ctx, name, ovncapture)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+}
+
+// GetOVNCapture retrieves a OVNCapture resource.
+//
+// The function returns a pointer to the retrieved OVNCapture resource.
+//
+// Example usage:
+//
+//	ovnCaptureName := th.CreateOVNCapture(namespace, spec)
+//	ovnCapture := th.GetOVNCapture(ovnCaptureName)
+func (th *TestHelper) GetOVNCapture(name types.NamespacedName) *ovnv1.OVNCapture {
+	instance := &ovnv1.OVNCapture{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(th.K8sClient.Get(th.Ctx, name, instance)).Should(gomega.Succeed())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+	return instance
+}
+
+// CreateOVNGlobalConfig creates a new OVNGlobalConfig instance with the specified
+// namespace in the Kubernetes cluster.
+//
+// Example usage:
+//
+//	ovnGlobalConfig := th.CreateOVNGlobalConfig(namespace, spec)
+//	DeferCleanup(th.DeleteOVNGlobalConfig, ovnGlobalConfig)
+func (th *TestHelper) CreateOVNGlobalConfig(namespace string, spec ovnv1.OVNGlobalConfigSpec) types.NamespacedName {
+	name := "ovnglobalconfig-" + uuid.New().String()
+	ovnglobalconfig := &ovnv1.OVNGlobalConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "ovn.openstack.org/v1beta1",
+			Kind:       "OVNGlobalConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+
+	gomega.Expect(th.K8sClient.Create(th.Ctx, ovnglobalconfig)).Should(gomega.Succeed())
+	th.Logger.Info("OVNGlobalConfig created", "OVNGlobalConfig", name)
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// DeleteOVNGlobalConfig deletes a OVNGlobalConfig resource from the Kubernetes cluster.
+//
+// After the deletion, the function checks again if the OVNGlobalConfig is
+// successfully deleted.
+//
+// Example usage:
+//
+//	ovnGlobalConfig := th.CreateOVNGlobalConfig(namespace, spec)
+//	DeferCleanup(th.DeleteOVNGlobalConfig, ovnGlobalConfig)
+func (th *TestHelper) DeleteOVNGlobalConfig(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		ovnglobalconfig := &ovnv1.OVNGlobalConfig{}
+		err := th.K8sClient.Get(th.Ctx, name, ovnglobalconfig)
+		// if it is already gone that is OK
+		if k8s_errors.IsNotFound(err) {
+			return
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		g.Expect(th.K8sClient.Delete(th.Ctx, ovnglobalconfig)).Should(gomega.Succeed())
+
+		err = th.K8sClient.Get(th.Ctx, name, ovnglobalconfig)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+}
+
+// GetOVNGlobalConfig retrieves a OVNGlobalConfig resource.
+//
+// The function returns a pointer to the retrieved OVNGlobalConfig resource.
+//
+// Example usage:
+//
+//	ovnGlobalConfigName := th.CreateOVNGlobalConfig(namespace, spec)
+//	ovnGlobalConfig := th.GetOVNGlobalConfig(ovnGlobalConfigName)
+func (th *TestHelper) GetOVNGlobalConfig(name types.NamespacedName) *ovnv1.OVNGlobalConfig {
+	instance := &ovnv1.OVNGlobalConfig{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(th.K8sClient.Get(th.Ctx, name, instance)).Should(gomega.Succeed())
+	}, th.Timeout, th.Interval).Should(gomega.Succeed())
+	return instance
+}
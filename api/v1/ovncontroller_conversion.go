@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &OVNController{}
+
+// ConvertTo converts this OVNController to the v1beta1 hub version
+func (src *OVNController) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.OVNController)
+	dst.ObjectMeta = src.ObjectMeta
+	src.Status.DeepCopyInto(&dst.Status)
+
+	spec := src.DeepCopy().Spec
+	dst.Spec = v1beta1.OVNControllerSpec{
+		OvsContainerImage:       spec.ContainerImages.Ovs,
+		OvnContainerImage:       spec.ContainerImages.Ovn,
+		ExporterContainerImage:  spec.ContainerImages.Exporter,
+		RbacProxyContainerImage: spec.ContainerImages.RbacProxy,
+		BGPAgentContainerImage:  spec.ContainerImages.BGPAgent,
+		ToolboxContainerImage:   spec.ContainerImages.Toolbox,
+		Suspend:                 spec.Suspend,
+		DriftPolicy:             spec.DriftPolicy,
+		OVNControllerSpecCore: v1beta1.OVNControllerSpecCore{
+			ExternalIDS:              spec.ExternalIDs,
+			NicMappings:              spec.NicMappings,
+			NodeSelector:             spec.NodeSelector,
+			NodeCapabilities:         spec.NodeCapabilities,
+			Architectures:            spec.Architectures,
+			Zones:                    spec.Zones,
+			NetworkAttachment:        spec.NetworkAttachment,
+			TLS:                      spec.TLS,
+			FIPS:                     spec.FIPS,
+			Metrics:                  spec.Metrics,
+			ChassisStatusInterval:    spec.ChassisStatusInterval,
+			Telemetry:                spec.Telemetry,
+			Canary:                   spec.Canary,
+			AutoRollback:             spec.AutoRollback,
+			GatewayDrain:             spec.GatewayDrain,
+			GatewayDrainTimeout:      spec.GatewayDrainTimeout,
+			ExternalChassis:          spec.ExternalChassis,
+			BGP:                      spec.BGP,
+			OVNKubernetesCoexistence: spec.OVNKubernetesCoexistence,
+			TunnelMTU:                spec.TunnelMTU,
+			TunnelMTUCheck:           spec.TunnelMTUCheck,
+			Toolbox:                  spec.Toolbox,
+			MemoryWatchdog:           spec.MemoryWatchdog,
+			SBRemote:                 spec.SBRemote,
+			Sidecars:                 spec.Sidecars,
+			ExtraArgs:                spec.ExtraArgs,
+			PodNamespaces:            spec.PodNamespaces,
+			TuningProfile:            spec.TuningProfile,
+			ConfigAgent:              spec.ConfigAgent,
+			ControlPlane:             spec.ControlPlane,
+			ServiceAccount:           spec.ServiceAccount,
+		},
+	}
+
+	// containers sharing the same resources keep the v1beta1 layout
+	resources := spec.Resources
+	if equality.Semantic.DeepEqual(resources.OvnController, resources.OvsdbServer) &&
+		equality.Semantic.DeepEqual(resources.OvnController, resources.OvsVswitchd) {
+		dst.Spec.Resources = resources.OvnController
+	} else {
+		dst.Spec.ContainerResources = v1beta1.OVNControllerContainerResources{
+			OvnController: &resources.OvnController,
+			OvsdbServer:   &resources.OvsdbServer,
+			OvsVswitchd:   &resources.OvsVswitchd,
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version to this OVNController
+func (dst *OVNController) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.OVNController).DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	spec := src.Spec
+	dst.Spec = OVNControllerSpec{
+		ContainerImages: OVNControllerContainerImages{
+			Ovs:       spec.OvsContainerImage,
+			Ovn:       spec.OvnContainerImage,
+			Exporter:  spec.ExporterContainerImage,
+			RbacProxy: spec.RbacProxyContainerImage,
+			BGPAgent:  spec.BGPAgentContainerImage,
+			Toolbox:   spec.ToolboxContainerImage,
+		},
+		Suspend:     spec.Suspend,
+		DriftPolicy: spec.DriftPolicy,
+		ExternalIDs: spec.ExternalIDS,
+		NicMappings: spec.NicMappings,
+		Resources: OVNControllerResources{
+			OvnController: spec.OvnControllerResources(),
+			OvsdbServer:   spec.OvsdbServerResources(),
+			OvsVswitchd:   spec.OvsVswitchdResources(),
+		},
+		NodeSelector:             spec.NodeSelector,
+		NodeCapabilities:         spec.NodeCapabilities,
+		Architectures:            spec.Architectures,
+		Zones:                    spec.Zones,
+		NetworkAttachment:        spec.NetworkAttachment,
+		TLS:                      spec.TLS,
+		FIPS:                     spec.FIPS,
+		Metrics:                  spec.Metrics,
+		ChassisStatusInterval:    spec.ChassisStatusInterval,
+		Telemetry:                spec.Telemetry,
+		Canary:                   spec.Canary,
+		AutoRollback:             spec.AutoRollback,
+		GatewayDrain:             spec.GatewayDrain,
+		GatewayDrainTimeout:      spec.GatewayDrainTimeout,
+		ExternalChassis:          spec.ExternalChassis,
+		BGP:                      spec.BGP,
+		OVNKubernetesCoexistence: spec.OVNKubernetesCoexistence,
+		TunnelMTU:                spec.TunnelMTU,
+		TunnelMTUCheck:           spec.TunnelMTUCheck,
+		Toolbox:                  spec.Toolbox,
+		MemoryWatchdog:           spec.MemoryWatchdog,
+		SBRemote:                 spec.SBRemote,
+		Sidecars:                 spec.Sidecars,
+		ExtraArgs:                spec.ExtraArgs,
+		PodNamespaces:            spec.PodNamespaces,
+		TuningProfile:            spec.TuningProfile,
+		ConfigAgent:              spec.ConfigAgent,
+		ControlPlane:             spec.ControlPlane,
+		ServiceAccount:           spec.ServiceAccount,
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OVNControllerSpec defines the desired state of OVNController
+type OVNControllerSpec struct {
+	// +kubebuilder:validation:Optional
+	// ContainerImages - images of the containers (will be set to environmental
+	// defaults if empty)
+	ContainerImages OVNControllerContainerImages `json:"containerImages,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Suspend - stop modifying the resources owned by the instance, so manual
+	// interventions are not reverted. The status is still updated.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// DriftPolicy - Enforce reverts the manual changes of the fields the
+	// operator sets on the workloads. Report keeps a modified workload as is,
+	// e.g. an incident hotfix, and lists the modified fields in the Drifted
+	// condition, the spec changes are not rolled out to it either until the
+	// changes are reverted or the policy is Enforce again.
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// ExternalIDs - OVS external-ids of the chassis
+	ExternalIDs v1beta1.OVSExternalIDs `json:"externalIDs"`
+
+	// +kubebuilder:validation:Optional
+	// NicMappings - NICs attached to the provider bridge of each physical
+	// network
+	NicMappings map[string]string `json:"nicMappings,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Resources - Compute Resources required by each container (Limits/Requests).
+	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	Resources OVNControllerResources `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector to target subset of worker nodes running this service
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=dpdk;hw-offload;kernel-datapath
+	// NodeCapabilities - run only on the nodes labeled by Node Feature
+	// Discovery with these capabilities, in addition to the NodeSelector
+	NodeCapabilities []string `json:"nodeCapabilities,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=amd64;arm64;ppc64le;s390x
+	// Architectures - the CPU architectures the ovn-controller and OVS
+	// images are built for. The pods only run on the nodes of these
+	// architectures, the other selected nodes are listed in the status
+	// instead of crash looping. Any architecture by default.
+	Architectures []string `json:"architectures,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=40
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Zones - the availability zones, i.e. the topology.kubernetes.io/zone
+	// label of the nodes, to run an ovn-controller and an OVS DaemonSet per
+	// zone in, named after it, e.g. ovn-controller-zone-a. Only the nodes of
+	// these zones run them then. The SB DB members aren't zoned.
+	Zones []string `json:"zones,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NetworkAttachment is a NetworkAttachment resource name to expose the service to the given network.
+	// If specified the IP address of this network is used as the OVNEncapIP.
+	NetworkAttachment string `json:"networkAttachment,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TLS - Parameters related to TLS
+	TLS v1beta1.TLSSection `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// FIPS - restrict the OVN connections to FIPS approved TLS protocols and
+	// ciphers, and report in the FIPSProviderReady condition whether the
+	// OpenSSL FIPS provider is active in the containers. Requires TLS.
+	FIPS bool `json:"fips,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Metrics - export ovn-controller and OVS metrics for Prometheus
+	Metrics v1beta1.OVNControllerMetrics `json:"metrics,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	// ChassisStatusInterval - how often (in seconds) the chassis registered in the SB DB are listed in the status, 0 disables it.
+	// The chassis of the nodes not selected by any OVNController anymore are removed from the SB DB with every refresh
+	ChassisStatusInterval int32 `json:"chassisStatusInterval"`
+
+	// +kubebuilder:validation:Optional
+	// Telemetry - collect the coverage counters and the memory usage of the
+	// ovn-controller pods every ChassisStatusInterval, and serve them as the
+	// ovn_coverage_events and ovn_memory_usage metrics of the operator. This
+	// runs ovn-appctl in every ovn-controller pod.
+	Telemetry bool `json:"telemetry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Canary - roll a new ovn-controller image out to the canary nodes first.
+	// The remaining nodes are only updated once ovn-controller is ready on
+	// all the canary nodes and their chassis processed the latest SB DB
+	// changes.
+	Canary *v1beta1.OVNControllerCanary `json:"canary,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AutoRollback - revert a DaemonSet to its previous pod template when the
+	// updated pods fail readiness on too many nodes. The failed pod template
+	// is not applied again until the spec changes.
+	AutoRollback *v1beta1.OVNControllerAutoRollback `json:"autoRollback,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// GatewayDrain - move the gateway ports off a node before its
+	// ovn-controller stops. The priority of its chassis in the Gateway_Chassis
+	// and HA_Chassis rows of the NB DB is lowered, and restored once
+	// ovn-controller starts again on the node.
+	GatewayDrain bool `json:"gatewayDrain,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// GatewayDrainTimeout - how long (in seconds) a stopping ovn-controller
+	// waits for the gateway ports to be bound to other chassis
+	GatewayDrainTimeout int32 `json:"gatewayDrainTimeout"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// ExternalChassis - nodes outside of the cluster joining the OVN fabric,
+	// e.g. the EDPM computes of the dataplane-operator. The ovn-controller
+	// configuration of each is published in the <name>-<chassis>-config
+	// ConfigMap, along with the secret of its client cert, requested from
+	// tls.issuer when set.
+	ExternalChassis []v1beta1.OVNExternalChassis `json:"externalChassis,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// BGP - run ovn-bgp-agent next to ovn-controller, advertising the
+	// routes to the provider and tenant IPs bound to the nodes of the
+	// OVNController through their FRR
+	BGP *v1beta1.OVNControllerBGP `json:"bgp,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OVNKubernetesCoexistence - the nodes also run the ovn-kubernetes CNI,
+	// which owns br-int, br-ex and the default Geneve port. The OVNController
+	// is then required to use another integration bridge and Geneve port.
+	OVNKubernetesCoexistence bool `json:"ovnKubernetesCoexistence,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1280
+	// +kubebuilder:validation:Maximum=9216
+	// TunnelMTU - MTU of the network of the tunnels, set on the NIC of the
+	// NetworkAttachment. The integration bridge gets the MTU left to the
+	// tenant networks by the encapsulation overhead. Left as is when unset.
+	TunnelMTU int32 `json:"tunnelMTU,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TunnelMTUCheck - ping each chassis from the one of the previous node
+	// with packets of the TunnelMTU which can't be fragmented, the nodes
+	// whose packets are dropped are reported in the TunnelMTUReady condition
+	TunnelMTUCheck bool `json:"tunnelMTUCheck,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Toolbox - run a debug container with the OVN and OVS utilities,
+	// tcpdump and conntrack on the nodes, sharing the sockets of OVS and
+	// ovn-controller. Removed when unset.
+	Toolbox *v1beta1.OVNControllerToolbox `json:"toolbox,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MemoryWatchdog - restart ovn-controller when its RSS exceeds a
+	// threshold, with exit --restart so that the flows of the node are kept
+	// until it has recomputed them
+	MemoryWatchdog *v1beta1.OVNControllerMemoryWatchdog `json:"memoryWatchdog,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// SBRemote - preference of the nodes for the SB DB members in their
+	// ovn-remote, all the members are equally preferred when unset
+	SBRemote *v1beta1.OVNControllerSBRemote `json:"sbRemote,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Sidecars - additional containers in the ovn-controller pods, e.g. log
+	// shippers or monitoring agents
+	Sidecars v1beta1.SidecarSection `json:"sidecars,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - options appended to the command lines of ovn-controller and
+	// ovs-vswitchd, for the ones the API doesn't model
+	ExtraArgs v1beta1.OVNControllerExtraArgs `json:"extraArgs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodNamespaces - namespaces of the node shared with the ovn-controller
+	// and OVS pods, e.g. the PIDs for perf, and process namespace shared by
+	// their containers. All off by default.
+	PodNamespaces v1beta1.OVNControllerPodNamespaces `json:"podNamespaces,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=small;medium;large;custom;auto
+	// TuningProfile - small, medium or large set the SB DB probe interval,
+	// ovn-monitor-all and the logical flow cache memory limit of the preset
+	// for the size of the cluster in the external-ids of the chassis, auto
+	// chooses one of them for the number of chassis of the namespace, custom
+	// or unset leaves them to the OVN defaults
+	TuningProfile string `json:"tuningProfile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ConfigAgent - run a config-agent container in the OVS pods applying
+	// the external-ids of the chassis of its node live from the node config
+	// ConfigMap rendered by the operator, instead of the config Jobs
+	ConfigAgent bool `json:"configAgent,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ControlPlane - run ovn-controller and OVS on the control plane nodes
+	// of compact deployments too: the pods tolerate the control plane taints,
+	// the liveness probes of OVS and the SB DB probe interval allow for busy
+	// nodes
+	ControlPlane bool `json:"controlPlane,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ServiceAccount - name of an existing ServiceAccount of the namespace the
+	// pods run with, e.g. one the security team binds to the SCCs. It needs
+	// the privileged SCC and to manage the pods of the namespace, the
+	// operator then creates no ServiceAccount, Role and RoleBinding. Created
+	// by the operator by default.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// OVNControllerContainerImages defines the images of the ovn-controller and
+// OVS pods
+type OVNControllerContainerImages struct {
+	// +kubebuilder:validation:Optional
+	// Ovs - image used for the ovsdb-server and ovs-vswitchd containers
+	Ovs string `json:"ovs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Ovn - image used for the ovn-controller container
+	Ovn string `json:"ovn,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Exporter - image used for the metrics exporter containers
+	Exporter string `json:"exporter,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RbacProxy - image used for the kube-rbac-proxy containers in front of
+	// the metrics exporters
+	RbacProxy string `json:"rbacProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// BGPAgent - image used for the ovn-bgp-agent containers
+	BGPAgent string `json:"bgpAgent,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Toolbox - image used for the debug toolbox containers
+	Toolbox string `json:"toolbox,omitempty"`
+}
+
+// OVNControllerResources defines the Compute Resources of the ovn-controller
+// and OVS containers
+type OVNControllerResources struct {
+	// +kubebuilder:validation:Optional
+	// OvnController - Compute Resources of the ovn-controller container
+	OvnController corev1.ResourceRequirements `json:"ovnController,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OvsdbServer - Compute Resources of the ovsdb-server container
+	OvsdbServer corev1.ResourceRequirements `json:"ovsdbServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OvsVswitchd - Compute Resources of the ovs-vswitchd container
+	OvsVswitchd corev1.ResourceRequirements `json:"ovsVswitchd,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="NetworkAttachments",type="string",JSONPath=".status.networkAttachments",description="NetworkAttachments"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNController is the Schema for the ovncontrollers API
+type OVNController struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNControllerSpec           `json:"spec,omitempty"`
+	Status v1beta1.OVNControllerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNControllerList contains a list of OVNController
+type OVNControllerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNController `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNController{}, &OVNControllerList{})
+}
@@ -0,0 +1,105 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &OVNDBCluster{}
+
+// ConvertTo converts this OVNDBCluster to the v1beta1 hub version
+func (src *OVNDBCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.OVNDBCluster)
+	dst.ObjectMeta = src.ObjectMeta
+	src.Status.DeepCopyInto(&dst.Status)
+
+	spec := src.DeepCopy().Spec
+	dst.Spec = v1beta1.OVNDBClusterSpec{
+		ContainerImage: spec.ContainerImage,
+		Suspend:        spec.Suspend,
+		DriftPolicy:    spec.DriftPolicy,
+		OVNDBClusterSpecCore: v1beta1.OVNDBClusterSpecCore{
+			DBType:                   spec.DBType,
+			Replicas:                 spec.Replicas,
+			NodeSelector:             spec.NodeSelector,
+			LogLevel:                 spec.Logging.Level,
+			LogModules:               spec.Logging.Modules,
+			LogFile:                  spec.Logging.File,
+			TuningProfile:            spec.TuningProfile,
+			ElectionTimer:            spec.ElectionTimer,
+			InactivityProbe:          spec.InactivityProbe,
+			ProbeIntervalToActive:    spec.ProbeIntervalToActive,
+			ClusterStatusInterval:    spec.ClusterStatusInterval,
+			ConsistencyCheckInterval: spec.ConsistencyCheckInterval,
+			Resources:                spec.Resources,
+			StorageClass:             spec.Storage.Class,
+			StorageRequest:           spec.Storage.Request,
+			StorageRetention:         spec.Storage.Retention,
+			NetworkAttachment:        spec.NetworkAttachment,
+			TLS:                      spec.TLS,
+			FIPS:                     spec.FIPS,
+			NetworkPolicy:            spec.NetworkPolicy,
+			Alerts:                   spec.Alerts,
+			OctaviaProvider:          spec.OctaviaProvider,
+			Override:                 spec.Override,
+			ExtraArgs:                spec.ExtraArgs,
+		},
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version to this OVNDBCluster
+func (dst *OVNDBCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.OVNDBCluster).DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	spec := src.Spec
+	dst.Spec = OVNDBClusterSpec{
+		ContainerImage: spec.ContainerImage,
+		Suspend:        spec.Suspend,
+		DriftPolicy:    spec.DriftPolicy,
+		DBType:         spec.DBType,
+		Replicas:       spec.Replicas,
+		NodeSelector:   spec.NodeSelector,
+		Logging: OVNDBClusterLogging{
+			Level:   spec.LogLevel,
+			Modules: spec.LogModules,
+			File:    spec.LogFile,
+		},
+		TuningProfile:            spec.TuningProfile,
+		ElectionTimer:            spec.ElectionTimer,
+		InactivityProbe:          spec.InactivityProbe,
+		ProbeIntervalToActive:    spec.ProbeIntervalToActive,
+		ClusterStatusInterval:    spec.ClusterStatusInterval,
+		ConsistencyCheckInterval: spec.ConsistencyCheckInterval,
+		Resources:                spec.Resources,
+		Storage: OVNDBClusterStorage{
+			Class:     spec.StorageClass,
+			Request:   spec.StorageRequest,
+			Retention: spec.StorageRetention,
+		},
+		NetworkAttachment: spec.NetworkAttachment,
+		TLS:               spec.TLS,
+		FIPS:              spec.FIPS,
+		NetworkPolicy:     spec.NetworkPolicy,
+		Alerts:            spec.Alerts,
+		OctaviaProvider:   spec.OctaviaProvider,
+		Override:          spec.Override,
+		ExtraArgs:         spec.ExtraArgs,
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OVNDBClusterSpec defines the desired state of OVNDBCluster
+type OVNDBClusterSpec struct {
+	// +kubebuilder:validation:Optional
+	// ContainerImage - Container Image URL (will be set to environmental default if empty)
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Suspend - stop modifying the resources owned by the instance, so manual
+	// interventions are not reverted. The status is still updated.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// DriftPolicy - Enforce reverts the manual changes of the fields the
+	// operator sets on the workloads. Report keeps a modified workload as is,
+	// e.g. an incident hotfix, and lists the modified fields in the Drifted
+	// condition, the spec changes are not rolled out to it either until the
+	// changes are reverted or the policy is Enforce again.
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default="NB"
+	// +kubebuilder:validation:Pattern="^(NB|SB|ICNB|ICSB)$"
+	// DBType - NB or SB, ICNB or ICSB for the OVN Interconnect databases
+	DBType string `json:"dbType"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Maximum=32
+	// +kubebuilder:validation:Minimum=0
+	// Replicas of OVN DBCluster to run
+	Replicas *int32 `json:"replicas"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector to target subset of worker nodes running this service
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Logging - log levels and log file of ovsdb-server
+	Logging OVNDBClusterLogging `json:"logging,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=small;medium;large;custom;auto
+	// TuningProfile - small, medium or large apply the election timer,
+	// inactivity probe and probe interval to active of the preset for the
+	// size of the cluster instead of the fields of the spec, auto chooses one
+	// of them for the number of chassis of the namespace, custom or unset
+	// uses the fields of the spec
+	TuningProfile string `json:"tuningProfile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10000
+	// OVN Northbound and Southbound RAFT db election timer to use on db creation (in milliseconds)
+	ElectionTimer int32 `json:"electionTimer"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60000
+	// Probe interval for the OVSDB session (in milliseconds)
+	InactivityProbe int32 `json:"inactivityProbe"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60000
+	// Active probe interval from standby to active ovsdb-server remote
+	ProbeIntervalToActive int32 `json:"probeIntervalToActive"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	// ClusterStatusInterval - how often (in seconds) the RAFT cluster status and the member metrics are refreshed, 0 disables them
+	ClusterStatusInterval int32 `json:"clusterStatusInterval"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// ConsistencyCheckInterval - how often (in seconds) the database files of
+	// the members are checked with ovsdb-tool check-cluster and, for a SB DB,
+	// its datapath bindings and logical flows are cross-checked against the
+	// NB DB. 0 disables the checks.
+	ConsistencyCheckInterval int32 `json:"consistencyCheckInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Resources - Compute Resources required by this service (Limits/Requests).
+	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// Storage - the PVCs holding the database
+	Storage OVNDBClusterStorage `json:"storage"`
+
+	// +kubebuilder:validation:Optional
+	// NetworkAttachment is a NetworkAttachment resource name to expose the service to the given network.
+	// If specified the IP address of this network is used as the dbAddress connection.
+	NetworkAttachment string `json:"networkAttachment,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TLS - Parameters related to TLS
+	TLS v1beta1.TLSSection `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// FIPS - restrict the OVN connections to FIPS approved TLS protocols and
+	// ciphers, and report in the FIPSProviderReady condition whether the
+	// OpenSSL FIPS provider is active in the containers. Requires TLS.
+	FIPS bool `json:"fips,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NetworkPolicy - restrict which clients can reach the database ports
+	NetworkPolicy v1beta1.OVNDBClusterNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Alerts - alert when the RAFT cluster has no leader or the database
+	// keeps growing. Requires a ClusterStatusInterval, the alerts are based
+	// on the member metrics.
+	Alerts v1beta1.AlertsSection `json:"alerts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OctaviaProvider - publish the DB address and a client cert dedicated to
+	// the Octavia OVN provider driver in the ovn-octavia-provider Secret. NB
+	// only, the client cert is requested from tls.issuer when TLS is enabled.
+	OctaviaProvider bool `json:"octaviaProvider,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Override - overrides of the resources generated for the DB
+	Override v1beta1.OVNDBClusterOverrideSpec `json:"override,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - options appended to the command line of ovsdb-server, for
+	// the ones the API doesn't model, by option name, e.g. --option or
+	// -vmodule:level, with an empty value for the options without value
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+}
+
+// OVNDBClusterLogging defines the logging of ovsdb-server
+type OVNDBClusterLogging struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=info
+	// Level - Set log level info, dbg, emer etc
+	Level string `json:"level,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Modules - additional per module log levels passed to ovsdb-server as -v<module>:<level>, e.g. jsonrpc:dbg
+	Modules []string `json:"modules,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// File - write the ovsdb-server log to a rotated file in addition to the console
+	File v1beta1.OVNDBClusterLogFile `json:"file,omitempty"`
+}
+
+// OVNDBClusterStorage defines the PVCs holding the database
+type OVNDBClusterStorage struct {
+	// +kubebuilder:validation:Optional
+	// Class - StorageClass of the PVCs
+	Class string `json:"class,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// Request - size of the PVCs
+	Request string `json:"request"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Delete
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// Retention - Delete or Retain the PVCs when the OVNDBCluster is deleted
+	Retention string `json:"retention,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="NetworkAttachments",type="string",JSONPath=".status.networkAttachments",description="NetworkAttachments"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNDBCluster is the Schema for the ovndbclusters API
+type OVNDBCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNDBClusterSpec           `json:"spec,omitempty"`
+	Status v1beta1.OVNDBClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNDBClusterList contains a list of OVNDBCluster
+type OVNDBClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNDBCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNDBCluster{}, &OVNDBClusterList{})
+}
@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &OVNNorthd{}
+
+// ConvertTo converts this OVNNorthd to the v1beta1 hub version
+func (src *OVNNorthd) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.OVNNorthd)
+	dst.ObjectMeta = src.ObjectMeta
+	src.Status.DeepCopyInto(&dst.Status)
+
+	spec := src.DeepCopy().Spec
+	dst.Spec = v1beta1.OVNNorthdSpec{
+		ContainerImage: spec.ContainerImage,
+		Suspend:        spec.Suspend,
+		DriftPolicy:    spec.DriftPolicy,
+		OVNNorthdSpecCore: v1beta1.OVNNorthdSpecCore{
+			Replicas:        spec.Replicas,
+			MinAvailable:    spec.MinAvailable,
+			NodeSelector:    spec.NodeSelector,
+			LogLevel:        spec.Logging.Level,
+			LogModules:      spec.Logging.Modules,
+			DryRun:          spec.DryRun,
+			Resources:       spec.Resources,
+			TLS:             spec.TLS,
+			FIPS:            spec.FIPS,
+			NThreads:        spec.NThreads,
+			TuningProfile:   spec.TuningProfile,
+			ProbeInterval:   spec.ProbeInterval,
+			BackoffInterval: spec.BackoffInterval,
+			DataRetention:   spec.DataRetention,
+			Paused:          spec.Paused,
+			Alerts:          spec.Alerts,
+			Telemetry:       spec.Telemetry,
+			Sidecars:        spec.Sidecars,
+			ExtraArgs:       spec.ExtraArgs,
+		},
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version to this OVNNorthd
+func (dst *OVNNorthd) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.OVNNorthd).DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	spec := src.Spec
+	dst.Spec = OVNNorthdSpec{
+		ContainerImage: spec.ContainerImage,
+		Suspend:        spec.Suspend,
+		DriftPolicy:    spec.DriftPolicy,
+		Replicas:       spec.Replicas,
+		MinAvailable:   spec.MinAvailable,
+		NodeSelector:   spec.NodeSelector,
+		Logging: OVNNorthdLogging{
+			Level:   spec.LogLevel,
+			Modules: spec.LogModules,
+		},
+		DryRun:          spec.DryRun,
+		Resources:       spec.Resources,
+		TLS:             spec.TLS,
+		FIPS:            spec.FIPS,
+		NThreads:        spec.NThreads,
+		TuningProfile:   spec.TuningProfile,
+		ProbeInterval:   spec.ProbeInterval,
+		BackoffInterval: spec.BackoffInterval,
+		DataRetention:   spec.DataRetention,
+		Paused:          spec.Paused,
+		Alerts:          spec.Alerts,
+		Telemetry:       spec.Telemetry,
+		Sidecars:        spec.Sidecars,
+		ExtraArgs:       spec.ExtraArgs,
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OVNNorthdSpec defines the desired state of OVNNorthd
+type OVNNorthdSpec struct {
+	// +kubebuilder:validation:Optional
+	// ContainerImage - Container Image URL (will be set to environmental default if empty)
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Suspend - stop modifying the resources owned by the instance, so manual
+	// interventions are not reverted. The status is still updated.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// DriftPolicy - Enforce reverts the manual changes of the fields the
+	// operator sets on the workloads. Report keeps a modified workload as is,
+	// e.g. an incident hotfix, and lists the modified fields in the Drifted
+	// condition, the spec changes are not rolled out to it either until the
+	// changes are reverted or the policy is Enforce again.
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Maximum=32
+	// +kubebuilder:validation:Minimum=0
+	// Replicas of OVN Northd to run
+	Replicas *int32 `json:"replicas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MinAvailable - minimum number of ovn-northd replicas kept running during
+	// voluntary disruptions, defaults to 1. A PodDisruptionBudget is only
+	// created when running more replicas than that, 0 disables it.
+	MinAvailable *int32 `json:"minAvailable,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector to target subset of worker nodes running this service
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Logging - log levels of ovn-northd
+	Logging OVNNorthdLogging `json:"logging,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DryRun - start ovn-northd with --dry-run, it monitors the databases but does not apply any change to them
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Resources - Compute Resources required by this service (Limits/Requests).
+	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TLS - Parameters related to TLS
+	TLS v1beta1.TLSSection `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// FIPS - restrict the OVN connections to FIPS approved TLS protocols and
+	// ciphers, and report in the FIPSProviderReady condition whether the
+	// OpenSSL FIPS provider is active in the containers. Requires TLS.
+	FIPS bool `json:"fips,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
+	// NThreads sets number of threads used for building logical flows
+	NThreads *int32 `json:"nThreads"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=small;medium;large;custom;auto
+	// TuningProfile - small, medium or large apply the probe interval of the
+	// preset for the size of the cluster instead of probeInterval, auto
+	// chooses one of them for the number of chassis of the namespace, custom
+	// or unset uses probeInterval
+	TuningProfile string `json:"tuningProfile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// ProbeInterval - interval in milliseconds of the inactivity probes sent by
+	// ovn-northd on its NB and SB database connections, stored in NB_Global
+	// options:northd_probe_interval. 0 disables the probes, ovn-northd uses
+	// 5000 when unset. Raise it when large SB databases cause reconnects.
+	ProbeInterval *int32 `json:"probeInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// BackoffInterval - minimum interval in milliseconds between two ovn-northd
+	// recomputations, batching the SB writes of bursts of NB changes. Stored in
+	// NB_Global options:northd-backoff-interval-ms, unset keeps the default.
+	BackoffInterval *int32 `json:"backoffInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DataRetention - aging of the learnt SB DB records, stored in the
+	// NB_Global options, so the MAC_Binding and FDB tables don't grow without
+	// bounds
+	DataRetention v1beta1.OVNNorthdDataRetention `json:"dataRetention,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Paused - pause ovn-northd so the SB database is not recomputed, e.g.
+	// during bulk NB changes or DB maintenance
+	Paused bool `json:"paused,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Alerts - alert when no ovn-northd replica is active while ovn-northd
+	// is not paused
+	Alerts v1beta1.AlertsSection `json:"alerts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Telemetry - collect the coverage counters and the memory usage of the
+	// replicas with every replica status refresh, and serve them as the
+	// ovn_coverage_events and ovn_memory_usage metrics of the operator
+	Telemetry bool `json:"telemetry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Sidecars - additional containers in the ovn-northd pods, e.g. log
+	// shippers or monitoring agents
+	Sidecars v1beta1.SidecarSection `json:"sidecars,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - options appended to the command line of ovn-northd, for the
+	// ones the API doesn't model, by option name, e.g. --option or
+	// -vmodule:level, with an empty value for the options without value
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+}
+
+// OVNNorthdLogging defines the logging of ovn-northd
+type OVNNorthdLogging struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=info
+	// Level - Set log level info, dbg, emer etc
+	Level string `json:"level,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Modules - additional per module log levels passed to ovn-northd as -v<module>:<level>, e.g. northd:dbg
+	Modules []string `json:"modules,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.activeInstance",description="Active"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNNorthd is the Schema for the ovnnorthds API
+type OVNNorthd struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNNorthdSpec           `json:"spec,omitempty"`
+	Status v1beta1.OVNNorthdStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNNorthdList contains a list of OVNNorthd
+type OVNNorthdList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNNorthd `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNNorthd{}, &OVNNorthdList{})
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// AlertsSection defines the default alerting rules of an OVN service. The
+// rules are evaluated from the metrics the operator publishes, they are only
+// created when the prometheus-operator CRDs are installed.
+type AlertsSection struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - create a PrometheusRule with the default alerts of the service
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// Labels - labels added to the PrometheusRule, to match the ruleSelector
+	// of Prometheus
+	Labels map[string]string `json:"labels,omitempty"`
+}
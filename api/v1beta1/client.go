@@ -22,6 +22,7 @@ import (
 	"reflect"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -52,7 +53,8 @@ func getDBClusters(
 	return ovnDBList, nil
 }
 
-// GetDBClusterByType - return OVNDBCluster for the given dbType
+// GetDBClusterByType - return OVNDBCluster for the given dbType, a NotFound
+// error when none is deployed
 func GetDBClusterByType(
 	ctx context.Context,
 	h *helper.Helper,
@@ -69,7 +71,7 @@ func GetDBClusterByType(
 			return &ovndb, nil
 		}
 	}
-	return nil, fmt.Errorf("failed to find DBCluster of type %s", dbType)
+	return nil, apierrors.NewNotFound(GroupVersion.WithResource("ovndbclusters").GroupResource(), dbType)
 }
 
 func getItems(list client.ObjectList) []client.Object {
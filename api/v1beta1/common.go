@@ -18,6 +18,16 @@ package v1beta1
 
 import "github.com/openstack-k8s-operators/lib-common/modules/common/util"
 
+const (
+	// DriftPolicyEnforce - the manual changes of the fields the operator sets
+	// on the workloads are reverted
+	DriftPolicyEnforce = "Enforce"
+	// DriftPolicyReport - the workloads with manual changes of the fields the
+	// operator sets are not modified, the changes are reported in the
+	// Drifted condition
+	DriftPolicyReport = "Report"
+)
+
 // SetupDefaults - initializes any CRD field defaults based on environment variables (the defaulting mechanism itself is implemented via webhooks)
 func SetupDefaults() {
 	// Acquire environmental defaults and initialize OVNDBCluster defaults with them
@@ -35,10 +45,21 @@ func SetupDefaults() {
 
 	SetupOVNNorthdDefaults(ovnNorthdDefaults)
 
+	// Acquire environmental defaults and initialize OVNInterconnect defaults with them
+	ovnInterconnectDefaults := OVNInterconnectDefaults{
+		ContainerImageURL: util.GetEnvVar("RELATED_IMAGE_OVN_IC_IMAGE_URL_DEFAULT", OVNInterconnectContainerImage),
+	}
+
+	SetupOVNInterconnectDefaults(ovnInterconnectDefaults)
+
 	// Acquire environmental defaults and initialize OVNController defaults with them
 	ovnControllerDefaults := OVNControllerDefaults{
 		OVSContainerImageURL:           util.GetEnvVar("RELATED_IMAGE_OVN_CONTROLLER_OVS_IMAGE_URL_DEFAULT", OVNControllerOVSContainerImage),
 		OVNControllerContainerImageURL: util.GetEnvVar("RELATED_IMAGE_OVN_CONTROLLER_IMAGE_URL_DEFAULT", OVNControllerContainerImage),
+		ExporterContainerImageURL:      util.GetEnvVar("RELATED_IMAGE_OVN_CONTROLLER_EXPORTER_IMAGE_URL_DEFAULT", OVNControllerExporterContainerImage),
+		KubeRbacProxyContainerImageURL: util.GetEnvVar("RELATED_IMAGE_KUBE_RBAC_PROXY_IMAGE_URL_DEFAULT", KubeRbacProxyContainerImage),
+		BGPAgentContainerImageURL:      util.GetEnvVar("RELATED_IMAGE_OVN_BGP_AGENT_IMAGE_URL_DEFAULT", OVNControllerBGPAgentContainerImage),
+		ToolboxContainerImageURL:       util.GetEnvVar("RELATED_IMAGE_OVN_TOOLBOX_IMAGE_URL_DEFAULT", OVNControllerToolboxContainerImage),
 	}
 
 	SetupOVNControllerDefaults(ovnControllerDefaults)
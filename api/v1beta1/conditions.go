@@ -0,0 +1,408 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+// OVN Condition Types used by API objects.
+const (
+	// OVNDBClusterDBIntegrityReadyCondition Status=True condition which indicates if the database files passed the startup integrity check
+	OVNDBClusterDBIntegrityReadyCondition condition.Type = "DBIntegrityReady"
+
+	// OVNDBClusterSchemaUpgradeReadyCondition Status=True condition which indicates if the database converts to the schema of the new image, it is only set during an upgrade
+	OVNDBClusterSchemaUpgradeReadyCondition condition.Type = "SchemaUpgradeReady"
+
+	// OVNNorthdPausedCondition Status=True condition which indicates that ovn-northd is paused, it is not set when running
+	OVNNorthdPausedCondition condition.Type = "NorthdPaused"
+
+	// OVNFIPSProviderReadyCondition Status=True condition which indicates if the OpenSSL FIPS provider is active in the images of the pods, it is only set when FIPS is requested
+	OVNFIPSProviderReadyCondition condition.Type = "FIPSProviderReady"
+
+	// OVNTunnelMTUReadyCondition Status=True condition which indicates if packets of the tunnel MTU get through between the chassis, it is only set when the check is requested
+	OVNTunnelMTUReadyCondition condition.Type = "TunnelMTUReady"
+
+	// OVNDBClusterConsistencyReadyCondition Status=True condition which indicates if the last consistency check of the database found no problem, it is only set when the checks are enabled
+	OVNDBClusterConsistencyReadyCondition condition.Type = "ConsistencyReady"
+
+	// OVNControllerDaemonSetReadyCondition Status=True condition which indicates if all the ovn-controller pods are ready
+	OVNControllerDaemonSetReadyCondition condition.Type = "OVNControllerDaemonSetReady"
+
+	// OVSDaemonSetReadyCondition Status=True condition which indicates if all the OVS pods are ready
+	OVSDaemonSetReadyCondition condition.Type = "OVSDaemonSetReady"
+
+	// OVNNorthdReadyCondition Status=True condition which indicates if an ovn-northd replica is ready
+	OVNNorthdReadyCondition condition.Type = "NorthdReady"
+
+	// OVNControllerCanaryReadyCondition Status=True condition which indicates if a new ovn-controller image is healthy on the canary nodes, it is only set during a canary rollout
+	OVNControllerCanaryReadyCondition condition.Type = "CanaryReady"
+
+	// OVNVersionSkewReadyCondition Status=True condition which indicates if a new ovn-controller image supports the schema of the deployed SB DB, it is only set while rolling out a new image
+	OVNVersionSkewReadyCondition condition.Type = "VersionSkewReady"
+
+	// OVNControllerDegradedCondition Status=True condition which indicates that a failed DaemonSet rollout was rolled back, it is only set while rolled back
+	OVNControllerDegradedCondition condition.Type = "Degraded"
+
+	// OVNSuspendedCondition Status=True condition which indicates that the owned resources are not modified, it is only set while suspended
+	OVNSuspendedCondition condition.Type = "Suspended"
+
+	// OVNDriftedCondition Status=True condition which indicates that fields set by the operator on the workloads were modified manually and are not reverted, it is only set while drifted with the Report drift policy
+	OVNDriftedCondition condition.Type = "Drifted"
+
+	// OVNUpgradeReadyCondition Status=False condition which indicates that a new image waits for the OVN components upgraded before it, it is only set while waiting
+	OVNUpgradeReadyCondition condition.Type = "UpgradeReady"
+
+	// OVNTraceReadyCondition Status=True condition which indicates if ovn-trace traced the packet of the OVNTrace
+	OVNTraceReadyCondition condition.Type = "TraceReady"
+
+	// OVNOFProtoTraceReadyCondition Status=True condition which indicates if ofproto/trace traced the packet on the chassis of the source port, it is only set when requested
+	OVNOFProtoTraceReadyCondition condition.Type = "OFProtoTraceReady"
+
+	// OVNCommandReadyCondition Status=True condition which indicates if the command of the OVNCommand completed
+	OVNCommandReadyCondition condition.Type = "CommandReady"
+
+	// OVNDiagnosticsReadyCondition Status=True condition which indicates if the diagnostics of the OVNDiagnostics got archived
+	OVNDiagnosticsReadyCondition condition.Type = "DiagnosticsReady"
+
+	// OVNCaptureReadyCondition Status=True condition which indicates if the packets of the OVNCapture got captured
+	OVNCaptureReadyCondition condition.Type = "CaptureReady"
+
+	// OVNGlobalConfigReadyCondition Status=True condition which indicates if the NB_Global options of the OVNGlobalConfig are applied
+	OVNGlobalConfigReadyCondition condition.Type = "GlobalConfigReady"
+)
+
+// OVNDBClusterReadyCondition Status=True condition which indicates if a
+// quorum of the RAFT cluster members are ready, e.g. NBClusterReady
+func OVNDBClusterReadyCondition(dbType string) condition.Type {
+	return condition.Type(fmt.Sprintf("%sClusterReady", dbType))
+}
+
+// OVN Reasons used by API objects.
+const (
+	// DBCorruptedReason - the database file of a cluster member failed the integrity check
+	DBCorruptedReason condition.Reason = "DBCorrupted"
+
+	// FIPSProviderInactiveReason - the OpenSSL FIPS provider is not active in the image of a pod
+	FIPSProviderInactiveReason condition.Reason = "FIPSProviderInactive"
+
+	// PathMTUExceededReason - the packets of the tunnel MTU of a chassis are dropped
+	PathMTUExceededReason condition.Reason = "PathMTUExceeded"
+
+	// DBInconsistentReason - the consistency check of the database found problems
+	DBInconsistentReason condition.Reason = "DBInconsistent"
+
+	// InputMissingReason - a Secret or ConfigMap referenced in the spec does not exist
+	InputMissingReason condition.Reason = "InputMissing"
+)
+
+// Common Messages used by API objects.
+const (
+	//
+	// OVNDBClusterDBIntegrityReady condition messages
+	//
+	// OVNDBClusterDBIntegrityReadyInitMessage -
+	OVNDBClusterDBIntegrityReadyInitMessage = "DB integrity not checked"
+
+	// OVNDBClusterDBIntegrityReadyMessage -
+	OVNDBClusterDBIntegrityReadyMessage = "DB integrity check passed"
+
+	// OVNDBClusterDBIntegrityReadyErrorMessage -
+	OVNDBClusterDBIntegrityReadyErrorMessage = "DB integrity check failed on pods: %s"
+
+	//
+	// OVNDBClusterSchemaUpgradeReady condition messages
+	//
+	// OVNDBClusterSchemaUpgradeReadyInitMessage -
+	OVNDBClusterSchemaUpgradeReadyInitMessage = "DB schema upgrade not validated"
+
+	// OVNDBClusterSchemaUpgradeReadyRunningMessage -
+	OVNDBClusterSchemaUpgradeReadyRunningMessage = "Validating the DB schema upgrade to %s"
+
+	// OVNDBClusterSchemaUpgradeReadyMessage -
+	OVNDBClusterSchemaUpgradeReadyMessage = "DB schema upgrade validated"
+
+	// OVNDBClusterSchemaUpgradeReadyErrorMessage -
+	OVNDBClusterSchemaUpgradeReadyErrorMessage = "DB schema upgrade failed, the rollout is aborted: %s"
+
+	//
+	// OVNNorthdPaused condition messages
+	//
+	// OVNNorthdPausedMessage -
+	OVNNorthdPausedMessage = "ovn-northd is paused"
+
+	// OVNNorthdPausedErrorMessage -
+	OVNNorthdPausedErrorMessage = "ovn-northd pause error occurred %s"
+
+	//
+	// OVNFIPSProviderReady condition messages
+	//
+	// OVNFIPSProviderReadyInitMessage -
+	OVNFIPSProviderReadyInitMessage = "OpenSSL FIPS provider not checked"
+
+	// OVNFIPSProviderReadyMessage -
+	OVNFIPSProviderReadyMessage = "OpenSSL FIPS provider active"
+
+	// OVNFIPSProviderReadyErrorMessage -
+	OVNFIPSProviderReadyErrorMessage = "OpenSSL FIPS provider check failed: %s"
+
+	// OVNFIPSProviderInactiveMessage -
+	OVNFIPSProviderInactiveMessage = "OpenSSL FIPS provider is not active in the images of pods: %s"
+
+	//
+	// OVNTunnelMTUReady condition messages
+	//
+	// OVNTunnelMTUReadyInitMessage -
+	OVNTunnelMTUReadyInitMessage = "Tunnel MTU not checked"
+
+	// OVNTunnelMTUReadyMessage -
+	OVNTunnelMTUReadyMessage = "Packets of the tunnel MTU %d get through between the chassis"
+
+	// OVNTunnelMTUReadyErrorMessage -
+	OVNTunnelMTUReadyErrorMessage = "Packets of the tunnel MTU %d are dropped from the chassis of nodes: %s"
+
+	//
+	// OVNDBClusterConsistencyReady condition messages
+	//
+	// OVNDBClusterConsistencyReadyInitMessage -
+	OVNDBClusterConsistencyReadyInitMessage = "DB consistency not checked"
+
+	// OVNDBClusterConsistencyReadyMessage -
+	OVNDBClusterConsistencyReadyMessage = "DB consistency check passed"
+
+	// OVNDBClusterConsistencyReadyErrorMessage -
+	OVNDBClusterConsistencyReadyErrorMessage = "DB consistency check failed: %s"
+
+	//
+	// OVNControllerDaemonSetReady condition messages
+	//
+	// OVNControllerDaemonSetReadyInitMessage -
+	OVNControllerDaemonSetReadyInitMessage = "ovn-controller DaemonSet not started"
+
+	// OVNControllerDaemonSetReadyMessage -
+	OVNControllerDaemonSetReadyMessage = "%d/%d ovn-controller pods ready"
+
+	// OVNControllerDaemonSetReadyRunningMessage -
+	OVNControllerDaemonSetReadyRunningMessage = "%d/%d ovn-controller pods ready, rollout in progress"
+
+	//
+	// OVSDaemonSetReady condition messages
+	//
+	// OVSDaemonSetReadyInitMessage -
+	OVSDaemonSetReadyInitMessage = "OVS DaemonSet not started"
+
+	// OVSDaemonSetReadyMessage -
+	OVSDaemonSetReadyMessage = "%d/%d OVS pods ready"
+
+	// OVSDaemonSetReadyRunningMessage -
+	OVSDaemonSetReadyRunningMessage = "%d/%d OVS pods ready, rollout in progress"
+
+	//
+	// OVNDBClusterReady condition messages
+	//
+	// OVNDBClusterReadyInitMessage -
+	OVNDBClusterReadyInitMessage = "RAFT cluster not started"
+
+	// OVNDBClusterReadyMessage -
+	OVNDBClusterReadyMessage = "%d/%d RAFT cluster members ready"
+
+	// OVNDBClusterReadyRunningMessage -
+	OVNDBClusterReadyRunningMessage = "%d/%d RAFT cluster members ready, waiting for a quorum"
+
+	//
+	// OVNNorthdReady condition messages
+	//
+	// OVNNorthdReadyInitMessage -
+	OVNNorthdReadyInitMessage = "ovn-northd not started"
+
+	// OVNNorthdReadyMessage -
+	OVNNorthdReadyMessage = "%d/%d ovn-northd replicas ready"
+
+	// OVNNorthdReadyRunningMessage -
+	OVNNorthdReadyRunningMessage = "%d/%d ovn-northd replicas ready, waiting for a replica"
+
+	//
+	// ServiceAccountReady condition messages of an externally managed
+	// ServiceAccount
+	//
+	// OVNServiceAccountExternalMessage -
+	OVNServiceAccountExternalMessage = "ServiceAccount %s managed externally"
+
+	// OVNServiceAccountMissingMessage -
+	OVNServiceAccountMissingMessage = "ServiceAccount %s not found"
+
+	// OVNControllerCanaryReady condition messages
+	//
+	// OVNControllerCanaryReadyRunningMessage -
+	OVNControllerCanaryReadyRunningMessage = "Rolling out %s to the canary nodes, waiting for: %s"
+
+	// OVNControllerCanaryReadyNoNodesMessage -
+	OVNControllerCanaryReadyNoNodesMessage = "Rolling out %s is blocked, no ovn-controller node matches the canary selection"
+
+	// OVNControllerCanaryReadyMessage -
+	OVNControllerCanaryReadyMessage = "Canary nodes verified, rolling out %s to all the nodes"
+
+	//
+	// OVNControllerDegraded condition messages
+	//
+	// OVNControllerDegradedMessage -
+	OVNControllerDegradedMessage = "Rollout of DaemonSet %s failed on nodes %s and was rolled back to %s, update the spec to retry"
+
+	//
+	// OVNVersionSkewReady condition messages
+	//
+	// OVNVersionSkewReadyRunningMessage -
+	OVNVersionSkewReadyRunningMessage = "Probing the OVN version of %s"
+
+	// OVNVersionSkewReadyMessage -
+	OVNVersionSkewReadyMessage = "ovn-controller %s supports SB DB schema %s"
+
+	// OVNVersionSkewReadyErrorMessage -
+	OVNVersionSkewReadyErrorMessage = "Version probe of %s failed: %s"
+
+	// OVNVersionSkewReadyUnknownMessage -
+	OVNVersionSkewReadyUnknownMessage = "Rollout held, the SB DB schema supported by ovn-controller %s could not be checked: %s"
+
+	// OVNVersionSkewReadyBlockedMessage -
+	OVNVersionSkewReadyBlockedMessage = "Rollout blocked, ovn-controller %s requires SB DB schema %s but the SB DB runs %s. " +
+		"Upgrade the OVN databases first or revert ovnContainerImage to %s"
+
+	//
+	// OVNSuspended condition messages
+	//
+	// OVNSuspendedMessage -
+	OVNSuspendedMessage = "Reconciliation suspended, the owned resources are not modified"
+
+	//
+	// OVNDrifted condition messages
+	//
+	// OVNDriftedMessage -
+	OVNDriftedMessage = "Manual changes kept, the spec is not rolled out to the modified workloads: %s"
+
+	//
+	// OVNUpgradeReady condition messages
+	//
+	// OVNUpgradeReadyWaitingMessage -
+	OVNUpgradeReadyWaitingMessage = "Waiting for %s to be upgraded before rolling out %s"
+
+	//
+	// InputReady condition messages
+	//
+	// OVNInputReadyMissingMessage -
+	OVNInputReadyMissingMessage = "Waiting for the referenced objects to be created: %s"
+
+	//
+	// OVNTraceReady condition messages
+	//
+	// OVNTraceReadyInitMessage -
+	OVNTraceReadyInitMessage = "Packet not traced"
+
+	// OVNTraceReadyRunningMessage -
+	OVNTraceReadyRunningMessage = "Tracing the packet from logical port %s"
+
+	// OVNTraceReadyMessage -
+	OVNTraceReadyMessage = "Packet traced, the result is in ConfigMap %s"
+
+	// OVNTraceReadyErrorMessage -
+	OVNTraceReadyErrorMessage = "ovn-trace failed: %s"
+
+	//
+	// OVNOFProtoTraceReady condition messages
+	//
+	// OVNOFProtoTraceReadyInitMessage -
+	OVNOFProtoTraceReadyInitMessage = "Packet not traced on the chassis"
+
+	// OVNOFProtoTraceReadyRunningMessage -
+	OVNOFProtoTraceReadyRunningMessage = "Tracing the packet on the chassis of node %s"
+
+	// OVNOFProtoTraceReadyMessage -
+	OVNOFProtoTraceReadyMessage = "Packet traced on the chassis of node %s"
+
+	// OVNOFProtoTraceReadyUnboundMessage -
+	OVNOFProtoTraceReadyUnboundMessage = "Logical port %s is not bound to a chassis"
+
+	// OVNOFProtoTraceReadyErrorMessage -
+	OVNOFProtoTraceReadyErrorMessage = "ofproto/trace failed: %s"
+
+	//
+	// OVNCommandReady condition messages
+	//
+	// OVNCommandReadyInitMessage -
+	OVNCommandReadyInitMessage = "Command not run"
+
+	// OVNCommandReadyRunningMessage -
+	OVNCommandReadyRunningMessage = "Running %s"
+
+	// OVNCommandReadyMessage -
+	OVNCommandReadyMessage = "%s completed"
+
+	// OVNCommandReadyErrorMessage -
+	OVNCommandReadyErrorMessage = "%s failed: %s"
+
+	//
+	// OVNDiagnosticsReady condition messages
+	//
+	// OVNDiagnosticsReadyInitMessage -
+	OVNDiagnosticsReadyInitMessage = "Diagnostics not collected"
+
+	// OVNDiagnosticsReadyRunningMessage -
+	OVNDiagnosticsReadyRunningMessage = "Collecting the diagnostics of %s"
+
+	// OVNDiagnosticsReadyMessage -
+	OVNDiagnosticsReadyMessage = "Diagnostics archived in %s of PVC %s"
+
+	// OVNDiagnosticsReadyErrorMessage -
+	OVNDiagnosticsReadyErrorMessage = "Collecting the diagnostics of %s failed: %s"
+
+	//
+	// OVNCaptureReady condition messages
+	//
+	// OVNCaptureReadyInitMessage -
+	OVNCaptureReadyInitMessage = "Packets not captured"
+
+	// OVNCaptureReadyRunningMessage -
+	OVNCaptureReadyRunningMessage = "Capturing the packets of %s on node %s"
+
+	// OVNCaptureReadyMessage -
+	OVNCaptureReadyMessage = "Packets captured in %s of PVC %s: %s"
+
+	// OVNCaptureReadyErrorMessage -
+	OVNCaptureReadyErrorMessage = "Capturing the packets failed: %s"
+
+	//
+	// OVNGlobalConfigReady condition messages
+	//
+	// OVNGlobalConfigReadyInitMessage -
+	OVNGlobalConfigReadyInitMessage = "NB_Global options not applied"
+
+	// OVNGlobalConfigReadyWaitingMessage -
+	OVNGlobalConfigReadyWaitingMessage = "Waiting for a running NB DB pod: %s"
+
+	// OVNGlobalConfigReadyConflictMessage -
+	OVNGlobalConfigReadyConflictMessage = "The NB_Global options of the namespace are managed by OVNGlobalConfig %s"
+
+	// OVNGlobalConfigReadyMessage -
+	OVNGlobalConfigReadyMessage = "NB_Global options applied"
+
+	// OVNGlobalConfigReadyErrorMessage -
+	OVNGlobalConfigReadyErrorMessage = "Applying the NB_Global options failed: %s"
+
+	// OVNGlobalConfigDriftedMessage -
+	OVNGlobalConfigDriftedMessage = "Manual changes of the NB_Global options kept: %s"
+)
@@ -0,0 +1,25 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// v1beta1 is the storage version and the conversion hub, the v1 API
+// converts from and to it
+
+// Hub marks OVNController as a conversion hub
+func (*OVNController) Hub() {}
+
+// Hub marks OVNDBCluster as a conversion hub
+func (*OVNDBCluster) Hub() {}
+
+// Hub marks OVNNorthd as a conversion hub
+func (*OVNNorthd) Hub() {}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+)
+
+// The endpoints ConfigMap is the contract with the OpenStack service
+// operators consuming the NB and SB DBs, e.g. neutron-operator,
+// octavia-operator and ironic-operator. Version 1 of its schema holds:
+//
+//	schema_version: "1"
+//	nb_internal_url, sb_internal_url: DB address used by the Pods in the cluster
+//	nb_external_url, sb_external_url: DB address used by the external nodes,
+//	  empty without a network attachment
+//	nb_tls, sb_tls: "true" when the DB requires TLS
+//	nb_ca_bundle_secret, sb_ca_bundle_secret: name of the Secret with the CA
+//	  bundle validating the cert of the DB, empty without one
+//
+// The keys of a DB type are published once its OVNDBCluster is reconciled.
+// Keys are only added within a schema version, removing or changing the
+// meaning of a key requires a new version.
+const (
+	// EndpointsConfigMapName - name of the ConfigMap publishing the NB and
+	// SB DB endpoints in the namespace of the OVNDBClusters
+	EndpointsConfigMapName = "ovn-endpoints"
+	// EndpointsSchemaVersionKey - ConfigMap key holding the version of the
+	// schema of the ConfigMap
+	EndpointsSchemaVersionKey = "schema_version"
+	// EndpointsSchemaVersion - version of the schema of the ConfigMap
+	EndpointsSchemaVersion = "1"
+)
+
+// EndpointsKey - key of the endpoints ConfigMap holding the connection
+// detail of the DB type, e.g. EndpointsKey(SBDBType,
+// ConnectionInternalURLKey) is sb_internal_url
+func EndpointsKey(dbType string, connectionKey string) string {
+	return strings.ToLower(dbType) + "_" + connectionKey
+}
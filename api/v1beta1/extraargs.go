@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	// extraArgNameRegex - a short or long option, e.g. -vjsonrpc:dbg or
+	// --n-threads
+	extraArgNameRegex = regexp.MustCompile(`^--?[A-Za-z0-9][A-Za-z0-9_.:/-]*$`)
+	// extraArgValueRegex - the values are rendered in shell scripts, so they
+	// can't hold whitespaces, quotes or expansions
+	extraArgValueRegex = regexp.MustCompile(`^[A-Za-z0-9_.:/,=@+%-]*$`)
+)
+
+// ValidateExtraArgs - the extra args are options with values that are safe
+// to render in the command lines
+func ValidateExtraArgs(args map[string]string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for name, value := range args {
+		if !extraArgNameRegex.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), name,
+				"must be an option starting with - or --"))
+		}
+		if !extraArgValueRegex.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), value,
+				"must not contain whitespaces, quotes or shell special characters"))
+		}
+	}
+	return allErrs
+}
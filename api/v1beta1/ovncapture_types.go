@@ -0,0 +1,127 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OVNCaptureSpec defines the desired state of OVNCapture
+type OVNCaptureSpec struct {
+	// +kubebuilder:validation:Optional
+	// ContainerImage - Container Image URL of the capture Job, the image of
+	// the ovs pods if empty. It has to ship ovs-tcpdump and tcpdump.
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Node - name of the node to capture the packets on
+	Node string `json:"node"`
+
+	// +kubebuilder:validation:Optional
+	// Port - name of the OVS port to capture the packets of. All the ports of
+	// the bridge are captured if empty.
+	Port string `json:"port,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Bridge - the OVS bridge to capture all the packets of when no port is
+	// given, the integration bridge of the OVNController if empty
+	Bridge string `json:"bridge,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Filter - tcpdump filter expression of the captured packets
+	Filter string `json:"filter,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// Duration - seconds after which the capture stops
+	Duration int32 `json:"duration"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// MaxSizeMB - size of the capture file in MB after which the capture
+	// stops, it has to fit in the PVC
+	MaxSizeMB int32 `json:"maxSizeMB"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// StorageClass - storage class of the PVC the capture file is written to,
+	// the volumes of the class have to be attachable on the node
+	StorageClass string `json:"storageClass"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1G"
+	// StorageRequest - size of the PVC the capture file is written to
+	StorageRequest string `json:"storageRequest"`
+}
+
+// OVNCaptureStatus defines the observed state of OVNCapture
+type OVNCaptureStatus struct {
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// PersistentVolumeClaim - name of the PVC holding the capture file
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+
+	// CaptureFile - path of the pcap file in the PVC
+	CaptureFile string `json:"captureFile,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.node",description="Node"
+//+kubebuilder:printcolumn:name="Port",type="string",JSONPath=".spec.port",description="Port"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNCapture is the Schema for the ovncaptures API
+type OVNCapture struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNCaptureSpec   `json:"spec,omitempty"`
+	Status OVNCaptureStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNCaptureList contains a list of OVNCapture
+type OVNCaptureList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNCapture `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNCapture{}, &OVNCaptureList{})
+}
+
+// IsReady - returns true if the packets got captured
+func (instance OVNCapture) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The read-only commands an OVNCommand runs
+const (
+	// OVNCommandNBShow - ovn-nbctl show
+	OVNCommandNBShow = "nb-show"
+	// OVNCommandSBShow - ovn-sbctl show
+	OVNCommandSBShow = "sb-show"
+	// OVNCommandSBListChassis - ovn-sbctl list Chassis
+	OVNCommandSBListChassis = "sb-list-chassis"
+	// OVNCommandSBLflowList - ovn-sbctl lflow-list
+	OVNCommandSBLflowList = "sb-lflow-list"
+	// OVNCommandOVSShow - ovs-vsctl show
+	OVNCommandOVSShow = "ovs-show"
+	// OVNCommandOVSDumpFlows - ovs-ofctl dump-flows of the integration bridge
+	OVNCommandOVSDumpFlows = "ovs-dump-flows"
+)
+
+// OVNCommandSpec defines the desired state of OVNCommand
+type OVNCommandSpec struct {
+	// +kubebuilder:validation:Optional
+	// ContainerImage - Container Image URL of the command Job, the image of
+	// the ovn-controller pods if empty
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=nb-show;sb-show;sb-list-chassis;sb-lflow-list;ovs-show;ovs-dump-flows
+	// Command - the read-only command to run: nb-show (ovn-nbctl show),
+	// sb-show (ovn-sbctl show), sb-list-chassis (ovn-sbctl list Chassis),
+	// sb-lflow-list (ovn-sbctl lflow-list), ovs-show (ovs-vsctl show) or
+	// ovs-dump-flows (ovs-ofctl dump-flows of the integration bridge)
+	Command string `json:"command"`
+
+	// +kubebuilder:validation:Optional
+	// Node - name of the node whose OVS the ovs- commands run against, they
+	// require it
+	Node string `json:"node,omitempty"`
+}
+
+// IsOVSCommand - whether the command runs against the OVS of a node rather
+// than an OVN DB
+func (spec OVNCommandSpec) IsOVSCommand() bool {
+	return spec.Command == OVNCommandOVSShow || spec.Command == OVNCommandOVSDumpFlows
+}
+
+// OVNCommandStatus defines the observed state of OVNCommand
+type OVNCommandStatus struct {
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// CommandLine - the command line the Job ran, without the connection
+	// options
+	CommandLine string `json:"commandLine,omitempty"`
+
+	// Output - the output of the command, the last 4KiB of it when longer.
+	// The complete output is in the log of the Job pod.
+	Output string `json:"output,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Command",type="string",JSONPath=".spec.command",description="Command"
+//+kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.node",description="Node"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNCommand is the Schema for the ovncommands API
+type OVNCommand struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNCommandSpec   `json:"spec,omitempty"`
+	Status OVNCommandStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNCommandList contains a list of OVNCommand
+type OVNCommandList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNCommand `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNCommand{}, &OVNCommandList{})
+}
+
+// IsReady - returns true if the command completed
+func (instance OVNCommand) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//
+// Generated by:
+//
+// operator-sdk create webhook --group ovn --version v1beta1 --kind OVNCommand --programmatic-validation
+//
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var ovncommandlog = logf.Log.WithName("ovncommand-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager
+func (r *OVNCommand) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-ovn-openstack-org-v1beta1-ovncommand,mutating=false,failurePolicy=fail,sideEffects=None,groups=ovn.openstack.org,resources=ovncommands,verbs=create;update,versions=v1beta1,name=vovncommand.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OVNCommand{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNCommand) ValidateCreate() (admission.Warnings, error) {
+	ovncommandlog.Info("validate create", "name", r.Name)
+
+	allErrs := field.ErrorList{}
+	basePath := field.NewPath("spec")
+	if r.Spec.IsOVSCommand() && r.Spec.Node == "" {
+		allErrs = append(allErrs, field.Required(basePath.Child("node"),
+			fmt.Sprintf("%s runs against the OVS of a node", r.Spec.Command)))
+	}
+	if !r.Spec.IsOVSCommand() && r.Spec.Node != "" {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("node"),
+			fmt.Sprintf("%s runs against an OVN DB", r.Spec.Command)))
+	}
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("OVNCommand").GroupKind(), r.Name, allErrs)
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNCommand) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	ovncommandlog.Info("validate update", "name", r.Name)
+
+	oldCommand, ok := old.(*OVNCommand)
+	if !ok || oldCommand == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	// an OVNCommand records a single run, another one runs the command again
+	if !equality.Semantic.DeepEqual(r.Spec, oldCommand.Spec) {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("OVNCommand").GroupKind(), r.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), "the spec is immutable, create another OVNCommand"),
+		})
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNCommand) ValidateDelete() (admission.Warnings, error) {
+	ovncommandlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
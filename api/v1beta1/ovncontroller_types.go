@@ -18,7 +18,6 @@ package v1beta1
 
 import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +33,14 @@ const (
 	OVNControllerOVSContainerImage = "quay.io/podified-antelope-centos9/openstack-ovn-base:current-podified"
 	// OVNControllerContainerImage is the fall-back container image for OVNController ovn-controller
 	OVNControllerContainerImage = "quay.io/podified-antelope-centos9/openstack-ovn-controller:current-podified"
+	// OVNControllerExporterContainerImage is the fall-back container image for the OVNController metrics exporter
+	OVNControllerExporterContainerImage = "quay.io/openstack-k8s-operators/openstack-network-exporter:current-podified"
+	// OVNControllerBGPAgentContainerImage is the fall-back container image for the OVNController ovn-bgp-agent
+	OVNControllerBGPAgentContainerImage = "quay.io/podified-antelope-centos9/openstack-ovn-bgp-agent:current-podified"
+	// OVNControllerToolboxContainerImage is the fall-back container image for the OVNController debug toolbox
+	OVNControllerToolboxContainerImage = "quay.io/podified-antelope-centos9/openstack-ovn-controller:current-podified"
+	// KubeRbacProxyContainerImage is the fall-back container image for kube-rbac-proxy in front of metrics endpoints
+	KubeRbacProxyContainerImage = "quay.io/openstack-k8s-operators/kube-rbac-proxy:v0.16.0"
 
 	// ServiceNameOVNController - ovn-controller service name
 	ServiceNameOVNController = "ovn-controller"
@@ -42,8 +49,31 @@ const (
 
 	// ServiceNameOVS - ovn-controller-ovs service name
 	ServiceNameOVS = "ovn-controller-ovs"
+
+	// NodeCapabilityDPDK - the node can run the OVS DPDK datapath
+	NodeCapabilityDPDK = "dpdk"
+	// NodeCapabilityHWOffload - the node has NICs offloading the OVS flows
+	NodeCapabilityHWOffload = "hw-offload"
+	// NodeCapabilityKernelDatapath - the node has the OVS kernel datapath
+	NodeCapabilityKernelDatapath = "kernel-datapath"
+
+	// DecommissionGatewaysDrained - the gateway ports moved off the chassis
+	// of the node, waiting for its pods to be removed
+	DecommissionGatewaysDrained = "GatewaysDrained"
+	// DecommissionPodsRemoved - the ovn-controller and OVS pods left the
+	// node, its chassis is being deleted from the SB DB
+	DecommissionPodsRemoved = "PodsRemoved"
+	// DecommissionCompleted - the chassis of the node was deleted from the
+	// SB DB
+	DecommissionCompleted = "Decommissioned"
 )
 
+// NodeCapabilityLabel - label set by Node Feature Discovery on the nodes
+// with the capability
+func NodeCapabilityLabel(capability string) string {
+	return "feature.node.kubernetes.io/ovn-" + capability
+}
+
 // OVNControllerSpec defines the desired state of OVNController
 type OVNControllerSpec struct {
 	// +kubebuilder:validation:Required
@@ -54,6 +84,36 @@ type OVNControllerSpec struct {
 	// Image used for the ovn-controller container (will be set to environmental default if empty)
 	OvnContainerImage string `json:"ovnContainerImage"`
 
+	// +kubebuilder:validation:Optional
+	// Image used for the metrics exporter container (will be set to environmental default if empty)
+	ExporterContainerImage string `json:"exporterContainerImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image used for the kube-rbac-proxy container in front of the metrics exporter (will be set to environmental default if empty)
+	RbacProxyContainerImage string `json:"rbacProxyContainerImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image used for the ovn-bgp-agent container (will be set to environmental default if empty)
+	BGPAgentContainerImage string `json:"bgpAgentContainerImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image used for the debug toolbox container (will be set to environmental default if empty)
+	ToolboxContainerImage string `json:"toolboxContainerImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Suspend - stop modifying the resources owned by the instance, so manual
+	// interventions are not reverted. The status is still updated.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// DriftPolicy - Enforce reverts the manual changes of the fields the
+	// operator sets on the workloads. Report keeps a modified workload as is,
+	// e.g. an incident hotfix, and lists the modified fields in the Drifted
+	// condition, the spec changes are not rolled out to it either until the
+	// changes are reverted or the policy is Enforce again.
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
 	OVNControllerSpecCore `json:",inline"`
 }
 
@@ -72,10 +132,42 @@ type OVNControllerSpecCore struct {
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// ContainerResources - Compute Resources of single containers, they take
+	// precedence over Resources
+	ContainerResources OVNControllerContainerResources `json:"containerResources,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// NodeSelector to target subset of worker nodes running this service
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=dpdk;hw-offload;kernel-datapath
+	// NodeCapabilities - run only on the nodes labeled by Node Feature
+	// Discovery with these capabilities, in addition to the NodeSelector
+	NodeCapabilities []string `json:"nodeCapabilities,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=amd64;arm64;ppc64le;s390x
+	// Architectures - the CPU architectures the ovn-controller and OVS
+	// images are built for. The pods only run on the nodes of these
+	// architectures, the other selected nodes are listed in the status
+	// instead of crash looping. Any architecture by default.
+	Architectures []string `json:"architectures,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=40
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Zones - the availability zones, i.e. the topology.kubernetes.io/zone
+	// label of the nodes, to run an ovn-controller and an OVS DaemonSet per
+	// zone in, named after it, e.g. ovn-controller-zone-a. Only the nodes of
+	// these zones run them then. The SB DB members aren't zoned.
+	Zones []string `json:"zones,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// NetworkAttachment is a NetworkAttachment resource name to expose the service to the given network.
 	// If specified the IP address of this network is used as the OVNEncapIP.
@@ -84,7 +176,347 @@ type OVNControllerSpecCore struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// TLS - Parameters related to TLS
-	TLS tls.SimpleService `json:"tls,omitempty"`
+	TLS TLSSection `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// FIPS - restrict the OVN connections to FIPS approved TLS protocols and
+	// ciphers, and report in the FIPSProviderReady condition whether the
+	// OpenSSL FIPS provider is active in the containers. Requires TLS.
+	FIPS bool `json:"fips,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Metrics - export ovn-controller and OVS metrics for Prometheus
+	Metrics OVNControllerMetrics `json:"metrics,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	// ChassisStatusInterval - how often (in seconds) the chassis registered in the SB DB are listed in the status, 0 disables it.
+	// The chassis of the nodes not selected by any OVNController anymore are removed from the SB DB with every refresh
+	ChassisStatusInterval int32 `json:"chassisStatusInterval"`
+
+	// +kubebuilder:validation:Optional
+	// Telemetry - collect the coverage counters and the memory usage of the
+	// ovn-controller pods every ChassisStatusInterval, and serve them as the
+	// ovn_coverage_events and ovn_memory_usage metrics of the operator. This
+	// runs ovn-appctl in every ovn-controller pod.
+	Telemetry bool `json:"telemetry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Canary - roll a new OvnContainerImage out to the canary nodes first.
+	// The remaining nodes are only updated once ovn-controller is ready on
+	// all the canary nodes and their chassis processed the latest SB DB
+	// changes.
+	Canary *OVNControllerCanary `json:"canary,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AutoRollback - revert a DaemonSet to its previous pod template when the
+	// updated pods fail readiness on too many nodes. The failed pod template
+	// is not applied again until the spec changes.
+	AutoRollback *OVNControllerAutoRollback `json:"autoRollback,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// GatewayDrain - move the gateway ports off a node before its
+	// ovn-controller stops. The priority of its chassis in the Gateway_Chassis
+	// and HA_Chassis rows of the NB DB is lowered, and restored once
+	// ovn-controller starts again on the node.
+	GatewayDrain bool `json:"gatewayDrain,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// GatewayDrainTimeout - how long (in seconds) a stopping ovn-controller
+	// waits for the gateway ports to be bound to other chassis
+	GatewayDrainTimeout int32 `json:"gatewayDrainTimeout"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// ExternalChassis - nodes outside of the cluster joining the OVN fabric,
+	// e.g. the EDPM computes of the dataplane-operator. The ovn-controller
+	// configuration of each is published in the <name>-<chassis>-config
+	// ConfigMap, along with the secret of its client cert, requested from
+	// tls.issuer when set.
+	ExternalChassis []OVNExternalChassis `json:"externalChassis,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// BGP - run ovn-bgp-agent next to ovn-controller, advertising the
+	// routes to the provider and tenant IPs bound to the nodes of the
+	// OVNController through their FRR
+	BGP *OVNControllerBGP `json:"bgp,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OVNKubernetesCoexistence - the nodes also run the ovn-kubernetes CNI,
+	// which owns br-int, br-ex and the default Geneve port. The OVNController
+	// is then required to use another integration bridge and Geneve port.
+	OVNKubernetesCoexistence bool `json:"ovnKubernetesCoexistence,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1280
+	// +kubebuilder:validation:Maximum=9216
+	// TunnelMTU - MTU of the network of the tunnels, set on the NIC of the
+	// NetworkAttachment. The integration bridge gets the MTU left to the
+	// tenant networks by the encapsulation overhead. Left as is when unset.
+	TunnelMTU int32 `json:"tunnelMTU,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TunnelMTUCheck - ping each chassis from the one of the previous node
+	// with packets of the TunnelMTU which can't be fragmented, the nodes
+	// whose packets are dropped are reported in the TunnelMTUReady condition
+	TunnelMTUCheck bool `json:"tunnelMTUCheck,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Toolbox - run a debug container with the OVN and OVS utilities,
+	// tcpdump and conntrack on the nodes, sharing the sockets of OVS and
+	// ovn-controller. Removed when unset.
+	Toolbox *OVNControllerToolbox `json:"toolbox,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MemoryWatchdog - restart ovn-controller when its RSS exceeds a
+	// threshold, with exit --restart so that the flows of the node are kept
+	// until it has recomputed them
+	MemoryWatchdog *OVNControllerMemoryWatchdog `json:"memoryWatchdog,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// SBRemote - preference of the nodes for the SB DB members in their
+	// ovn-remote, all the members are equally preferred when unset
+	SBRemote *OVNControllerSBRemote `json:"sbRemote,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Sidecars - additional containers in the ovn-controller pods, e.g. log
+	// shippers or monitoring agents
+	Sidecars SidecarSection `json:"sidecars,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - options appended to the command lines of ovn-controller and
+	// ovs-vswitchd, for the ones the API doesn't model
+	ExtraArgs OVNControllerExtraArgs `json:"extraArgs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodNamespaces - namespaces of the node shared with the ovn-controller
+	// and OVS pods, e.g. the PIDs for perf, and process namespace shared by
+	// their containers. All off by default.
+	PodNamespaces OVNControllerPodNamespaces `json:"podNamespaces,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=small;medium;large;custom;auto
+	// TuningProfile - small, medium or large set the SB DB probe interval,
+	// ovn-monitor-all and the logical flow cache memory limit of the preset
+	// for the size of the cluster in the external-ids of the chassis, auto
+	// chooses one of them for the number of chassis of the namespace, custom
+	// or unset leaves them to the OVN defaults
+	TuningProfile string `json:"tuningProfile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ConfigAgent - run a config-agent container in the OVS pods applying
+	// the external-ids of the chassis of its node live from the node config
+	// ConfigMap rendered by the operator, instead of the config Jobs
+	ConfigAgent bool `json:"configAgent,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ControlPlane - run ovn-controller and OVS on the control plane nodes
+	// of compact deployments too: the pods tolerate the control plane taints,
+	// the liveness probes of OVS and the SB DB probe interval allow for busy
+	// nodes
+	ControlPlane bool `json:"controlPlane,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ServiceAccount - name of an existing ServiceAccount of the namespace the
+	// pods run with, e.g. one the security team binds to the SCCs. It needs
+	// the privileged SCC and to manage the pods of the namespace, the
+	// operator then creates no ServiceAccount, Role and RoleBinding. Created
+	// by the operator by default.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// OVNControllerToolbox defines the nodes running the debug toolbox
+type OVNControllerToolbox struct {
+	// +kubebuilder:validation:Optional
+	// NodeSelector - labels of the nodes of the OVNController running the
+	// toolbox, all of them when empty
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// OVNControllerMemoryWatchdog defines when ovn-controller is restarted for
+// its memory usage
+type OVNControllerMemoryWatchdog struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// MaxRSSMB - RSS (in megabytes) of ovn-controller above which it is
+	// restarted
+	MaxRSSMB int32 `json:"maxRSSMB"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// Interval - how often (in seconds) the RSS of ovn-controller is checked
+	Interval int32 `json:"interval"`
+}
+
+// OVNControllerSBRemote defines the preference of the nodes for the SB DB
+// members. ovn-controller connects to a random entry of its ovn-remote and
+// fails over to the next ones, each member is listed as many times as its
+// weight.
+type OVNControllerSBRemote struct {
+	// +kubebuilder:validation:Optional
+	// Weights - weight (from 1 to 10) of the SB DB members by pod name, e.g.
+	// ovsdbserver-sb-0, 1 for the members not listed
+	Weights map[string]int32 `json:"weights,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// SameZoneWeight - factor of the weight of the SB DB members running in
+	// the topology.kubernetes.io/zone of the node
+	SameZoneWeight int32 `json:"sameZoneWeight"`
+}
+
+// OVNControllerExtraArgs defines the options appended to the command lines
+// of the daemons, by option name, e.g. --option or -vmodule:level, with an
+// empty value for the options without value
+type OVNControllerExtraArgs struct {
+	// +kubebuilder:validation:Optional
+	// OVNController - options of ovn-controller
+	OVNController map[string]string `json:"ovnController,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OVSVswitchd - options of ovs-vswitchd
+	OVSVswitchd map[string]string `json:"ovsVswitchd,omitempty"`
+}
+
+// OVNControllerPodNamespaces defines the namespaces of the ovn-controller
+// and OVS pods
+type OVNControllerPodNamespaces struct {
+	// +kubebuilder:validation:Optional
+	// OVNController - namespaces of the ovn-controller pods
+	OVNController PodNamespaces `json:"ovnController,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OVS - namespaces of the OVS pods, e.g. the PIDs of the node to profile
+	// ovs-vswitchd
+	OVS PodNamespaces `json:"ovs,omitempty"`
+}
+
+// PodNamespaces defines the namespaces of the node shared with a pod and the
+// process namespace shared by its containers
+type PodNamespaces struct {
+	// +kubebuilder:validation:Optional
+	// HostPID - use the PID namespace of the node. Ignored when the operator
+	// forbids the host namespaces.
+	HostPID bool `json:"hostPID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// HostIPC - use the IPC namespace of the node. Ignored when the operator
+	// forbids the host namespaces.
+	HostIPC bool `json:"hostIPC,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ShareProcessNamespace - share a PID namespace between the containers of
+	// the pod, the zombie processes are then reaped by the pause container
+	ShareProcessNamespace bool `json:"shareProcessNamespace,omitempty"`
+}
+
+// OVNControllerBGP defines the ovn-bgp-agent of the nodes
+type OVNControllerBGP struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=underlay
+	// +kubebuilder:validation:Enum=underlay;vrf
+	// ExposingMethod - underlay to route the traffic to the IPs through the
+	// kernel of the nodes, vrf to advertise them in an EVPN VRF
+	ExposingMethod string `json:"exposingMethod"`
+
+	// +kubebuilder:validation:Optional
+	// ExposeTenantNetworks - also advertise the tenant networks connected to
+	// a router with its gateway on the node, not only the provider IPs
+	ExposeTenantNetworks bool `json:"exposeTenantNetworks,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=64999
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// AS - autonomous system number of the FRR of the nodes
+	AS int64 `json:"as"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="/run/frr"
+	// FRRSocketPath - directory of the vty sockets of FRR on the nodes
+	FRRSocketPath string `json:"frrSocketPath"`
+}
+
+// OVNControllerContainerResources defines the Compute Resources of the
+// ovn-controller and OVS containers
+type OVNControllerContainerResources struct {
+	// +kubebuilder:validation:Optional
+	// OvnController - Compute Resources of the ovn-controller container
+	OvnController *corev1.ResourceRequirements `json:"ovnController,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OvsdbServer - Compute Resources of the ovsdb-server container
+	OvsdbServer *corev1.ResourceRequirements `json:"ovsdbServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OvsVswitchd - Compute Resources of the ovs-vswitchd container
+	OvsVswitchd *corev1.ResourceRequirements `json:"ovsVswitchd,omitempty"`
+}
+
+// OVNControllerAutoRollback defines when a failed DaemonSet rollout is
+// rolled back
+type OVNControllerAutoRollback struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	// MaxFailedNodes - number of nodes tolerated to run an updated pod which
+	// is not ready
+	MaxFailedNodes int32 `json:"maxFailedNodes"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=1
+	// FailureTimeout - how long (in seconds) after the start of the rollout
+	// the updated pods may fail readiness before it is rolled back
+	FailureTimeout int32 `json:"failureTimeout"`
+}
+
+// OVNControllerCanary defines the nodes a new ovn-controller image is rolled
+// out to first
+type OVNControllerCanary struct {
+	// +kubebuilder:validation:Optional
+	// NodeSelector - labels of the canary nodes, it takes precedence over
+	// Percentage
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// Percentage - share of the ovn-controller nodes used as canary nodes,
+	// picked in the order of their names, at least one node
+	Percentage int32 `json:"percentage,omitempty"`
+}
+
+// OVNControllerMetrics defines the metrics exporter of the ovn-controller pods
+type OVNControllerMetrics struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - add an exporter to the ovn-controller pods. Its metrics are
+	// served through kube-rbac-proxy, scraping requires RBAC permission to
+	// get the /metrics non resource URL.
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// OVSEnabled - add an exporter of the datapath metrics, e.g. interface
+	// stats, PMD utilization and upcalls, to the OVS pods. Changing it
+	// restarts the OVS pods.
+	OVSEnabled bool `json:"ovsEnabled"`
+
+	// +kubebuilder:validation:Optional
+	// MonitorLabels - labels added to the ServiceMonitors created for the
+	// enabled exporters, to match the serviceMonitorSelector of Prometheus.
+	// The ServiceMonitors are only created when the prometheus-operator CRDs
+	// are installed.
+	MonitorLabels map[string]string `json:"monitorLabels,omitempty"`
 }
 
 // OVNControllerStatus defines the observed state of OVNController
@@ -107,12 +539,234 @@ type OVNControllerStatus struct {
 	// NetworkAttachments status of the deployment pods
 	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
 
+	// TLSHashes - per DaemonSet, the hashes of the TLS secrets its pods were
+	// started with once the DaemonSet is rolled out, e.g. the CA bundle
+	TLSHashes map[string]map[string]string `json:"tlsHashes,omitempty"`
+
+	// Rollout - per DaemonSet, the progress of the rollout of the latest
+	// spec to its pods
+	Rollout map[string]OVNControllerRolloutStatus `json:"rollout,omitempty"`
+
+	// Rollback - per DaemonSet, the health of the rollout of its latest pod
+	// template, tracked when AutoRollback is enabled
+	Rollback map[string]OVNControllerRollbackStatus `json:"rollback,omitempty"`
+
+	// Chassis - the chassis registered in the SB DB
+	Chassis []OVNControllerChassis `json:"chassis,omitempty"`
+
+	// NodesWithoutChassis - nodes running ovn-controller without a healthy
+	// chassis in the SB DB, i.e. none registered with the node hostname or
+	// one lacking its encap or Chassis_Private record
+	NodesWithoutChassis []string `json:"nodesWithoutChassis,omitempty"`
+
+	// UnsupportedArchNodes - nodes selected for ovn-controller which don't
+	// run it, as their architecture isn't one of the Architectures
+	UnsupportedArchNodes []string `json:"unsupportedArchNodes,omitempty"`
+
+	// ChassisErrorsCountedUntil - end of the window of the ovn-controller
+	// logs the errors of the chassis were counted in
+	ChassisErrorsCountedUntil *metav1.Time `json:"chassisErrorsCountedUntil,omitempty"`
+
+	// OvnContainerImage - image all the ovn-controller pods were rolled out with
+	OvnContainerImage string `json:"ovnContainerImage,omitempty"`
+
+	// OvsContainerImage - image all the OVS pods were rolled out with
+	OvsContainerImage string `json:"ovsContainerImage,omitempty"`
+
+	// Canary - the canary rollout of the latest OvnContainerImage
+	Canary *OVNControllerCanaryStatus `json:"canary,omitempty"`
+
+	// OvnVersion - versions reported by the latest OvnContainerImage, probed
+	// before it is rolled out
+	OvnVersion *OVNControllerVersion `json:"ovnVersion,omitempty"`
+
+	// MaintenanceNodes - nodes annotated with ovn.openstack.org/maintenance
+	// whose chassis is cordoned. Their pods are not updated until the
+	// annotation is removed.
+	MaintenanceNodes []string `json:"maintenanceNodes,omitempty"`
+
+	// Decommission - the progress of the decommission of the chassis of the
+	// nodes annotated with ovn.openstack.org/decommission
+	Decommission []OVNControllerDecommissionStatus `json:"decommission,omitempty"`
+
+	// OvnVersions - number of running ovn-controller pods per OVN version,
+	// as reported by ovn-controller
+	OvnVersions map[string]int32 `json:"ovnVersions,omitempty"`
+
+	// OvsVersions - number of running OVS pods per OVS version, as reported
+	// by ovs-vswitchd
+	OvsVersions map[string]int32 `json:"ovsVersions,omitempty"`
+
+	// ExternalChassis - the enrollment of the external chassis
+	ExternalChassis []OVNExternalChassisStatus `json:"externalChassis,omitempty"`
+
+	// Tuning - the profile chosen for the auto tuning profile
+	Tuning TuningStatus `json:"tuning,omitempty"`
+
 	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// OVNExternalChassis defines a node outside of the cluster running
+// ovn-controller
+type OVNExternalChassis struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// Name - hostname of the node, the system-id of its chassis and the
+	// common name of its client cert
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// EncapIP - IP of the tunnel endpoint of the chassis, set by the node
+	// itself when empty
+	EncapIP string `json:"encapIP,omitempty"`
+}
+
+// OVNExternalChassisStatus defines the enrollment of an external chassis
+type OVNExternalChassisStatus struct {
+	// Name - name of the external chassis
+	Name string `json:"name"`
+
+	// ConfigMap - ConfigMap of the ovn-controller configuration of the node
+	ConfigMap string `json:"configMap"`
+
+	// CertSecretName - secret the client cert of the node is issued into,
+	// empty when not requested by the operator
+	CertSecretName string `json:"certSecretName,omitempty"`
+}
+
+// OVNControllerDecommissionStatus defines the progress of the decommission
+// of the chassis of a node
+type OVNControllerDecommissionStatus struct {
+	// Node - name of the node
+	Node string `json:"node"`
+
+	// Phase - GatewaysDrained, PodsRemoved or Decommissioned
+	Phase string `json:"phase"`
+}
+
+// OVNControllerChassis defines a chassis registered in the SB DB
+type OVNControllerChassis struct {
+	// Name - name of the chassis, the system-id of OVS
+	Name string `json:"name"`
+
+	// Hostname - hostname the chassis registered with, the name of the node
+	Hostname string `json:"hostname,omitempty"`
+
+	// EncapIP - IP of the tunnel endpoint of the chassis
+	EncapIP string `json:"encapIP,omitempty"`
+
+	// LastHeartbeat - last time ovn-controller acknowledged a NB_Global
+	// nb_cfg update, i.e. nb_cfg_timestamp of its Chassis_Private record
+	LastHeartbeat *metav1.Time `json:"lastHeartbeat,omitempty"`
+
+	// CfgLag - number of SB_Global nb_cfg updates the chassis has not
+	// processed yet
+	CfgLag int64 `json:"cfgLag,omitempty"`
+
+	// FlowInstallErrors - number of OpenFlow errors ovs-vswitchd returned to
+	// ovn-controller of the node for its flow installations
+	FlowInstallErrors int64 `json:"flowInstallErrors,omitempty"`
+
+	// SBTransactionErrors - number of failed transactions of ovn-controller
+	// of the node with the SB DB
+	SBTransactionErrors int64 `json:"sbTransactionErrors,omitempty"`
+}
+
+// OVNControllerCanaryStatus defines the progress of a canary rollout
+type OVNControllerCanaryStatus struct {
+	// ContainerImage - image rolled out to the canary nodes
+	ContainerImage string `json:"containerImage"`
+
+	// Nodes - the canary nodes
+	Nodes []string `json:"nodes,omitempty"`
+
+	// PendingNodes - canary nodes not running a healthy ovn-controller with
+	// the image yet
+	PendingNodes []string `json:"pendingNodes,omitempty"`
+
+	// Verified - the image is healthy on all the canary nodes and rolled
+	// out to the remaining nodes
+	Verified bool `json:"verified"`
+}
+
+// OVNControllerVersion defines the versions reported by an ovn-controller
+// image
+type OVNControllerVersion struct {
+	// ContainerImage - the probed image
+	ContainerImage string `json:"containerImage"`
+
+	// Version - OVN version of ovn-controller
+	Version string `json:"version,omitempty"`
+
+	// SBSchemaVersion - SB DB schema version ovn-controller was built with
+	SBSchemaVersion string `json:"sbSchemaVersion,omitempty"`
+}
+
+// OVNControllerRollbackStatus defines the health of the rollout of a pod
+// template
+type OVNControllerRollbackStatus struct {
+	// TemplateHash - hash of the pod template rendered from the spec
+	TemplateHash string `json:"templateHash"`
+
+	// StartTime - when the pod template was first applied
+	StartTime metav1.Time `json:"startTime"`
+
+	// RolledOut - the pod template was rolled out to all the nodes, it is
+	// not rolled back anymore
+	RolledOut bool `json:"rolledOut,omitempty"`
+
+	// FailedNodes - nodes running an updated pod which is not ready
+	FailedNodes []string `json:"failedNodes,omitempty"`
+
+	// RolledBack - the rollout failed, the DaemonSet runs the pod template of
+	// Revision instead
+	RolledBack bool `json:"rolledBack,omitempty"`
+
+	// Revision - name of the ControllerRevision the DaemonSet was rolled
+	// back to
+	Revision string `json:"revision,omitempty"`
+}
+
+// OVNControllerRolloutStatus defines the rollout progress of a DaemonSet
+type OVNControllerRolloutStatus struct {
+	// ChangedInputs - inputs of the pods whose change started the latest
+	// rollout, e.g. scripts, ovs-tuning or tls-ca-bundle
+	ChangedInputs []string `json:"changedInputs,omitempty"`
+
+	// InputHashes - hash of each input of the pods of the latest pod
+	// template, by input
+	InputHashes map[string]string `json:"inputHashes,omitempty"`
+
+	// ObservedGeneration - generation of the OVNController the DaemonSet was
+	// last updated for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// DesiredNumberScheduled - number of nodes which should run the pod
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled,omitempty"`
+
+	// UpdatedNumberScheduled - number of nodes running the latest pod template
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled,omitempty"`
+
+	// NumberReady - number of nodes running a ready pod
+	NumberReady int32 `json:"numberReady,omitempty"`
+
+	// NumberAvailable - number of nodes running a pod ready for at least
+	// minReadySeconds
+	NumberAvailable int32 `json:"numberAvailable,omitempty"`
+
+	// Complete - all the nodes run a ready pod with the latest pod template
+	Complete bool `json:"complete"`
+
+	// PendingNodes - nodes running a pod not updated or not ready yet, at
+	// most the first 10 of them
+	PendingNodes []string `json:"pendingNodes,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="NetworkAttachments",type="string",JSONPath=".status.networkAttachments",description="NetworkAttachments"
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
@@ -146,6 +800,64 @@ func (instance OVNController) IsReady() bool {
 	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
 }
 
+// NodesSelector - the labels of the nodes to run on, the NodeSelector
+// along with the labels of the NodeCapabilities
+func (spec OVNControllerSpecCore) NodesSelector() map[string]string {
+	if len(spec.NodeCapabilities) == 0 {
+		return spec.NodeSelector
+	}
+	selector := make(map[string]string, len(spec.NodeSelector)+len(spec.NodeCapabilities))
+	for label, value := range spec.NodeSelector {
+		selector[label] = value
+	}
+	for _, capability := range spec.NodeCapabilities {
+		selector[NodeCapabilityLabel(capability)] = "true"
+	}
+	return selector
+}
+
+// ZonesNodeSelectors - the labels of the nodes to run on, one set per zone
+// with the topology.kubernetes.io/zone of the Zones
+func (spec OVNControllerSpecCore) ZonesNodeSelectors() []map[string]string {
+	if len(spec.Zones) == 0 {
+		return []map[string]string{spec.NodesSelector()}
+	}
+	selectors := make([]map[string]string, 0, len(spec.Zones))
+	for _, zone := range spec.Zones {
+		selector := map[string]string{corev1.LabelTopologyZone: zone}
+		for label, value := range spec.NodesSelector() {
+			selector[label] = value
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors
+}
+
+// OvnControllerResources - return the Compute Resources of the
+// ovn-controller container
+func (spec OVNControllerSpecCore) OvnControllerResources() corev1.ResourceRequirements {
+	return containerResources(spec.ContainerResources.OvnController, spec.Resources)
+}
+
+// OvsdbServerResources - return the Compute Resources of the ovsdb-server
+// container
+func (spec OVNControllerSpecCore) OvsdbServerResources() corev1.ResourceRequirements {
+	return containerResources(spec.ContainerResources.OvsdbServer, spec.Resources)
+}
+
+// OvsVswitchdResources - return the Compute Resources of the ovs-vswitchd
+// container
+func (spec OVNControllerSpecCore) OvsVswitchdResources() corev1.ResourceRequirements {
+	return containerResources(spec.ContainerResources.OvsVswitchd, spec.Resources)
+}
+
+func containerResources(resources *corev1.ResourceRequirements, defaults corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if resources != nil {
+		return *resources
+	}
+	return defaults
+}
+
 // OVSExternalIDs is a set of configuration options for OVS external-ids table
 type OVSExternalIDs struct {
 	// +kubebuilder:validation:Optional
@@ -161,6 +873,15 @@ type OVSExternalIDs struct {
 	// +kubebuilder:validation:Enum={"geneve","vxlan"}
 	OvnEncapType string `json:"ovn-encap-type,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// OvnEncapDstPort - UDP destination port of the tunnels to the chassis,
+	// the default port of ovn-encap-type when unset, 6081 for geneve and 4789
+	// for vxlan. A change is applied by the configuration Jobs one node at a
+	// time, without restarting the pods.
+	OvnEncapDstPort int32 `json:"ovn-encap-dst-port,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default={}
 	OvnAvailabilityZones []string `json:"availability-zones,omitempty"`
@@ -168,6 +889,18 @@ type OVSExternalIDs struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=true
 	EnableChassisAsGateway *bool `json:"enable-chassis-as-gateway"`
+
+	// +kubebuilder:validation:Optional
+	// GatewayNodeSelector - enable as gateways only the chassis of the nodes
+	// with these labels, enable-chassis-as-gateway applies to all the chassis
+	// when empty. Labeling a node reconfigures its chassis without restarting
+	// its pods.
+	GatewayNodeSelector map[string]string `json:"gateway-node-selector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OvnIsInterconn - use the chassis as gateway for the OVN Interconnect
+	// transit switches
+	OvnIsInterconn bool `json:"ovn-is-interconn,omitempty"`
 }
 
 // RbacConditionsSet - set the conditions for the rbac object
@@ -184,3 +917,26 @@ func (instance OVNController) RbacNamespace() string {
 func (instance OVNController) RbacResourceName() string {
 	return "ovncontroller-" + instance.Name
 }
+
+// OVSRbacResourceName - return the name of the rbac objects of the OVS pods
+func (instance OVNController) OVSRbacResourceName() string {
+	return instance.RbacResourceName() + "-ovs"
+}
+
+// ServiceAccountName - return the name of the ServiceAccount of the
+// ovn-controller pods, the externally managed one when set
+func (instance OVNController) ServiceAccountName() string {
+	if instance.Spec.ServiceAccount != "" {
+		return instance.Spec.ServiceAccount
+	}
+	return instance.RbacResourceName()
+}
+
+// OVSServiceAccountName - return the name of the ServiceAccount of the OVS
+// pods, the externally managed one when set
+func (instance OVNController) OVSServiceAccountName() string {
+	if instance.Spec.ServiceAccount != "" {
+		return instance.Spec.ServiceAccount
+	}
+	return instance.OVSRbacResourceName()
+}
@@ -17,8 +17,16 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -28,10 +36,32 @@ import (
 type OVNControllerDefaults struct {
 	OVSContainerImageURL           string
 	OVNControllerContainerImageURL string
+	ExporterContainerImageURL      string
+	KubeRbacProxyContainerImageURL string
+	BGPAgentContainerImageURL      string
+	ToolboxContainerImageURL       string
 }
 
 var ovnDefaults OVNControllerDefaults
 
+const (
+	// bridges and Geneve port of ovn-kubernetes on the nodes
+	ovnKubernetesIntegrationBridge = "br-int"
+	ovnKubernetesExternalBridge    = "br-ex"
+	ovnKubernetesGenevePort        = 6081
+
+	// default ports of the tunnels, a UDP port of a node is bound by the
+	// tunnels of a single type
+	defaultGenevePort = 6081
+	defaultVXLANPort  = 4789
+)
+
+// ovnControllerContainers - containers of the ovn-controller pods the
+// sidecars can't be named after
+var ovnControllerContainers = []string{
+	"ovn-controller", "openstack-network-exporter", "kube-rbac-proxy", "ovn-bgp-agent",
+}
+
 // log is for logging in this package.
 var ovncontrollerlog = logf.Log.WithName("ovncontroller-resource")
 
@@ -44,6 +74,7 @@ func SetupOVNControllerDefaults(defaults OVNControllerDefaults) {
 func (r *OVNController) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(&ovnControllerValidator{reader: mgr.GetAPIReader()}).
 		Complete()
 }
 
@@ -56,6 +87,7 @@ func (r *OVNController) Default() {
 	ovncontrollerlog.Info("default", "name", r.Name)
 
 	r.Spec.Default()
+	r.Spec.TLS.Default(r.Name)
 }
 
 // Default - set defaults for this OVNController spec
@@ -66,12 +98,51 @@ func (spec *OVNControllerSpec) Default() {
 	if spec.OvnContainerImage == "" {
 		spec.OvnContainerImage = ovnDefaults.OVNControllerContainerImageURL
 	}
+	if spec.ExporterContainerImage == "" {
+		spec.ExporterContainerImage = ovnDefaults.ExporterContainerImageURL
+	}
+	if spec.RbacProxyContainerImage == "" {
+		spec.RbacProxyContainerImage = ovnDefaults.KubeRbacProxyContainerImageURL
+	}
+	if spec.BGPAgentContainerImage == "" {
+		spec.BGPAgentContainerImage = ovnDefaults.BGPAgentContainerImageURL
+	}
+	if spec.ToolboxContainerImage == "" {
+		spec.ToolboxContainerImage = ovnDefaults.ToolboxContainerImageURL
+	}
 	spec.OVNControllerSpecCore.Default()
 }
 
 // Default - set defaults for this OVNController core spec (this version is called by OpenStackControlplane webhooks)
 func (spec *OVNControllerSpecCore) Default() {
-	// nothing here yet
+	// same values as the CRD defaults, for specs not defaulted by the API server
+	if spec.ExternalIDS.SystemID == "" {
+		spec.ExternalIDS.SystemID = "random"
+	}
+	if spec.ExternalIDS.OvnBridge == "" {
+		spec.ExternalIDS.OvnBridge = "br-int"
+	}
+	if spec.ExternalIDS.OvnEncapType == "" {
+		spec.ExternalIDS.OvnEncapType = "geneve"
+	}
+	if spec.ExternalIDS.EnableChassisAsGateway == nil {
+		enableChassisAsGateway := true
+		spec.ExternalIDS.EnableChassisAsGateway = &enableChassisAsGateway
+	}
+	if spec.GatewayDrainTimeout == 0 {
+		spec.GatewayDrainTimeout = 60
+	}
+	if spec.BGP != nil {
+		if spec.BGP.ExposingMethod == "" {
+			spec.BGP.ExposingMethod = "underlay"
+		}
+		if spec.BGP.AS == 0 {
+			spec.BGP.AS = 64999
+		}
+		if spec.BGP.FRRSocketPath == "" {
+			spec.BGP.FRRSocketPath = "/run/frr"
+		}
+	}
 }
 
 //+kubebuilder:webhook:path=/validate-ovn-openstack-org-v1beta1-ovncontroller,mutating=false,failurePolicy=fail,sideEffects=None,groups=ovn.openstack.org,resources=ovncontrollers,verbs=create;update,versions=v1beta1,name=vovncontroller.kb.io,admissionReviewVersions=v1
@@ -82,14 +153,159 @@ var _ webhook.Validator = &OVNController{}
 func (r *OVNController) ValidateCreate() (admission.Warnings, error) {
 	ovncontrollerlog.Info("validate create", "name", r.Name)
 
-	return nil, nil
+	return nil, r.validate()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *OVNController) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	ovncontrollerlog.Info("validate update", "name", r.Name)
 
-	return nil, nil
+	return nil, r.validate()
+}
+
+// validate - check the OVNController spec
+func (r *OVNController) validate() error {
+	return r.invalid(r.validateSpec(field.NewPath("spec")))
+}
+
+// invalid - the Invalid error of the OVNController, nil without errors
+func (r *OVNController) invalid(allErrs field.ErrorList) error {
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("OVNController").GroupKind(), r.Name, allErrs)
+	}
+	return nil
+}
+
+// validateSpec - check the OVNController spec on its own
+func (r *OVNController) validateSpec(basePath *field.Path) field.ErrorList {
+	allErrs := r.Spec.TLS.ValidateFIPS(r.Spec.FIPS, basePath)
+	allErrs = append(allErrs, r.Spec.TLS.ValidateCABundle(basePath)...)
+	allErrs = append(allErrs, r.Spec.TLS.ValidateCertificate(basePath)...)
+
+	switch r.Spec.ExternalIDS.OvnEncapType {
+	case "", "geneve", "vxlan":
+	default:
+		allErrs = append(allErrs, field.NotSupported(
+			basePath.Child("external-ids").Child("ovn-encap-type"),
+			r.Spec.ExternalIDS.OvnEncapType, []string{"geneve", "vxlan"}))
+	}
+
+	for i, chassis := range r.Spec.ExternalChassis {
+		if chassis.EncapIP != "" && net.ParseIP(chassis.EncapIP) == nil {
+			allErrs = append(allErrs, field.Invalid(
+				basePath.Child("externalChassis").Index(i).Child("encapIP"),
+				chassis.EncapIP, "not an IP address"))
+		}
+	}
+
+	allErrs = append(allErrs, r.validateEncapDstPort(basePath)...)
+	// the MTU of the pod network is set by the CNI
+	if r.Spec.TunnelMTU != 0 && r.Spec.NetworkAttachment == "" {
+		allErrs = append(allErrs, field.Invalid(
+			basePath.Child("tunnelMTU"), r.Spec.TunnelMTU,
+			"the MTU of the tunnels over the pod network is set by the CNI, set networkAttachment"))
+	}
+	if r.Spec.TunnelMTUCheck && r.Spec.TunnelMTU == 0 {
+		allErrs = append(allErrs, field.Invalid(
+			basePath.Child("tunnelMTUCheck"), r.Spec.TunnelMTUCheck,
+			"the packets are checked with the tunnel MTU, set tunnelMTU"))
+	}
+	if r.Spec.OVNKubernetesCoexistence {
+		allErrs = append(allErrs, r.validateOVNKubernetesCoexistence(basePath)...)
+	}
+	allErrs = append(allErrs, r.Spec.Sidecars.Validate(ovnControllerContainers, basePath)...)
+	extraArgsPath := basePath.Child("extraArgs")
+	allErrs = append(allErrs, ValidateExtraArgs(r.Spec.ExtraArgs.OVNController, extraArgsPath.Child("ovnController"))...)
+	allErrs = append(allErrs, ValidateExtraArgs(r.Spec.ExtraArgs.OVSVswitchd, extraArgsPath.Child("ovsVswitchd"))...)
+	if r.Spec.SBRemote != nil {
+		for member, weight := range r.Spec.SBRemote.Weights {
+			if weight < 1 || weight > 10 {
+				allErrs = append(allErrs, field.Invalid(
+					basePath.Child("sbRemote").Child("weights").Key(member), weight,
+					"must be between 1 and 10"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateEncapDstPort - the tunnels can't use the default port of the other
+// type of tunnels, which other overlays of the nodes are likely bound to
+func (r *OVNController) validateEncapDstPort(basePath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	otherEncapType, otherPort := "vxlan", int32(defaultVXLANPort)
+	if r.Spec.ExternalIDS.OvnEncapType == "vxlan" {
+		otherEncapType, otherPort = "geneve", int32(defaultGenevePort)
+	}
+	if r.Spec.ExternalIDS.OvnEncapDstPort == otherPort {
+		allErrs = append(allErrs, field.Invalid(
+			basePath.Child("external-ids").Child("ovn-encap-dst-port"), r.Spec.ExternalIDS.OvnEncapDstPort,
+			fmt.Sprintf("%d is the default port of the %s tunnels, set another one", otherPort, otherEncapType)))
+	}
+	return allErrs
+}
+
+// validateOVNKubernetesCoexistence - the bridges and the Geneve port of
+// ovn-kubernetes must not be used on the nodes it also runs on
+func (r *OVNController) validateOVNKubernetesCoexistence(basePath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	externalIDsPath := basePath.Child("external-ids")
+	if r.Spec.ExternalIDS.OvnBridge == "" || r.Spec.ExternalIDS.OvnBridge == ovnKubernetesIntegrationBridge {
+		allErrs = append(allErrs, field.Invalid(
+			externalIDsPath.Child("ovn-bridge"), r.Spec.ExternalIDS.OvnBridge,
+			fmt.Sprintf("%s is owned by ovn-kubernetes, set another integration bridge", ovnKubernetesIntegrationBridge)))
+	}
+	if r.Spec.ExternalIDS.OvnEncapType != "vxlan" &&
+		(r.Spec.ExternalIDS.OvnEncapDstPort == 0 || r.Spec.ExternalIDS.OvnEncapDstPort == ovnKubernetesGenevePort) {
+		allErrs = append(allErrs, field.Invalid(
+			externalIDsPath.Child("ovn-encap-dst-port"), r.Spec.ExternalIDS.OvnEncapDstPort,
+			fmt.Sprintf("the Geneve port %d is used by ovn-kubernetes, set another one", ovnKubernetesGenevePort)))
+	}
+	// the physical networks are connected to the br-<name> bridges
+	for physicalNetwork := range r.Spec.NicMappings {
+		if "br-"+physicalNetwork == ovnKubernetesExternalBridge {
+			allErrs = append(allErrs, field.Invalid(
+				basePath.Child("nicMappings").Key(physicalNetwork), r.Spec.NicMappings[physicalNetwork],
+				fmt.Sprintf("%s is owned by ovn-kubernetes, rename the physical network", ovnKubernetesExternalBridge)))
+		}
+	}
+	return allErrs
+}
+
+// validateNodeSelector - only one OVS and ovn-controller can run per node, so
+// the nodes selected by the OVNControllers must not overlap. The nodes are
+// shared by the namespaces, the OVNControllers of all of them are checked.
+func (r *OVNController) validateNodeSelector(
+	ctx context.Context, reader client.Reader, basePath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	ovnControllers := &OVNControllerList{}
+	if err := reader.List(ctx, ovnControllers); err != nil {
+		return append(allErrs, field.InternalError(basePath.Child("nodeSelector"), err))
+	}
+	for _, other := range ovnControllers.Items {
+		if (other.Namespace == r.Namespace && other.Name == r.Name) || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if nodeSelectorsOverlap(r.Spec.NodeSelector, other.Spec.NodeSelector) {
+			allErrs = append(allErrs, field.Invalid(
+				basePath.Child("nodeSelector"), r.Spec.NodeSelector,
+				fmt.Sprintf("nodes are also selected by OVNController %s/%s, set a label "+
+					"with different values in both nodeSelectors", other.Namespace, other.Name)))
+		}
+	}
+	return allErrs
+}
+
+// nodeSelectorsOverlap - a node can match both selectors unless they require
+// different values for the same label
+func nodeSelectorsOverlap(a map[string]string, b map[string]string) bool {
+	for label, value := range a {
+		if otherValue, ok := b[label]; ok && otherValue != value {
+			return false
+		}
+	}
+	return true
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -98,3 +314,55 @@ func (r *OVNController) ValidateDelete() (admission.Warnings, error) {
 
 	return nil, nil
 }
+
+// ovnControllerValidator - validating webhook of the manager, checks the
+// OVNController against the other instances in addition to its spec, which
+// is all OpenStackControlplane checks through webhook.Validator
+type ovnControllerValidator struct {
+	reader client.Reader
+}
+
+var _ webhook.CustomValidator = &ovnControllerValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *ovnControllerValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	r, ok := obj.(*OVNController)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OVNController but got a %T", obj))
+	}
+	ovncontrollerlog.Info("validate create", "name", r.Name)
+
+	basePath := field.NewPath("spec")
+	allErrs := r.validateSpec(basePath)
+	allErrs = append(allErrs, r.validateNodeSelector(ctx, v.reader, basePath)...)
+	return nil, r.invalid(allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator. The node selector is
+// only checked when it changes, an overlap left by an OVNController created
+// without the webhooks must not block the updates fixing it.
+func (v *ovnControllerValidator) ValidateUpdate(
+	ctx context.Context, oldObj runtime.Object, newObj runtime.Object,
+) (admission.Warnings, error) {
+	r, ok := newObj.(*OVNController)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OVNController but got a %T", newObj))
+	}
+	old, ok := oldObj.(*OVNController)
+	if !ok || old == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+	ovncontrollerlog.Info("validate update", "name", r.Name)
+
+	basePath := field.NewPath("spec")
+	allErrs := r.validateSpec(basePath)
+	if !equality.Semantic.DeepEqual(r.Spec.NodeSelector, old.Spec.NodeSelector) {
+		allErrs = append(allErrs, r.validateNodeSelector(ctx, v.reader, basePath)...)
+	}
+	return nil, r.invalid(allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *ovnControllerValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testOVNController(namespace string, name string, nodeSelector map[string]string) *OVNController {
+	instance := &OVNController{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+	instance.Spec.NodeSelector = nodeSelector
+	return instance
+}
+
+func testValidator(g *WithT, objs ...client.Object) *ovnControllerValidator {
+	scheme := runtime.NewScheme()
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+	return &ovnControllerValidator{
+		reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+	}
+}
+
+func TestValidateNodeSelectorAcrossNamespaces(t *testing.T) {
+	g := NewWithT(t)
+	v := testValidator(g, testOVNController("openstack", "ovn-controller", map[string]string{"ovn": "a"}))
+
+	// the DaemonSets of another namespace would run on the same nodes
+	_, err := v.ValidateCreate(context.TODO(),
+		testOVNController("openstack-2", "ovn-controller", map[string]string{"ovn": "a", "zone": "z1"}))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("nodes are also selected by OVNController openstack/ovn-controller"))
+
+	_, err = v.ValidateCreate(context.TODO(),
+		testOVNController("openstack-2", "ovn-controller", map[string]string{"ovn": "b"}))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestValidateUpdateUnchangedNodeSelector(t *testing.T) {
+	g := NewWithT(t)
+	// an overlap left by OVNControllers created without the webhooks
+	existing := testOVNController("openstack", "ovn-controller", map[string]string{"ovn": "a"})
+	v := testValidator(g, existing,
+		testOVNController("openstack-2", "ovn-controller", map[string]string{"ovn": "a"}))
+
+	updated := existing.DeepCopy()
+	updated.Spec.GatewayDrain = true
+	_, err := v.ValidateUpdate(context.TODO(), existing, updated)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	updated.Spec.NodeSelector = map[string]string{"ovn": "a", "zone": "z1"}
+	_, err = v.ValidateUpdate(context.TODO(), existing, updated)
+	g.Expect(err).To(HaveOccurred())
+
+	// the update fixing the overlap is accepted
+	updated.Spec.NodeSelector = map[string]string{"ovn": "b"}
+	_, err = v.ValidateUpdate(context.TODO(), existing, updated)
+	g.Expect(err).NotTo(HaveOccurred())
+}
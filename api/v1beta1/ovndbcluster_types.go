@@ -20,7 +20,7 @@ import (
 	"fmt"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,12 +33,41 @@ const (
 	// SBDBType - Southbound database type
 	SBDBType      = "SB"
 	ServiceNameSB = "ovsdbserver-sb"
+	// ICNBDBType - OVN Interconnect Northbound database type
+	ICNBDBType      = "ICNB"
+	ServiceNameICNB = "ovsdbserver-ic-nb"
+	// ICSBDBType - OVN Interconnect Southbound database type
+	ICSBDBType      = "ICSB"
+	ServiceNameICSB = "ovsdbserver-ic-sb"
 
 	// ServiceHeadlessType - Constant to identify Headless services
 	ServiceHeadlessType = "headless"
 	// ServiceClusterType - Constant to identify Cluster services
 	ServiceClusterType = "cluster"
 
+	// StorageRetentionDelete - PVCs are deleted together with the OVNDBCluster
+	StorageRetentionDelete = "Delete"
+	// StorageRetentionRetain - PVCs are kept when the OVNDBCluster is deleted
+	StorageRetentionRetain = "Retain"
+
+	// ConnectionConfigMapSuffix - suffix of the ConfigMap publishing the DB connection details
+	ConnectionConfigMapSuffix = "-connection"
+	// ConnectionInternalURLKey - ConfigMap key holding the DB address used by other Pods in the cluster
+	ConnectionInternalURLKey = "internal_url"
+	// ConnectionExternalURLKey - ConfigMap key holding the DB address used by external nodes
+	ConnectionExternalURLKey = "external_url"
+	// ConnectionTLSKey - ConfigMap key set to "true" when the DB requires TLS
+	ConnectionTLSKey = "tls"
+	// ConnectionCABundleSecretKey - ConfigMap key holding the name of the CA bundle Secret
+	ConnectionCABundleSecretKey = "ca_bundle_secret"
+
+	// OctaviaProviderSecretName - name of the Secret publishing the NB DB
+	// connection of the Octavia OVN provider driver
+	OctaviaProviderSecretName = "ovn-octavia-provider"
+	// OctaviaProviderNBConnectionKey - Secret key holding the NB DB address,
+	// the client cert and CA are in the tls.crt, tls.key and ca.crt keys
+	OctaviaProviderNBConnectionKey = "ovn_nb_connection"
+
 	// DNSSuffix : hardcoded value on how DNSCore domain is configured
 	DNSSuffix = "cluster.local"
 	// TODO: retrieve it from environment
@@ -57,6 +86,20 @@ type OVNDBClusterSpec struct {
 	// ContainerImage - Container Image URL (will be set to environmental default if empty)
 	ContainerImage string `json:"containerImage"`
 
+	// +kubebuilder:validation:Optional
+	// Suspend - stop modifying the resources owned by the instance, so manual
+	// interventions are not reverted. The status is still updated.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// DriftPolicy - Enforce reverts the manual changes of the fields the
+	// operator sets on the workloads. Report keeps a modified workload as is,
+	// e.g. an incident hotfix, and lists the modified fields in the Drifted
+	// condition, the spec changes are not rolled out to it either until the
+	// changes are reverted or the policy is Enforce again.
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
 	OVNDBClusterSpecCore `json:",inline"`
 }
 
@@ -65,8 +108,8 @@ type OVNDBClusterSpecCore struct {
 
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default="NB"
-	// +kubebuilder:validation:Pattern="^NB|SB$"
-	// DBType - NB or SB
+	// +kubebuilder:validation:Pattern="^(NB|SB|ICNB|ICSB)$"
+	// DBType - NB or SB, ICNB or ICSB for the OVN Interconnect databases
 	DBType string `json:"dbType"`
 
 	// +kubebuilder:validation:Optional
@@ -85,6 +128,23 @@ type OVNDBClusterSpecCore struct {
 	// LogLevel - Set log level info, dbg, emer etc
 	LogLevel string `json:"logLevel,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// LogModules - additional per module log levels passed to ovsdb-server as -v<module>:<level>, e.g. jsonrpc:dbg
+	LogModules []string `json:"logModules,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// LogFile - write the ovsdb-server log to a rotated file in addition to the console
+	LogFile OVNDBClusterLogFile `json:"logFile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=small;medium;large;custom;auto
+	// TuningProfile - small, medium or large apply the election timer,
+	// inactivity probe and probe interval to active of the preset for the
+	// size of the cluster instead of the fields of the spec, auto chooses one
+	// of them for the number of chassis of the namespace, custom or unset
+	// uses the fields of the spec
+	TuningProfile string `json:"tuningProfile,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=10000
 	// OVN Northbound and Southbound RAFT db election timer to use on db creation (in milliseconds)
@@ -100,6 +160,20 @@ type OVNDBClusterSpecCore struct {
 	// Active probe interval from standby to active ovsdb-server remote
 	ProbeIntervalToActive int32 `json:"probeIntervalToActive"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	// ClusterStatusInterval - how often (in seconds) the RAFT cluster status and the member metrics are refreshed, 0 disables them
+	ClusterStatusInterval int32 `json:"clusterStatusInterval"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// ConsistencyCheckInterval - how often (in seconds) the database files of
+	// the members are checked with ovsdb-tool check-cluster and, for a SB DB,
+	// its datapath bindings and logical flows are cross-checked against the
+	// NB DB. 0 disables the checks.
+	ConsistencyCheckInterval int32 `json:"consistencyCheckInterval,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// Resources - Compute Resources required by this service (Limits/Requests).
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
@@ -113,6 +187,12 @@ type OVNDBClusterSpecCore struct {
 	// StorageRequest
 	StorageRequest string `json:"storageRequest"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Delete
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// StorageRetention - Delete or Retain the PVCs holding the database when the OVNDBCluster is deleted
+	StorageRetention string `json:"storageRetention,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// NetworkAttachment is a NetworkAttachment resource name to expose the service to the given network.
 	// If specified the IP address of this network is used as the dbAddress connection.
@@ -121,7 +201,88 @@ type OVNDBClusterSpecCore struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// TLS - Parameters related to TLS
-	TLS tls.SimpleService `json:"tls,omitempty"`
+	TLS TLSSection `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// FIPS - restrict the OVN connections to FIPS approved TLS protocols and
+	// ciphers, and report in the FIPSProviderReady condition whether the
+	// OpenSSL FIPS provider is active in the containers. Requires TLS.
+	FIPS bool `json:"fips,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NetworkPolicy - restrict which clients can reach the database ports
+	NetworkPolicy OVNDBClusterNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Alerts - alert when the RAFT cluster has no leader or the database
+	// keeps growing. Requires a ClusterStatusInterval, the alerts are based
+	// on the member metrics.
+	Alerts AlertsSection `json:"alerts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OctaviaProvider - publish the DB address and a client cert dedicated to
+	// the Octavia OVN provider driver in the OctaviaProviderSecretName Secret.
+	// NB only, the client cert is requested from tls.issuer when TLS is enabled.
+	OctaviaProvider bool `json:"octaviaProvider,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Override - overrides of the resources generated for the DB
+	Override OVNDBClusterOverrideSpec `json:"override,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - options appended to the command line of ovsdb-server, for
+	// the ones the API doesn't model, by option name, e.g. --option or
+	// -vmodule:level, with an empty value for the options without value
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+}
+
+// OVNDBClusterOverrideSpec defines the overrides of the generated resources
+type OVNDBClusterOverrideSpec struct {
+	// +kubebuilder:validation:Optional
+	// Services - override of the Services of the cluster members, e.g. to
+	// expose them with a LoadBalancer. The headless Service is not overridden.
+	Services *service.OverrideSpec `json:"services,omitempty"`
+}
+
+// OVNDBClusterNetworkPolicy defines the NetworkPolicy protecting the database ports.
+// The policy applies to the pod network only, connections through the
+// NetworkAttachment are not filtered by it.
+type OVNDBClusterNetworkPolicy struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - only allow ovn-northd, ovn-ic, ovn-controller and the other
+	// cluster members to connect, plus the additionally allowed clients below
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// AllowedCIDRs - additional source networks allowed to connect to the
+	// database port, e.g. of external chassis
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AllowedServices - values of the service label of additional pods in the
+	// namespace allowed to connect to the database port, e.g. neutron
+	AllowedServices []string `json:"allowedServices,omitempty"`
+}
+
+// OVNDBClusterLogFile defines the ovsdb-server file logging
+type OVNDBClusterLogFile struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - write the log to /var/log/ovn in the DB pods
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// MaxSizeMB - size (in megabytes) at which the log file gets rotated
+	MaxSizeMB int32 `json:"maxSizeMB"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// MaxFiles - number of rotated log files to keep
+	MaxFiles int32 `json:"maxFiles"`
 }
 
 // OVNDBClusterStatus defines the observed state of OVNDBCluster
@@ -146,10 +307,118 @@ type OVNDBClusterStatus struct {
 
 	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ConnectionConfigMap - name of the ConfigMap publishing the DB connection details
+	ConnectionConfigMap string `json:"connectionConfigMap,omitempty"`
+
+	// EndpointsConfigMap - name of the ConfigMap publishing the NB and SB DB
+	// endpoints for the OpenStack service operators, see EndpointsConfigMapName
+	EndpointsConfigMap string `json:"endpointsConfigMap,omitempty"`
+
+	// OctaviaProviderSecret - name of the Secret publishing the NB DB
+	// connection of the Octavia OVN provider driver, once published
+	OctaviaProviderSecret string `json:"octaviaProviderSecret,omitempty"`
+
+	// TLS - whether the DB requires TLS
+	TLS bool `json:"tls,omitempty"`
+
+	// CaBundleSecretName - name of the Secret with the CA bundle validating
+	// the cert of the DB
+	CaBundleSecretName string `json:"caBundleSecretName,omitempty"`
+
+	// ClusterID - RAFT cluster ID of the database
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// ClusterMembers - RAFT cluster members as reported by their ovsdb-server
+	ClusterMembers []OVNDBClusterMember `json:"clusterMembers,omitempty"`
+
+	// ContainerImage - image all the members were rolled out with, ovn-northd
+	// and ovn-controller wait for it to match the spec before an upgrade
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// SchemaUpgrade - result of the last pre-flight schema upgrade Job
+	SchemaUpgrade *OVNDBClusterSchemaUpgrade `json:"schemaUpgrade,omitempty"`
+
+	// ConsistencyCheck - result of the last consistency check
+	ConsistencyCheck *OVNDBClusterConsistencyCheck `json:"consistencyCheck,omitempty"`
+
+	// StaleChassis - chassis without node the SB DB janitor found half
+	// deleted on its last scan, they are deleted if the next scan finds them
+	// half deleted again
+	StaleChassis []string `json:"staleChassis,omitempty"`
+
+	// Tuning - the profile chosen for the auto tuning profile
+	Tuning TuningStatus `json:"tuning,omitempty"`
+}
+
+// OVNDBClusterConsistencyCheck defines the result of a consistency check
+type OVNDBClusterConsistencyCheck struct {
+	// LastCheck - when the check ran
+	LastCheck metav1.Time `json:"lastCheck"`
+
+	// Problems - the inconsistencies found, empty when the check passed
+	Problems []string `json:"problems,omitempty"`
+}
+
+// OVNDBClusterSchemaUpgrade defines the result of the Job validating the
+// conversion of the database schema before a new image is rolled out
+type OVNDBClusterSchemaUpgrade struct {
+	// ContainerImage - image the database schema conversion was validated with
+	ContainerImage string `json:"containerImage"`
+
+	// Succeeded - whether the database converts to the schema of the image
+	Succeeded bool `json:"succeeded"`
+
+	// Message - schema versions or error reported by the Job
+	Message string `json:"message,omitempty"`
+}
+
+// OVNDBClusterMember defines the RAFT state of a single OVNDBCluster pod
+type OVNDBClusterMember struct {
+	// Name - name of the pod running the member
+	Name string `json:"name"`
+
+	// ServerID - RAFT server ID of the member
+	ServerID string `json:"serverID,omitempty"`
+
+	// Address - RAFT address of the member
+	Address string `json:"address,omitempty"`
+
+	// Role - RAFT role of the member (leader, follower or candidate)
+	Role string `json:"role,omitempty"`
+
+	// Lag - number of log entries the member has not yet applied
+	Lag int64 `json:"lag,omitempty"`
+
+	// Term - current RAFT term, it increases with every election
+	Term int64 `json:"term,omitempty"`
+
+	// LogIndex - end index of the RAFT log of the member
+	LogIndex int64 `json:"logIndex,omitempty"`
+
+	// ElectionTimer - RAFT election timer of the member in milliseconds
+	ElectionTimer int64 `json:"electionTimer,omitempty"`
+
+	// DBSize - size of the database file in bytes, it grows with the RAFT
+	// log until the next compaction
+	DBSize int64 `json:"dbSize,omitempty"`
+
+	// Sessions - number of clients connected to the member
+	Sessions int64 `json:"sessions,omitempty"`
+
+	// Monitors - number of monitors the clients registered on the member
+	Monitors int64 `json:"monitors,omitempty"`
+
+	// Version - OVS version as reported by ovsdb-server
+	Version string `json:"version,omitempty"`
+
+	// SchemaVersion - version of the database schema served by the member
+	SchemaVersion string `json:"schemaVersion,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="NetworkAttachments",type="string",JSONPath=".status.networkAttachments",description="NetworkAttachments"
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
@@ -213,3 +482,22 @@ func (instance OVNDBCluster) GetExternalEndpoint() (string, error) {
 	}
 	return instance.Status.DBAddress, nil
 }
+
+// GetServiceName - return the name of the StatefulSet and Services of the DB
+func (instance OVNDBCluster) GetServiceName() string {
+	switch instance.Spec.DBType {
+	case SBDBType:
+		return ServiceNameSB
+	case ICNBDBType:
+		return ServiceNameICNB
+	case ICSBDBType:
+		return ServiceNameICSB
+	}
+	return ServiceNameNB
+}
+
+// GetConnectionConfigMapName - return the name of the ConfigMap publishing
+// the connection details of the DB to its consumers
+func (instance OVNDBCluster) GetConnectionConfigMapName() string {
+	return instance.GetServiceName() + ConnectionConfigMapSuffix
+}
@@ -23,7 +23,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -63,14 +68,16 @@ func (r *OVNDBCluster) Default() {
 	ovndbclusterlog.Info("default", "name", r.Name)
 
 	r.Spec.Default()
+	r.Spec.TLS.Default(r.Name)
 }
 
 // Default - set defaults for this OVNDBCluster spec
 func (spec *OVNDBClusterSpec) Default() {
 	if spec.ContainerImage == "" {
-		if spec.DBType == NBDBType {
+		// the interconnect DB schemas ship with the NB and SB images
+		if spec.DBType == NBDBType || spec.DBType == ICNBDBType {
 			spec.ContainerImage = ovnDbClusterDefaults.NBContainerImageURL
-		} else if spec.DBType == SBDBType {
+		} else if spec.DBType == SBDBType || spec.DBType == ICSBDBType {
 			spec.ContainerImage = ovnDbClusterDefaults.SBContainerImageURL
 		}
 	}
@@ -79,7 +86,21 @@ func (spec *OVNDBClusterSpec) Default() {
 
 // Default - set defaults for this OVNDBCluster core spec (this version is called by OpenStackControlplane webhooks)
 func (spec *OVNDBClusterSpecCore) Default() {
-	// nothing here yet
+	// same values as the CRD defaults, for specs not defaulted by the API server
+	if spec.Replicas == nil {
+		replicas := int32(1)
+		spec.Replicas = &replicas
+	}
+	if spec.LogLevel == "" {
+		spec.LogLevel = "info"
+	}
+	// 0 disables the inactivity probes, but is not a valid election timer
+	if spec.ElectionTimer == 0 {
+		spec.ElectionTimer = 10000
+	}
+	if spec.StorageRetention == "" {
+		spec.StorageRetention = StorageRetentionDelete
+	}
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
@@ -91,16 +112,99 @@ var _ webhook.Validator = &OVNDBCluster{}
 func (r *OVNDBCluster) ValidateCreate() (admission.Warnings, error) {
 	ovndbclusterlog.Info("validate create", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
-	return nil, nil
+	return append(r.replicaWarnings(), r.tuningWarnings()...), r.validate(field.ErrorList{})
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *OVNDBCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	ovndbclusterlog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	oldDBCluster, ok := old.(*OVNDBCluster)
+	if !ok || oldDBCluster == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	// the DB files are created for the schema of the DB type in a PVC of the
+	// storage class, neither can be changed in place
+	basePath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+	if r.Spec.DBType != oldDBCluster.Spec.DBType {
+		allErrs = append(allErrs, field.Forbidden(
+			basePath.Child("dbType"), "dbType is immutable, create a new OVNDBCluster instead"))
+	}
+	if r.Spec.StorageClass != oldDBCluster.Spec.StorageClass {
+		allErrs = append(allErrs, field.Forbidden(
+			basePath.Child("storageClass"),
+			"storageClass is immutable, back up the DB and restore it into a new OVNDBCluster instead"))
+	}
+
+	return append(r.replicaWarnings(), r.tuningWarnings()...), r.validate(allErrs)
+}
+
+// replicaWarnings - a raft cluster of N members tolerates the loss of
+// (N-1)/2 of them, so an even member does not add any fault tolerance
+func (r *OVNDBCluster) replicaWarnings() admission.Warnings {
+	if r.Spec.Replicas != nil && *r.Spec.Replicas > 0 && *r.Spec.Replicas%2 == 0 {
+		return admission.Warnings{fmt.Sprintf(
+			"spec.replicas: %d members tolerate the same number of failures as %d, use an odd number of replicas",
+			*r.Spec.Replicas, *r.Spec.Replicas-1)}
+	}
+	return nil
+}
+
+// tuningWarnings - a tuning profile other than custom overrides the fields
+// of the spec it tunes, warn when they are not the CRD defaults
+func (r *OVNDBCluster) tuningWarnings() admission.Warnings {
+	if r.Spec.TuningProfile == "" || r.Spec.TuningProfile == TuningProfileCustom {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, f := range []struct {
+		name         string
+		value        int32
+		defaultValue int32
+	}{
+		{"electionTimer", r.Spec.ElectionTimer, 10000},
+		{"inactivityProbe", r.Spec.InactivityProbe, 60000},
+		{"probeIntervalToActive", r.Spec.ProbeIntervalToActive, 60000},
+	} {
+		if f.value != f.defaultValue {
+			warnings = append(warnings, fmt.Sprintf(
+				"spec.%s: ignored, the %s tuning profile sets it, use the custom tuning profile to set it",
+				f.name, r.Spec.TuningProfile))
+		}
+	}
+	return warnings
+}
+
+// validate - check the OVNDBCluster spec
+func (r *OVNDBCluster) validate(allErrs field.ErrorList) error {
+	basePath := field.NewPath("spec")
+	allErrs = append(allErrs, r.Spec.TLS.ValidateFIPS(r.Spec.FIPS, basePath)...)
+	allErrs = append(allErrs, r.Spec.TLS.ValidateCABundle(basePath)...)
+	allErrs = append(allErrs, r.Spec.TLS.ValidateCertificate(basePath)...)
+	for i, cidr := range r.Spec.NetworkPolicy.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				basePath.Child("networkPolicy").Child("allowedCIDRs").Index(i), cidr, err.Error()))
+		}
+	}
+	if r.Spec.OctaviaProvider {
+		if r.Spec.DBType != NBDBType {
+			allErrs = append(allErrs, field.Invalid(
+				basePath.Child("octaviaProvider"), r.Spec.OctaviaProvider,
+				"the Octavia OVN provider driver only connects to the NB DB"))
+		} else if r.Spec.TLS.Enabled() && r.Spec.TLS.Issuer == "" {
+			allErrs = append(allErrs, field.Invalid(
+				basePath.Child("octaviaProvider"), r.Spec.OctaviaProvider,
+				"the client cert of the Octavia OVN provider driver is requested from tls.issuer, set it"))
+		}
+	}
+	allErrs = append(allErrs, ValidateExtraArgs(r.Spec.ExtraArgs, basePath.Child("extraArgs"))...)
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("OVNDBCluster").GroupKind(), r.Name, allErrs)
+	}
+	return nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
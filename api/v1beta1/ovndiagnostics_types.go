@@ -0,0 +1,101 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OVNDiagnosticsSpec defines the desired state of OVNDiagnostics
+type OVNDiagnosticsSpec struct {
+	// +kubebuilder:validation:Optional
+	// ContainerImage - Container Image URL of the collection Jobs, the image
+	// of the ovn-controller pods if empty
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// StorageClass - storage class of the PVC the archive is written to.
+	// The collection Jobs run one after the other, the volumes of the class
+	// have to be attachable on the given nodes.
+	StorageClass string `json:"storageClass"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1G"
+	// StorageRequest - size of the PVC the archive is written to
+	StorageRequest string `json:"storageRequest"`
+
+	// +kubebuilder:validation:Optional
+	// Nodes - names of the nodes whose OVS and ovn-controller state is
+	// collected: the OVS configuration, the flows of the integration bridge
+	// and the coverage counters
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// OVNDiagnosticsStatus defines the observed state of OVNDiagnostics
+type OVNDiagnosticsStatus struct {
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// PersistentVolumeClaim - name of the PVC holding the archive
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+
+	// Archive - path of the archive in the PVC
+	Archive string `json:"archive,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="PVC",type="string",JSONPath=".status.persistentVolumeClaim",description="PVC"
+//+kubebuilder:printcolumn:name="Archive",type="string",JSONPath=".status.archive",description="Archive"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNDiagnostics is the Schema for the ovndiagnostics API
+type OVNDiagnostics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNDiagnosticsSpec   `json:"spec,omitempty"`
+	Status OVNDiagnosticsStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNDiagnosticsList contains a list of OVNDiagnostics
+type OVNDiagnosticsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNDiagnostics `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNDiagnostics{}, &OVNDiagnosticsList{})
+}
+
+// IsReady - returns true if the diagnostics got archived
+func (instance OVNDiagnostics) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
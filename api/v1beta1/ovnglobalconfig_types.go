@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OVNGlobalConfigSpec defines the desired state of OVNGlobalConfig
+type OVNGlobalConfigSpec struct {
+	// +kubebuilder:validation:Optional
+	// NBGlobalOptions - options of the NB_Global record of the NB DB of the
+	// namespace, by option name, e.g. ignore_lsp_down or debug_drop_mode.
+	// ovn-northd copies them into the SB_Global options. The options set by
+	// other clients are left alone, the ones removed from the spec are
+	// removed from NB_Global.
+	NBGlobalOptions map[string]string `json:"nbGlobalOptions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// DriftPolicy - Enforce reverts the manual changes of the options set
+	// from the spec, e.g. by ovn-nbctl. Report keeps them and lists them in
+	// the Drifted condition until they are reverted or the policy is Enforce
+	// again.
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+}
+
+// OVNGlobalConfigStatus defines the observed state of OVNGlobalConfig
+type OVNGlobalConfigStatus struct {
+	// Options - the NB_Global options last set from the spec, the manual
+	// changes are detected against them
+	Options map[string]string `json:"options,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNGlobalConfig is the Schema for the ovnglobalconfigs API
+type OVNGlobalConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNGlobalConfigSpec   `json:"spec,omitempty"`
+	Status OVNGlobalConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNGlobalConfigList contains a list of OVNGlobalConfig
+type OVNGlobalConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNGlobalConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNGlobalConfig{}, &OVNGlobalConfigList{})
+}
+
+// IsReady - returns true if the options are applied
+func (instance OVNGlobalConfig) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
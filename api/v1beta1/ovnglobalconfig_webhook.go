@@ -0,0 +1,122 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//
+// Generated by:
+//
+// operator-sdk create webhook --group ovn --version v1beta1 --kind OVNGlobalConfig --programmatic-validation
+//
+
+package v1beta1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var ovnglobalconfiglog = logf.Log.WithName("ovnglobalconfig-resource")
+
+var (
+	// nbGlobalOptionNameRegex - the names of the NB_Global options
+	nbGlobalOptionNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// nbGlobalOptionValueRegex - the values are quoted in the ovn-nbctl
+	// command line, so they can't hold quotes or escapes
+	nbGlobalOptionValueRegex = regexp.MustCompile(`^[A-Za-z0-9_.:/,=@+%-]*$`)
+)
+
+// ovnNorthdNBGlobalOptions - the NB_Global options set from the OVNNorthd
+// spec, by the field setting them
+var ovnNorthdNBGlobalOptions = map[string]string{
+	"northd_probe_interval":      "probeInterval",
+	"northd-backoff-interval-ms": "backoffInterval",
+	"mac_binding_age_threshold":  "dataRetention.macBindingAgeThreshold",
+	"mac_binding_removal_limit":  "dataRetention.macBindingRemovalLimit",
+	"fdb_age_threshold":          "dataRetention.fdbAgeThreshold",
+	"fdb_removal_limit":          "dataRetention.fdbRemovalLimit",
+}
+
+// SetupWebhookWithManager sets up the webhook with the Manager
+func (r *OVNGlobalConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-ovn-openstack-org-v1beta1-ovnglobalconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=ovn.openstack.org,resources=ovnglobalconfigs,verbs=create;update,versions=v1beta1,name=vovnglobalconfig.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OVNGlobalConfig{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNGlobalConfig) ValidateCreate() (admission.Warnings, error) {
+	ovnglobalconfiglog.Info("validate create", "name", r.Name)
+
+	return nil, r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNGlobalConfig) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	ovnglobalconfiglog.Info("validate update", "name", r.Name)
+
+	return nil, r.validate()
+}
+
+// validate - check the OVNGlobalConfig spec
+func (r *OVNGlobalConfig) validate() error {
+	allErrs := field.ErrorList{}
+	path := field.NewPath("spec").Child("nbGlobalOptions")
+
+	names := make([]string, 0, len(r.Spec.NBGlobalOptions))
+	for name := range r.Spec.NBGlobalOptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := r.Spec.NBGlobalOptions[name]
+		if !nbGlobalOptionNameRegex.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), name,
+				"must only contain letters, digits, _ and -"))
+		}
+		if !nbGlobalOptionValueRegex.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), value,
+				"must not contain whitespaces, quotes or escapes"))
+		}
+		if northdField, ok := ovnNorthdNBGlobalOptions[name]; ok {
+			allErrs = append(allErrs, field.Forbidden(path.Key(name),
+				fmt.Sprintf("%s is set from the OVNNorthd, use its spec.%s instead", name, northdField)))
+		}
+	}
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("OVNGlobalConfig").GroupKind(), r.Name, allErrs)
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNGlobalConfig) ValidateDelete() (admission.Warnings, error) {
+	ovnglobalconfiglog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
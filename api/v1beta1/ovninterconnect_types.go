@@ -0,0 +1,163 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Container image fall-back defaults
+
+	// OVNInterconnectContainerImage is the fall-back container image for OVNInterconnect
+	OVNInterconnectContainerImage = "quay.io/podified-antelope-centos9/openstack-ovn-northd:current-podified"
+	// ServiceNameOVNInterconnect -
+	ServiceNameOVNInterconnect = "ovn-ic"
+)
+
+// OVNInterconnectSpec defines the desired state of OVNInterconnect
+type OVNInterconnectSpec struct {
+	// +kubebuilder:validation:Required
+	// ContainerImage - Container Image URL (will be set to environmental default if empty)
+	ContainerImage string `json:"containerImage"`
+
+	// +kubebuilder:validation:Optional
+	// Suspend - stop modifying the resources owned by the instance, so manual
+	// interventions are not reverted. The status is still updated.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// DriftPolicy - Enforce reverts the manual changes of the fields the
+	// operator sets on the workloads. Report keeps a modified workload as is,
+	// e.g. an incident hotfix, and lists the modified fields in the Drifted
+	// condition, the spec changes are not rolled out to it either until the
+	// changes are reverted or the policy is Enforce again.
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
+	OVNInterconnectSpecCore `json:",inline"`
+}
+
+// OVNInterconnectSpecCore -
+type OVNInterconnectSpecCore struct {
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9_.-]+$"
+	// AvailabilityZone - name of this OVN deployment in the interconnection,
+	// it is set as the NB_Global name and must be unique across the zones
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Maximum=32
+	// +kubebuilder:validation:Minimum=0
+	// Replicas of ovn-ic to run
+	Replicas *int32 `json:"replicas"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector to target subset of worker nodes running this service
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=info
+	// LogLevel - Set log level info, dbg, emer etc
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Resources - Compute Resources required by this service (Limits/Requests).
+	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TLS - Parameters related to TLS
+	TLS TLSSection `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Sidecars - additional containers in the ovn-ic pods, e.g. log shippers
+	// or monitoring agents
+	Sidecars SidecarSection `json:"sidecars,omitempty"`
+}
+
+// OVNInterconnectStatus defines the observed state of OVNInterconnect
+type OVNInterconnectStatus struct {
+	// ReadyCount of ovn-ic instances
+	ReadyCount int32 `json:"readyCount,omitempty"`
+
+	// Versions - number of running ovn-ic pods per OVN version, as reported
+	// by ovn-ic
+	Versions map[string]int32 `json:"versions,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.availabilityZone",description="Zone"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNInterconnect is the Schema for the ovninterconnects API
+type OVNInterconnect struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNInterconnectSpec   `json:"spec,omitempty"`
+	Status OVNInterconnectStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNInterconnectList contains a list of OVNInterconnect
+type OVNInterconnectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNInterconnect `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNInterconnect{}, &OVNInterconnectList{})
+}
+
+// IsReady - returns true if service is ready to server requests
+func (instance OVNInterconnect) IsReady() bool {
+	// Ready when:
+	// OVNInterconnect is reconciled successfully
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
+
+// RbacConditionsSet - set the conditions for the rbac object
+func (instance OVNInterconnect) RbacConditionsSet(c *condition.Condition) {
+	instance.Status.Conditions.Set(c)
+}
+
+// RbacNamespace - return the namespace
+func (instance OVNInterconnect) RbacNamespace() string {
+	return instance.Namespace
+}
+
+// RbacResourceName - return the name to be used for rbac objects (serviceaccount, role, rolebinding)
+func (instance OVNInterconnect) RbacResourceName() string {
+	return "ovninterconnect-" + instance.Name
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//
+// Generated by:
+//
+// operator-sdk create webhook --group ovn --version v1beta1 --kind OVNInterconnect --programmatic-validation --defaulting
+//
+
+package v1beta1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// OVNInterconnectDefaults -
+type OVNInterconnectDefaults struct {
+	ContainerImageURL string
+}
+
+var ovnInterconnectDefaults OVNInterconnectDefaults
+
+// log is for logging in this package.
+var ovninterconnectlog = logf.Log.WithName("ovninterconnect-resource")
+
+// SetupOVNInterconnectDefaults - initialize OVNInterconnect spec defaults for use with either internal or external webhooks
+func SetupOVNInterconnectDefaults(defaults OVNInterconnectDefaults) {
+	ovnInterconnectDefaults = defaults
+	ovninterconnectlog.Info("OVNInterconnect defaults initialized", "defaults", defaults)
+}
+
+// SetupWebhookWithManager sets up the webhook with the Manager
+func (r *OVNInterconnect) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-ovn-openstack-org-v1beta1-ovninterconnect,mutating=true,failurePolicy=fail,sideEffects=None,groups=ovn.openstack.org,resources=ovninterconnects,verbs=create;update,versions=v1beta1,name=movninterconnect.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &OVNInterconnect{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *OVNInterconnect) Default() {
+	ovninterconnectlog.Info("default", "name", r.Name)
+
+	r.Spec.Default()
+	r.Spec.TLS.Default(r.Name)
+}
+
+// Default - set defaults for this OVNInterconnect spec
+func (spec *OVNInterconnectSpec) Default() {
+	if spec.ContainerImage == "" {
+		spec.ContainerImage = ovnInterconnectDefaults.ContainerImageURL
+	}
+	spec.OVNInterconnectSpecCore.Default()
+}
+
+// Default - set defaults for this OVNInterconnect core spec (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNInterconnectSpecCore) Default() {
+	// same values as the CRD defaults, for specs not defaulted by the API server
+	if spec.Replicas == nil {
+		replicas := int32(1)
+		spec.Replicas = &replicas
+	}
+	if spec.LogLevel == "" {
+		spec.LogLevel = "info"
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-ovn-openstack-org-v1beta1-ovninterconnect,mutating=false,failurePolicy=fail,sideEffects=None,groups=ovn.openstack.org,resources=ovninterconnects,verbs=create;update,versions=v1beta1,name=vovninterconnect.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OVNInterconnect{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNInterconnect) ValidateCreate() (admission.Warnings, error) {
+	ovninterconnectlog.Info("validate create", "name", r.Name)
+
+	return nil, r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNInterconnect) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	ovninterconnectlog.Info("validate update", "name", r.Name)
+
+	return nil, r.validate()
+}
+
+// validate - check the OVNInterconnect spec
+func (r *OVNInterconnect) validate() error {
+	basePath := field.NewPath("spec")
+	allErrs := r.Spec.TLS.ValidateCABundle(basePath)
+	allErrs = append(allErrs, r.Spec.TLS.ValidateCertificate(basePath)...)
+	allErrs = append(allErrs, r.Spec.Sidecars.Validate([]string{ServiceNameOVNInterconnect}, basePath)...)
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("OVNInterconnect").GroupKind(), r.Name, allErrs)
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *OVNInterconnect) ValidateDelete() (admission.Warnings, error) {
+	ovninterconnectlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
@@ -18,7 +18,6 @@ package v1beta1
 
 import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +40,20 @@ type OVNNorthdSpec struct {
 	// ContainerImage - Container Image URL (will be set to environmental default if empty)
 	ContainerImage string `json:"containerImage"`
 
+	// +kubebuilder:validation:Optional
+	// Suspend - stop modifying the resources owned by the instance, so manual
+	// interventions are not reverted. The status is still updated.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;Report
+	// DriftPolicy - Enforce reverts the manual changes of the fields the
+	// operator sets on the workloads. Report keeps a modified workload as is,
+	// e.g. an incident hotfix, and lists the modified fields in the Drifted
+	// condition, the spec changes are not rolled out to it either until the
+	// changes are reverted or the policy is Enforce again.
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
 	OVNNorthdSpecCore `json:",inline"`
 }
 
@@ -54,6 +67,13 @@ type OVNNorthdSpecCore struct {
 	// Replicas of OVN Northd to run
 	Replicas *int32 `json:"replicas"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MinAvailable - minimum number of ovn-northd replicas kept running during
+	// voluntary disruptions, defaults to 1. A PodDisruptionBudget is only
+	// created when running more replicas than that, 0 disables it.
+	MinAvailable *int32 `json:"minAvailable,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// NodeSelector to target subset of worker nodes running this service
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
@@ -63,6 +83,14 @@ type OVNNorthdSpecCore struct {
 	// LogLevel - Set log level info, dbg, emer etc
 	LogLevel string `json:"logLevel,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// LogModules - additional per module log levels passed to ovn-northd as -v<module>:<level>, e.g. northd:dbg
+	LogModules []string `json:"logModules,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DryRun - start ovn-northd with --dry-run, it monitors the databases but does not apply any change to them
+	DryRun bool `json:"dryRun,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// Resources - Compute Resources required by this service (Limits/Requests).
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
@@ -71,12 +99,109 @@ type OVNNorthdSpecCore struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// TLS - Parameters related to TLS
-	TLS tls.SimpleService `json:"tls,omitempty"`
+	TLS TLSSection `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// FIPS - restrict the OVN connections to FIPS approved TLS protocols and
+	// ciphers, and report in the FIPSProviderReady condition whether the
+	// OpenSSL FIPS provider is active in the containers. Requires TLS.
+	FIPS bool `json:"fips,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
 	// NThreads sets number of threads used for building logical flows
 	NThreads *int32 `json:"nThreads"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=small;medium;large;custom;auto
+	// TuningProfile - small, medium or large apply the probe interval of the
+	// preset for the size of the cluster instead of probeInterval, auto
+	// chooses one of them for the number of chassis of the namespace, custom
+	// or unset uses probeInterval
+	TuningProfile string `json:"tuningProfile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// ProbeInterval - interval in milliseconds of the inactivity probes sent by
+	// ovn-northd on its NB and SB database connections, stored in NB_Global
+	// options:northd_probe_interval. 0 disables the probes, ovn-northd uses
+	// 5000 when unset. Raise it when large SB databases cause reconnects.
+	ProbeInterval *int32 `json:"probeInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// BackoffInterval - minimum interval in milliseconds between two ovn-northd
+	// recomputations, batching the SB writes of bursts of NB changes. Stored in
+	// NB_Global options:northd-backoff-interval-ms, unset keeps the default.
+	BackoffInterval *int32 `json:"backoffInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DataRetention - aging of the learnt SB DB records, stored in the
+	// NB_Global options, so the MAC_Binding and FDB tables don't grow without
+	// bounds
+	DataRetention OVNNorthdDataRetention `json:"dataRetention,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Paused - pause ovn-northd so the SB database is not recomputed, e.g.
+	// during bulk NB changes or DB maintenance
+	Paused bool `json:"paused,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Alerts - alert when no ovn-northd replica is active while ovn-northd
+	// is not paused
+	Alerts AlertsSection `json:"alerts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Telemetry - collect the coverage counters and the memory usage of the
+	// replicas with every replica status refresh, and serve them as the
+	// ovn_coverage_events and ovn_memory_usage metrics of the operator
+	Telemetry bool `json:"telemetry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Sidecars - additional containers in the ovn-northd pods, e.g. log
+	// shippers or monitoring agents
+	Sidecars SidecarSection `json:"sidecars,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - options appended to the command line of ovn-northd, for the
+	// ones the API doesn't model, by option name, e.g. --option or
+	// -vmodule:level, with an empty value for the options without value
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+}
+
+// OVNNorthdDataRetention defines the aging of the MAC_Binding and FDB
+// records of the SB DB. Unset values are removed from NB_Global so ovn-northd
+// falls back to its defaults.
+type OVNNorthdDataRetention struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MACBindingAgeThreshold - age in seconds after which the learnt
+	// MAC_Binding records are removed, stored in NB_Global
+	// options:mac_binding_age_threshold. 0 disables the aging.
+	MACBindingAgeThreshold *int32 `json:"macBindingAgeThreshold,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MACBindingRemovalLimit - maximum number of aged MAC_Binding records
+	// removed per transaction, stored in NB_Global
+	// options:mac_binding_removal_limit. 0 removes them all at once.
+	MACBindingRemovalLimit *int32 `json:"macBindingRemovalLimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// FDBAgeThreshold - age in seconds after which the learnt FDB records
+	// are removed, stored in NB_Global options:fdb_age_threshold. 0 disables
+	// the aging.
+	FDBAgeThreshold *int32 `json:"fdbAgeThreshold,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// FDBRemovalLimit - maximum number of aged FDB records removed per
+	// transaction, stored in NB_Global options:fdb_removal_limit. 0 removes
+	// them all at once.
+	FDBRemovalLimit *int32 `json:"fdbRemovalLimit,omitempty"`
 }
 
 // OVNNorthdStatus defines the observed state of OVNNorthd
@@ -84,15 +209,60 @@ type OVNNorthdStatus struct {
 	// ReadyCount of OVN Northd instances
 	ReadyCount int32 `json:"readyCount,omitempty"`
 
+	// ActiveInstance - name of the ovn-northd pod holding the SB lock, the
+	// other replicas are on standby
+	ActiveInstance string `json:"activeInstance,omitempty"`
+
+	// Replicas - state of each running ovn-northd replica
+	Replicas []OVNNorthdReplicaStatus `json:"replicas,omitempty"`
+
+	// NBCfg - nb_cfg sequence number last requested by the NB clients
+	NBCfg int64 `json:"nbCfg,omitempty"`
+
+	// SBCfg - nb_cfg sequence number ovn-northd has propagated to the SB
+	// database, it lags behind NBCfg while the logical flows are computed
+	SBCfg int64 `json:"sbCfg,omitempty"`
+
+	// HVCfg - nb_cfg sequence number all the chassis have processed, it
+	// lags behind SBCfg until the changes reached the data plane
+	HVCfg int64 `json:"hvCfg,omitempty"`
+
+	// ContainerImage - image all the replicas were rolled out with,
+	// ovn-controller waits for it to match the spec before an upgrade
+	ContainerImage string `json:"containerImage,omitempty"`
+
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
 
+	// Tuning - the profile chosen for the auto tuning profile
+	Tuning TuningStatus `json:"tuning,omitempty"`
+
 	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// OVNNorthdReplicaStatus defines the observed state of an ovn-northd replica
+type OVNNorthdReplicaStatus struct {
+	// Name - name of the ovn-northd pod
+	Name string `json:"name"`
+
+	// Status - active, standby or paused as reported by ovn-northd
+	Status string `json:"status,omitempty"`
+
+	// NBConnected - ovn-northd is connected to the NB database
+	NBConnected bool `json:"nbConnected"`
+
+	// SBConnected - ovn-northd is connected to the SB database
+	SBConnected bool `json:"sbConnected"`
+
+	// Version - OVN version as reported by ovn-northd
+	Version string `json:"version,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.activeInstance",description="Active"
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
 
@@ -139,3 +309,12 @@ func (instance OVNNorthd) RbacNamespace() string {
 func (instance OVNNorthd) RbacResourceName() string {
 	return "ovnnorthd-" + instance.Name
 }
+
+// GetMinAvailable - return the minimum number of available replicas to
+// enforce with the PodDisruptionBudget
+func (instance OVNNorthd) GetMinAvailable() int32 {
+	if instance.Spec.MinAvailable == nil {
+		return 1
+	}
+	return *instance.Spec.MinAvailable
+}
@@ -23,7 +23,11 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -62,6 +66,7 @@ func (r *OVNNorthd) Default() {
 	ovnnorthdlog.Info("default", "name", r.Name)
 
 	r.Spec.Default()
+	r.Spec.TLS.Default(r.Name)
 }
 
 // Default - set defaults for this OVNNorthd spec
@@ -74,7 +79,18 @@ func (spec *OVNNorthdSpec) Default() {
 
 // Default - set defaults for this OVNNorthd core spec (this version is called by OpenStackControlplane webhooks)
 func (spec *OVNNorthdSpecCore) Default() {
-	// nothing here yet
+	// same values as the CRD defaults, for specs not defaulted by the API server
+	if spec.Replicas == nil {
+		replicas := int32(1)
+		spec.Replicas = &replicas
+	}
+	if spec.NThreads == nil {
+		nThreads := int32(1)
+		spec.NThreads = &nThreads
+	}
+	if spec.LogLevel == "" {
+		spec.LogLevel = "info"
+	}
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
@@ -86,16 +102,50 @@ var _ webhook.Validator = &OVNNorthd{}
 func (r *OVNNorthd) ValidateCreate() (admission.Warnings, error) {
 	ovnnorthdlog.Info("validate create", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
-	return nil, nil
+	return append(r.minAvailableWarnings(), r.tuningWarnings()...), r.validate()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *OVNNorthd) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	ovnnorthdlog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	return append(r.minAvailableWarnings(), r.tuningWarnings()...), r.validate()
+}
+
+// validate - check the OVNNorthd spec
+func (r *OVNNorthd) validate() error {
+	basePath := field.NewPath("spec")
+	allErrs := r.Spec.TLS.ValidateFIPS(r.Spec.FIPS, basePath)
+	allErrs = append(allErrs, r.Spec.TLS.ValidateCABundle(basePath)...)
+	allErrs = append(allErrs, r.Spec.TLS.ValidateCertificate(basePath)...)
+	allErrs = append(allErrs, r.Spec.Sidecars.Validate([]string{ServiceNameOVNNorthd}, basePath)...)
+	allErrs = append(allErrs, ValidateExtraArgs(r.Spec.ExtraArgs, basePath.Child("extraArgs"))...)
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("OVNNorthd").GroupKind(), r.Name, allErrs)
+	}
+	return nil
+}
+
+// minAvailableWarnings - no PodDisruptionBudget is created unless more
+// replicas than minAvailable run, so a minAvailable set that high has no effect
+func (r *OVNNorthd) minAvailableWarnings() admission.Warnings {
+	if r.Spec.MinAvailable != nil && r.Spec.Replicas != nil && *r.Spec.Replicas > 0 &&
+		r.GetMinAvailable() >= *r.Spec.Replicas {
+		return admission.Warnings{fmt.Sprintf(
+			"spec.minAvailable: %d is not lower than the %d replicas, ovn-northd is not protected "+
+				"against voluntary disruptions, raise spec.replicas", r.GetMinAvailable(), *r.Spec.Replicas)}
+	}
+	return nil
+}
+
+// tuningWarnings - a tuning profile other than custom overrides probeInterval
+func (r *OVNNorthd) tuningWarnings() admission.Warnings {
+	if r.Spec.TuningProfile != "" && r.Spec.TuningProfile != TuningProfileCustom && r.Spec.ProbeInterval != nil {
+		return admission.Warnings{fmt.Sprintf(
+			"spec.probeInterval: ignored, the %s tuning profile sets the probe interval, "+
+				"use the custom tuning profile to set it", r.Spec.TuningProfile)}
+	}
+	return nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
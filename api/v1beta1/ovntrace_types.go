@@ -0,0 +1,134 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// OVNTraceResultKey - key of the ovn-trace output in the result ConfigMap
+	OVNTraceResultKey = "ovn-trace"
+	// OFProtoTraceResultKey - key of the ofproto/trace output in the result
+	// ConfigMap
+	OFProtoTraceResultKey = "ofproto-trace"
+)
+
+// OVNTraceSpec defines the desired state of OVNTrace
+type OVNTraceSpec struct {
+	// +kubebuilder:validation:Optional
+	// ContainerImage - Container Image URL of the trace Jobs, the image of the
+	// ovn-controller pods if empty
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// SourcePort - name of the logical switch port the packet enters from,
+	// its MAC and IP addresses are the source of the packet
+	SourcePort string `json:"sourcePort"`
+
+	// +kubebuilder:validation:Optional
+	// DestinationPort - name of the logical switch port the packet is sent
+	// to, its MAC and IP addresses are the destination of the packet. Without
+	// it only the Ethernet source is set.
+	DestinationPort string `json:"destinationPort,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=icmp
+	// +kubebuilder:validation:Enum=icmp;tcp;udp
+	// Protocol - IP protocol of the packet, an echo request for icmp. Only
+	// applies when both ports have an IP address.
+	Protocol string `json:"protocol,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// L4DestinationPort - destination port of a tcp or udp packet
+	L4DestinationPort int32 `json:"l4DestinationPort,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Microflow - additional ovn-trace match expression ANDed to the packet
+	// properties, e.g. vlan.vid == 10. It is not applied to ofproto/trace.
+	Microflow string `json:"microflow,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OFProtoTrace - also trace the packet through the OpenFlow tables of
+	// br-int with ovs-appctl ofproto/trace on the chassis the source port is
+	// bound to
+	OFProtoTrace bool `json:"ofprotoTrace,omitempty"`
+}
+
+// OVNTraceStatus defines the observed state of OVNTrace
+type OVNTraceStatus struct {
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// Chassis - node of the chassis the source port is bound to
+	Chassis string `json:"chassis,omitempty"`
+
+	// Flow - the traced packet as an OpenFlow flow, without the in_port
+	Flow string `json:"flow,omitempty"`
+
+	// ResultConfigMap - name of the ConfigMap holding the output of the traces
+	ResultConfigMap string `json:"resultConfigMap,omitempty"`
+
+	// DatapathActions - the datapath actions ofproto/trace reported for the
+	// packet
+	DatapathActions string `json:"datapathActions,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourcePort",description="Source"
+//+kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".spec.destinationPort",description="Destination"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OVNTrace is the Schema for the ovntraces API
+type OVNTrace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OVNTraceSpec   `json:"spec,omitempty"`
+	Status OVNTraceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OVNTraceList contains a list of OVNTrace
+type OVNTraceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OVNTrace `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OVNTrace{}, &OVNTraceList{})
+}
+
+// IsReady - returns true if the packet got traced
+func (instance OVNTrace) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
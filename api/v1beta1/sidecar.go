@@ -0,0 +1,147 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// SidecarSection defines the additional containers run in the pods of a
+// service, e.g. log shippers or monitoring agents
+type SidecarSection struct {
+	// +kubebuilder:validation:Optional
+	// Containers - the sidecar containers, they can only mount ExtraVolumes
+	Containers []Sidecar `json:"containers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraVolumes - the volumes the sidecar containers can mount
+	ExtraVolumes []ExtraVolume `json:"extraVolumes,omitempty"`
+}
+
+// Sidecar defines a sidecar container
+type Sidecar struct {
+	// +kubebuilder:validation:Required
+	// Name - name of the container, unique in the pod
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// Image - image of the container
+	Image string `json:"image"`
+
+	// +kubebuilder:validation:Optional
+	// Command - entrypoint of the container, the one of the image when empty
+	Command []string `json:"command,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Args - arguments of the entrypoint
+	Args []string `json:"args,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Env - environment variables of the container
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Resources - compute resources of the container
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// VolumeMounts - mounts of the ExtraVolumes
+	VolumeMounts []SidecarVolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// SidecarVolumeMount defines the mount of an ExtraVolume in a sidecar
+type SidecarVolumeMount struct {
+	// +kubebuilder:validation:Required
+	// Name - name of the ExtraVolume
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// MountPath - path of the volume in the container
+	MountPath string `json:"mountPath"`
+
+	// +kubebuilder:validation:Optional
+	// SubPath - path within the volume to mount, its root when empty
+	SubPath string `json:"subPath,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ReadOnly - mount the volume read-only
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// ExtraVolume defines a volume of the sidecars, exactly one of its sources
+// must be set
+type ExtraVolume struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=57
+	// Name - name of the volume, unique among the ExtraVolumes
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// ConfigMap - ConfigMap of the namespace to populate the volume with
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Secret - Secret of the namespace to populate the volume with
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// EmptyDir - an empty directory shared by the sidecars of the pod
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+}
+
+// Validate - the sidecars must not replace the containers of the operator,
+// reserved, and only mount declared ExtraVolumes
+func (s *SidecarSection) Validate(reserved []string, basePath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	path := basePath.Child("sidecars")
+
+	volumes := sets.New[string]()
+	for i, volume := range s.ExtraVolumes {
+		volumePath := path.Child("extraVolumes").Index(i)
+		if volumes.Has(volume.Name) {
+			allErrs = append(allErrs, field.Duplicate(volumePath.Child("name"), volume.Name))
+		}
+		volumes.Insert(volume.Name)
+
+		sources := 0
+		for _, set := range []bool{volume.ConfigMap != nil, volume.Secret != nil, volume.EmptyDir != nil} {
+			if set {
+				sources++
+			}
+		}
+		if sources != 1 {
+			allErrs = append(allErrs, field.Invalid(volumePath, volume.Name,
+				"exactly one of configMap, secret and emptyDir must be set"))
+		}
+	}
+
+	names := sets.New(reserved...)
+	for i, container := range s.Containers {
+		containerPath := path.Child("containers").Index(i)
+		if names.Has(container.Name) {
+			allErrs = append(allErrs, field.Duplicate(containerPath.Child("name"), container.Name))
+		}
+		names.Insert(container.Name)
+
+		for j, mount := range container.VolumeMounts {
+			if !volumes.Has(mount.Name) {
+				allErrs = append(allErrs, field.NotFound(
+					containerPath.Child("volumeMounts").Index(j).Child("name"), mount.Name))
+			}
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// TLSSection defines the TLS parameters of the OVN services
+type TLSSection struct {
+	tls.SimpleService `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// Issuer - name of a cert-manager Issuer in the namespace. When set the
+	// operator requests the service cert from it and stores it in SecretName,
+	// which defaults to cert-<name>-ovndbs, instead of expecting a pre-created
+	// secret
+	Issuer string `json:"issuer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// IssuerKind - kind of the cert-manager issuer named Issuer, Issuer in
+	// the namespace or a ClusterIssuer. Issuer by default.
+	IssuerKind string `json:"issuerKind,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraDNSNames - DNS names added to the SANs of the service cert
+	// requested from the Issuer, e.g. the name of an external load balancer
+	// of the DB
+	ExtraDNSNames []string `json:"extraDNSNames,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraIPAddresses - IP addresses added to the SANs of the service cert
+	// requested from the Issuer, e.g. the address of an external load
+	// balancer of the DB
+	ExtraIPAddresses []string `json:"extraIPAddresses,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Duration - lifetime of the certs requested from the Issuer, the
+	// default of cert-manager when unset
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RenewBefore - how long before their expiry cert-manager renews the
+	// certs requested from the Issuer, the default of cert-manager when unset
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+const (
+	// minCertificateDuration - the shortest lifetime of a cert cert-manager
+	// accepts
+	minCertificateDuration = time.Hour
+	// minCertificateRenewBefore - the shortest renewal period before the
+	// expiry of a cert cert-manager accepts
+	minCertificateRenewBefore = 5 * time.Minute
+
+	// IssuerKindIssuer - a cert-manager Issuer in the namespace
+	IssuerKindIssuer = "Issuer"
+	// IssuerKindClusterIssuer - a cert-manager ClusterIssuer
+	IssuerKindClusterIssuer = "ClusterIssuer"
+)
+
+// IssuerRefKind - kind of the issuer of the Certificates the operator requests
+func (t *TLSSection) IssuerRefKind() string {
+	if t.IssuerKind == "" {
+		return IssuerKindIssuer
+	}
+	return t.IssuerKind
+}
+
+// Default - set the secret the cert is issued into when an Issuer is used
+func (t *TLSSection) Default(name string) {
+	if t.Issuer != "" && !t.Enabled() {
+		secretName := fmt.Sprintf("cert-%s-ovndbs", name)
+		t.SecretName = &secretName
+	}
+}
+
+// CertificateName - name of the cert-manager Certificate the operator
+// requests from the Issuer, empty when the cert is not issued by the operator
+func (t *TLSSection) CertificateName() string {
+	if t.Issuer == "" || !t.Enabled() {
+		return ""
+	}
+	return *t.SecretName
+}
+
+// ValidateFIPS - FIPS mode only applies to TLS connections
+func (t *TLSSection) ValidateFIPS(fips bool, basePath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if fips && !t.Enabled() {
+		allErrs = append(allErrs, field.Invalid(
+			basePath.Child("fips"), fips,
+			"FIPS mode requires TLS, set tls.secretName or tls.issuer"))
+	}
+	return allErrs
+}
+
+// ValidateCABundle - the CA bundle is only used to verify the peers of a TLS
+// connection, which also needs the service cert
+func (t *TLSSection) ValidateCABundle(basePath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if t.CaBundleSecretName != "" && !t.Enabled() {
+		allErrs = append(allErrs, field.Invalid(
+			basePath.Child("tls").Child("caBundleSecretName"), t.CaBundleSecretName,
+			"a CA bundle requires the service cert, set tls.secretName or tls.issuer"))
+	}
+	return allErrs
+}
+
+// ValidateCertificate - the SANs and the lifetime of the certs only apply to
+// the certs requested from the Issuer, and must be accepted by cert-manager
+func (t *TLSSection) ValidateCertificate(basePath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	tlsPath := basePath.Child("tls")
+	if t.Issuer == "" {
+		fields := []struct {
+			name string
+			set  bool
+		}{
+			{"extraDNSNames", len(t.ExtraDNSNames) > 0},
+			{"extraIPAddresses", len(t.ExtraIPAddresses) > 0},
+			{"duration", t.Duration != nil},
+			{"renewBefore", t.RenewBefore != nil},
+		}
+		for _, f := range fields {
+			if f.set {
+				allErrs = append(allErrs, field.Forbidden(
+					tlsPath.Child(f.name), "only applies to the certs requested from tls.issuer"))
+			}
+		}
+		return allErrs
+	}
+	for i, ip := range t.ExtraIPAddresses {
+		if net.ParseIP(ip) == nil {
+			allErrs = append(allErrs, field.Invalid(
+				tlsPath.Child("extraIPAddresses").Index(i), ip, "not an IP address"))
+		}
+	}
+	if t.Duration != nil && t.Duration.Duration < minCertificateDuration {
+		allErrs = append(allErrs, field.Invalid(
+			tlsPath.Child("duration"), t.Duration.Duration.String(),
+			fmt.Sprintf("must be at least %s", minCertificateDuration)))
+	}
+	if t.RenewBefore != nil {
+		if t.RenewBefore.Duration < minCertificateRenewBefore {
+			allErrs = append(allErrs, field.Invalid(
+				tlsPath.Child("renewBefore"), t.RenewBefore.Duration.String(),
+				fmt.Sprintf("must be at least %s", minCertificateRenewBefore)))
+		} else if t.Duration != nil && t.RenewBefore.Duration >= t.Duration.Duration {
+			allErrs = append(allErrs, field.Invalid(
+				tlsPath.Child("renewBefore"), t.RenewBefore.Duration.String(),
+				"must be shorter than tls.duration"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TuningProfileSmall - up to ~50 chassis
+	TuningProfileSmall = "small"
+	// TuningProfileMedium - up to ~250 chassis
+	TuningProfileMedium = "medium"
+	// TuningProfileLarge - more than ~250 chassis
+	TuningProfileLarge = "large"
+	// TuningProfileCustom - the individual fields of the spec apply, the
+	// ovn-controller tuning is left to the OVN defaults
+	TuningProfileCustom = "custom"
+	// TuningProfileAuto - the small, medium or large profile is chosen for
+	// the number of chassis of the namespace
+	TuningProfileAuto = "auto"
+
+	// tuningSmallMaxChassis - the most chassis of the small profile
+	tuningSmallMaxChassis = 50
+	// tuningMediumMaxChassis - the most chassis of the medium profile
+	tuningMediumMaxChassis = 250
+	// tuningStepDownPercent - the auto tuning profile only steps down once
+	// the chassis fall below this percentage of the most chassis of the
+	// smaller profile, so it doesn't flap around a threshold
+	tuningStepDownPercent = 80
+)
+
+// tuningProfiles - the presets by increasing size
+var tuningProfiles = []string{TuningProfileSmall, TuningProfileMedium, TuningProfileLarge}
+
+// TuningStatus - the profile chosen for the auto tuning profile
+type TuningStatus struct {
+	// Profile - the small, medium or large profile applied
+	Profile string `json:"profile,omitempty"`
+
+	// Chassis - the number of chassis of the namespace last observed
+	Chassis int32 `json:"chassis,omitempty"`
+
+	// ChangedAt - when the profile was last changed
+	ChangedAt *metav1.Time `json:"changedAt,omitempty"`
+
+	// Values - the values of the profile applied, by field
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// TuningPreset - the values a tuning profile applies, they are tested
+// together so a profile never combines e.g. a short election timer with long
+// inactivity probes
+type TuningPreset struct {
+	// ElectionTimer - RAFT election timer of the DB clusters, in milliseconds
+	ElectionTimer int32
+	// InactivityProbe - inactivity probe of the DB client connections, in
+	// milliseconds
+	InactivityProbe int32
+	// ProbeIntervalToActive - probe interval from the standby to the active
+	// ovsdb-server, in milliseconds
+	ProbeIntervalToActive int32
+	// NorthdProbeInterval - ovn-northd probe interval on its DB connections,
+	// in milliseconds
+	NorthdProbeInterval int32
+	// RemoteProbeInterval - ovn-controller probe interval on its SB DB
+	// connection, in milliseconds
+	RemoteProbeInterval int32
+	// MonitorAll - ovn-controller monitors all the SB DB records instead of
+	// the ones of its chassis, trading memory for less SB DB load
+	MonitorAll bool
+	// LflowCacheMemLimitKB - memory limit of the ovn-controller logical flow
+	// cache, in KB
+	LflowCacheMemLimitKB int32
+}
+
+var tuningPresets = map[string]TuningPreset{
+	TuningProfileSmall: {
+		ElectionTimer:         5000,
+		InactivityProbe:       60000,
+		ProbeIntervalToActive: 60000,
+		NorthdProbeInterval:   10000,
+		RemoteProbeInterval:   60000,
+		MonitorAll:            false,
+		LflowCacheMemLimitKB:  524288,
+	},
+	TuningProfileMedium: {
+		ElectionTimer:         10000,
+		InactivityProbe:       60000,
+		ProbeIntervalToActive: 60000,
+		NorthdProbeInterval:   30000,
+		RemoteProbeInterval:   120000,
+		MonitorAll:            true,
+		LflowCacheMemLimitKB:  1048576,
+	},
+	TuningProfileLarge: {
+		ElectionTimer:         16000,
+		InactivityProbe:       180000,
+		ProbeIntervalToActive: 180000,
+		NorthdProbeInterval:   60000,
+		RemoteProbeInterval:   180000,
+		MonitorAll:            true,
+		LflowCacheMemLimitKB:  2097152,
+	},
+}
+
+// GetTuningPreset - return the values of a tuning profile, false for custom
+// or unset profiles
+func GetTuningPreset(profile string) (TuningPreset, bool) {
+	preset, ok := tuningPresets[profile]
+	return preset, ok
+}
+
+// AutoTuningProfile - return the profile for the number of chassis, at most
+// one step from the current profile so e.g. the election timer never more
+// than doubles at once. The initial profile is the one of the number of
+// chassis.
+func AutoTuningProfile(current string, chassis int32) string {
+	target := 2
+	switch {
+	case chassis <= tuningSmallMaxChassis:
+		target = 0
+	case chassis <= tuningMediumMaxChassis:
+		target = 1
+	}
+	index := -1
+	for i, profile := range tuningProfiles {
+		if profile == current {
+			index = i
+		}
+	}
+	switch {
+	case index < 0:
+		return tuningProfiles[target]
+	case target > index:
+		return tuningProfiles[index+1]
+	case target < index && chassis*100 < stepDownThreshold(index-1)*tuningStepDownPercent:
+		return tuningProfiles[index-1]
+	}
+	return current
+}
+
+// stepDownThreshold - the most chassis of the profile at the index
+func stepDownThreshold(index int) int32 {
+	if index == 0 {
+		return tuningSmallMaxChassis
+	}
+	return tuningMediumMaxChassis
+}
+
+// tuningProfile - the profile applied, the one chosen for the number of
+// chassis with the auto tuning profile
+func tuningProfile(profile string, status TuningStatus) string {
+	if profile == TuningProfileAuto {
+		return status.Profile
+	}
+	return profile
+}
+
+// TuningPreset - return the values of the tuning profile of the
+// OVNDBCluster, false for the custom profile
+func (instance OVNDBCluster) TuningPreset() (TuningPreset, bool) {
+	return GetTuningPreset(tuningProfile(instance.Spec.TuningProfile, instance.Status.Tuning))
+}
+
+// TunedElectionTimer - return the election timer of the tuning profile, or
+// the one of the spec for the custom profile
+func (instance OVNDBCluster) TunedElectionTimer() int32 {
+	if preset, ok := instance.TuningPreset(); ok {
+		return preset.ElectionTimer
+	}
+	return instance.Spec.ElectionTimer
+}
+
+// TunedInactivityProbe - return the inactivity probe of the tuning profile,
+// or the one of the spec for the custom profile
+func (instance OVNDBCluster) TunedInactivityProbe() int32 {
+	if preset, ok := instance.TuningPreset(); ok {
+		return preset.InactivityProbe
+	}
+	return instance.Spec.InactivityProbe
+}
+
+// TunedProbeIntervalToActive - return the probe interval to the active
+// ovsdb-server of the tuning profile, or the one of the spec for the custom
+// profile
+func (instance OVNDBCluster) TunedProbeIntervalToActive() int32 {
+	if preset, ok := instance.TuningPreset(); ok {
+		return preset.ProbeIntervalToActive
+	}
+	return instance.Spec.ProbeIntervalToActive
+}
+
+// TunedValues - return the values chosen by the auto tuning profile, nil
+// for the other profiles
+func (instance OVNDBCluster) TunedValues() map[string]string {
+	preset, ok := instance.TuningPreset()
+	if !ok || instance.Spec.TuningProfile != TuningProfileAuto {
+		return nil
+	}
+	return map[string]string{
+		"electionTimer":         fmt.Sprintf("%d", preset.ElectionTimer),
+		"inactivityProbe":       fmt.Sprintf("%d", preset.InactivityProbe),
+		"probeIntervalToActive": fmt.Sprintf("%d", preset.ProbeIntervalToActive),
+	}
+}
+
+// TuningPreset - return the values of the tuning profile of the OVNNorthd,
+// false for the custom profile
+func (instance OVNNorthd) TuningPreset() (TuningPreset, bool) {
+	return GetTuningPreset(tuningProfile(instance.Spec.TuningProfile, instance.Status.Tuning))
+}
+
+// TunedProbeInterval - return the ovn-northd probe interval of the tuning
+// profile, or the one of the spec for the custom profile
+func (instance OVNNorthd) TunedProbeInterval() *int32 {
+	if preset, ok := instance.TuningPreset(); ok {
+		return &preset.NorthdProbeInterval
+	}
+	return instance.Spec.ProbeInterval
+}
+
+// TunedValues - return the values chosen by the auto tuning profile, nil
+// for the other profiles
+func (instance OVNNorthd) TunedValues() map[string]string {
+	preset, ok := instance.TuningPreset()
+	if !ok || instance.Spec.TuningProfile != TuningProfileAuto {
+		return nil
+	}
+	return map[string]string{
+		"probeInterval": fmt.Sprintf("%d", preset.NorthdProbeInterval),
+	}
+}
+
+// TuningPreset - return the values of the tuning profile of the
+// OVNController, false for the custom profile
+func (instance OVNController) TuningPreset() (TuningPreset, bool) {
+	return GetTuningPreset(tuningProfile(instance.Spec.TuningProfile, instance.Status.Tuning))
+}
+
+// TunedValues - return the values chosen by the auto tuning profile, nil
+// for the other profiles
+func (instance OVNController) TunedValues() map[string]string {
+	preset, ok := instance.TuningPreset()
+	if !ok || instance.Spec.TuningProfile != TuningProfileAuto {
+		return nil
+	}
+	return map[string]string{
+		"ovn-remote-probe-interval":   fmt.Sprintf("%d", preset.RemoteProbeInterval),
+		"ovn-monitor-all":             fmt.Sprintf("%t", preset.MonitorAll),
+		"ovn-memlimit-lflow-cache-kb": fmt.Sprintf("%d", preset.LflowCacheMemLimitKB),
+	}
+}
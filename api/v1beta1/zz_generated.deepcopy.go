@@ -23,77 +23,117 @@ package v1beta1
 
 import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNController) DeepCopyInto(out *OVNController) {
+func (in *AlertsSection) DeepCopyInto(out *AlertsSection) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNController.
-func (in *OVNController) DeepCopy() *OVNController {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertsSection.
+func (in *AlertsSection) DeepCopy() *AlertsSection {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNController)
+	out := new(AlertsSection)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OVNController) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraVolume) DeepCopyInto(out *ExtraVolume) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapVolumeSource)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(v1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraVolume.
+func (in *ExtraVolume) DeepCopy() *ExtraVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraVolume)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNControllerDefaults) DeepCopyInto(out *OVNControllerDefaults) {
+func (in *OVNCapture) DeepCopyInto(out *OVNCapture) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerDefaults.
-func (in *OVNControllerDefaults) DeepCopy() *OVNControllerDefaults {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNCapture.
+func (in *OVNCapture) DeepCopy() *OVNCapture {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNControllerDefaults)
+	out := new(OVNCapture)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNCapture) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNControllerList) DeepCopyInto(out *OVNControllerList) {
+func (in *OVNCaptureList) DeepCopyInto(out *OVNCaptureList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OVNController, len(*in))
+		*out = make([]OVNCapture, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerList.
-func (in *OVNControllerList) DeepCopy() *OVNControllerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNCaptureList.
+func (in *OVNCaptureList) DeepCopy() *OVNCaptureList {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNControllerList)
+	out := new(OVNCaptureList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OVNControllerList) DeepCopyObject() runtime.Object {
+func (in *OVNCaptureList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -101,56 +141,30 @@ func (in *OVNControllerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNControllerSpec) DeepCopyInto(out *OVNControllerSpec) {
+func (in *OVNCaptureSpec) DeepCopyInto(out *OVNCaptureSpec) {
 	*out = *in
-	in.OVNControllerSpecCore.DeepCopyInto(&out.OVNControllerSpecCore)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerSpec.
-func (in *OVNControllerSpec) DeepCopy() *OVNControllerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNCaptureSpec.
+func (in *OVNCaptureSpec) DeepCopy() *OVNCaptureSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNControllerSpec)
+	out := new(OVNCaptureSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNControllerSpecCore) DeepCopyInto(out *OVNControllerSpecCore) {
+func (in *OVNCaptureStatus) DeepCopyInto(out *OVNCaptureStatus) {
 	*out = *in
-	in.ExternalIDS.DeepCopyInto(&out.ExternalIDS)
-	if in.NicMappings != nil {
-		in, out := &in.NicMappings, &out.NicMappings
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	in.TLS.DeepCopyInto(&out.TLS)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerSpecCore.
-func (in *OVNControllerSpecCore) DeepCopy() *OVNControllerSpecCore {
-	if in == nil {
-		return nil
-	}
-	out := new(OVNControllerSpecCore)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNControllerStatus) DeepCopyInto(out *OVNControllerStatus) {
-	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -158,61 +172,39 @@ func (in *OVNControllerStatus) DeepCopyInto(out *OVNControllerStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Hash != nil {
-		in, out := &in.Hash, &out.Hash
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.NetworkAttachments != nil {
-		in, out := &in.NetworkAttachments, &out.NetworkAttachments
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerStatus.
-func (in *OVNControllerStatus) DeepCopy() *OVNControllerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNCaptureStatus.
+func (in *OVNCaptureStatus) DeepCopy() *OVNCaptureStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNControllerStatus)
+	out := new(OVNCaptureStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNDBCluster) DeepCopyInto(out *OVNDBCluster) {
+func (in *OVNCommand) DeepCopyInto(out *OVNCommand) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBCluster.
-func (in *OVNDBCluster) DeepCopy() *OVNDBCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNCommand.
+func (in *OVNCommand) DeepCopy() *OVNCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNDBCluster)
+	out := new(OVNCommand)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OVNDBCluster) DeepCopyObject() runtime.Object {
+func (in *OVNCommand) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -220,46 +212,31 @@ func (in *OVNDBCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNDBClusterDefaults) DeepCopyInto(out *OVNDBClusterDefaults) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterDefaults.
-func (in *OVNDBClusterDefaults) DeepCopy() *OVNDBClusterDefaults {
-	if in == nil {
-		return nil
-	}
-	out := new(OVNDBClusterDefaults)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNDBClusterList) DeepCopyInto(out *OVNDBClusterList) {
+func (in *OVNCommandList) DeepCopyInto(out *OVNCommandList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OVNDBCluster, len(*in))
+		*out = make([]OVNCommand, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterList.
-func (in *OVNDBClusterList) DeepCopy() *OVNDBClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNCommandList.
+func (in *OVNCommandList) DeepCopy() *OVNCommandList {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNDBClusterList)
+	out := new(OVNCommandList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OVNDBClusterList) DeepCopyObject() runtime.Object {
+func (in *OVNCommandList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -267,52 +244,22 @@ func (in *OVNDBClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNDBClusterSpec) DeepCopyInto(out *OVNDBClusterSpec) {
-	*out = *in
-	in.OVNDBClusterSpecCore.DeepCopyInto(&out.OVNDBClusterSpecCore)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterSpec.
-func (in *OVNDBClusterSpec) DeepCopy() *OVNDBClusterSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(OVNDBClusterSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNDBClusterSpecCore) DeepCopyInto(out *OVNDBClusterSpecCore) {
+func (in *OVNCommandSpec) DeepCopyInto(out *OVNCommandSpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	in.TLS.DeepCopyInto(&out.TLS)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterSpecCore.
-func (in *OVNDBClusterSpecCore) DeepCopy() *OVNDBClusterSpecCore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNCommandSpec.
+func (in *OVNCommandSpec) DeepCopy() *OVNCommandSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNDBClusterSpecCore)
+	out := new(OVNCommandSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNDBClusterStatus) DeepCopyInto(out *OVNDBClusterStatus) {
+func (in *OVNCommandStatus) DeepCopyInto(out *OVNCommandStatus) {
 	*out = *in
 	if in.Hash != nil {
 		in, out := &in.Hash, &out.Hash
@@ -328,35 +275,20 @@ func (in *OVNDBClusterStatus) DeepCopyInto(out *OVNDBClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.NetworkAttachments != nil {
-		in, out := &in.NetworkAttachments, &out.NetworkAttachments
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterStatus.
-func (in *OVNDBClusterStatus) DeepCopy() *OVNDBClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNCommandStatus.
+func (in *OVNCommandStatus) DeepCopy() *OVNCommandStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNDBClusterStatus)
+	out := new(OVNCommandStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNNorthd) DeepCopyInto(out *OVNNorthd) {
+func (in *OVNController) DeepCopyInto(out *OVNController) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -364,18 +296,18 @@ func (in *OVNNorthd) DeepCopyInto(out *OVNNorthd) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthd.
-func (in *OVNNorthd) DeepCopy() *OVNNorthd {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNController.
+func (in *OVNController) DeepCopy() *OVNController {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNNorthd)
+	out := new(OVNController)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OVNNorthd) DeepCopyObject() runtime.Object {
+func (in *OVNController) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -383,76 +315,38 @@ func (in *OVNNorthd) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNNorthdDefaults) DeepCopyInto(out *OVNNorthdDefaults) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdDefaults.
-func (in *OVNNorthdDefaults) DeepCopy() *OVNNorthdDefaults {
-	if in == nil {
-		return nil
-	}
-	out := new(OVNNorthdDefaults)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNNorthdList) DeepCopyInto(out *OVNNorthdList) {
+func (in *OVNControllerAutoRollback) DeepCopyInto(out *OVNControllerAutoRollback) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]OVNNorthd, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdList.
-func (in *OVNNorthdList) DeepCopy() *OVNNorthdList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerAutoRollback.
+func (in *OVNControllerAutoRollback) DeepCopy() *OVNControllerAutoRollback {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNNorthdList)
+	out := new(OVNControllerAutoRollback)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OVNNorthdList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNNorthdSpec) DeepCopyInto(out *OVNNorthdSpec) {
+func (in *OVNControllerBGP) DeepCopyInto(out *OVNControllerBGP) {
 	*out = *in
-	in.OVNNorthdSpecCore.DeepCopyInto(&out.OVNNorthdSpecCore)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdSpec.
-func (in *OVNNorthdSpec) DeepCopy() *OVNNorthdSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerBGP.
+func (in *OVNControllerBGP) DeepCopy() *OVNControllerBGP {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNNorthdSpec)
+	out := new(OVNControllerBGP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVNNorthdSpecCore) DeepCopyInto(out *OVNNorthdSpecCore) {
+func (in *OVNControllerCanary) DeepCopyInto(out *OVNControllerCanary) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -460,21 +354,1496 @@ func (in *OVNNorthdSpecCore) DeepCopyInto(out *OVNNorthdSpecCore) {
 			(*out)[key] = val
 		}
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	in.TLS.DeepCopyInto(&out.TLS)
-	if in.NThreads != nil {
-		in, out := &in.NThreads, &out.NThreads
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdSpecCore.
-func (in *OVNNorthdSpecCore) DeepCopy() *OVNNorthdSpecCore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerCanary.
+func (in *OVNControllerCanary) DeepCopy() *OVNControllerCanary {
 	if in == nil {
 		return nil
 	}
-	out := new(OVNNorthdSpecCore)
+	out := new(OVNControllerCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerCanaryStatus) DeepCopyInto(out *OVNControllerCanaryStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingNodes != nil {
+		in, out := &in.PendingNodes, &out.PendingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerCanaryStatus.
+func (in *OVNControllerCanaryStatus) DeepCopy() *OVNControllerCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerChassis) DeepCopyInto(out *OVNControllerChassis) {
+	*out = *in
+	if in.LastHeartbeat != nil {
+		in, out := &in.LastHeartbeat, &out.LastHeartbeat
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerChassis.
+func (in *OVNControllerChassis) DeepCopy() *OVNControllerChassis {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerChassis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerDecommissionStatus) DeepCopyInto(out *OVNControllerDecommissionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerDecommissionStatus.
+func (in *OVNControllerDecommissionStatus) DeepCopy() *OVNControllerDecommissionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerDecommissionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerDefaults) DeepCopyInto(out *OVNControllerDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerDefaults.
+func (in *OVNControllerDefaults) DeepCopy() *OVNControllerDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerContainerResources) DeepCopyInto(out *OVNControllerContainerResources) {
+	*out = *in
+	if in.OvnController != nil {
+		in, out := &in.OvnController, &out.OvnController
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OvsdbServer != nil {
+		in, out := &in.OvsdbServer, &out.OvsdbServer
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OvsVswitchd != nil {
+		in, out := &in.OvsVswitchd, &out.OvsVswitchd
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerContainerResources.
+func (in *OVNControllerContainerResources) DeepCopy() *OVNControllerContainerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerContainerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerExtraArgs) DeepCopyInto(out *OVNControllerExtraArgs) {
+	*out = *in
+	if in.OVNController != nil {
+		in, out := &in.OVNController, &out.OVNController
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OVSVswitchd != nil {
+		in, out := &in.OVSVswitchd, &out.OVSVswitchd
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerExtraArgs.
+func (in *OVNControllerExtraArgs) DeepCopy() *OVNControllerExtraArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerExtraArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerList) DeepCopyInto(out *OVNControllerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OVNController, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerList.
+func (in *OVNControllerList) DeepCopy() *OVNControllerList {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNControllerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerMemoryWatchdog) DeepCopyInto(out *OVNControllerMemoryWatchdog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerMemoryWatchdog.
+func (in *OVNControllerMemoryWatchdog) DeepCopy() *OVNControllerMemoryWatchdog {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerMemoryWatchdog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerMetrics) DeepCopyInto(out *OVNControllerMetrics) {
+	*out = *in
+	if in.MonitorLabels != nil {
+		in, out := &in.MonitorLabels, &out.MonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerMetrics.
+func (in *OVNControllerMetrics) DeepCopy() *OVNControllerMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerPodNamespaces) DeepCopyInto(out *OVNControllerPodNamespaces) {
+	*out = *in
+	out.OVNController = in.OVNController
+	out.OVS = in.OVS
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerPodNamespaces.
+func (in *OVNControllerPodNamespaces) DeepCopy() *OVNControllerPodNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerPodNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerRollbackStatus) DeepCopyInto(out *OVNControllerRollbackStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.FailedNodes != nil {
+		in, out := &in.FailedNodes, &out.FailedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerRollbackStatus.
+func (in *OVNControllerRollbackStatus) DeepCopy() *OVNControllerRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerRolloutStatus) DeepCopyInto(out *OVNControllerRolloutStatus) {
+	*out = *in
+	if in.ChangedInputs != nil {
+		in, out := &in.ChangedInputs, &out.ChangedInputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PendingNodes != nil {
+		in, out := &in.PendingNodes, &out.PendingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerRolloutStatus.
+func (in *OVNControllerRolloutStatus) DeepCopy() *OVNControllerRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerSBRemote) DeepCopyInto(out *OVNControllerSBRemote) {
+	*out = *in
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerSBRemote.
+func (in *OVNControllerSBRemote) DeepCopy() *OVNControllerSBRemote {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerSBRemote)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerSpec) DeepCopyInto(out *OVNControllerSpec) {
+	*out = *in
+	in.OVNControllerSpecCore.DeepCopyInto(&out.OVNControllerSpecCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerSpec.
+func (in *OVNControllerSpec) DeepCopy() *OVNControllerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerSpecCore) DeepCopyInto(out *OVNControllerSpecCore) {
+	*out = *in
+	in.ExternalIDS.DeepCopyInto(&out.ExternalIDS)
+	if in.NicMappings != nil {
+		in, out := &in.NicMappings, &out.NicMappings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.ContainerResources.DeepCopyInto(&out.ContainerResources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeCapabilities != nil {
+		in, out := &in.NodeCapabilities, &out.NodeCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.TLS.DeepCopyInto(&out.TLS)
+	in.Metrics.DeepCopyInto(&out.Metrics)
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(OVNControllerCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(OVNControllerAutoRollback)
+		**out = **in
+	}
+	if in.ExternalChassis != nil {
+		in, out := &in.ExternalChassis, &out.ExternalChassis
+		*out = make([]OVNExternalChassis, len(*in))
+		copy(*out, *in)
+	}
+	if in.BGP != nil {
+		in, out := &in.BGP, &out.BGP
+		*out = new(OVNControllerBGP)
+		**out = **in
+	}
+	if in.Toolbox != nil {
+		in, out := &in.Toolbox, &out.Toolbox
+		*out = new(OVNControllerToolbox)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryWatchdog != nil {
+		in, out := &in.MemoryWatchdog, &out.MemoryWatchdog
+		*out = new(OVNControllerMemoryWatchdog)
+		**out = **in
+	}
+	if in.SBRemote != nil {
+		in, out := &in.SBRemote, &out.SBRemote
+		*out = new(OVNControllerSBRemote)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Sidecars.DeepCopyInto(&out.Sidecars)
+	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
+	out.PodNamespaces = in.PodNamespaces
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerSpecCore.
+func (in *OVNControllerSpecCore) DeepCopy() *OVNControllerSpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerSpecCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerStatus) DeepCopyInto(out *OVNControllerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.TLSHashes != nil {
+		in, out := &in.TLSHashes, &out.TLSHashes
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = make(map[string]OVNControllerRolloutStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = make(map[string]OVNControllerRollbackStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Chassis != nil {
+		in, out := &in.Chassis, &out.Chassis
+		*out = make([]OVNControllerChassis, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodesWithoutChassis != nil {
+		in, out := &in.NodesWithoutChassis, &out.NodesWithoutChassis
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnsupportedArchNodes != nil {
+		in, out := &in.UnsupportedArchNodes, &out.UnsupportedArchNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChassisErrorsCountedUntil != nil {
+		in, out := &in.ChassisErrorsCountedUntil, &out.ChassisErrorsCountedUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(OVNControllerCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OvnVersion != nil {
+		in, out := &in.OvnVersion, &out.OvnVersion
+		*out = new(OVNControllerVersion)
+		**out = **in
+	}
+	if in.MaintenanceNodes != nil {
+		in, out := &in.MaintenanceNodes, &out.MaintenanceNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Decommission != nil {
+		in, out := &in.Decommission, &out.Decommission
+		*out = make([]OVNControllerDecommissionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.OvnVersions != nil {
+		in, out := &in.OvnVersions, &out.OvnVersions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OvsVersions != nil {
+		in, out := &in.OvsVersions, &out.OvsVersions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExternalChassis != nil {
+		in, out := &in.ExternalChassis, &out.ExternalChassis
+		*out = make([]OVNExternalChassisStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.Tuning.DeepCopyInto(&out.Tuning)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerStatus.
+func (in *OVNControllerStatus) DeepCopy() *OVNControllerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerToolbox) DeepCopyInto(out *OVNControllerToolbox) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerToolbox.
+func (in *OVNControllerToolbox) DeepCopy() *OVNControllerToolbox {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerToolbox)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerVersion) DeepCopyInto(out *OVNControllerVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerVersion.
+func (in *OVNControllerVersion) DeepCopy() *OVNControllerVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBCluster) DeepCopyInto(out *OVNDBCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBCluster.
+func (in *OVNDBCluster) DeepCopy() *OVNDBCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNDBCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterConsistencyCheck) DeepCopyInto(out *OVNDBClusterConsistencyCheck) {
+	*out = *in
+	in.LastCheck.DeepCopyInto(&out.LastCheck)
+	if in.Problems != nil {
+		in, out := &in.Problems, &out.Problems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterConsistencyCheck.
+func (in *OVNDBClusterConsistencyCheck) DeepCopy() *OVNDBClusterConsistencyCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterConsistencyCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterDefaults) DeepCopyInto(out *OVNDBClusterDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterDefaults.
+func (in *OVNDBClusterDefaults) DeepCopy() *OVNDBClusterDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterList) DeepCopyInto(out *OVNDBClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OVNDBCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterList.
+func (in *OVNDBClusterList) DeepCopy() *OVNDBClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNDBClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterLogFile) DeepCopyInto(out *OVNDBClusterLogFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterLogFile.
+func (in *OVNDBClusterLogFile) DeepCopy() *OVNDBClusterLogFile {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterLogFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterMember) DeepCopyInto(out *OVNDBClusterMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterMember.
+func (in *OVNDBClusterMember) DeepCopy() *OVNDBClusterMember {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterNetworkPolicy) DeepCopyInto(out *OVNDBClusterNetworkPolicy) {
+	*out = *in
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedServices != nil {
+		in, out := &in.AllowedServices, &out.AllowedServices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterNetworkPolicy.
+func (in *OVNDBClusterNetworkPolicy) DeepCopy() *OVNDBClusterNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterOverrideSpec) DeepCopyInto(out *OVNDBClusterOverrideSpec) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(service.OverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterOverrideSpec.
+func (in *OVNDBClusterOverrideSpec) DeepCopy() *OVNDBClusterOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterSchemaUpgrade) DeepCopyInto(out *OVNDBClusterSchemaUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterSchemaUpgrade.
+func (in *OVNDBClusterSchemaUpgrade) DeepCopy() *OVNDBClusterSchemaUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterSchemaUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterSpec) DeepCopyInto(out *OVNDBClusterSpec) {
+	*out = *in
+	in.OVNDBClusterSpecCore.DeepCopyInto(&out.OVNDBClusterSpecCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterSpec.
+func (in *OVNDBClusterSpec) DeepCopy() *OVNDBClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterSpecCore) DeepCopyInto(out *OVNDBClusterSpecCore) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LogModules != nil {
+		in, out := &in.LogModules, &out.LogModules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.LogFile = in.LogFile
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.TLS.DeepCopyInto(&out.TLS)
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	in.Alerts.DeepCopyInto(&out.Alerts)
+	in.Override.DeepCopyInto(&out.Override)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterSpecCore.
+func (in *OVNDBClusterSpecCore) DeepCopy() *OVNDBClusterSpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterSpecCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterStatus) DeepCopyInto(out *OVNDBClusterStatus) {
+	*out = *in
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ClusterMembers != nil {
+		in, out := &in.ClusterMembers, &out.ClusterMembers
+		*out = make([]OVNDBClusterMember, len(*in))
+		copy(*out, *in)
+	}
+	if in.SchemaUpgrade != nil {
+		in, out := &in.SchemaUpgrade, &out.SchemaUpgrade
+		*out = new(OVNDBClusterSchemaUpgrade)
+		**out = **in
+	}
+	if in.ConsistencyCheck != nil {
+		in, out := &in.ConsistencyCheck, &out.ConsistencyCheck
+		*out = new(OVNDBClusterConsistencyCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaleChassis != nil {
+		in, out := &in.StaleChassis, &out.StaleChassis
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Tuning.DeepCopyInto(&out.Tuning)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterStatus.
+func (in *OVNDBClusterStatus) DeepCopy() *OVNDBClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDiagnostics) DeepCopyInto(out *OVNDiagnostics) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDiagnostics.
+func (in *OVNDiagnostics) DeepCopy() *OVNDiagnostics {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDiagnostics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNDiagnostics) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDiagnosticsList) DeepCopyInto(out *OVNDiagnosticsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OVNDiagnostics, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDiagnosticsList.
+func (in *OVNDiagnosticsList) DeepCopy() *OVNDiagnosticsList {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDiagnosticsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNDiagnosticsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDiagnosticsSpec) DeepCopyInto(out *OVNDiagnosticsSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDiagnosticsSpec.
+func (in *OVNDiagnosticsSpec) DeepCopy() *OVNDiagnosticsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDiagnosticsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDiagnosticsStatus) DeepCopyInto(out *OVNDiagnosticsStatus) {
+	*out = *in
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDiagnosticsStatus.
+func (in *OVNDiagnosticsStatus) DeepCopy() *OVNDiagnosticsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDiagnosticsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNExternalChassis) DeepCopyInto(out *OVNExternalChassis) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNExternalChassis.
+func (in *OVNExternalChassis) DeepCopy() *OVNExternalChassis {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNExternalChassis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNExternalChassisStatus) DeepCopyInto(out *OVNExternalChassisStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNExternalChassisStatus.
+func (in *OVNExternalChassisStatus) DeepCopy() *OVNExternalChassisStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNExternalChassisStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNGlobalConfig) DeepCopyInto(out *OVNGlobalConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNGlobalConfig.
+func (in *OVNGlobalConfig) DeepCopy() *OVNGlobalConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNGlobalConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNGlobalConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNGlobalConfigList) DeepCopyInto(out *OVNGlobalConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OVNGlobalConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNGlobalConfigList.
+func (in *OVNGlobalConfigList) DeepCopy() *OVNGlobalConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNGlobalConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNGlobalConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNGlobalConfigSpec) DeepCopyInto(out *OVNGlobalConfigSpec) {
+	*out = *in
+	if in.NBGlobalOptions != nil {
+		in, out := &in.NBGlobalOptions, &out.NBGlobalOptions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNGlobalConfigSpec.
+func (in *OVNGlobalConfigSpec) DeepCopy() *OVNGlobalConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNGlobalConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNGlobalConfigStatus) DeepCopyInto(out *OVNGlobalConfigStatus) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNGlobalConfigStatus.
+func (in *OVNGlobalConfigStatus) DeepCopy() *OVNGlobalConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNGlobalConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNInterconnect) DeepCopyInto(out *OVNInterconnect) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNInterconnect.
+func (in *OVNInterconnect) DeepCopy() *OVNInterconnect {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNInterconnect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNInterconnect) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNInterconnectDefaults) DeepCopyInto(out *OVNInterconnectDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNInterconnectDefaults.
+func (in *OVNInterconnectDefaults) DeepCopy() *OVNInterconnectDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNInterconnectDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNInterconnectList) DeepCopyInto(out *OVNInterconnectList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OVNInterconnect, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNInterconnectList.
+func (in *OVNInterconnectList) DeepCopy() *OVNInterconnectList {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNInterconnectList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNInterconnectList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNInterconnectSpec) DeepCopyInto(out *OVNInterconnectSpec) {
+	*out = *in
+	in.OVNInterconnectSpecCore.DeepCopyInto(&out.OVNInterconnectSpecCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNInterconnectSpec.
+func (in *OVNInterconnectSpec) DeepCopy() *OVNInterconnectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNInterconnectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNInterconnectSpecCore) DeepCopyInto(out *OVNInterconnectSpecCore) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.TLS.DeepCopyInto(&out.TLS)
+	in.Sidecars.DeepCopyInto(&out.Sidecars)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNInterconnectSpecCore.
+func (in *OVNInterconnectSpecCore) DeepCopy() *OVNInterconnectSpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNInterconnectSpecCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNInterconnectStatus) DeepCopyInto(out *OVNInterconnectStatus) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNInterconnectStatus.
+func (in *OVNInterconnectStatus) DeepCopy() *OVNInterconnectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNInterconnectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthd) DeepCopyInto(out *OVNNorthd) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthd.
+func (in *OVNNorthd) DeepCopy() *OVNNorthd {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthd)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNNorthd) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdDataRetention) DeepCopyInto(out *OVNNorthdDataRetention) {
+	*out = *in
+	if in.MACBindingAgeThreshold != nil {
+		in, out := &in.MACBindingAgeThreshold, &out.MACBindingAgeThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MACBindingRemovalLimit != nil {
+		in, out := &in.MACBindingRemovalLimit, &out.MACBindingRemovalLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FDBAgeThreshold != nil {
+		in, out := &in.FDBAgeThreshold, &out.FDBAgeThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FDBRemovalLimit != nil {
+		in, out := &in.FDBRemovalLimit, &out.FDBRemovalLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdDataRetention.
+func (in *OVNNorthdDataRetention) DeepCopy() *OVNNorthdDataRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdDataRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdDefaults) DeepCopyInto(out *OVNNorthdDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdDefaults.
+func (in *OVNNorthdDefaults) DeepCopy() *OVNNorthdDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdList) DeepCopyInto(out *OVNNorthdList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OVNNorthd, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdList.
+func (in *OVNNorthdList) DeepCopy() *OVNNorthdList {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNNorthdList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdReplicaStatus) DeepCopyInto(out *OVNNorthdReplicaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdReplicaStatus.
+func (in *OVNNorthdReplicaStatus) DeepCopy() *OVNNorthdReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdSpec) DeepCopyInto(out *OVNNorthdSpec) {
+	*out = *in
+	in.OVNNorthdSpecCore.DeepCopyInto(&out.OVNNorthdSpecCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdSpec.
+func (in *OVNNorthdSpec) DeepCopy() *OVNNorthdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdSpecCore) DeepCopyInto(out *OVNNorthdSpecCore) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LogModules != nil {
+		in, out := &in.LogModules, &out.LogModules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.TLS.DeepCopyInto(&out.TLS)
+	if in.NThreads != nil {
+		in, out := &in.NThreads, &out.NThreads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProbeInterval != nil {
+		in, out := &in.ProbeInterval, &out.ProbeInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffInterval != nil {
+		in, out := &in.BackoffInterval, &out.BackoffInterval
+		*out = new(int32)
+		**out = **in
+	}
+	in.DataRetention.DeepCopyInto(&out.DataRetention)
+	in.Alerts.DeepCopyInto(&out.Alerts)
+	in.Sidecars.DeepCopyInto(&out.Sidecars)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdSpecCore.
+func (in *OVNNorthdSpecCore) DeepCopy() *OVNNorthdSpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdSpecCore)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -482,6 +1851,11 @@ func (in *OVNNorthdSpecCore) DeepCopy() *OVNNorthdSpecCore {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVNNorthdStatus) DeepCopyInto(out *OVNNorthdStatus) {
 	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]OVNNorthdReplicaStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -489,6 +1863,7 @@ func (in *OVNNorthdStatus) DeepCopyInto(out *OVNNorthdStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Tuning.DeepCopyInto(&out.Tuning)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdStatus.
@@ -501,6 +1876,109 @@ func (in *OVNNorthdStatus) DeepCopy() *OVNNorthdStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNTrace) DeepCopyInto(out *OVNTrace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNTrace.
+func (in *OVNTrace) DeepCopy() *OVNTrace {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNTrace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNTrace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNTraceList) DeepCopyInto(out *OVNTraceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OVNTrace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNTraceList.
+func (in *OVNTraceList) DeepCopy() *OVNTraceList {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNTraceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OVNTraceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNTraceSpec) DeepCopyInto(out *OVNTraceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNTraceSpec.
+func (in *OVNTraceSpec) DeepCopy() *OVNTraceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNTraceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNTraceStatus) DeepCopyInto(out *OVNTraceStatus) {
+	*out = *in
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNTraceStatus.
+func (in *OVNTraceStatus) DeepCopy() *OVNTraceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNTraceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSExternalIDs) DeepCopyInto(out *OVSExternalIDs) {
 	*out = *in
@@ -514,6 +1992,13 @@ func (in *OVSExternalIDs) DeepCopyInto(out *OVSExternalIDs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.GatewayNodeSelector != nil {
+		in, out := &in.GatewayNodeSelector, &out.GatewayNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSExternalIDs.
@@ -525,3 +2010,177 @@ func (in *OVSExternalIDs) DeepCopy() *OVSExternalIDs {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodNamespaces) DeepCopyInto(out *PodNamespaces) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNamespaces.
+func (in *PodNamespaces) DeepCopy() *PodNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(PodNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Sidecar) DeepCopyInto(out *Sidecar) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]SidecarVolumeMount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sidecar.
+func (in *Sidecar) DeepCopy() *Sidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(Sidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSection) DeepCopyInto(out *SidecarSection) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]Sidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]ExtraVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSection.
+func (in *SidecarSection) DeepCopy() *SidecarSection {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarVolumeMount) DeepCopyInto(out *SidecarVolumeMount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarVolumeMount.
+func (in *SidecarVolumeMount) DeepCopy() *SidecarVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSSection) DeepCopyInto(out *TLSSection) {
+	*out = *in
+	in.SimpleService.DeepCopyInto(&out.SimpleService)
+	if in.ExtraDNSNames != nil {
+		in, out := &in.ExtraDNSNames, &out.ExtraDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraIPAddresses != nil {
+		in, out := &in.ExtraIPAddresses, &out.ExtraIPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSection.
+func (in *TLSSection) DeepCopy() *TLSSection {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSSection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TuningPreset) DeepCopyInto(out *TuningPreset) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TuningPreset.
+func (in *TuningPreset) DeepCopy() *TuningPreset {
+	if in == nil {
+		return nil
+	}
+	out := new(TuningPreset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TuningStatus) DeepCopyInto(out *TuningStatus) {
+	*out = *in
+	if in.ChangedAt != nil {
+		in, out := &in.ChangedAt, &out.ChangedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TuningStatus.
+func (in *TuningStatus) DeepCopy() *TuningStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TuningStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -16,15 +16,362 @@ limitations under the License.
 
 package controllers
 
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
+	"go.opentelemetry.io/otel/attribute"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
 // fields to index to reconcile when changed
 const (
 	tlsField                = ".spec.tls.secretName"
 	caBundleSecretNameField = ".spec.tls.caBundleSecretName"
+	// octaviaProviderCertField - client cert of the Octavia OVN provider
+	// driver, only indexed for the OVNDBClusters
+	octaviaProviderCertField = ".spec.octaviaProvider"
 )
 
+// autoTuningStepInterval - the least time between two steps of the auto
+// tuning profile, and the interval the chassis are counted again
+const autoTuningStepInterval = 10 * time.Minute
+
 var (
 	allWatchFields = []string{
 		caBundleSecretNameField,
 		tlsField,
 	}
 )
+
+// reconcileFIPS - report in the FIPSProviderReady condition whether the
+// OpenSSL FIPS provider is active in the images of the pods, pods which are
+// not running yet get checked on a later reconcile
+func reconcileFIPS(
+	ctx context.Context,
+	h *helper.Helper,
+	restConfig *rest.Config,
+	conditions *condition.Conditions,
+	pods []corev1.Pod,
+) error {
+	checked, inactive, err := ovn_common.FIPSProviderInactivePods(ctx, h, restConfig, pods)
+	if err != nil {
+		conditions.Set(condition.FalseCondition(
+			ovnv1.OVNFIPSProviderReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.OVNFIPSProviderReadyErrorMessage,
+			err.Error()))
+		return err
+	}
+	if len(inactive) > 0 {
+		conditions.Set(condition.FalseCondition(
+			ovnv1.OVNFIPSProviderReadyCondition,
+			ovnv1.FIPSProviderInactiveReason,
+			condition.SeverityError,
+			ovnv1.OVNFIPSProviderInactiveMessage,
+			strings.Join(inactive, ", ")))
+	} else if checked > 0 {
+		conditions.MarkTrue(ovnv1.OVNFIPSProviderReadyCondition, ovnv1.OVNFIPSProviderReadyMessage)
+	}
+	return nil
+}
+
+// setReplicasReadyCondition - report the ready and desired pod counts of a
+// child resource in its condition, the message formats take both counts
+func setReplicasReadyCondition(
+	conditions *condition.Conditions,
+	conditionType condition.Type,
+	ready bool,
+	readyMessage string,
+	runningMessage string,
+	readyCount int32,
+	desiredCount int32,
+) {
+	if ready {
+		conditions.MarkTrue(conditionType, readyMessage, readyCount, desiredCount)
+		return
+	}
+	conditions.Set(condition.FalseCondition(
+		conditionType,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		runningMessage,
+		readyCount,
+		desiredCount))
+}
+
+// verifyClientTLS - TLS is enabled per database, but the OVN daemons use a
+// single SSL configuration for all their connections. A client needs a cert
+// as soon as one of the databases it connects to uses TLS.
+func verifyClientTLS(tlsSection ovnv1.TLSSection, endpoints ...string) error {
+	if tlsSection.Enabled() {
+		return nil
+	}
+	for _, endpoint := range endpoints {
+		if strings.HasPrefix(endpoint, "ssl:") {
+			return fmt.Errorf("%s uses TLS, set spec.tls.secretName to connect to it", endpoint)
+		}
+	}
+	return nil
+}
+
+// pendingDBClusterUpgrade - name of an OVNDBCluster of the namespace which
+// does not run the image of its spec on all its members yet, or is not
+// ready. Empty when all of them are upgraded.
+func pendingDBClusterUpgrade(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+) (string, error) {
+	dbs := &ovnv1.OVNDBClusterList{}
+	err := h.GetClient().List(ctx, dbs, client.InNamespace(namespace))
+	if err != nil {
+		return "", err
+	}
+	// the cache lists in no particular order, the same pending one is
+	// reported on every reconcile
+	sort.Slice(dbs.Items, func(i, j int) bool { return dbs.Items[i].Name < dbs.Items[j].Name })
+	for _, db := range dbs.Items {
+		if db.Status.ContainerImage != db.Spec.ContainerImage || !db.IsReady() {
+			return db.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// pendingNorthdUpgrade - name of an OVNNorthd of the namespace which does
+// not run the image of its spec on all its replicas yet, or is not ready.
+// Empty when all of them are upgraded.
+func pendingNorthdUpgrade(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+) (string, error) {
+	northds := &ovnv1.OVNNorthdList{}
+	err := h.GetClient().List(ctx, northds, client.InNamespace(namespace))
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(northds.Items, func(i, j int) bool { return northds.Items[i].Name < northds.Items[j].Name })
+	for _, northd := range northds.Items {
+		if *northd.Spec.Replicas == 0 {
+			continue
+		}
+		if northd.Status.ContainerImage != northd.Spec.ContainerImage || !northd.IsReady() {
+			return northd.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// upgradeImage - the image to roll out. The image of the spec is rolled out
+// on the first deployment, or once the components upgraded before are done.
+// Until then the deployed image is kept and the UpgradeReady condition
+// reports what the upgrade waits for.
+func upgradeImage(
+	conditions *condition.Conditions,
+	specImage string,
+	deployedImage string,
+	pending string,
+) string {
+	if deployedImage == "" || deployedImage == specImage || pending == "" {
+		return specImage
+	}
+	conditions.Set(condition.FalseCondition(
+		ovnv1.OVNUpgradeReadyCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		ovnv1.OVNUpgradeReadyWaitingMessage,
+		pending,
+		specImage))
+	return deployedImage
+}
+
+// suspendReconcile - keep the conditions of the last reconciliation while
+// suspended, the owned resources they report on are not reconciled
+func suspendReconcile(conditions *condition.Conditions, savedConditions condition.Conditions) {
+	if len(savedConditions) > 0 {
+		*conditions = savedConditions.DeepCopy()
+	}
+	conditions.MarkTrue(ovnv1.OVNSuspendedCondition, ovnv1.OVNSuspendedMessage)
+}
+
+// applyWorkload - apply a DaemonSet, StatefulSet or Deployment following the
+// drift policy of the instance. The manual changes of the fields set by the
+// operator are reported with an event when reverted, in the Drifted
+// condition when kept.
+func applyWorkload(
+	ctx context.Context,
+	helper *helper.Helper,
+	recorder record.EventRecorder,
+	conditions *condition.Conditions,
+	driftPolicy string,
+	obj client.Object,
+) (err error) {
+	gvk, err := apiutil.GVKForObject(obj, helper.GetScheme())
+	if err != nil {
+		return err
+	}
+	ctx, span := ovn_common.StartSpan(ctx, "ApplyWorkload",
+		attribute.String("kind", gvk.Kind), attribute.String("name", obj.GetName()))
+	defer func() { ovn_common.EndSpan(span, err) }()
+
+	revert := driftPolicy != ovnv1.DriftPolicyReport
+	drifted, err := ovn_common.ApplyDetectingDrift(ctx, helper, obj, revert)
+	if err != nil || len(drifted) == 0 {
+		return err
+	}
+	workload := fmt.Sprintf("%s %s (%s)", gvk.Kind, obj.GetName(), strings.Join(drifted, ", "))
+
+	if revert {
+		recorder.Eventf(helper.GetBeforeObject(), corev1.EventTypeWarning, ovn_common.EventReasonDriftReverted,
+			"Reverted the manual changes of %s", workload)
+		return nil
+	}
+	message := fmt.Sprintf(ovnv1.OVNDriftedMessage, workload)
+	if c := conditions.Get(ovnv1.OVNDriftedCondition); c != nil {
+		message = c.Message + "; " + workload
+	}
+	conditions.Set(condition.TrueCondition(ovnv1.OVNDriftedCondition, "%s", message))
+	return nil
+}
+
+// getRunningDBPod - a running pod of the DB cluster to query and update the
+// DB through
+func getRunningDBPod(
+	ctx context.Context,
+	helper *helper.Helper,
+	dbCluster *ovnv1.OVNDBCluster,
+) (*corev1.Pod, error) {
+	pods, err := ovndbcluster.OVNDBPods(ctx, dbCluster, helper, map[string]string{
+		common.AppSelector: dbCluster.GetServiceName(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s DB pods: %w", dbCluster.Spec.DBType, err)
+	}
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no running %s DB pod to query", dbCluster.Spec.DBType)
+}
+
+// reconcileInputs - set the InputReady condition, False naming the Secrets
+// referenced in the TLS section which don't exist. They are not a reconcile
+// error: the caller requeues with the backoff of the rate limiter when
+// missing is true, and the watch of the Secrets requeues once they are
+// created. The Secret of a cert issued by the operator is not an input.
+func reconcileInputs(
+	ctx context.Context,
+	h *helper.Helper,
+	conditions *condition.Conditions,
+	tlsSection ovnv1.TLSSection,
+) (_ bool, err error) {
+	ctx, span := ovn_common.StartSpan(ctx, "ReconcileInputs")
+	defer func() { ovn_common.EndSpan(span, err) }()
+
+	names := []string{}
+	if tlsSection.CaBundleSecretName != "" {
+		names = append(names, tlsSection.CaBundleSecretName)
+	}
+	if tlsSection.Enabled() && tlsSection.Issuer == "" {
+		names = append(names, *tlsSection.SecretName)
+	}
+
+	missing := []string{}
+	for _, name := range names {
+		err := h.GetClient().Get(ctx, types.NamespacedName{
+			Name:      name,
+			Namespace: h.GetBeforeObject().GetNamespace(),
+		}, &corev1.Secret{})
+		if k8s_errors.IsNotFound(err) {
+			missing = append(missing, "Secret "+name)
+		} else if err != nil {
+			return false, err
+		}
+	}
+
+	if len(missing) > 0 {
+		conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			ovnv1.InputMissingReason,
+			condition.SeverityWarning,
+			ovnv1.OVNInputReadyMissingMessage,
+			strings.Join(missing, ", ")))
+		return true, nil
+	}
+	conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+	return false, nil
+}
+
+// reconcileAutoTuning - with the auto tuning profile, choose the small,
+// medium or large profile for the number of chassis of the namespace. The
+// profile steps one size at a time, at most every autoTuningStepInterval, so
+// the DB clusters and the chassis follow each step before the next one. The
+// chassis are counted again every autoTuningStepInterval.
+func reconcileAutoTuning(
+	ctx context.Context,
+	h *helper.Helper,
+	profile string,
+	status *ovnv1.TuningStatus,
+) (ctrl.Result, error) {
+	if profile != ovnv1.TuningProfileAuto {
+		*status = ovnv1.TuningStatus{}
+		return ctrl.Result{}, nil
+	}
+	chassis, err := getChassisCount(ctx, h)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status.Chassis = chassis
+
+	next := ovnv1.AutoTuningProfile(status.Profile, chassis)
+	if next == status.Profile {
+		return ctrl.Result{RequeueAfter: autoTuningStepInterval}, nil
+	}
+	if status.Profile != "" && status.ChangedAt != nil {
+		if elapsed := time.Since(status.ChangedAt.Time); elapsed < autoTuningStepInterval {
+			return ctrl.Result{RequeueAfter: autoTuningStepInterval - elapsed}, nil
+		}
+	}
+	h.GetLogger().Info(fmt.Sprintf("Tuning profile %s chosen for %d chassis", next, chassis))
+	status.Profile = next
+	now := metav1.Now()
+	status.ChangedAt = &now
+	return ctrl.Result{RequeueAfter: autoTuningStepInterval}, nil
+}
+
+// getChassisCount - the number of chassis of the namespace, the nodes the
+// OVNControllers run on and their external chassis
+func getChassisCount(ctx context.Context, h *helper.Helper) (int32, error) {
+	ovnControllers := &ovnv1.OVNControllerList{}
+	err := h.GetClient().List(ctx, ovnControllers, client.InNamespace(h.GetBeforeObject().GetNamespace()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list the OVNControllers: %w", err)
+	}
+	chassis := int32(0)
+	for _, ovnController := range ovnControllers.Items {
+		chassis += ovnController.Status.DesiredNumberScheduled + int32(len(ovnController.Spec.ExternalChassis))
+	}
+	return chassis, nil
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncapture"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// OVNCaptureReconciler reconciles a OVNCapture object
+type OVNCaptureReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
+}
+
+// GetClient -
+func (r *OVNCaptureReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetScheme -
+func (r *OVNCaptureReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *OVNCaptureReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("OVNCapture")
+}
+
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncaptures,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncaptures/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncaptures/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncontrollers,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;
+
+// Reconcile - OVN Capture
+func (r *OVNCaptureReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovncapture", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
+	Log := r.GetLogger(ctx)
+
+	// Fetch the OVNCapture instance
+	instance := &ovnv1.OVNCapture{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovncapture", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovncapture", req.NamespacedName, start, _err)
+	}()
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	//
+	// initialize status
+	//
+	if instance.Status.Conditions == nil {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+	if instance.Status.Hash == nil {
+		instance.Status.Hash = map[string]string{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// initialize conditions used later as Status=Unknown
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNCaptureReadyCondition, condition.InitReason, ovnv1.OVNCaptureReadyInitMessage),
+	)
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// Always patch the instance status when exiting this function so we can persist any changes.
+	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		// update the Ready condition based on the sub conditions
+		if instance.Status.Conditions.AllSubConditionIsTrue() {
+			instance.Status.Conditions.MarkTrue(
+				condition.ReadyCondition, condition.ReadyMessage)
+		} else {
+			// something is not ready so reset the Ready condition
+			instance.Status.Conditions.MarkUnknown(
+				condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
+			// and recalculate it based on the state of the rest of the conditions
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		return ctrl.Result{}, nil
+	}
+
+	// Handle service delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	// Handle non-deleted captures
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OVNCaptureReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	crs := &ovnv1.OVNCaptureList{}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ovnv1.OVNCapture{}).
+		Owns(&batchv1.Job{}).
+		Watches(&ovnv1.OVNController{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		WithOptions(r.Options).
+		Complete(r)
+}
+
+func (r *OVNCaptureReconciler) reconcileDelete(ctx context.Context, instance *ovnv1.OVNCapture, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service delete")
+
+	// Service is deleted so remove the finalizer.
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info("Reconciled Service delete successfully")
+	return ctrl.Result{}, nil
+}
+
+func (r *OVNCaptureReconciler) reconcileNormal(ctx context.Context, instance *ovnv1.OVNCapture, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service")
+
+	// the packets are captured through the OVS deployed by an OVNController
+	ovnControllers := &ovnv1.OVNControllerList{}
+	err := helper.GetClient().List(ctx, ovnControllers, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(ovnControllers.Items) == 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNInputReadyMissingMessage,
+			"[OVNController]"))
+		// the OVNController watch requeues the capture
+		return ctrl.Result{}, nil
+	}
+	ovnController := &ovnControllers.Items[0]
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	image := instance.Spec.ContainerImage
+	if image == "" {
+		image = ovnController.Spec.OvsContainerImage
+	}
+
+	err = ovn_common.EnsurePVC(ctx, helper, ovncapture.PVCName(instance), instance.Namespace,
+		instance.Spec.StorageClass, instance.Spec.StorageRequest)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.PersistentVolumeClaim = ovncapture.PVCName(instance)
+
+	jobDef := ovncapture.CaptureJob(instance, ovnController, image)
+	target := ovncapture.Target(instance, ovnController)
+	captureJob := job.NewJob(
+		jobDef,
+		ovncapture.CaptureHash,
+		true,
+		time.Duration(5)*time.Second,
+		instance.Status.Hash[ovncapture.CaptureHash],
+	)
+	ctrlResult, err := captureJob.DoJob(ctx, helper)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.CaptureFile = ""
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNCaptureReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNCaptureReadyRunningMessage,
+			target,
+			instance.Spec.Node))
+		return ctrlResult, nil
+	}
+	if err != nil {
+		message, msgErr := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, jobDef.Name)
+		if msgErr != nil || message == "" {
+			message = err.Error()
+		}
+		Log.Info(fmt.Sprintf("Job %s failed: %s", jobDef.Name, message))
+		instance.Status.CaptureFile = ""
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNCaptureReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.OVNCaptureReadyErrorMessage,
+			message))
+		return ctrl.Result{}, nil
+	}
+	if captureJob.HasChanged() {
+		instance.Status.Hash[ovncapture.CaptureHash] = captureJob.GetHash()
+		Log.Info(fmt.Sprintf("Job %s hash added - %s", jobDef.Name, instance.Status.Hash[ovncapture.CaptureHash]))
+	}
+	summary, err := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, jobDef.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.CaptureFile = ovncapture.CaptureFileName(instance)
+	instance.Status.Conditions.MarkTrue(
+		ovnv1.OVNCaptureReadyCondition,
+		ovnv1.OVNCaptureReadyMessage,
+		instance.Status.CaptureFile,
+		instance.Status.PersistentVolumeClaim,
+		ovncapture.Summary(summary))
+
+	Log.Info("Reconciled Service successfully")
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncommand"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// OVNCommandReconciler reconciles a OVNCommand object
+type OVNCommandReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
+}
+
+// GetClient -
+func (r *OVNCommandReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetScheme -
+func (r *OVNCommandReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *OVNCommandReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("OVNCommand")
+}
+
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncommands,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncommands/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncommands/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncontrollers,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;
+
+// Reconcile - OVN Command
+func (r *OVNCommandReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovncommand", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
+	Log := r.GetLogger(ctx)
+
+	// Fetch the OVNCommand instance
+	instance := &ovnv1.OVNCommand{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovncommand", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovncommand", req.NamespacedName, start, _err)
+	}()
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	//
+	// initialize status
+	//
+	if instance.Status.Conditions == nil {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+	if instance.Status.Hash == nil {
+		instance.Status.Hash = map[string]string{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// initialize conditions used later as Status=Unknown
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNCommandReadyCondition, condition.InitReason, ovnv1.OVNCommandReadyInitMessage),
+	)
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// Always patch the instance status when exiting this function so we can persist any changes.
+	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		// update the Ready condition based on the sub conditions
+		if instance.Status.Conditions.AllSubConditionIsTrue() {
+			instance.Status.Conditions.MarkTrue(
+				condition.ReadyCondition, condition.ReadyMessage)
+		} else {
+			// something is not ready so reset the Ready condition
+			instance.Status.Conditions.MarkUnknown(
+				condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
+			// and recalculate it based on the state of the rest of the conditions
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		return ctrl.Result{}, nil
+	}
+
+	// Handle service delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	// Handle non-deleted commands
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OVNCommandReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	crs := &ovnv1.OVNCommandList{}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ovnv1.OVNCommand{}).
+		Owns(&batchv1.Job{}).
+		Watches(&ovnv1.OVNDBCluster{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		Watches(&ovnv1.OVNController{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		WithOptions(r.Options).
+		Complete(r)
+}
+
+func (r *OVNCommandReconciler) reconcileDelete(ctx context.Context, instance *ovnv1.OVNCommand, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service delete")
+
+	// Service is deleted so remove the finalizer.
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info("Reconciled Service delete successfully")
+	return ctrl.Result{}, nil
+}
+
+func (r *OVNCommandReconciler) reconcileNormal(ctx context.Context, instance *ovnv1.OVNCommand, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service")
+
+	// the OVS commands run against the OVS deployed by an OVNController
+	missing := []string{}
+	var dbCluster *ovnv1.OVNDBCluster
+	dbEndpoint := ""
+	if dbType := ovncommand.DBType(instance); dbType != "" {
+		var err error
+		dbCluster, err = ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, dbType)
+		if err != nil {
+			missing = append(missing, "OVNDBCluster "+dbType)
+		} else if dbEndpoint, err = dbCluster.GetInternalEndpoint(); err != nil {
+			missing = append(missing, "OVNDBCluster "+dbType+" endpoint")
+		}
+	}
+	ovnControllers := &ovnv1.OVNControllerList{}
+	err := helper.GetClient().List(ctx, ovnControllers, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var ovnController *ovnv1.OVNController
+	if len(ovnControllers.Items) > 0 {
+		ovnController = &ovnControllers.Items[0]
+	} else if instance.Spec.IsOVSCommand() {
+		missing = append(missing, "OVNController")
+	}
+	if len(missing) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNInputReadyMissingMessage,
+			fmt.Sprintf("%v", missing)))
+		// the OVNDBCluster and OVNController watches requeue the command
+		return ctrl.Result{}, nil
+	}
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	image := instance.Spec.ContainerImage
+	if image == "" {
+		image = ovnv1.OVNControllerContainerImage
+		if ovnController != nil {
+			image = ovnController.Spec.OvnContainerImage
+		}
+	}
+
+	var jobDef *batchv1.Job
+	if instance.Spec.IsOVSCommand() {
+		jobDef = ovncommand.OVSCommandJob(instance, ovnController, image)
+	} else {
+		jobDef = ovncommand.DBCommandJob(instance, dbCluster, dbEndpoint, image)
+	}
+	commandLine := ovncommand.CommandLine(instance, ovnController)
+
+	commandJob := job.NewJob(
+		jobDef,
+		ovncommand.CommandHash,
+		true,
+		time.Duration(5)*time.Second,
+		instance.Status.Hash[ovncommand.CommandHash],
+	)
+	ctrlResult, err := commandJob.DoJob(ctx, helper)
+	if (ctrlResult != ctrl.Result{}) {
+		// the spec is immutable, the command is only run once
+		if instance.Status.CommandLine == "" {
+			instance.Status.CommandLine = commandLine
+			Log.Info(fmt.Sprintf("Running %s in Job %s", commandLine, jobDef.Name))
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonCommandRun,
+				"Running %s in Job %s", commandLine, jobDef.Name)
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNCommandReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNCommandReadyRunningMessage,
+			commandLine))
+		return ctrlResult, nil
+	}
+	if err != nil {
+		message, msgErr := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, jobDef.Name)
+		if msgErr != nil || message == "" {
+			message = err.Error()
+		}
+		Log.Info(fmt.Sprintf("Job %s failed: %s", jobDef.Name, message))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNCommandReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.OVNCommandReadyErrorMessage,
+			commandLine,
+			message))
+		return ctrl.Result{}, nil
+	}
+	if commandJob.HasChanged() {
+		output, err := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, jobDef.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.Output = output
+		instance.Status.Hash[ovncommand.CommandHash] = commandJob.GetHash()
+		Log.Info(fmt.Sprintf("Job %s hash added - %s", jobDef.Name, instance.Status.Hash[ovncommand.CommandHash]))
+	}
+	instance.Status.Conditions.MarkTrue(
+		ovnv1.OVNCommandReadyCondition,
+		ovnv1.OVNCommandReadyMessage,
+		commandLine)
+
+	Log.Info("Reconciled Service successfully")
+	return ctrl.Result{}, nil
+}
@@ -20,19 +20,26 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -42,7 +49,6 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/daemonset"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
@@ -52,7 +58,9 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -67,8 +75,16 @@ func (r *OVNControllerReconciler) GetLogger(ctx context.Context) logr.Logger {
 // OVNControllerReconciler reconciles a OVNController object
 type OVNControllerReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient    kubernetes.Interface
+	RestConfig *rest.Config
+	Scheme     *runtime.Scheme
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
+	// ForbidHostNamespaces - never share the PID and IPC namespaces of the
+	// nodes with the pods, whatever the spec requests
+	ForbidHostNamespaces bool
 }
 
 // GetClient -
@@ -80,12 +96,24 @@ func (r *OVNControllerReconciler) GetClient() client.Client {
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncontrollers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncontrollers/finalizers,verbs=update;patch
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=nfd.k8s-sigs.io,resources=nodefeaturerules,verbs=get;list;watch;create;update;patch;
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create;
+//+kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;
 //+kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;patch;update;delete;
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovnnorthds,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;patch;update;delete;
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,resourceNames=system:auth-delegator,verbs=bind
 
 // service account, role, rolebinding
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
@@ -96,6 +124,8 @@ func (r *OVNControllerReconciler) GetClient() client.Client {
 // +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
 
 func (r *OVNControllerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovncontroller", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
 	Log := r.GetLogger(ctx)
 
 	// Fetch OVNController instance
@@ -106,12 +136,20 @@ func (r *OVNControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovncontroller", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
 
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovncontroller", req.NamespacedName, start, _err)
+	}()
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -136,6 +174,9 @@ func (r *OVNControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 	// Always patch the instance status when exiting this function so we can persist any changes.
 	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
 		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
 		// update the Ready condition based on the sub conditions
 		if instance.Status.Conditions.AllSubConditionIsTrue() {
@@ -167,12 +208,27 @@ func (r *OVNControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
 		condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNControllerDaemonSetReadyCondition, condition.InitReason, ovnv1.OVNControllerDaemonSetReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVSDaemonSetReadyCondition, condition.InitReason, ovnv1.OVSDaemonSetReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceAccountReadyCondition, condition.InitReason, condition.ServiceAccountReadyInitMessage),
 		condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
 		condition.UnknownCondition(condition.RoleBindingReadyCondition, condition.InitReason, condition.RoleBindingReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 	)
 
+	// FIPSProviderReady is only reported when FIPS is requested
+	if instance.Spec.FIPS {
+		cl.Set(condition.UnknownCondition(ovnv1.OVNFIPSProviderReadyCondition, condition.InitReason, ovnv1.OVNFIPSProviderReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(ovnv1.OVNFIPSProviderReadyCondition)
+	}
+	// TunnelMTUReady is only reported when the check is requested
+	if instance.Spec.TunnelMTUCheck {
+		cl.Set(condition.UnknownCondition(ovnv1.OVNTunnelMTUReadyCondition, condition.InitReason, ovnv1.OVNTunnelMTUReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(ovnv1.OVNTunnelMTUReadyCondition)
+	}
+
 	instance.Status.Conditions.Init(&cl)
 	instance.Status.ObservedGeneration = instance.Generation
 
@@ -188,6 +244,12 @@ func (r *OVNControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return r.reconcileDelete(ctx, instance, helper)
 	}
 
+	// Leave the owned resources alone while suspended
+	if instance.Spec.Suspend {
+		return r.reconcileSuspended(ctx, instance, helper, savedConditions)
+	}
+	instance.Status.Conditions.Remove(ovnv1.OVNSuspendedCondition)
+
 	// Handle non-deleted clusters
 	return r.reconcileNormal(ctx, instance, helper)
 }
@@ -223,19 +285,51 @@ func (r *OVNControllerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ConfigMap{}).
 		Owns(&batchv1.Job{}).
 		Owns(&netattdefv1.NetworkAttachmentDefinition{}).
-		Owns(&appsv1.DaemonSet{}).
+		Owns(&appsv1.DaemonSet{}, builder.WithPredicates(ovncontroller.DaemonSetChangedPredicate())).
+		Owns(&corev1.Service{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
 		Watches(&ovnv1.OVNDBCluster{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		Watches(&ovnv1.OVNNorthd{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForNode),
+			builder.WithPredicates(ovncontroller.NodeChangedPredicate()),
+		).
+		WithOptions(r.Options).
 		Complete(r)
 }
 
+// findObjectsForNode - all the OVNControllers, a node may be annotated for a
+// maintenance or with its encap IP, leave their NodeSelector or their
+// GatewayNodeSelector
+func (r *OVNControllerReconciler) findObjectsForNode(ctx context.Context, node client.Object) []reconcile.Request {
+	requests := []reconcile.Request{}
+
+	crList := &ovnv1.OVNControllerList{}
+	err := r.Client.List(ctx, crList)
+	if err != nil {
+		return requests
+	}
+	for _, item := range crList.Items {
+		requests = append(requests,
+			reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      item.GetName(),
+					Namespace: item.GetNamespace(),
+				},
+			},
+		)
+	}
+	return requests
+}
+
 func (r *OVNControllerReconciler) findObjectsForSrc(ctx context.Context, src client.Object) []reconcile.Request {
 	requests := []reconcile.Request{}
 
@@ -274,6 +368,22 @@ func (r *OVNControllerReconciler) reconcileDelete(ctx context.Context, instance
 
 	Log.Info("Reconciling Service delete")
 
+	ctrlResult, err := r.deleteNodesChassis(ctx, instance, helper)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+
+	// the ClusterRoleBinding is cluster scoped and can't be garbage
+	// collected through the owner reference
+	err = r.deleteAuthDelegatorBinding(ctx, instance, helper)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	ovn_common.DeleteTelemetryMetrics(instance.Namespace, instance.Name, ovnv1.ServiceNameOVNController)
+	ovncontroller.DeleteChassisMetrics(instance)
+
 	// Service is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
 	Log.Info("Reconciled Service delete successfully")
@@ -281,6 +391,63 @@ func (r *OVNControllerReconciler) reconcileDelete(ctx context.Context, instance
 	return ctrl.Result{}, nil
 }
 
+// deleteNodesChassis - remove the chassis of the nodes of the instance from
+// the SB DB. ovn-controller is stopped first, otherwise it registers them
+// again. The cleanup is skipped when no SB DB is running anymore.
+func (r *OVNControllerReconciler) deleteNodesChassis(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	// the ovn-controller DaemonSets, the one of each zone with zones
+	err := ovncontroller.DeleteUnusedDaemonSets(ctx, helper, instance, ovnv1.ServiceNameOVNController, nil)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(map[string]string{common.AppSelector: ovnv1.ServiceNameOVNController}).String(),
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	nodeList, err := helper.GetKClient().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error listing the nodes: %w", err)
+	}
+	nodes := ovncontroller.SelectedNodes(nodeList.Items, instance.Spec.NodesSelector())
+	for _, pod := range podList.Items {
+		if slices.Contains(nodes, pod.Spec.NodeName) {
+			Log.Info("Waiting for ovn-controller to stop before deleting the chassis", "pod", pod.Name)
+			return ctrl.Result{RequeueAfter: time.Duration(5) * time.Second}, nil
+		}
+	}
+
+	sbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.SBDBType)
+	if err != nil {
+		Log.Info(fmt.Sprintf("Chassis not deleted: %s", err.Error()))
+		return ctrl.Result{}, nil
+	}
+	sbPod, err := getRunningDBPod(ctx, helper, sbCluster)
+	if err != nil {
+		Log.Info(fmt.Sprintf("Chassis not deleted: %s", err.Error()))
+		return ctrl.Result{}, nil
+	}
+	inventory, err := ovncontroller.GetChassisInventory(ctx, helper, r.RestConfig, sbPod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, ch := range inventory.ChassisOfNodes(nodes) {
+		err := ovncontroller.DeleteChassis(ctx, helper, r.RestConfig, sbPod, ch.Name)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error deleting chassis %s: %w", ch.Name, err)
+		}
+		Log.Info("Deleted chassis", "chassis", ch.Name, "node", ch.Hostname)
+	}
+	return ctrl.Result{}, nil
+}
+
 func (r *OVNControllerReconciler) reconcileInit(
 	ctx context.Context,
 ) (ctrl.Result, error) {
@@ -295,6 +462,41 @@ func (r *OVNControllerReconciler) reconcileInit(
 	return ctrl.Result{}, nil
 }
 
+// reconcileSuspended - only refresh the status from the DaemonSets, none of
+// the owned resources is modified
+func (r *OVNControllerReconciler) reconcileSuspended(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	savedConditions condition.Conditions,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciliation suspended")
+	suspendReconcile(&instance.Status.Conditions, savedConditions)
+
+	instance.Status.DesiredNumberScheduled = 0
+	instance.Status.NumberReady = 0
+	instance.Status.OVSNumberReady = 0
+	for _, name := range []string{ovnv1.ServiceNameOVNController, ovnv1.ServiceNameOVS} {
+		for _, dsName := range ovncontroller.DaemonSetNames(name, instance.Spec.Zones) {
+			ds := &appsv1.DaemonSet{}
+			err := helper.GetClient().Get(ctx, types.NamespacedName{Name: dsName, Namespace: instance.Namespace}, ds)
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			if name == ovnv1.ServiceNameOVS {
+				instance.Status.OVSNumberReady += ds.Status.NumberReady
+				continue
+			}
+			instance.Status.DesiredNumberScheduled += ds.Status.DesiredNumberScheduled
+			instance.Status.NumberReady += ds.Status.NumberReady
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
 func (r *OVNControllerReconciler) reconcileUpdate(ctx context.Context) (ctrl.Result, error) {
 	Log := r.GetLogger(ctx)
 
@@ -318,21 +520,25 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 
 	Log.Info("Reconciling Service")
 
-	// Service account, role, binding
-	rbacRules := []rbacv1.PolicyRule{
-		{
-			APIGroups:     []string{"security.openshift.io"},
-			ResourceNames: []string{"anyuid", "privileged"},
-			Resources:     []string{"securitycontextconstraints"},
-			Verbs:         []string{"use"},
-		},
-		{
-			APIGroups: []string{""},
-			Resources: []string{"pods"},
-			Verbs:     []string{"create", "get", "list", "watch", "update", "patch", "delete"},
-		},
+	// Service accounts, roles and bindings of the ovn-controller and OVS
+	// pods, only allowed to use the privileged SCC
+	rbacRules := ovn_common.SCCRules(ovn_common.SCCPrivileged)
+	// an externally managed ServiceAccount is only checked
+	rbacResult := ctrl.Result{}
+	var err error
+	if instance.Spec.ServiceAccount != "" {
+		rbacResult, err = r.reconcileExternalServiceAccount(ctx, instance, helper)
+	} else {
+		rbacResult, err = common_rbac.ReconcileRbac(ctx, helper, instance, rbacRules)
+		if err == nil && (rbacResult == ctrl.Result{}) {
+			// the config agents of the OVS pods watch their node config
+			ovsRbacRules := rbacRules
+			if instance.Spec.ConfigAgent {
+				ovsRbacRules = append(ovncontroller.ConfigAgentRbacRules(), rbacRules...)
+			}
+			rbacResult, err = common_rbac.ReconcileRbac(ctx, helper, ovncontroller.OVSRbacInstance{OVNController: instance}, ovsRbacRules)
+		}
 	}
-	rbacResult, err := common_rbac.ReconcileRbac(ctx, helper, instance, rbacRules)
 	if err != nil {
 		return rbacResult, err
 	} else if (rbacResult != ctrl.Result{}) {
@@ -341,8 +547,15 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 
 	// ConfigMap
 	configMapVars := make(map[string]env.Setter)
+	// the hashes of the inputs the DaemonSets are built from
+	daemonSetInputs := ovncontroller.DaemonSetInputs{}
 
-	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+	missing, err := reconcileInputs(ctx, helper, &instance.Status.Conditions, instance.Spec.TLS)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if missing {
+		return ctrl.Result{Requeue: true}, nil
+	}
 
 	//
 	// TLS input validation
@@ -369,14 +582,41 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 			return ctrlResult, nil
 		}
 
-		if hash != "" {
-			configMapVars[tls.CABundleKey] = env.SetValue(hash)
+		daemonSetInputs.CABundleHash = hash
+	}
+
+	// Request the service cert from cert-manager
+	if instance.Spec.TLS.Issuer != "" && instance.Spec.TLS.Enabled() {
+		err := ovn_common.EnsureCertificate(
+			ctx,
+			helper,
+			&instance.Spec.TLS,
+			*instance.Spec.TLS.GenericService.SecretName,
+			ovnv1.ServiceNameOVNController,
+			ovn_common.ServiceCertDNSNames(&instance.Spec.TLS, ovnv1.ServiceNameOVNController, instance.Namespace),
+			instance.Spec.TLS.ExtraIPAddresses,
+		)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
 		}
 	}
 
+	// Certificates requested for a previous Issuer or secret, or for removed
+	// external chassis
+	certificateNames := append(ovncontroller.ExternalChassisCertificateNames(instance), instance.Spec.TLS.CertificateName())
+	if err := ovn_common.DeleteStaleCertificates(ctx, helper, certificateNames...); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Validate service cert secret
 	if instance.Spec.TLS.Enabled() {
-		hash, ctrlResult, err := instance.Spec.TLS.ValidateCertSecret(ctx, helper, instance.Namespace)
+		_, ctrlResult, err := instance.Spec.TLS.ValidateCertSecret(ctx, helper, instance.Namespace)
 		if err != nil {
 			instance.Status.Conditions.Set(condition.FalseCondition(
 				condition.TLSInputReadyCondition,
@@ -388,7 +628,8 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 		} else if (ctrlResult != ctrl.Result{}) {
 			return ctrlResult, nil
 		}
-		configMapVars[tls.TLSHashName] = env.SetValue(hash)
+		// the cert hash is left out of the config hash, ovn-controller reloads the
+		// rotated cert from the mounted secret without restarting the pods
 	}
 	// all cert input checks out so report InputReady
 	instance.Status.Conditions.MarkTrue(condition.TLSInputReadyCondition, condition.InputReadyMessage)
@@ -397,7 +638,7 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	// create Configmap required for OVNController input
 	// - %-scripts configmap holding scripts to e.g. bootstrap the service
 	//
-	err = r.generateServiceConfigMaps(ctx, helper, instance, &configMapVars)
+	err = ovncontroller.EnsureScriptsConfigMap(ctx, helper, instance, &configMapVars)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ServiceConfigReadyCondition,
@@ -412,7 +653,7 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	// create hash over all the different input resources to identify if any those changed
 	// and a restart/recreate is required.
 	//
-	inputHash, hashChanged, err := r.createHashOfInputHashes(ctx, instance, configMapVars)
+	_, hashChanged, err := r.createHashOfInputHashes(ctx, instance, configMapVars)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ServiceConfigReadyCondition,
@@ -426,6 +667,18 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 		// so we need to return and reconcile again
 		return ctrl.Result{}, nil
 	}
+
+	// The pods are annotated with the hashes of the scripts they run
+	daemonSetInputs.Scripts, err = r.scriptsConfigMap(ctx, instance, helper)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
 	// TODO(slaweq): configure service (ovn controller and ovs settings)
 	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
 
@@ -442,7 +695,7 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	}
 
 	// Create or Update additional Physical Network Attachments
-	networkAttachments, err := ovncontroller.CreateOrUpdateAdditionalNetworks(ctx, helper, instance, ovsServiceLabels)
+	_, err = ovncontroller.CreateOrUpdateAdditionalNetworks(ctx, helper, instance, ovsServiceLabels)
 	if err != nil {
 		Log.Info(fmt.Sprintf("Failed to create additional networks: %s", err))
 		return ctrl.Result{}, err
@@ -451,12 +704,10 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	// network to attach to
 	networkAttachmentsNoPhysNet := []string{}
 	if instance.Spec.NetworkAttachment != "" {
-		networkAttachments = append(networkAttachments, instance.Spec.NetworkAttachment)
 		networkAttachmentsNoPhysNet = append(networkAttachmentsNoPhysNet, instance.Spec.NetworkAttachment)
 	}
-	sort.Strings(networkAttachments)
 
-	for _, netAtt := range networkAttachments {
+	for _, netAtt := range ovncontroller.NetworkAttachments(instance) {
 		_, err = nad.GetNADWithName(ctx, helper, netAtt, instance.Namespace)
 		if err != nil {
 			if k8s_errors.IsNotFound(err) {
@@ -478,12 +729,6 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 		}
 	}
 
-	serviceAnnotations, err := nad.CreateNetworksAnnotation(instance.Namespace, networkAttachments)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to create network annotation from %s: %w",
-			networkAttachments, err)
-	}
-
 	// Handle service init
 	ctrlResult, err := r.reconcileInit(ctx)
 	if err != nil {
@@ -508,13 +753,9 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 		return ctrlResult, nil
 	}
 
-	// Define a new DaemonSet object for OVNController
-	dset := daemonset.NewDaemonSet(
-		ovncontroller.CreateOVNDaemonSet(instance, inputHash, ovnServiceLabels),
-		time.Duration(5)*time.Second,
-	)
-
-	ctrlResult, err = dset.CreateOrPatch(ctx, helper)
+	// Metrics exporter config, Services and kube-rbac-proxy permissions
+	daemonSetInputs.MetricsConfigHash, daemonSetInputs.OVSMetricsConfigHash, err = r.reconcileMetrics(
+		ctx, instance, helper, ovnServiceLabels, ovsServiceLabels)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
@@ -522,26 +763,125 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 			condition.SeverityWarning,
 			condition.DeploymentReadyErrorMessage,
 			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrl.Result{}, err
+	}
+
+	// ovn-bgp-agent config, the pods are restarted when it changes
+	daemonSetInputs.BGPConfigHash, err = r.reconcileBGPAgent(ctx, instance, helper)
+	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// The nodes are labeled with their capabilities by Node Feature Discovery
+	if len(instance.Spec.NodeCapabilities) > 0 {
+		nfdInstalled, err := ovncontroller.EnsureNodeFeatureRule(ctx, helper)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !nfdInstalled {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonNodeFeatureDiscoveryMissing,
+				"Node Feature Discovery is not installed, no node is labeled with the capabilities in nodeCapabilities")
+		}
 	}
 
-	instance.Status.DesiredNumberScheduled = dset.GetDaemonSet().Status.DesiredNumberScheduled
-	instance.Status.NumberReady = dset.GetDaemonSet().Status.NumberReady
+	// The nodes of other architectures than the ones of the images are
+	// listed instead of running the pods
+	instance.Status.UnsupportedArchNodes = nil
+	if len(instance.Spec.Architectures) > 0 {
+		nodeList, err := helper.GetKClient().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error listing the nodes: %w", err)
+		}
+		instance.Status.UnsupportedArchNodes = ovncontroller.UnsupportedArchNodes(
+			nodeList.Items, instance.Spec.NodesSelector(), instance.Spec.Architectures)
+	}
 
-	// Define a new DaemonSet object for OVS (ovsdb-server + ovs-vswitchd)
-	ovsdset := daemonset.NewDaemonSet(
-		ovncontroller.CreateOVSDaemonSet(instance, inputHash, ovsServiceLabels, serviceAnnotations),
-		time.Duration(5)*time.Second,
-	)
+	// Choose the tuning profile of the number of chassis with the auto
+	// tuning profile, the config jobs set its values on the chassis
+	tuningResult, err := reconcileAutoTuning(ctx, helper, instance.Spec.TuningProfile, &instance.Status.Tuning)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.Tuning.Values = instance.TunedValues()
+
+	// A new image is only rolled out once the OVN databases and ovn-northd
+	// are upgraded
+	deployInstance, upgradeResult, err := r.upgradeOrder(ctx, instance, helper)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The gateway ports are drained through the NB DB, it is set once the
+	// NB cluster is ready
+	nbEndpoint := ovncontroller.NBEndpoint(ctx, helper, instance.Namespace)
+	if nbEndpoint == "" && instance.Spec.GatewayDrain {
+		Log.Info("No ready NB OVNDBCluster, the gateway ports are not drained yet")
+	}
+
+	// The chassis of the nodes annotated for a maintenance are cordoned,
+	// and their pods are not updated until it ends
+	ovnPods, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(ovnServiceLabels).String(),
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	maintenanceNodes, err := ovncontroller.MaintenanceNodes(ctx, helper, ovnPods.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.reconcileMaintenance(ctx, instance, helper, ovnPods.Items, maintenanceNodes, nbEndpoint)
 
-	ctrlResult, err = ovsdset.CreateOrPatch(ctx, helper)
+	// The gateway ports of the chassis of the nodes annotated for a
+	// decommission are drained, and their chassis deleted once the nodes
+	// leave the NodeSelector or the cluster
+	decommissionResult, err := r.reconcileDecommission(ctx, instance, helper, ovnPods.Items, nbEndpoint)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The Drifted condition lists the DaemonSets keeping manual changes
+	instance.Status.Conditions.Remove(ovnv1.OVNDriftedCondition)
+
+	// During a canary rollout the pods are updated OnDelete, the canary nodes
+	// first and the remaining ones once the canary nodes are verified. So
+	// are they during a maintenance, skipping the nodes in maintenance.
+	canary := r.canaryRollout(instance, deployInstance)
+	daemonSetInputs.OVNUpdateStrategy = appsv1.RollingUpdateDaemonSetStrategyType
+	daemonSetInputs.OVSUpdateStrategy = appsv1.RollingUpdateDaemonSetStrategyType
+	if (canary && !instance.Status.Canary.Verified) || len(maintenanceNodes) > 0 {
+		daemonSetInputs.OVNUpdateStrategy = appsv1.OnDeleteDaemonSetStrategyType
+	}
+	if len(maintenanceNodes) > 0 {
+		daemonSetInputs.OVSUpdateStrategy = appsv1.OnDeleteDaemonSetStrategyType
+	}
+	daemonSetInputs.NBEndpoint = nbEndpoint
+
+	// Define the new DaemonSet objects for OVNController and OVS
+	// (ovsdb-server + ovs-vswitchd), with zones a pair of them per zone
+	ovnDaemonSet, ovsDaemonSet, err := ovncontroller.DaemonSets(deployInstance, daemonSetInputs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.forbidHostNamespaces(instance, ovnDaemonSet)
+	r.forbidHostNamespaces(instance, ovsDaemonSet)
+	ovnDaemonSets := ovncontroller.ZoneDaemonSets(ovnDaemonSet, instance.Spec.Zones)
+	ovsDaemonSets := ovncontroller.ZoneDaemonSets(ovsDaemonSet, instance.Spec.Zones)
+	err = r.deleteUnusedDaemonSets(ctx, instance, helper)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A failed rollout keeps the pod template it was rolled back to until
+	// the spec changes
+	instance.Status.Conditions.Remove(ovnv1.OVNControllerDegradedCondition)
+	desired, ready, rolledOut, rolloutResult, err := r.applyDaemonSets(ctx, instance, helper, ovnDaemonSets)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
@@ -549,17 +889,47 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 			condition.SeverityWarning,
 			condition.DeploymentReadyErrorMessage,
 			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrl.Result{}, err
+	}
+
+	instance.Status.DesiredNumberScheduled = desired
+	instance.Status.NumberReady = ready
+	setReplicasReadyCondition(
+		&instance.Status.Conditions,
+		ovnv1.OVNControllerDaemonSetReadyCondition,
+		instance.Status.NumberReady == instance.Status.DesiredNumberScheduled,
+		ovnv1.OVNControllerDaemonSetReadyMessage,
+		ovnv1.OVNControllerDaemonSetReadyRunningMessage,
+		instance.Status.NumberReady,
+		instance.Status.DesiredNumberScheduled)
+	if rolledOut {
+		instance.Status.OvnContainerImage = deployInstance.Spec.OvnContainerImage
+	}
+
+	_, ready, rolledOut, ovsRolloutResult, err := r.applyDaemonSets(ctx, instance, helper, ovsDaemonSets)
+	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
 	}
 
-	instance.Status.OVSNumberReady = ovsdset.GetDaemonSet().Status.NumberReady
+	instance.Status.OVSNumberReady = ready
+	setReplicasReadyCondition(
+		&instance.Status.Conditions,
+		ovnv1.OVSDaemonSetReadyCondition,
+		instance.Status.OVSNumberReady == instance.Status.DesiredNumberScheduled,
+		ovnv1.OVSDaemonSetReadyMessage,
+		ovnv1.OVSDaemonSetReadyRunningMessage,
+		instance.Status.OVSNumberReady,
+		instance.Status.DesiredNumberScheduled)
+	if rolledOut {
+		instance.Status.OvsContainerImage = deployInstance.Spec.OvsContainerImage
+	}
+	rolloutResult = earliestRequeue(rolloutResult, ovsRolloutResult)
 
 	// verify if network attachment matches expectations
 	networkReady, networkAttachmentStatus, err := nad.VerifyNetworkStatusFromAnnotation(ctx, helper, networkAttachmentsNoPhysNet, ovsServiceLabels, instance.Status.OVSNumberReady)
@@ -586,6 +956,30 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	}
 	// create DaemonSet - end
 
+	err = r.reconcileToolbox(ctx, instance, helper)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.reconcileVersions(ctx, instance, helper, ovnServiceLabels, ovsServiceLabels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Check the OpenSSL FIPS provider is active in the ovn-controller pods
+	if instance.Spec.FIPS {
+		podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: k8s_labels.Set(ovnServiceLabels).String(),
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		err = reconcileFIPS(ctx, helper, r.RestConfig, &instance.Status.Conditions, podList.Items)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	sbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.SBDBType)
 	if err != nil {
 		Log.Info("No SB OVNDBCluster defined, deleting external ConfigMap")
@@ -597,6 +991,18 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 		return ctrl.Result{}, nil
 	}
 
+	// TLS is enabled per database, a cert is needed to reach the TLS ones
+	err = verifyClientTLS(instance.Spec.TLS, sbCluster.Status.InternalDBAddress)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.TLSInputReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.TLSInputErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
 	ep, err := sbCluster.GetExternalEndpoint()
 	if err != nil || ep == "" {
 		Log.Info("No external endpoint defined for SB OVNDBCluster, deleting external ConfigMap")
@@ -615,6 +1021,13 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 			Log.Error(err, "Failed to generate external ConfigMap")
 			return ctrl.Result{}, err
 		}
+
+		// the external chassis join the fabric through the same endpoint
+		instance.Status.ExternalChassis, err = ovncontroller.EnsureExternalChassis(ctx, helper, instance, sbCluster)
+		if err != nil {
+			Log.Error(err, "Failed to enroll the external chassis")
+			return ctrl.Result{}, err
+		}
 	}
 
 	// create OVN Config Job - start
@@ -623,11 +1036,36 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 		Log.Info("OVS DaemonSet not ready yet. Configuration job cannot be started.")
 		return ctrl.Result{Requeue: true}, nil
 	}
-	jobsDef, err := ovncontroller.ConfigJob(ctx, r.Client, instance, sbCluster, ovnServiceLabels)
+	// the config agents apply the config of the chassis instead of the Jobs
+	err = r.reconcileNodeConfig(ctx, deployInstance, helper, sbCluster)
 	if err != nil {
-		Log.Error(err, "Failed to create OVN controller configuration Job")
+		Log.Error(err, "Failed to render the node config of the config agents")
+		instance.Status.Conditions.Set(
+			condition.FalseCondition(
+				condition.ServiceConfigReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.ServiceConfigReadyErrorMessage,
+				err.Error(),
+			),
+		)
 		return ctrl.Result{}, err
 	}
+	var jobsDef []*batchv1.Job
+	if deployInstance.Spec.ConfigAgent {
+		// the config Jobs are run again when the config agent is disabled
+		for key := range instance.Status.Hash {
+			if strings.HasPrefix(key, ovnv1.OVNConfigHash+"-") {
+				delete(instance.Status.Hash, key)
+			}
+		}
+	} else {
+		jobsDef, err = ovncontroller.ConfigJob(ctx, r.Client, deployInstance, sbCluster, ovnServiceLabels)
+		if err != nil {
+			Log.Error(err, "Failed to create OVN controller configuration Job")
+			return ctrl.Result{}, err
+		}
+	}
 	for _, jobDef := range jobsDef {
 		configHashKey := ovnv1.OVNConfigHash + "-" + jobDef.Spec.Template.Spec.NodeName
 		configHash := instance.Status.Hash[configHashKey]
@@ -671,39 +1109,624 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
 	// create OVN Config Job - end
 
+	// Check the packets of the tunnel MTU get through, once the chassis are
+	// configured
+	if instance.Spec.TunnelMTUCheck {
+		err = r.reconcileTunnelMTU(ctx, instance, helper, ovsServiceLabels)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Update the pods of the nodes which are not in maintenance
+	if len(maintenanceNodes) > 0 {
+		err = r.rolloutMaintenance(ctx, helper, ovnDaemonSets, ovsDaemonSets,
+			maintenanceNodes, !canary || instance.Status.Canary.Verified)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Verify the new image on the canary nodes before rolling it out to all
+	// the nodes
+	if canary {
+		ctrlResult, err = r.reconcileCanary(ctx, instance, helper, ovnDaemonSets, sbCluster, ovnServiceLabels)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, nil
+		}
+	}
+
+	// Restart the ovn-controllers grown over the memory threshold
+	if instance.Spec.MemoryWatchdog != nil {
+		rolloutResult = earliestRequeue(rolloutResult, r.reconcileMemoryWatchdog(ctx, instance, helper, ovnServiceLabels))
+	}
+	rolloutResult = earliestRequeue(rolloutResult, tuningResult)
+	rolloutResult = earliestRequeue(rolloutResult, upgradeResult)
+	rolloutResult = earliestRequeue(rolloutResult, decommissionResult)
+
+	// Refresh the chassis inventory and keep polling it
+	if instance.Spec.ChassisStatusInterval > 0 {
+		r.reconcileChassisStatus(ctx, instance, helper, sbCluster, ovnServiceLabels)
+		r.reconcileTelemetry(ctx, instance, helper, ovnServiceLabels)
+		Log.Info("Reconciled Service successfully")
+		return earliestRequeue(rolloutResult,
+			ctrl.Result{RequeueAfter: time.Duration(instance.Spec.ChassisStatusInterval) * time.Second}), nil
+	}
+	instance.Status.Chassis = nil
+	instance.Status.NodesWithoutChassis = nil
+	instance.Status.ChassisErrorsCountedUntil = nil
+	ovncontroller.DeleteChassisMetrics(instance)
+	ovn_common.DeleteTelemetryMetrics(instance.Namespace, instance.Name, ovnv1.ServiceNameOVNController)
+
 	Log.Info("Reconciled Service successfully")
 
-	return ctrl.Result{}, nil
+	return rolloutResult, nil
 }
 
-// generateServiceConfigMaps - create configmaps which hold scripts and service configuration
-func (r *OVNControllerReconciler) generateServiceConfigMaps(
+// reconcileChassisStatus - list the chassis registered in the SB DB and the
+// nodes running ovn-controller without a healthy one. The inventory is
+// informational, failures to query it are only logged.
+func (r *OVNControllerReconciler) reconcileChassisStatus(
 	ctx context.Context,
-	h *helper.Helper,
 	instance *ovnv1.OVNController,
-	envVars *map[string]env.Setter,
-) error {
-	// Create/update configmaps from templates
-	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(ovnv1.ServiceNameOVNController), map[string]string{})
-
-	templateParameters := make(map[string]interface{})
-	if instance.Spec.NetworkAttachment != "" {
-		templateParameters["OVNEncapNIC"] = nad.GetNetworkIFName(instance.Spec.NetworkAttachment)
-	} else {
-		templateParameters["OVNEncapNIC"] = "eth0"
+	helper *helper.Helper,
+	sbCluster *ovnv1.OVNDBCluster,
+	ovnServiceLabels map[string]string,
+) {
+	Log := r.GetLogger(ctx)
+
+	sbPod, err := getRunningDBPod(ctx, helper, sbCluster)
+	if err != nil {
+		Log.Info(err.Error())
+		return
 	}
-	cms := []util.Template{
-		// ScriptsConfigMap
-		{
-			Name:          fmt.Sprintf("%s-scripts", instance.Name),
-			Namespace:     instance.Namespace,
-			Type:          util.TemplateTypeScripts,
-			InstanceType:  instance.Kind,
-			Labels:        cmLabels,
-			ConfigOptions: templateParameters,
-		},
+	inventory, err := ovncontroller.GetChassisInventory(ctx, helper, r.RestConfig, sbPod)
+	if err != nil {
+		Log.Info(err.Error())
+		return
 	}
-	return configmap.EnsureConfigMaps(ctx, h, instance, cms, envVars)
+	podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(ovnServiceLabels).String(),
+	})
+	if err != nil {
+		Log.Error(err, "Failed to list ovn-controller pods for chassis status")
+		return
+	}
+
+	// the chassis of the nodes which left the NodeSelectors are not removed
+	// by their stopped ovn-controller
+	staleNodes, err := r.getUnselectedNodes(ctx, instance, helper, podList.Items)
+	if err != nil {
+		Log.Error(err, "Failed to list the nodes not selected for ovn-controller")
+	}
+	for _, ch := range inventory.ChassisOfNodes(staleNodes) {
+		err := ovncontroller.DeleteChassis(ctx, helper, r.RestConfig, sbPod, ch.Name)
+		if err != nil {
+			Log.Error(err, "Failed to delete the stale chassis", "chassis", ch.Name)
+			continue
+		}
+		inventory.Remove(ch.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonChassisDeleted,
+			"Chassis %s of node %s not selected anymore removed from the SB DB", ch.Name, ch.Hostname)
+	}
+
+	r.countChassisErrors(ctx, instance, helper, inventory.Chassis, podList.Items)
+	instance.Status.Chassis = inventory.Chassis
+	ovncontroller.SetChassisMetrics(instance, inventory.Chassis)
+	instance.Status.NodesWithoutChassis = inventory.NodesWithoutChassis(podList.Items)
+}
+
+// countChassisErrors - add the errors ovn-controller logged since the last
+// chassis status refresh to the counts of the chassis of its node. The logs
+// are read up to the last whole second, where the next refresh resumes, as
+// the status only keeps the time to the second.
+func (r *OVNControllerReconciler) countChassisErrors(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	chassis []ovnv1.OVNControllerChassis,
+	pods []corev1.Pod,
+) {
+	Log := r.GetLogger(ctx)
+
+	until := time.Now().Truncate(time.Second)
+	nodeErrors := map[string]ovncontroller.ChassisErrors{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		errors, err := ovncontroller.GetChassisErrors(ctx, helper, pod, instance.Status.ChassisErrorsCountedUntil, until)
+		if err != nil {
+			Log.Info(fmt.Sprintf("Failed to count the errors of ovn-controller pod %s: %s", pod.Name, err))
+			continue
+		}
+		nodeErrors[pod.Spec.NodeName] = errors
+	}
+
+	counted := map[string]ovnv1.OVNControllerChassis{}
+	for _, ch := range instance.Status.Chassis {
+		counted[ch.Name] = ch
+	}
+	for i := range chassis {
+		ch := &chassis[i]
+		errors := nodeErrors[ch.Hostname]
+		ch.FlowInstallErrors = counted[ch.Name].FlowInstallErrors + errors.FlowInstall
+		ch.SBTransactionErrors = counted[ch.Name].SBTransactionErrors + errors.SBTransaction
+	}
+	instance.Status.ChassisErrorsCountedUntil = &metav1.Time{Time: until}
+}
+
+// getChassisInventory - list the chassis registered in the SB DB, queried
+// from a running SB DB pod
+func (r *OVNControllerReconciler) getChassisInventory(
+	ctx context.Context,
+	helper *helper.Helper,
+	sbCluster *ovnv1.OVNDBCluster,
+) (*ovncontroller.ChassisInventory, error) {
+	sbPod, err := getRunningDBPod(ctx, helper, sbCluster)
+	if err != nil {
+		return nil, err
+	}
+	return ovncontroller.GetChassisInventory(ctx, helper, r.RestConfig, sbPod)
+}
+
+// getUnselectedNodes - the nodes selected by none of the OVNControllers of
+// the namespace which don't run ovn-controller anymore
+func (r *OVNControllerReconciler) getUnselectedNodes(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	pods []corev1.Pod,
+) ([]string, error) {
+	nodeList, err := helper.GetKClient().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing the nodes: %w", err)
+	}
+	ovnControllers := &ovnv1.OVNControllerList{}
+	err = helper.GetClient().List(ctx, ovnControllers, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("error listing the OVNControllers: %w", err)
+	}
+	nodeSelectors := []map[string]string{}
+	for _, ovnController := range ovnControllers.Items {
+		if ovnController.DeletionTimestamp.IsZero() {
+			nodeSelectors = append(nodeSelectors, ovnController.Spec.ZonesNodeSelectors()...)
+		}
+	}
+	return ovncontroller.UnselectedNodes(nodeList.Items, nodeSelectors, pods), nil
+}
+
+// canaryRollout - whether a new ovn-controller image is rolled out to the
+// canary nodes first. It resets the canary status for a new image.
+func (r *OVNControllerReconciler) canaryRollout(
+	instance *ovnv1.OVNController,
+	deployInstance *ovnv1.OVNController,
+) bool {
+	instance.Status.Conditions.Remove(ovnv1.OVNControllerCanaryReadyCondition)
+	if instance.Spec.Canary == nil || instance.Status.OvnContainerImage == "" ||
+		deployInstance.Spec.OvnContainerImage == instance.Status.OvnContainerImage {
+		return false
+	}
+	if instance.Status.Canary == nil || instance.Status.Canary.ContainerImage != deployInstance.Spec.OvnContainerImage {
+		instance.Status.Canary = &ovnv1.OVNControllerCanaryStatus{
+			ContainerImage: deployInstance.Spec.OvnContainerImage,
+		}
+	}
+	return true
+}
+
+// reconcileCanary - roll the new image out to the canary nodes and verify
+// ovn-controller is ready there and its chassis processed the latest SB DB
+// changes. Once verified the DaemonSets are switched back to RollingUpdate.
+func (r *OVNControllerReconciler) reconcileCanary(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	daemonSets []*appsv1.DaemonSet,
+	sbCluster *ovnv1.OVNDBCluster,
+	ovnServiceLabels map[string]string,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	canary := instance.Status.Canary
+
+	if !canary.Verified {
+		podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: k8s_labels.Set(ovnServiceLabels).String(),
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		// the nodes in maintenance are not updated
+		pods := []corev1.Pod{}
+		for _, pod := range podList.Items {
+			if !slices.Contains(instance.Status.MaintenanceNodes, pod.Spec.NodeName) {
+				pods = append(pods, pod)
+			}
+		}
+		if len(canary.Nodes) == 0 {
+			canary.Nodes, err = ovncontroller.CanaryNodes(ctx, helper, instance.Spec.Canary, pods)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if len(canary.Nodes) == 0 {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				ovnv1.OVNControllerCanaryReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				ovnv1.OVNControllerCanaryReadyNoNodesMessage,
+				canary.ContainerImage))
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+
+		pending, err := ovncontroller.RolloutCanary(ctx, helper, daemonSets, pods, canary.Nodes)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(pending) == 0 {
+			inventory, err := r.getChassisInventory(ctx, helper, sbCluster)
+			if err != nil {
+				Log.Info(err.Error())
+				pending = canary.Nodes
+			} else {
+				for _, node := range canary.Nodes {
+					if !inventory.ChassisReady(node) {
+						pending = append(pending, node)
+					}
+				}
+			}
+		}
+		canary.PendingNodes = pending
+		if len(pending) > 0 {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				ovnv1.OVNControllerCanaryReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				ovnv1.OVNControllerCanaryReadyRunningMessage,
+				canary.ContainerImage,
+				strings.Join(pending, ", ")))
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+
+		canary.Verified = true
+		Log.Info(fmt.Sprintf("Canary nodes verified for %s", canary.ContainerImage))
+		if len(instance.Status.MaintenanceNodes) == 0 {
+			for _, ds := range daemonSets {
+				err = ovncontroller.SetUpdateStrategy(ctx, helper,
+					types.NamespacedName{Name: ds.Name, Namespace: ds.Namespace}, appsv1.RollingUpdateDaemonSetStrategyType)
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+		}
+	}
+
+	instance.Status.Conditions.MarkTrue(ovnv1.OVNControllerCanaryReadyCondition, ovnv1.OVNControllerCanaryReadyMessage, canary.ContainerImage)
+	return ctrl.Result{}, nil
+}
+
+// reconcileMaintenance - cordon the chassis of the nodes annotated for a
+// maintenance, and uncordon them once the annotation is removed. Nodes
+// whose ovn-controller pod can't be reached are retried on the next
+// reconciliation.
+func (r *OVNControllerReconciler) reconcileMaintenance(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	pods []corev1.Pod,
+	maintenanceNodes []string,
+	nbEndpoint string,
+) {
+	Log := r.GetLogger(ctx)
+
+	nodePods := map[string]*corev1.Pod{}
+	for i := range pods {
+		nodePods[pods[i].Spec.NodeName] = &pods[i]
+	}
+	podRunning := func(node string) bool {
+		pod, ok := nodePods[node]
+		return ok && pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero()
+	}
+
+	cordoned := []string{}
+	for _, node := range maintenanceNodes {
+		if slices.Contains(instance.Status.MaintenanceNodes, node) {
+			cordoned = append(cordoned, node)
+			continue
+		}
+		if !podRunning(node) {
+			continue
+		}
+		err := ovncontroller.CordonChassis(ctx, helper, r.RestConfig, nodePods[node], nbEndpoint, true)
+		if err != nil {
+			Log.Error(err, "Failed to cordon the chassis for a maintenance", "node", node)
+			continue
+		}
+		cordoned = append(cordoned, node)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonMaintenanceStarted,
+			"Chassis of node %s cordoned for a maintenance", node)
+	}
+	for _, node := range instance.Status.MaintenanceNodes {
+		if slices.Contains(maintenanceNodes, node) {
+			continue
+		}
+		if _, ok := nodePods[node]; !ok {
+			// the node doesn't run ovn-controller anymore
+			continue
+		}
+		if !podRunning(node) {
+			cordoned = append(cordoned, node)
+			continue
+		}
+		err := ovncontroller.CordonChassis(ctx, helper, r.RestConfig, nodePods[node], nbEndpoint, false)
+		if err != nil {
+			Log.Error(err, "Failed to uncordon the chassis after a maintenance", "node", node)
+			cordoned = append(cordoned, node)
+			continue
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonMaintenanceEnded,
+			"Chassis of node %s uncordoned after a maintenance", node)
+	}
+
+	sort.Strings(cordoned)
+	instance.Status.MaintenanceNodes = nil
+	if len(cordoned) > 0 {
+		instance.Status.MaintenanceNodes = cordoned
+	}
+}
+
+// reconcileExternalServiceAccount - check that the externally managed
+// ServiceAccount of the pods exists, the Role and RoleBinding are managed
+// with it. It requeues until it is created.
+func (r *OVNControllerReconciler) reconcileExternalServiceAccount(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+) (ctrl.Result, error) {
+	sa := &corev1.ServiceAccount{}
+	err := helper.GetClient().Get(ctx, types.NamespacedName{Name: instance.Spec.ServiceAccount, Namespace: instance.Namespace}, sa)
+	if k8s_errors.IsNotFound(err) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceAccountReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNServiceAccountMissingMessage,
+			instance.Spec.ServiceAccount))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	} else if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceAccountReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceAccountReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	for _, c := range []condition.Type{
+		condition.ServiceAccountReadyCondition,
+		condition.RoleReadyCondition,
+		condition.RoleBindingReadyCondition,
+	} {
+		instance.Status.Conditions.MarkTrue(c, ovnv1.OVNServiceAccountExternalMessage, instance.Spec.ServiceAccount)
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileDecommission - drain the gateway ports of the chassis of the
+// nodes annotated for a decommission, and delete their chassis from the SB
+// DB once their ovn-controller pod is gone. The nodes deleted from the
+// cluster meanwhile are still decommissioned, a node whose annotation is
+// removed is uncordoned once its pod runs again. It requeues while a chassis
+// can't be deleted yet.
+func (r *OVNControllerReconciler) reconcileDecommission(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	pods []corev1.Pod,
+	nbEndpoint string,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	annotated, existing, err := ovncontroller.DecommissionNodes(ctx, helper)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	nodePods := map[string]*corev1.Pod{}
+	for i := range pods {
+		nodePods[pods[i].Spec.NodeName] = &pods[i]
+	}
+	podRunning := func(node string) bool {
+		pod, ok := nodePods[node]
+		return ok && pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero()
+	}
+	phases := map[string]string{}
+	for _, d := range instance.Status.Decommission {
+		phases[d.Node] = d.Phase
+	}
+
+	// the chassis of the nodes whose pod is gone are deleted through the
+	// SB DB, queried once
+	var inventory *ovncontroller.ChassisInventory
+	var sbPod *corev1.Pod
+	deleteChassis := func(node string) error {
+		if inventory == nil {
+			sbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.SBDBType)
+			if err != nil {
+				return err
+			}
+			sbPod, err = getRunningDBPod(ctx, helper, sbCluster)
+			if err != nil {
+				return err
+			}
+			inventory, err = ovncontroller.GetChassisInventory(ctx, helper, r.RestConfig, sbPod)
+			if err != nil {
+				return err
+			}
+		}
+		for _, ch := range inventory.ChassisOfNodes([]string{node}) {
+			err := ovncontroller.DeleteChassis(ctx, helper, r.RestConfig, sbPod, ch.Name)
+			if err != nil {
+				return fmt.Errorf("error deleting chassis %s: %w", ch.Name, err)
+			}
+			inventory.Remove(ch.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonChassisDecommissioned,
+				"Chassis %s of node %s decommissioned", ch.Name, node)
+		}
+		return nil
+	}
+
+	// the nodes deleted from the cluster can't be annotated anymore
+	nodes := annotated
+	for _, d := range instance.Status.Decommission {
+		if !existing[d.Node] && !slices.Contains(nodes, d.Node) {
+			nodes = append(nodes, d.Node)
+		}
+	}
+
+	result := ctrl.Result{}
+	status := []ovnv1.OVNControllerDecommissionStatus{}
+	for _, node := range nodes {
+		phase := phases[node]
+		if phase == "" {
+			if podRunning(node) {
+				err := ovncontroller.CordonChassis(ctx, helper, r.RestConfig, nodePods[node], nbEndpoint, true)
+				if err != nil {
+					Log.Error(err, "Failed to drain the chassis for a decommission", "node", node)
+					continue
+				}
+			}
+			phase = ovnv1.DecommissionGatewaysDrained
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonDecommissionStarted,
+				"Gateway ports of the chassis of node %s drained for a decommission", node)
+		}
+		if _, ok := nodePods[node]; !ok && phase == ovnv1.DecommissionGatewaysDrained {
+			phase = ovnv1.DecommissionPodsRemoved
+		}
+		if phase == ovnv1.DecommissionPodsRemoved {
+			err := deleteChassis(node)
+			if err != nil {
+				Log.Info(fmt.Sprintf("Chassis of node %s not decommissioned yet: %s", node, err.Error()))
+				result = ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}
+			} else {
+				phase = ovnv1.DecommissionCompleted
+			}
+		}
+		if phase == ovnv1.DecommissionCompleted && !existing[node] {
+			// nothing is left of the node
+			continue
+		}
+		status = append(status, ovnv1.OVNControllerDecommissionStatus{Node: node, Phase: phase})
+	}
+
+	// the decommission was canceled, the chassis registers again with the
+	// new pod
+	for _, d := range instance.Status.Decommission {
+		if slices.Contains(nodes, d.Node) {
+			continue
+		}
+		if _, ok := nodePods[d.Node]; !ok {
+			// the node doesn't run ovn-controller anymore
+			continue
+		}
+		if !podRunning(d.Node) {
+			status = append(status, d)
+			continue
+		}
+		err := ovncontroller.CordonChassis(ctx, helper, r.RestConfig, nodePods[d.Node], nbEndpoint, false)
+		if err != nil {
+			Log.Error(err, "Failed to uncordon the chassis after a canceled decommission", "node", d.Node)
+			status = append(status, d)
+		}
+	}
+
+	sort.Slice(status, func(i, j int) bool { return status[i].Node < status[j].Node })
+	instance.Status.Decommission = nil
+	if len(status) > 0 {
+		instance.Status.Decommission = status
+	}
+	return result, nil
+}
+
+// rolloutMaintenance - update the ovn-controller and OVS pods of the nodes
+// which are not in maintenance, the ovn-controller ones only once a canary
+// rollout is verified
+func (r *OVNControllerReconciler) rolloutMaintenance(
+	ctx context.Context,
+	helper *helper.Helper,
+	ovnDaemonSets []*appsv1.DaemonSet,
+	ovsDaemonSets []*appsv1.DaemonSet,
+	maintenanceNodes []string,
+	ovnRollout bool,
+) error {
+	daemonSets := []*appsv1.DaemonSet{}
+	if ovnRollout {
+		daemonSets = append(daemonSets, ovnDaemonSets...)
+	}
+	daemonSets = append(daemonSets, ovsDaemonSets...)
+	for _, ds := range daemonSets {
+		podList, err := helper.GetKClient().CoreV1().Pods(ds.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: k8s_labels.Set(ds.Spec.Selector.MatchLabels).String(),
+		})
+		if err != nil {
+			return err
+		}
+		err = ovncontroller.RolloutNodes(ctx, helper, ds, podList.Items, maintenanceNodes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileVersions - report the OVN and OVS versions the running pods
+// actually run, which differ while a rollout is in progress
+func (r *OVNControllerReconciler) reconcileVersions(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	ovnServiceLabels map[string]string,
+	ovsServiceLabels map[string]string,
+) error {
+	podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(ovnServiceLabels).String(),
+	})
+	if err != nil {
+		return err
+	}
+	instance.Status.OvnVersions = ovn_common.RunningVersions(ctx, helper, r.RestConfig, podList.Items,
+		[]string{"ovn-appctl", "-t", "ovn-controller", "version"})
+	if len(instance.Status.OvnVersions) == 0 {
+		instance.Status.OvnVersions = nil
+	}
+
+	podList, err = helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(ovsServiceLabels).String(),
+	})
+	if err != nil {
+		return err
+	}
+	instance.Status.OvsVersions = ovn_common.RunningVersions(ctx, helper, r.RestConfig, podList.Items,
+		[]string{"ovs-appctl", "version"})
+	if len(instance.Status.OvsVersions) == 0 {
+		instance.Status.OvsVersions = nil
+	}
+	return nil
+}
+
+// scriptsConfigMap - the scripts ConfigMap the ovn-controller and the OVS
+// pods run
+func (r *OVNControllerReconciler) scriptsConfigMap(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	h *helper.Helper,
+) (*corev1.ConfigMap, error) {
+	// read from the API server, the cache may not have the ConfigMap just
+	// created or updated yet
+	return h.GetKClient().CoreV1().ConfigMaps(instance.Namespace).Get(
+		ctx, ovncontroller.ScriptsConfigMapName(instance), metav1.GetOptions{})
 }
 
 // generateExternalConfigMaps - create configmaps for external dataplane consumption
@@ -725,6 +1748,7 @@ func (r *OVNControllerReconciler) generateExternalConfigMaps(
 	externalTemplateParameters := make(map[string]interface{})
 	externalTemplateParameters["OVNRemote"] = externalEndpoint
 	externalTemplateParameters["OVNEncapType"] = instance.Spec.ExternalIDS.OvnEncapType
+	externalTemplateParameters["OVNEncapDstPort"] = instance.Spec.ExternalIDS.OvnEncapDstPort
 
 	cms := []util.Template{
 		// EDP ConfigMap
@@ -758,9 +1782,799 @@ func (r *OVNControllerReconciler) deleteExternalConfigMaps(
 	if err != nil && !k8s_errors.IsNotFound(err) {
 		return fmt.Errorf("error deleting external config map %s: %w", cm.Name, err)
 	}
+	instance.Status.ExternalChassis = nil
+	return ovncontroller.DeleteExternalChassisConfigMaps(ctx, h, instance, nil)
+}
+
+// reconcileMetrics - the exporter ConfigMaps and Services of the
+// ovn-controller and OVS pods, and the ClusterRoleBinding allowing
+// kube-rbac-proxy to authorize the scrapers, or their removal when the
+// metrics are disabled. Returns the hashes of the ovn-controller and the OVS
+// exporter configuration.
+func (r *OVNControllerReconciler) reconcileMetrics(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	ovnServiceLabels map[string]string,
+	ovsServiceLabels map[string]string,
+) (string, string, error) {
+	ovnHash, err := r.reconcileMetricsEndpoint(
+		ctx, instance, helper,
+		instance.Spec.Metrics.Enabled,
+		ovncontroller.MetricsConfigMapName(instance),
+		ovncontroller.MetricsConfigTemplate,
+		ovncontroller.MetricsServiceName,
+		ovnServiceLabels,
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	ovsHash, err := r.reconcileMetricsEndpoint(
+		ctx, instance, helper,
+		instance.Spec.Metrics.OVSEnabled,
+		ovncontroller.OVSMetricsConfigMapName(instance),
+		ovncontroller.OVSMetricsConfigTemplate,
+		ovncontroller.OVSMetricsServiceName,
+		ovsServiceLabels,
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	// the kube-rbac-proxy containers of both DaemonSets share the binding
+	if !instance.Spec.Metrics.Enabled && !instance.Spec.Metrics.OVSEnabled {
+		return "", "", r.deleteAuthDelegatorBinding(ctx, instance, helper)
+	}
+
+	crb := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ovncontroller.AuthDelegatorBindingName(instance),
+			Labels: labels.GetLabels(instance, labels.GetGroupLabel(ovnv1.ServiceNameOVNController), map[string]string{}),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "system:auth-delegator",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      instance.ServiceAccountName(),
+				Namespace: instance.Namespace,
+			},
+		},
+	}
+	if instance.OVSServiceAccountName() != instance.ServiceAccountName() {
+		crb.Subjects = append(crb.Subjects, rbacv1.Subject{
+			Kind:      "ServiceAccount",
+			Name:      instance.OVSServiceAccountName(),
+			Namespace: instance.Namespace,
+		})
+	}
+	err = ovn_common.Apply(ctx, helper, crb)
+	if err != nil {
+		return "", "", fmt.Errorf("Error creating ClusterRoleBinding %s: %w", crb.Name, err)
+	}
+
+	return ovnHash, ovsHash, nil
+}
+
+// reconcileMetricsEndpoint - the exporter ConfigMap, the metrics Service and
+// its ServiceMonitor of a DaemonSet. Returns the hash of the exporter configuration.
+func (r *OVNControllerReconciler) reconcileMetricsEndpoint(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	enabled bool,
+	configMapName string,
+	configTemplate string,
+	serviceName string,
+	serviceLabels map[string]string,
+) (string, error) {
+	if !enabled {
+		objs := []client.Object{
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceName,
+					Namespace: instance.Namespace,
+				},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      configMapName,
+					Namespace: instance.Namespace,
+				},
+			},
+		}
+		for _, obj := range objs {
+			err := helper.GetClient().Delete(ctx, obj)
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return "", fmt.Errorf("Error deleting %s: %w", obj.GetName(), err)
+			}
+		}
+		return "", ovn_common.DeleteServiceMonitor(ctx, helper, serviceName)
+	}
+
+	hash, err := ovncontroller.EnsureMetricsConfigMap(ctx, helper, instance, configMapName, configTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	err = ovn_common.Apply(ctx, helper, ovncontroller.MetricsService(serviceName, instance, serviceLabels, serviceLabels))
+	if err != nil {
+		return "", err
+	}
+
+	err = ovn_common.EnsureServiceMonitor(ctx, helper, serviceName, serviceLabels, instance.Spec.Metrics.MonitorLabels)
+	if err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// reconcileTunnelMTU - report in the TunnelMTUReady condition whether the
+// packets of the tunnel MTU get through between the chassis
+func (r *OVNControllerReconciler) reconcileTunnelMTU(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	ovsServiceLabels map[string]string,
+) error {
+	podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(ovsServiceLabels).String(),
+	})
+	if err != nil {
+		return err
+	}
+	checked, failed, err := ovncontroller.TunnelMTUFailedNodes(ctx, helper, r.RestConfig, instance, podList.Items)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNTunnelMTUReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.OVNTunnelMTUReadyErrorMessage,
+			instance.Spec.TunnelMTU,
+			err.Error()))
+		return err
+	}
+	if len(failed) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNTunnelMTUReadyCondition,
+			ovnv1.PathMTUExceededReason,
+			condition.SeverityError,
+			ovnv1.OVNTunnelMTUReadyErrorMessage,
+			instance.Spec.TunnelMTU,
+			strings.Join(failed, ", ")))
+	} else if checked > 0 {
+		instance.Status.Conditions.MarkTrue(ovnv1.OVNTunnelMTUReadyCondition, ovnv1.OVNTunnelMTUReadyMessage, instance.Spec.TunnelMTU)
+	}
+	return nil
+}
+
+// reconcileNodeConfig - the node config ConfigMaps the config-agent
+// containers apply the config of the chassis of their node from, or their
+// removal when the config Jobs configure the chassis
+func (r *OVNControllerReconciler) reconcileNodeConfig(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	sbCluster *ovnv1.OVNDBCluster,
+) (err error) {
+	ctx, span := ovn_common.StartSpan(ctx, "ReconcileNodeConfig")
+	defer func() { ovn_common.EndSpan(span, err) }()
+
+	keep := map[string]bool{}
+	if instance.Spec.ConfigAgent {
+		cms, err := ovncontroller.NodeConfigMaps(
+			ctx,
+			r.Client,
+			instance,
+			sbCluster,
+			labels.GetLabels(instance, labels.GetGroupLabel(ovnv1.ServiceNameOVNController), map[string]string{}),
+		)
+		if err != nil {
+			return err
+		}
+		for _, cm := range cms {
+			err = ovn_common.Apply(ctx, helper, cm)
+			if err != nil {
+				return fmt.Errorf("error publishing the node config %s: %w", cm.Name, err)
+			}
+			keep[cm.Name] = true
+		}
+	}
+	return ovncontroller.DeleteNodeConfigMaps(ctx, helper, instance, keep)
+}
+
+// reconcileBGPAgent - the ovn-bgp-agent ConfigMap, or its removal when BGP
+// is disabled. Returns the hash of the configuration.
+func (r *OVNControllerReconciler) reconcileBGPAgent(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+) (string, error) {
+	if instance.Spec.BGP == nil {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ovncontroller.BGPConfigMapName(instance),
+				Namespace: instance.Namespace,
+			},
+		}
+		err := helper.GetClient().Delete(ctx, cm)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return "", fmt.Errorf("Error deleting %s: %w", cm.Name, err)
+		}
+		return "", nil
+	}
+
+	return ovncontroller.EnsureBGPConfigMap(ctx, helper, instance)
+}
+
+// reconcileToolbox - the DaemonSet of the debug toolbox, or its removal when
+// the toolbox is disabled
+func (r *OVNControllerReconciler) reconcileToolbox(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+) error {
+	if instance.Spec.Toolbox == nil {
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ovncontroller.ToolboxDaemonSetName,
+				Namespace: instance.Namespace,
+			},
+		}
+		err := helper.GetClient().Delete(ctx, ds)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("Error deleting DaemonSet %s: %w", ds.Name, err)
+		}
+		return nil
+	}
+
+	return applyWorkload(ctx, helper, r.Recorder, &instance.Status.Conditions, instance.Spec.DriftPolicy,
+		ovncontroller.ToolboxDaemonSet(instance))
+}
+
+// deleteAuthDelegatorBinding - remove the ClusterRoleBinding of the
+// kube-rbac-proxy containers
+func (r *OVNControllerReconciler) deleteAuthDelegatorBinding(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+) error {
+	crb := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ovncontroller.AuthDelegatorBindingName(instance),
+		},
+	}
+	err := helper.GetClient().Delete(ctx, crb)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("Error deleting ClusterRoleBinding %s: %w", crb.Name, err)
+	}
+	return nil
+}
+
+// setRolledOutTLSHashes - report the TLS secret hashes the pods of the
+// DaemonSet run with, the previous ones are kept until its rollout completes.
+// An Event is recorded once the pods run with a rotated secret.
+func (r *OVNControllerReconciler) setRolledOutTLSHashes(
+	instance *ovnv1.OVNController,
+	ds appsv1.DaemonSet,
+) {
+	hashes := ovn_common.RolledOutTLSHashes(&ds)
+	if hashes == nil {
+		return
+	}
+	if len(hashes) == 0 {
+		delete(instance.Status.TLSHashes, ds.Name)
+		return
+	}
+	names := maps.Keys(hashes)
+	sort.Strings(names)
+	for _, name := range names {
+		hash := hashes[name]
+		if oldHash, found := instance.Status.TLSHashes[ds.Name][name]; found && oldHash != hash {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonTLSRolledOut,
+				"Pods of %s restarted with the rotated %s", ds.Name, name)
+		}
+	}
+	if instance.Status.TLSHashes == nil {
+		instance.Status.TLSHashes = map[string]map[string]string{}
+	}
+	instance.Status.TLSHashes[ds.Name] = hashes
+}
+
+// forbidHostNamespaces - drop the namespaces of the node requested for the
+// pods of the DaemonSet when the operator forbids them, an Event reports it
+func (r *OVNControllerReconciler) forbidHostNamespaces(
+	instance *ovnv1.OVNController,
+	ds *appsv1.DaemonSet,
+) {
+	if !r.ForbidHostNamespaces || !ovn_common.ForbidHostNamespaces(&ds.Spec.Template.Spec) {
+		return
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeWarning, ovn_common.EventReasonHostNamespacesForbidden,
+		"The host PID and IPC namespaces are forbidden by the operator, they are not shared with the pods of %s", ds.Name)
+}
+
+// upgradeOrder - the instance to render the DaemonSets from. A new
+// ovn-controller or OVS image is kept back until the OVN databases and
+// ovn-northd run their new image, the deployed one is rendered until then.
+// The result requeues while the version skew can't be checked.
+func (r *OVNControllerReconciler) upgradeOrder(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+) (*ovnv1.OVNController, ctrl.Result, error) {
+	instance.Status.Conditions.Remove(ovnv1.OVNUpgradeReadyCondition)
+	instance.Status.Conditions.Remove(ovnv1.OVNVersionSkewReadyCondition)
+	if (instance.Status.OvnContainerImage == "" || instance.Status.OvnContainerImage == instance.Spec.OvnContainerImage) &&
+		(instance.Status.OvsContainerImage == "" || instance.Status.OvsContainerImage == instance.Spec.OvsContainerImage) {
+		return instance, ctrl.Result{}, nil
+	}
+
+	pending, err := pendingDBClusterUpgrade(ctx, helper, instance.Namespace)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	if pending == "" {
+		pending, err = pendingNorthdUpgrade(ctx, helper, instance.Namespace)
+		if err != nil {
+			return nil, ctrl.Result{}, err
+		}
+	}
+
+	deployInstance := instance.DeepCopy()
+	deployInstance.Spec.OvnContainerImage = upgradeImage(&instance.Status.Conditions,
+		instance.Spec.OvnContainerImage, instance.Status.OvnContainerImage, pending)
+	deployInstance.Spec.OvsContainerImage = upgradeImage(&instance.Status.Conditions,
+		instance.Spec.OvsContainerImage, instance.Status.OvsContainerImage, pending)
+
+	// The new ovn-controller must support the schema of the deployed SB DB
+	if instance.Status.OvnContainerImage != "" &&
+		deployInstance.Spec.OvnContainerImage != instance.Status.OvnContainerImage {
+		supported, result, err := r.checkVersionSkew(ctx, instance, helper, deployInstance.Spec.OvnContainerImage)
+		if err != nil {
+			return nil, ctrl.Result{}, err
+		}
+		if !supported {
+			deployInstance.Spec.OvnContainerImage = instance.Status.OvnContainerImage
+		}
+		return deployInstance, result, nil
+	}
+	return deployInstance, ctrl.Result{}, nil
+}
+
+// checkVersionSkew - whether the given ovn-controller image supports the
+// schema of the deployed SB DB. The image is probed by a Job for the SB DB
+// schema version it was built with, the rollout is blocked until it is not
+// newer than the one of the SB DB. The image is also held while the schema
+// of the SB DB can't be read, the result requeues the check.
+func (r *OVNControllerReconciler) checkVersionSkew(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	image string,
+) (bool, ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	if instance.Status.OvnVersion == nil || instance.Status.OvnVersion.ContainerImage != image {
+		jobDef := ovncontroller.VersionProbeJob(instance, image)
+		probeJob := job.NewJob(
+			jobDef,
+			ovncontroller.VersionProbeHash,
+			false,
+			time.Duration(5)*time.Second,
+			instance.Status.Hash[ovncontroller.VersionProbeHash],
+		)
+		ctrlResult, err := probeJob.DoJob(ctx, helper)
+		if (ctrlResult != ctrl.Result{}) {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				ovnv1.OVNVersionSkewReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				ovnv1.OVNVersionSkewReadyRunningMessage,
+				image))
+			return false, ctrl.Result{}, nil
+		}
+		if err != nil {
+			Log.Error(err, "Failed to probe the ovn-controller version", "image", image)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				ovnv1.OVNVersionSkewReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityError,
+				ovnv1.OVNVersionSkewReadyErrorMessage,
+				image,
+				err.Error()))
+			return false, ctrl.Result{}, nil
+		}
+		output, err := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, jobDef.Name)
+		if err != nil {
+			return false, ctrl.Result{}, err
+		}
+		instance.Status.OvnVersion = ovncontroller.ParseVersion(image, output)
+		instance.Status.Hash[ovncontroller.VersionProbeHash] = probeJob.GetHash()
+		Log.Info(fmt.Sprintf("Job %s hash added - %s", jobDef.Name, instance.Status.Hash[ovncontroller.VersionProbeHash]))
+	}
+
+	// Without a known schema on either side there is nothing to compare
+	version := instance.Status.OvnVersion
+	if version.SBSchemaVersion == "" {
+		return true, ctrl.Result{}, nil
+	}
+	sbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.SBDBType)
+	if k8s_errors.IsNotFound(err) {
+		// no SB DB deployed yet
+		return true, ctrl.Result{}, nil
+	}
+	dbSchema := ""
+	if err == nil {
+		dbSchema, err = r.getSBSchemaVersion(ctx, helper, sbCluster)
+	}
+	if err != nil {
+		Log.Error(err, "Unable to get the SB DB schema version, holding the ovn-controller image", "image", image)
+		instance.Status.Conditions.Set(condition.UnknownCondition(
+			ovnv1.OVNVersionSkewReadyCondition,
+			condition.ErrorReason,
+			ovnv1.OVNVersionSkewReadyUnknownMessage,
+			image,
+			err.Error()))
+		return false, ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	if ovn_common.CompareVersions(version.SBSchemaVersion, dbSchema) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNVersionSkewReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			ovnv1.OVNVersionSkewReadyBlockedMessage,
+			version.Version,
+			version.SBSchemaVersion,
+			dbSchema,
+			instance.Status.OvnContainerImage))
+		return false, ctrl.Result{}, nil
+	}
+	instance.Status.Conditions.MarkTrue(
+		ovnv1.OVNVersionSkewReadyCondition,
+		ovnv1.OVNVersionSkewReadyMessage,
+		version.Version,
+		dbSchema)
+	return true, ctrl.Result{}, nil
+}
+
+// getSBSchemaVersion - the schema version of the deployed SB DB, queried
+// from a running SB DB pod
+func (r *OVNControllerReconciler) getSBSchemaVersion(
+	ctx context.Context,
+	helper *helper.Helper,
+	sbCluster *ovnv1.OVNDBCluster,
+) (string, error) {
+	sbPods, err := ovndbcluster.OVNDBPods(ctx, sbCluster, helper, map[string]string{
+		common.AppSelector: sbCluster.GetServiceName(),
+	})
+	if err != nil {
+		return "", err
+	}
+	for i := range sbPods.Items {
+		if sbPods.Items[i].Status.Phase == corev1.PodRunning {
+			return ovndbcluster.GetSchemaVersion(ctx, helper, r.RestConfig, sbCluster, &sbPods.Items[i])
+		}
+	}
+	return "", fmt.Errorf("no running SB DB pod to query the schema version from")
+}
+
+// rollbackTemplate - track the rollout of the pod template rendered for the
+// DaemonSet. Once its rollout failed, the DaemonSet keeps the pod template
+// it was rolled back to until the rendered one changes.
+func (r *OVNControllerReconciler) rollbackTemplate(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	ds *appsv1.DaemonSet,
+) error {
+	if instance.Spec.AutoRollback == nil {
+		delete(instance.Status.Rollback, ds.Name)
+		return nil
+	}
+
+	hash, err := ovncontroller.TemplateHash(ds)
+	if err != nil {
+		return err
+	}
+	if instance.Status.Rollback == nil {
+		instance.Status.Rollback = map[string]ovnv1.OVNControllerRollbackStatus{}
+	}
+	rollback, ok := instance.Status.Rollback[ds.Name]
+	if !ok || rollback.TemplateHash != hash {
+		instance.Status.Rollback[ds.Name] = ovnv1.OVNControllerRollbackStatus{
+			TemplateHash: hash,
+			StartTime:    metav1.Now(),
+		}
+		return nil
+	}
+	if !rollback.RolledBack {
+		return nil
+	}
+
+	template, err := ovncontroller.RevisionTemplate(ctx, helper, ds.Namespace, rollback.Revision)
+	if err != nil {
+		return err
+	}
+	ds.Spec.Template = *template
+	instance.Status.Conditions.Set(condition.TrueCondition(
+		ovnv1.OVNControllerDegradedCondition,
+		ovnv1.OVNControllerDegradedMessage,
+		ds.Name,
+		strings.Join(rollback.FailedNodes, ", "),
+		rollback.Revision))
+	return nil
+}
+
+// checkRollout - roll the DaemonSet back to its previous pod template when
+// the updated pods are not ready on more than MaxFailedNodes nodes once
+// FailureTimeout passed. The result requeues for the end of FailureTimeout.
+func (r *OVNControllerReconciler) checkRollout(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	ds appsv1.DaemonSet,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	rollback, ok := instance.Status.Rollback[ds.Name]
+	if instance.Spec.AutoRollback == nil || !ok || rollback.RolledOut || rollback.RolledBack {
+		return ctrl.Result{}, nil
+	}
+	if instance.Status.Rollout[ds.Name].Complete {
+		rollback.RolledOut = true
+		rollback.FailedNodes = nil
+		instance.Status.Rollback[ds.Name] = rollback
+		return ctrl.Result{}, nil
+	}
+
+	failedNodes, err := ovncontroller.FailedNodes(ctx, r.Client, &ds)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	rollback.FailedNodes = nil
+	if len(failedNodes) > 0 {
+		rollback.FailedNodes = failedNodes
+	}
+	instance.Status.Rollback[ds.Name] = rollback
+
+	timeout := time.Duration(instance.Spec.AutoRollback.FailureTimeout) * time.Second
+	if remaining := time.Until(rollback.StartTime.Add(timeout)); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	if len(failedNodes) <= int(instance.Spec.AutoRollback.MaxFailedNodes) {
+		return ctrl.Result{}, nil
+	}
+
+	revision, err := ovncontroller.PreviousRevision(ctx, helper, &ds)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if revision == "" {
+		Log.Info(fmt.Sprintf("Rollout of DaemonSet %s failed, no previous revision to roll back to", ds.Name))
+		return ctrl.Result{}, nil
+	}
+	rollback.RolledBack = true
+	rollback.Revision = revision
+	instance.Status.Rollback[ds.Name] = rollback
+	r.Recorder.Eventf(instance, corev1.EventTypeWarning, ovn_common.EventReasonRolledBack,
+		"Rollout of DaemonSet %s failed on nodes %s, rolled back to %s",
+		ds.Name, strings.Join(failedNodes, ", "), revision)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// applyDaemonSets - apply the DaemonSets rendered from the same one, i.e.
+// the ones of the zones, and track their rollouts. The pods scheduled and
+// ready are summed up, the image is rolled out once all of them are.
+func (r *OVNControllerReconciler) applyDaemonSets(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	daemonSets []*appsv1.DaemonSet,
+) (int32, int32, bool, ctrl.Result, error) {
+	var desired, ready int32
+	rolledOut := true
+	result := ctrl.Result{}
+	for _, ds := range daemonSets {
+		err := r.rollbackTemplate(ctx, instance, helper, ds)
+		if err != nil {
+			return 0, 0, false, ctrl.Result{}, err
+		}
+		err = applyWorkload(ctx, helper, r.Recorder, &instance.Status.Conditions, instance.Spec.DriftPolicy, ds)
+		if err != nil {
+			return 0, 0, false, ctrl.Result{}, err
+		}
+
+		desired += ds.Status.DesiredNumberScheduled
+		ready += ds.Status.NumberReady
+		r.setRolledOutTLSHashes(instance, *ds)
+		rolledOut = rolledOut && ovn_common.DaemonSetRolledOut(ds) && !rolledBack(instance, ds.Name)
+		err = r.setRolloutStatus(ctx, instance, *ds)
+		if err != nil {
+			return 0, 0, false, ctrl.Result{}, err
+		}
+		rolloutResult, err := r.checkRollout(ctx, instance, helper, *ds)
+		if err != nil {
+			return 0, 0, false, ctrl.Result{}, err
+		}
+		result = earliestRequeue(result, rolloutResult)
+	}
+	return desired, ready, rolledOut, result, nil
+}
+
+// deleteUnusedDaemonSets - delete the ovn-controller and OVS DaemonSets the
+// zones don't use anymore, along with their rollout status
+func (r *OVNControllerReconciler) deleteUnusedDaemonSets(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+) error {
+	used := []string{}
+	for _, name := range []string{ovnv1.ServiceNameOVNController, ovnv1.ServiceNameOVS} {
+		names := ovncontroller.DaemonSetNames(name, instance.Spec.Zones)
+		err := ovncontroller.DeleteUnusedDaemonSets(ctx, helper, instance, name, names)
+		if err != nil {
+			return err
+		}
+		used = append(used, names...)
+	}
+	for name := range instance.Status.Rollout {
+		if !slices.Contains(used, name) {
+			delete(instance.Status.Rollout, name)
+		}
+	}
+	for name := range instance.Status.Rollback {
+		if !slices.Contains(used, name) {
+			delete(instance.Status.Rollback, name)
+		}
+	}
+	for name := range instance.Status.TLSHashes {
+		if !slices.Contains(used, name) {
+			delete(instance.Status.TLSHashes, name)
+		}
+	}
+	return nil
+}
+
+// rolledBack - whether the rollout of the DaemonSet was rolled back
+func rolledBack(instance *ovnv1.OVNController, name string) bool {
+	return instance.Status.Rollback[name].RolledBack
+}
+
+// earliestRequeue - the result which requeues first
+func earliestRequeue(a ctrl.Result, b ctrl.Result) ctrl.Result {
+	switch {
+	case a.Requeue && a.RequeueAfter == 0:
+		return a
+	case b.Requeue && b.RequeueAfter == 0:
+		return b
+	case a.RequeueAfter == 0:
+		return b
+	case b.RequeueAfter == 0 || a.RequeueAfter < b.RequeueAfter:
+		return a
+	}
+	return b
+}
+
+// setRolloutStatus - report the progress of the rollout of the DaemonSet
+func (r *OVNControllerReconciler) setRolloutStatus(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	ds appsv1.DaemonSet,
+) error {
+	rollout, err := ovncontroller.GetRolloutStatus(ctx, r.Client, &ds, instance.Generation)
+	if err != nil {
+		return err
+	}
+	// the inputs which changed since the previous pod template explain the
+	// latest rollout
+	previous, found := instance.Status.Rollout[ds.Name]
+	rollout.InputHashes = ovncontroller.InputHashes(&ds)
+	rollout.ChangedInputs = previous.ChangedInputs
+	if found && previous.InputHashes != nil {
+		if changed := ovncontroller.ChangedInputs(previous.InputHashes, rollout.InputHashes); len(changed) > 0 {
+			rollout.ChangedInputs = changed
+		}
+	}
+	if instance.Status.Rollout == nil {
+		instance.Status.Rollout = map[string]ovnv1.OVNControllerRolloutStatus{}
+	}
+	instance.Status.Rollout[ds.Name] = rollout
 	return nil
 }
 
+// reconcileTelemetry - collect the coverage counters and the memory usage
+// of the running ovn-controller pods when requested. Pods which can't be
+// queried are skipped.
+func (r *OVNControllerReconciler) reconcileTelemetry(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	ovnServiceLabels map[string]string,
+) {
+	Log := r.GetLogger(ctx)
+
+	if !instance.Spec.Telemetry {
+		ovn_common.DeleteTelemetryMetrics(instance.Namespace, instance.Name, ovnv1.ServiceNameOVNController)
+		return
+	}
+
+	podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(ovnServiceLabels).String(),
+	})
+	if err != nil {
+		Log.Error(err, "Failed to list ovn-controller pods for telemetry")
+		return
+	}
+
+	telemetry := map[string]*ovn_common.Telemetry{}
+	for i := range podList.Items {
+		ovnPod := &podList.Items[i]
+		if ovnPod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		t, err := ovn_common.CollectTelemetry(ctx, helper, r.RestConfig, ovnPod, ovnv1.ServiceNameOVNController)
+		if err != nil {
+			Log.Info(err.Error())
+			continue
+		}
+		telemetry[ovnPod.Name] = t
+	}
+	ovn_common.SetTelemetryMetrics(instance.Namespace, instance.Name, ovnv1.ServiceNameOVNController, telemetry)
+}
+
+// reconcileMemoryWatchdog - restart ovn-controller in the running pods
+// whose RSS exceeds MaxRSSMB, and requeue for the next check
+func (r *OVNControllerReconciler) reconcileMemoryWatchdog(
+	ctx context.Context,
+	instance *ovnv1.OVNController,
+	helper *helper.Helper,
+	ovnServiceLabels map[string]string,
+) ctrl.Result {
+	Log := r.GetLogger(ctx)
+	result := ctrl.Result{RequeueAfter: time.Duration(instance.Spec.MemoryWatchdog.Interval) * time.Second}
+
+	podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(ovnServiceLabels).String(),
+	})
+	if err != nil {
+		Log.Error(err, "Failed to list ovn-controller pods for the memory watchdog")
+		return result
+	}
+
+	maxRSS := int64(instance.Spec.MemoryWatchdog.MaxRSSMB) * 1024
+	for i := range podList.Items {
+		ovnPod := &podList.Items[i]
+		if ovnPod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		rss, err := ovncontroller.GetRSS(ctx, helper, r.RestConfig, ovnPod)
+		if err != nil {
+			Log.Info(err.Error())
+			continue
+		}
+		if rss <= maxRSS {
+			continue
+		}
+		err = ovncontroller.Restart(ctx, helper, r.RestConfig, ovnPod)
+		if err != nil {
+			Log.Error(err, "Failed to restart ovn-controller", "pod", ovnPod.Name)
+			continue
+		}
+		Log.Info(fmt.Sprintf("Restarted ovn-controller of pod %s with RSS %d kB", ovnPod.Name, rss))
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, ovn_common.EventReasonMemoryThresholdExceeded,
+			"ovn-controller of pod %s on node %s restarted with RSS %d MB over %d MB",
+			ovnPod.Name, ovnPod.Spec.NodeName, rss/1024, instance.Spec.MemoryWatchdog.MaxRSSMB)
+	}
+	return result
+}
+
 // createHashOfInputHashes - creates a hash of hashes which gets added to the resources which requires a restart
 // if any of the input resources change, like configs, passwords, ...
 //
@@ -774,8 +2588,7 @@ func (r *OVNControllerReconciler) createHashOfInputHashes(
 
 	var hashMap map[string]string
 	changed := false
-	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
-	hash, err := util.ObjectHash(mergedMapVars)
+	hash, err := ovn_common.InputHash(envVars)
 	if err != nil {
 		return hash, changed, err
 	}
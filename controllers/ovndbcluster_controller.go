@@ -19,6 +19,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,9 +29,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -42,27 +47,37 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
 	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/statefulset"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // OVNDBClusterReconciler reconciles a OVNDBCluster object
 type OVNDBClusterReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient    kubernetes.Interface
+	RestConfig *rest.Config
+	Scheme     *runtime.Scheme
+	// RestrictedPodSecurity - render the pods for the restricted PSA profile
+	RestrictedPodSecurity bool
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
 }
 
 // GetClient -
@@ -88,12 +103,20 @@ func (r *OVNDBClusterReconciler) GetLogger(ctx context.Context) logr.Logger {
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncontrollers,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;patch;update;delete;
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;patch;update;delete;
-//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create;
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch;
 //+kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=network.openstack.org,resources=dnsdata,verbs=get;list;watch;create;update;patch;delete
 
 // service account, role, rolebinding
@@ -106,6 +129,8 @@ func (r *OVNDBClusterReconciler) GetLogger(ctx context.Context) logr.Logger {
 
 // Reconcile - OVN DBCluster
 func (r *OVNDBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovndbcluster", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
 	Log := r.GetLogger(ctx)
 
 	// Fetch the OVNDBCluster instance
@@ -116,12 +141,20 @@ func (r *OVNDBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovndbcluster", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
 
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovndbcluster", req.NamespacedName, start, _err)
+	}()
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -155,8 +188,31 @@ func (r *OVNDBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
 		condition.UnknownCondition(condition.RoleBindingReadyCondition, condition.InitReason, condition.RoleBindingReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNDBClusterDBIntegrityReadyCondition, condition.InitReason, ovnv1.OVNDBClusterDBIntegrityReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNDBClusterReadyCondition(instance.Spec.DBType), condition.InitReason, ovnv1.OVNDBClusterReadyInitMessage),
 	)
 
+	// FIPSProviderReady is only reported when FIPS is requested
+	if instance.Spec.FIPS {
+		cl.Set(condition.UnknownCondition(ovnv1.OVNFIPSProviderReadyCondition, condition.InitReason, ovnv1.OVNFIPSProviderReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(ovnv1.OVNFIPSProviderReadyCondition)
+	}
+
+	// SchemaUpgradeReady is only reported while a new image is rolled out
+	if isSchemaUpgrade(instance) {
+		cl.Set(condition.UnknownCondition(ovnv1.OVNDBClusterSchemaUpgradeReadyCondition, condition.InitReason, ovnv1.OVNDBClusterSchemaUpgradeReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(ovnv1.OVNDBClusterSchemaUpgradeReadyCondition)
+	}
+
+	// ConsistencyReady is only reported when the checks are enabled
+	if instance.Spec.ConsistencyCheckInterval > 0 {
+		cl.Set(condition.UnknownCondition(ovnv1.OVNDBClusterConsistencyReadyCondition, condition.InitReason, ovnv1.OVNDBClusterConsistencyReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(ovnv1.OVNDBClusterConsistencyReadyCondition)
+	}
+
 	instance.Status.Conditions.Init(&cl)
 	instance.Status.ObservedGeneration = instance.Generation
 
@@ -169,6 +225,9 @@ func (r *OVNDBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Always patch the instance status when exiting this function so we can persist any changes.
 	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
 		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
 		// update the Ready condition based on the sub conditions
 		if instance.Status.Conditions.AllSubConditionIsTrue() {
@@ -199,6 +258,12 @@ func (r *OVNDBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return r.reconcileDelete(ctx, instance, helper)
 	}
 
+	// Leave the owned resources alone while suspended
+	if instance.Spec.Suspend {
+		return r.reconcileSuspended(ctx, instance, helper, savedConditions)
+	}
+	instance.Status.Conditions.Remove(ovnv1.OVNSuspendedCondition)
+
 	// Handle non-deleted clusters
 	return r.reconcileNormal(ctx, instance, helper)
 }
@@ -229,29 +294,79 @@ func (r *OVNDBClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// index octaviaProviderCertField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &ovnv1.OVNDBCluster{}, octaviaProviderCertField, func(rawObj client.Object) []string {
+		// Extract the secret the client cert is issued into, if requested
+		cr := rawObj.(*ovnv1.OVNDBCluster)
+		certName := ovndbcluster.OctaviaProviderCertificateName(cr)
+		if certName == "" {
+			return nil
+		}
+		return []string{certName}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ovnv1.OVNDBCluster{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&batchv1.Job{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
 		Owns(&infranetworkv1.DNSData{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		// the pods are owned by the StatefulSets, their restarts are
+		// watched to report the members failing the DB integrity check
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForPod),
+			builder.WithPredicates(ovndbcluster.PodTerminatedPredicate()),
+		).
+		WithOptions(r.Options).
 		Complete(r)
 }
 
+// findObjectsForPod - the OVNDBCluster whose StatefulSet runs the pod
+func (r *OVNDBClusterReconciler) findObjectsForPod(ctx context.Context, pod client.Object) []reconcile.Request {
+	serviceName := pod.GetLabels()[common.AppSelector]
+	if serviceName == "" {
+		return []reconcile.Request{}
+	}
+
+	crList := &ovnv1.OVNDBClusterList{}
+	err := r.Client.List(ctx, crList, client.InNamespace(pod.GetNamespace()))
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, item := range crList.Items {
+		if item.GetServiceName() == serviceName {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      item.GetName(),
+					Namespace: item.GetNamespace(),
+				},
+			})
+		}
+	}
+	return requests
+}
+
 func (r *OVNDBClusterReconciler) findObjectsForSrc(ctx context.Context, src client.Object) []reconcile.Request {
 	requests := []reconcile.Request{}
 
 	Log := r.GetLogger(ctx)
 
-	for _, field := range allWatchFields {
+	for _, field := range append([]string{octaviaProviderCertField}, allWatchFields...) {
 		crList := &ovnv1.OVNDBClusterList{}
 		listOps := &client.ListOptions{
 			FieldSelector: fields.OneTermEqualSelector(field, src.GetName()),
@@ -284,6 +399,29 @@ func (r *OVNDBClusterReconciler) reconcileDelete(ctx context.Context, instance *
 
 	Log.Info("Reconciling Service delete")
 
+	// Detach the PVCs from the CR and the StatefulSet so the garbage collector
+	// keeps the database around when retention is requested
+	if instance.Spec.StorageRetention == ovnv1.StorageRetentionRetain {
+		serviceName := instance.GetServiceName()
+		serviceLabels := map[string]string{
+			common.AppSelector: serviceName,
+		}
+		err := ovndbcluster.OrphanPVCs(ctx, instance, helper, serviceLabels, serviceName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	ovndbcluster.DeleteMemberMetrics(instance)
+	ovndbcluster.DeleteConsistencyMetrics(instance)
+
+	if ovndbcluster.PublishesEndpoints(instance.Spec.DBType) {
+		err := ovndbcluster.DeleteEndpoints(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Service is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
 	Log.Info("Reconciled Service delete successfully")
@@ -291,6 +429,29 @@ func (r *OVNDBClusterReconciler) reconcileDelete(ctx context.Context, instance *
 	return ctrl.Result{}, nil
 }
 
+// reconcileSuspended - only refresh the status from the StatefulSet, none
+// of the owned resources is modified
+func (r *OVNDBClusterReconciler) reconcileSuspended(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	helper *helper.Helper,
+	savedConditions condition.Conditions,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciliation suspended")
+	suspendReconcile(&instance.Status.Conditions, savedConditions)
+
+	sts := &appsv1.StatefulSet{}
+	err := helper.GetClient().Get(ctx, types.NamespacedName{Name: instance.GetServiceName(), Namespace: instance.Namespace}, sts)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	instance.Status.ReadyCount = sts.Status.ReadyReplicas
+
+	return ctrl.Result{}, nil
+}
+
 func (r *OVNDBClusterReconciler) reconcileUpdate(ctx context.Context) (ctrl.Result, error) {
 	Log := r.GetLogger(ctx)
 
@@ -303,13 +464,75 @@ func (r *OVNDBClusterReconciler) reconcileUpdate(ctx context.Context) (ctrl.Resu
 	return ctrl.Result{}, nil
 }
 
-func (r *OVNDBClusterReconciler) reconcileUpgrade(ctx context.Context) (ctrl.Result, error) {
+// isSchemaUpgrade - whether the image of the spec differs from the one the
+// members were rolled out with, the database schema might need a conversion
+func isSchemaUpgrade(instance *ovnv1.OVNDBCluster) bool {
+	return instance.Status.ContainerImage != "" &&
+		instance.Status.ContainerImage != instance.Spec.ContainerImage &&
+		instance.Status.InternalDBAddress != ""
+}
+
+func (r *OVNDBClusterReconciler) reconcileUpgrade(ctx context.Context, instance *ovnv1.OVNDBCluster, helper *helper.Helper) (ctrl.Result, error) {
 	Log := r.GetLogger(ctx)
 
+	if !isSchemaUpgrade(instance) {
+		return ctrl.Result{}, nil
+	}
+
 	Log.Info("Reconciling Service upgrade")
 
-	// TODO: should have major version upgrade tasks
-	// -delete dbsync hash from status to rerun it?
+	// Validate the conversion of the deployed database to the schema of the
+	// new image, the StatefulSet is not updated until the Job succeeded
+	jobDef := ovndbcluster.SchemaUpgradeJob(instance)
+	if r.RestrictedPodSecurity {
+		ovn_common.SetRestrictedPodSecurity(&jobDef.Spec.Template.Spec)
+	}
+	schemaUpgradeJob := job.NewJob(
+		jobDef,
+		ovndbcluster.SchemaUpgradeHash,
+		false,
+		time.Duration(5)*time.Second,
+		instance.Status.Hash[ovndbcluster.SchemaUpgradeHash],
+	)
+	ctrlResult, err := schemaUpgradeJob.DoJob(ctx, helper)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNDBClusterSchemaUpgradeReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNDBClusterSchemaUpgradeReadyRunningMessage,
+			instance.Spec.ContainerImage))
+		return ctrlResult, nil
+	}
+	if err != nil || schemaUpgradeJob.HasChanged() {
+		message, resultErr := ovndbcluster.SchemaUpgradeResult(ctx, helper, instance)
+		if resultErr != nil {
+			return ctrl.Result{}, resultErr
+		}
+		instance.Status.SchemaUpgrade = &ovnv1.OVNDBClusterSchemaUpgrade{
+			ContainerImage: instance.Spec.ContainerImage,
+			Succeeded:      err == nil,
+			Message:        message,
+		}
+	}
+	if err != nil {
+		message := instance.Status.SchemaUpgrade.Message
+		if message == "" {
+			message = err.Error()
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNDBClusterSchemaUpgradeReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			ovnv1.OVNDBClusterSchemaUpgradeReadyErrorMessage,
+			message))
+		return ctrl.Result{}, err
+	}
+	if schemaUpgradeJob.HasChanged() {
+		instance.Status.Hash[ovndbcluster.SchemaUpgradeHash] = schemaUpgradeJob.GetHash()
+		Log.Info(fmt.Sprintf("Job %s hash added - %s", jobDef.Name, instance.Status.Hash[ovndbcluster.SchemaUpgradeHash]))
+	}
+	instance.Status.Conditions.MarkTrue(ovnv1.OVNDBClusterSchemaUpgradeReadyCondition, ovnv1.OVNDBClusterSchemaUpgradeReadyMessage)
 
 	Log.Info("Reconciled Service upgrade successfully")
 	return ctrl.Result{}, nil
@@ -321,19 +544,7 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 	Log.Info("Reconciling Service")
 
 	// Service account, role, binding
-	rbacRules := []rbacv1.PolicyRule{
-		{
-			APIGroups:     []string{"security.openshift.io"},
-			ResourceNames: []string{"restricted-v2"},
-			Resources:     []string{"securitycontextconstraints"},
-			Verbs:         []string{"use"},
-		},
-		{
-			APIGroups: []string{""},
-			Resources: []string{"pods"},
-			Verbs:     []string{"create", "get", "list", "watch", "update", "patch", "delete"},
-		},
-	}
+	rbacRules := ovn_common.SCCRules(ovn_common.SCCRestricted)
 	rbacResult, err := common_rbac.ReconcileRbac(ctx, helper, instance, rbacRules)
 	if err != nil {
 		return rbacResult, err
@@ -341,10 +552,7 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 		return rbacResult, nil
 	}
 
-	serviceName := ovnv1.ServiceNameNB
-	if instance.Spec.DBType == ovnv1.SBDBType {
-		serviceName = ovnv1.ServiceNameSB
-	}
+	serviceName := instance.GetServiceName()
 	serviceLabels := map[string]string{
 		common.AppSelector: serviceName,
 	}
@@ -375,16 +583,15 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 		}
 	}
 
-	serviceAnnotations, err := nad.CreateNetworksAnnotation(instance.Namespace, networkAttachments)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
-			instance.Spec.NetworkAttachment, err)
-	}
-
 	// ConfigMap
 	configMapVars := make(map[string]env.Setter)
 
-	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+	missing, err := reconcileInputs(ctx, helper, &instance.Status.Conditions, instance.Spec.TLS)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if missing {
+		return ctrl.Result{Requeue: true}, nil
+	}
 
 	//
 	// TLS input validation
@@ -416,9 +623,44 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 		}
 	}
 
+	// Request the service cert from cert-manager, the ovsdb-server pods
+	// are reached through their own services in the namespace
+	if instance.Spec.TLS.Issuer != "" && instance.Spec.TLS.Enabled() {
+		dnsNames := append(
+			ovn_common.ServiceCertDNSNames(&instance.Spec.TLS, instance.GetServiceName(), instance.Namespace),
+			fmt.Sprintf("*.%s.svc", instance.Namespace),
+			fmt.Sprintf("*.%s.svc.cluster.local", instance.Namespace),
+		)
+		err := ovn_common.EnsureCertificate(
+			ctx,
+			helper,
+			&instance.Spec.TLS,
+			*instance.Spec.TLS.GenericService.SecretName,
+			instance.GetServiceName(),
+			dnsNames,
+			instance.Spec.TLS.ExtraIPAddresses,
+		)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Certificates requested for a previous Issuer or secret
+	if err := ovn_common.DeleteStaleCertificates(
+		ctx, helper, instance.Spec.TLS.CertificateName(), ovndbcluster.OctaviaProviderCertificateName(instance),
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Validate service cert secret
 	if instance.Spec.TLS.Enabled() {
-		hash, ctrlResult, err := instance.Spec.TLS.ValidateCertSecret(ctx, helper, instance.Namespace)
+		_, ctrlResult, err := instance.Spec.TLS.ValidateCertSecret(ctx, helper, instance.Namespace)
 		if err != nil {
 			instance.Status.Conditions.Set(condition.FalseCondition(
 				condition.TLSInputReadyCondition,
@@ -430,7 +672,8 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 		} else if (ctrlResult != ctrl.Result{}) {
 			return ctrlResult, nil
 		}
-		configMapVars[tls.TLSHashName] = env.SetValue(hash)
+		// the cert hash is left out of the config hash, ovsdb-server reloads the
+		// rotated cert from the mounted secret without restarting the pods
 	}
 	// all cert input checks out so report InputReady
 	instance.Status.Conditions.MarkTrue(condition.TLSInputReadyCondition, condition.InputReadyMessage)
@@ -439,11 +682,19 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 	// Create ConfigMaps and Secrets required as input for the Service and calculate an overall hash of hashes
 	//
 
+	// Choose the tuning profile of the number of chassis with the auto
+	// tuning profile, the scripts are rendered with its values
+	tuningResult, err := reconcileAutoTuning(ctx, helper, instance.Spec.TuningProfile, &instance.Status.Tuning)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.Tuning.Values = instance.TunedValues()
+
 	//
 	// create Configmap required for dbcluster input
 	// - %-config configmap holding minimal dbcluster config required to get the service up
 	//
-	err = r.generateServiceConfigMaps(ctx, helper, instance, &configMapVars, serviceName)
+	err = ovndbcluster.EnsureScriptsConfigMap(ctx, helper, instance, &configMapVars)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ServiceConfigReadyCondition,
@@ -485,19 +736,24 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 	}
 
 	// Handle service upgrade
-	ctrlResult, err = r.reconcileUpgrade(ctx)
+	ctrlResult, err = r.reconcileUpgrade(ctx, instance, helper)
 	if err != nil {
 		return ctrlResult, err
 	} else if (ctrlResult != ctrl.Result{}) {
 		return ctrlResult, nil
 	}
-	// Define a new Statefulset object
-	sfset := statefulset.NewStatefulSet(
-		ovndbcluster.StatefulSet(instance, inputHash, serviceLabels, serviceAnnotations),
-		time.Duration(5)*time.Second,
-	)
 
-	ctrlResult, err = sfset.CreateOrPatch(ctx, helper)
+	instance.Status.Conditions.Remove(ovnv1.OVNDriftedCondition)
+
+	// Define a new Statefulset object
+	sfset, err := ovndbcluster.StatefulSet(instance, ovndbcluster.StatefulSetInputs{
+		InputHash:             inputHash,
+		RestrictedPodSecurity: r.RestrictedPodSecurity,
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = applyWorkload(ctx, helper, r.Recorder, &instance.Status.Conditions, instance.Spec.DriftPolicy, sfset)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
@@ -505,17 +761,48 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 			condition.SeverityWarning,
 			condition.DeploymentReadyErrorMessage,
 			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrl.Result{}, err
+	}
+
+	// Report members whose database file failed the startup integrity check
+	podList, err := ovndbcluster.OVNDBPods(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if corruptedPods := ovndbcluster.CorruptedDBPods(podList); len(corruptedPods) > 0 {
 		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
+			ovnv1.OVNDBClusterDBIntegrityReadyCondition,
+			ovnv1.DBCorruptedReason,
+			condition.SeverityError,
+			ovnv1.OVNDBClusterDBIntegrityReadyErrorMessage,
+			strings.Join(corruptedPods, ", ")))
+	} else {
+		instance.Status.Conditions.MarkTrue(ovnv1.OVNDBClusterDBIntegrityReadyCondition, ovnv1.OVNDBClusterDBIntegrityReadyMessage)
+	}
+
+	// Check the OpenSSL FIPS provider is active in the ovsdb-server pods
+	if instance.Spec.FIPS {
+		err = reconcileFIPS(ctx, helper, r.RestConfig, &instance.Status.Conditions, podList.Items)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	instance.Status.ReadyCount = sfset.GetStatefulSet().Status.ReadyReplicas
+	instance.Status.ReadyCount = sfset.Status.ReadyReplicas
+	// the databases are upgraded first, ovn-northd and ovn-controller wait
+	// for the image to be rolled out to all the members
+	if ovn_common.StatefulSetRolledOut(sfset) {
+		instance.Status.ContainerImage = instance.Spec.ContainerImage
+	}
+	// the RAFT cluster only serves the database with a quorum of members
+	setReplicasReadyCondition(
+		&instance.Status.Conditions,
+		ovnv1.OVNDBClusterReadyCondition(instance.Spec.DBType),
+		instance.Status.ReadyCount > *instance.Spec.Replicas/2,
+		ovnv1.OVNDBClusterReadyMessage,
+		ovnv1.OVNDBClusterReadyRunningMessage,
+		instance.Status.ReadyCount,
+		*instance.Spec.Replicas)
 
 	// verify if network attachment matches expectations
 	networkReady, networkAttachmentStatus, err := nad.VerifyNetworkStatusFromAnnotation(ctx, helper, networkAttachments, serviceLabels, instance.Status.ReadyCount)
@@ -597,11 +884,250 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 		// Since the clients are connecting to the pod dns names directly the TLS certs will need to include
 		// all (potential) pod names or use a wildcard
 
-		// Set DB Address
+		// Set DB Address, sorted so the endpoint the clients are rendered
+		// with does not depend on the order the Services are listed in
+		sort.Strings(internalDbAddress)
 		instance.Status.InternalDBAddress = strings.Join(internalDbAddress, ",")
+
+		// Publish the connection details for the DB consumers
+		err = r.generateConnectionConfigMap(ctx, helper, instance, serviceName)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.ExposeServiceReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.ExposeServiceReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		instance.Status.ConnectionConfigMap = instance.GetConnectionConfigMapName()
+		instance.Status.TLS = instance.Spec.TLS.Enabled()
+		instance.Status.CaBundleSecretName = instance.Spec.TLS.CaBundleSecretName
+
+		if ovndbcluster.PublishesEndpoints(instance.Spec.DBType) {
+			err = ovndbcluster.EnsureEndpoints(ctx, helper, instance)
+			if err != nil {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.ExposeServiceReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.ExposeServiceReadyErrorMessage,
+					err.Error()))
+				return ctrl.Result{}, err
+			}
+			instance.Status.EndpointsConfigMap = ovnv1.EndpointsConfigMapName
+		}
+
+		if instance.Spec.OctaviaProvider {
+			published, err := ovndbcluster.EnsureOctaviaProvider(ctx, helper, instance)
+			if err != nil {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.ExposeServiceReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.ExposeServiceReadyErrorMessage,
+					err.Error()))
+				return ctrl.Result{}, err
+			}
+			if published {
+				instance.Status.OctaviaProviderSecret = ovnv1.OctaviaProviderSecretName
+			} else {
+				// reconciled again once cert-manager issues the client cert
+				Log.Info("Waiting for the client cert of the Octavia OVN provider driver")
+			}
+		} else {
+			err = ovndbcluster.DeleteOctaviaProvider(ctx, helper, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			instance.Status.OctaviaProviderSecret = ""
+		}
+	}
+
+	// Default alerts, based on the member metrics refreshed below
+	if instance.Spec.Alerts.Enabled && instance.Spec.ClusterStatusInterval > 0 {
+		err = ovn_common.EnsurePrometheusRule(ctx, helper, ovndbcluster.AlertsName(instance), ovndbcluster.Alerts(instance), instance.Spec.Alerts.Labels)
+	} else {
+		err = ovn_common.DeletePrometheusRule(ctx, helper, ovndbcluster.AlertsName(instance))
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Check the consistency of the DB once the interval elapsed
+	consistencyResult := ctrl.Result{}
+	if instance.Spec.ConsistencyCheckInterval > 0 {
+		consistencyResult, err = r.reconcileConsistency(ctx, instance, helper, serviceLabels)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		instance.Status.ConsistencyCheck = nil
+		ovndbcluster.DeleteConsistencyMetrics(instance)
 	}
+
+	// Refresh the RAFT cluster status and keep polling it
+	if instance.Spec.ClusterStatusInterval > 0 {
+		r.reconcileClusterStatus(ctx, instance, helper, serviceLabels)
+		Log.Info("Reconciled Service successfully")
+		return earliestRequeue(
+			ctrl.Result{RequeueAfter: time.Duration(instance.Spec.ClusterStatusInterval) * time.Second},
+			earliestRequeue(consistencyResult, tuningResult)), nil
+	}
+	// the member metrics are not refreshed anymore
+	ovndbcluster.DeleteMemberMetrics(instance)
+
 	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
+	return earliestRequeue(consistencyResult, tuningResult), nil
+}
+
+// reconcileConsistency - check the database files of the running members
+// with ovsdb-tool check-cluster and, for a SB DB, cross-check its datapaths
+// with the NB DB, once ConsistencyCheckInterval elapsed since the last
+// check. The result is kept in the status, the ConsistencyReady condition
+// and the metrics. Like the cluster status, members which can't be queried
+// are skipped. Returns when the next check is due.
+func (r *OVNDBClusterReconciler) reconcileConsistency(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	interval := time.Duration(instance.Spec.ConsistencyCheckInterval) * time.Second
+
+	last := instance.Status.ConsistencyCheck
+	if last != nil {
+		if elapsed := time.Since(last.LastCheck.Time); elapsed < interval {
+			setConsistencyCondition(instance, last.Problems)
+			return ctrl.Result{RequeueAfter: interval - elapsed}, nil
+		}
+	}
+
+	podList, err := ovndbcluster.OVNDBPods(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	consistency := &ovndbcluster.Consistency{CorruptedMembers: []string{}}
+	var runningPod *corev1.Pod
+	for i := range podList.Items {
+		ovnPod := &podList.Items[i]
+		if ovnPod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		failure, err := ovndbcluster.CheckCluster(ctx, helper, r.RestConfig, instance, ovnPod)
+		if err != nil {
+			Log.Info(err.Error())
+			continue
+		}
+		runningPod = ovnPod
+		if failure != "" {
+			Log.Info("Database file failed check-cluster", "pod", ovnPod.Name, "error", failure)
+			consistency.CorruptedMembers = append(consistency.CorruptedMembers, ovnPod.Name)
+		}
+	}
+	if runningPod == nil {
+		// checked again once a member can be queried
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	// without a NB DB the datapaths are not cross-checked
+	if instance.Spec.DBType == ovnv1.SBDBType {
+		nbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.NBDBType)
+		if err == nil && nbCluster.IsReady() {
+			nbPod, err := getRunningDBPod(ctx, helper, nbCluster)
+			if err != nil {
+				Log.Info(err.Error())
+			} else {
+				err = ovndbcluster.CheckDatapaths(ctx, helper, r.RestConfig, runningPod, nbPod, consistency)
+				if err != nil {
+					Log.Info(err.Error())
+				}
+			}
+		}
+	}
+
+	instance.Status.ConsistencyCheck = &ovnv1.OVNDBClusterConsistencyCheck{
+		LastCheck: metav1.Now(),
+		Problems:  consistency.Problems(),
+	}
+	setConsistencyCondition(instance, instance.Status.ConsistencyCheck.Problems)
+	ovndbcluster.SetConsistencyMetrics(instance, consistency)
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// setConsistencyCondition - report the problems found by the last
+// consistency check in the ConsistencyReady condition
+func setConsistencyCondition(instance *ovnv1.OVNDBCluster, problems []string) {
+	if len(problems) == 0 {
+		instance.Status.Conditions.MarkTrue(ovnv1.OVNDBClusterConsistencyReadyCondition, ovnv1.OVNDBClusterConsistencyReadyMessage)
+		return
+	}
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		ovnv1.OVNDBClusterConsistencyReadyCondition,
+		ovnv1.DBInconsistentReason,
+		condition.SeverityWarning,
+		ovnv1.OVNDBClusterConsistencyReadyErrorMessage,
+		strings.Join(problems, "; ")))
+}
+
+// reconcileClusterStatus - query every running member for its RAFT state
+// and publish it in the status and the member metrics. Members which can't
+// be queried are skipped, the cluster status is informational and must not
+// block the reconciliation.
+func (r *OVNDBClusterReconciler) reconcileClusterStatus(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+) {
+	Log := r.GetLogger(ctx)
+
+	podList, err := ovndbcluster.OVNDBPods(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		Log.Error(err, "Failed to list pods for cluster status")
+		return
+	}
+
+	members := []ovnv1.OVNDBClusterMember{}
+	for i := range podList.Items {
+		ovnPod := &podList.Items[i]
+		if ovnPod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		status, err := ovndbcluster.GetClusterStatus(ctx, helper, r.RestConfig, instance, ovnPod)
+		if err != nil {
+			Log.Info(err.Error())
+			continue
+		}
+		if status.ClusterID != "" {
+			instance.Status.ClusterID = status.ClusterID
+		}
+		members = append(members, status.Member)
+	}
+	r.recordLeaderChange(instance, members)
+	instance.Status.ClusterMembers = members
+	ovndbcluster.SetMemberMetrics(instance, members)
+}
+
+// recordLeaderChange - record an Event when the RAFT leader moved to another
+// member or none of the queried members is the leader anymore
+func (r *OVNDBClusterReconciler) recordLeaderChange(
+	instance *ovnv1.OVNDBCluster,
+	members []ovnv1.OVNDBClusterMember,
+) {
+	oldLeader := ovndbcluster.Leader(instance.Status.ClusterMembers)
+	newLeader := ovndbcluster.Leader(members)
+	if oldLeader == "" || oldLeader == newLeader || len(members) == 0 {
+		return
+	}
+	if newLeader == "" {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, ovn_common.EventReasonLeaderLost,
+			"RAFT leader %s lost, none of the members is the leader", oldLeader)
+		return
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonLeaderChanged,
+		"RAFT leader moved from %s to %s", oldLeader, newLeader)
 }
 
 func getPodIPInNetwork(ovnPod corev1.Pod, namespace string, networkAttachment string) (string, error) {
@@ -638,20 +1164,15 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 	//
 	headlessServiceLabels := util.MergeMaps(serviceLabels, map[string]string{"type": ovnv1.ServiceHeadlessType})
 
-	headlesssvc, err := service.NewService(
-		ovndbcluster.HeadlessService(serviceName, instance, headlessServiceLabels, serviceLabels),
-		time.Duration(5)*time.Second,
-		nil,
-	)
+	err := ovn_common.Apply(ctx, helper, ovndbcluster.HeadlessService(serviceName, instance, headlessServiceLabels, serviceLabels))
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	ctrlResult, err := headlesssvc.CreateOrPatch(ctx, helper)
+	// Restrict who can reach the DB ports on the pod network
+	err = ovndbcluster.NetworkPolicy(ctx, helper, instance, serviceName, serviceLabels)
 	if err != nil {
 		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrl.Result{}, nil
 	}
 
 	podList, err := ovndbcluster.OVNDBPods(ctx, instance, helper, serviceLabels)
@@ -668,19 +1189,14 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 			"statefulset.kubernetes.io/pod-name": ovnPod.Name,
 		}
 		ovndbServiceLabels := util.MergeMaps(ovndbSelectorLabels, map[string]string{"type": ovnv1.ServiceClusterType})
-		svc, err := service.NewService(
-			ovndbcluster.Service(ovnPod.Name, instance, ovndbServiceLabels, ovndbSelectorLabels),
-			time.Duration(5)*time.Second,
-			nil,
-		)
+		ovndbService, err := ovndbcluster.Service(ovnPod.Name, instance, ovndbServiceLabels, ovndbSelectorLabels)
 		if err != nil {
+			err = fmt.Errorf("error while overriding service %s: %w", ovnPod.Name, err)
 			return ctrl.Result{}, err
 		}
-		ctrlResult, err := svc.CreateOrPatch(ctx, helper)
+		err = ovn_common.Apply(ctx, helper, ovndbService)
 		if err != nil {
 			return ctrl.Result{}, err
-		} else if (ctrlResult != ctrl.Result{}) {
-			return ctrl.Result{}, nil
 		}
 		// create service - end
 	}
@@ -710,6 +1226,8 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 				err = fmt.Errorf("error while deleting service with name %s: %w", fullServiceName, err)
 				return ctrl.Result{}, err
 			}
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonMemberRemoved,
+				"Removed member %s after scale down", fullServiceName)
 		}
 	}
 
@@ -742,6 +1260,7 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 		// DNSData info is called every reconcile loop to ensure that even if a pod gets
 		// restarted and it's IP has changed, the DNSData CR will have the correct info.
 		// If nothing changed this won't modify the current dnsmasq pod.
+		sort.Strings(dnsIPsList)
 		err = ovndbcluster.DNSData(
 			ctx,
 			helper,
@@ -761,6 +1280,11 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 			err = fmt.Errorf("not all pods are yet created, number of expected pods: %v, current pods: %v", *(instance.Spec.Replicas), len(podList.Items))
 			return ctrl.Result{RequeueAfter: 1 * time.Second}, err
 		}
+	} else {
+		err = ovndbcluster.DeleteDNSData(ctx, helper, serviceName, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 	// dbAddress will contain ovsdbserver-(nb|sb).openstack.svc or empty
 	scheme := "tcp"
@@ -773,49 +1297,34 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 	return ctrl.Result{}, nil
 }
 
-// generateServiceConfigMaps - create create configmaps which hold service configuration
-func (r *OVNDBClusterReconciler) generateServiceConfigMaps(
+// generateConnectionConfigMap - create the configmap publishing the DB
+// connection details, so consumers don't need to derive the service names
+func (r *OVNDBClusterReconciler) generateConnectionConfigMap(
 	ctx context.Context,
 	h *helper.Helper,
 	instance *ovnv1.OVNDBCluster,
-	envVars *map[string]env.Setter,
 	serviceName string,
 ) error {
-	// Create/update configmaps from templates
 	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(serviceName), map[string]string{})
 
-	templateParameters := make(map[string]interface{})
-
-	templateParameters["OVN_LOG_LEVEL"] = instance.Spec.LogLevel
-	templateParameters["SERVICE_NAME"] = serviceName
-	templateParameters["NAMESPACE"] = instance.GetNamespace()
-	templateParameters["DB_TYPE"] = strings.ToLower(instance.Spec.DBType)
-	templateParameters["DB_PORT"] = ovndbcluster.DbPortNB
-	templateParameters["RAFT_PORT"] = ovndbcluster.RaftPortNB
-	if instance.Spec.DBType == ovnv1.SBDBType {
-		templateParameters["DB_PORT"] = ovndbcluster.DbPortSB
-		templateParameters["RAFT_PORT"] = ovndbcluster.RaftPortSB
+	customData := map[string]string{
+		ovnv1.ConnectionInternalURLKey:    instance.Status.InternalDBAddress,
+		ovnv1.ConnectionExternalURLKey:    instance.Status.DBAddress,
+		ovnv1.ConnectionTLSKey:            strconv.FormatBool(instance.Spec.TLS.Enabled()),
+		ovnv1.ConnectionCABundleSecretKey: instance.Spec.TLS.CaBundleSecretName,
 	}
-	templateParameters["OVN_ELECTION_TIMER"] = instance.Spec.ElectionTimer
-	templateParameters["OVN_INACTIVITY_PROBE"] = instance.Spec.InactivityProbe
-	templateParameters["OVN_PROBE_INTERVAL_TO_ACTIVE"] = instance.Spec.ProbeIntervalToActive
-	templateParameters["TLS"] = instance.Spec.TLS.Enabled()
-	templateParameters["OVNDB_CERT_PATH"] = ovn_common.OVNDbCertPath
-	templateParameters["OVNDB_KEY_PATH"] = ovn_common.OVNDbKeyPath
-	templateParameters["OVNDB_CACERT_PATH"] = ovn_common.OVNDbCaCertPath
 
 	cms := []util.Template{
-		// ScriptsConfigMap
 		{
-			Name:          fmt.Sprintf("%s-scripts", instance.Name),
-			Namespace:     instance.Namespace,
-			Type:          util.TemplateTypeScripts,
-			InstanceType:  instance.Kind,
-			Labels:        cmLabels,
-			ConfigOptions: templateParameters,
+			Name:         instance.GetConnectionConfigMapName(),
+			Namespace:    instance.Namespace,
+			Type:         util.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			Labels:       cmLabels,
+			CustomData:   customData,
 		},
 	}
-	return configmap.EnsureConfigMaps(ctx, h, instance, cms, envVars)
+	return configmap.EnsureConfigMaps(ctx, h, instance, cms, nil)
 }
 
 // createHashOfInputHashes - creates a hash of hashes which gets added to the resources which requires a restart
@@ -827,8 +1336,7 @@ func (r *OVNDBClusterReconciler) createHashOfInputHashes(
 ) (string, error) {
 	Log := r.GetLogger(ctx)
 
-	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
-	hash, err := util.ObjectHash(mergedMapVars)
+	hash, err := ovn_common.InputHash(envVars)
 	if err != nil {
 		return hash, err
 	}
@@ -0,0 +1,382 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndiagnostics"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OVNDiagnosticsReconciler reconciles a OVNDiagnostics object
+type OVNDiagnosticsReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
+	// OperatorPod - the pod of the operator, its log is added to the
+	// archives
+	OperatorPod types.NamespacedName
+}
+
+// GetClient -
+func (r *OVNDiagnosticsReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetScheme -
+func (r *OVNDiagnosticsReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *OVNDiagnosticsReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("OVNDiagnostics")
+}
+
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndiagnostics,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndiagnostics/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndiagnostics/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncontrollers,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch;
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;
+
+// Reconcile - OVN Diagnostics
+func (r *OVNDiagnosticsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovndiagnostics", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
+	Log := r.GetLogger(ctx)
+
+	// Fetch the OVNDiagnostics instance
+	instance := &ovnv1.OVNDiagnostics{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovndiagnostics", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovndiagnostics", req.NamespacedName, start, _err)
+	}()
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	//
+	// initialize status
+	//
+	if instance.Status.Conditions == nil {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+	if instance.Status.Hash == nil {
+		instance.Status.Hash = map[string]string{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// initialize conditions used later as Status=Unknown
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNDiagnosticsReadyCondition, condition.InitReason, ovnv1.OVNDiagnosticsReadyInitMessage),
+	)
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// Always patch the instance status when exiting this function so we can persist any changes.
+	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		// update the Ready condition based on the sub conditions
+		if instance.Status.Conditions.AllSubConditionIsTrue() {
+			instance.Status.Conditions.MarkTrue(
+				condition.ReadyCondition, condition.ReadyMessage)
+		} else {
+			// something is not ready so reset the Ready condition
+			instance.Status.Conditions.MarkUnknown(
+				condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
+			// and recalculate it based on the state of the rest of the conditions
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		return ctrl.Result{}, nil
+	}
+
+	// Handle service delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	// Handle non-deleted diagnostics
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OVNDiagnosticsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	crs := &ovnv1.OVNDiagnosticsList{}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ovnv1.OVNDiagnostics{}).
+		Owns(&batchv1.Job{}).
+		Watches(&ovnv1.OVNDBCluster{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		Watches(&ovnv1.OVNController{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		WithOptions(r.Options).
+		Complete(r)
+}
+
+func (r *OVNDiagnosticsReconciler) reconcileDelete(ctx context.Context, instance *ovnv1.OVNDiagnostics, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service delete")
+
+	// Service is deleted so remove the finalizer.
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info("Reconciled Service delete successfully")
+	return ctrl.Result{}, nil
+}
+
+func (r *OVNDiagnosticsReconciler) reconcileNormal(ctx context.Context, instance *ovnv1.OVNDiagnostics, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service")
+
+	// the DBs are dumped with the client certs of their clusters, the nodes
+	// through the OVS deployed by an OVNController
+	missing := []string{}
+	dbClusters := []*ovnv1.OVNDBCluster{}
+	dbEndpoints := []string{}
+	for _, dbType := range []string{ovnv1.NBDBType, ovnv1.SBDBType} {
+		dbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, dbType)
+		if err != nil {
+			missing = append(missing, "OVNDBCluster "+dbType)
+			continue
+		}
+		dbEndpoint, err := dbCluster.GetInternalEndpoint()
+		if err != nil {
+			missing = append(missing, "OVNDBCluster "+dbType+" endpoint")
+			continue
+		}
+		dbClusters = append(dbClusters, dbCluster)
+		dbEndpoints = append(dbEndpoints, dbEndpoint)
+	}
+	ovnControllers := &ovnv1.OVNControllerList{}
+	err := helper.GetClient().List(ctx, ovnControllers, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var ovnController *ovnv1.OVNController
+	if len(ovnControllers.Items) > 0 {
+		ovnController = &ovnControllers.Items[0]
+	} else if len(instance.Spec.Nodes) > 0 {
+		missing = append(missing, "OVNController")
+	}
+	if len(missing) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNInputReadyMissingMessage,
+			fmt.Sprintf("%v", missing)))
+		// the OVNDBCluster and OVNController watches requeue the diagnostics
+		return ctrl.Result{}, nil
+	}
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	image := instance.Spec.ContainerImage
+	if image == "" {
+		image = ovnv1.OVNControllerContainerImage
+		if ovnController != nil {
+			image = ovnController.Spec.OvnContainerImage
+		}
+	}
+
+	err = ovn_common.EnsurePVC(ctx, helper, ovndiagnostics.PVCName(instance), instance.Namespace,
+		instance.Spec.StorageClass, instance.Spec.StorageRequest)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.PersistentVolumeClaim = ovndiagnostics.PVCName(instance)
+
+	//
+	// collect the nodes and the DBs, then archive them with the state of
+	// the operator
+	//
+	steps := []ovndiagnostics.Step{}
+	for i := range instance.Spec.Nodes {
+		steps = append(steps, ovndiagnostics.NodeStep(instance, ovnController, i, image))
+	}
+	for i, dbCluster := range dbClusters {
+		steps = append(steps, ovndiagnostics.DBStep(instance, dbCluster, dbEndpoints[i], image))
+	}
+	for _, step := range steps {
+		completed, ctrlResult, err := r.runStep(ctx, instance, helper, step)
+		if !completed {
+			return ctrlResult, err
+		}
+	}
+
+	collected := []string{}
+	for _, step := range steps {
+		collected = append(collected, instance.Status.Hash[step.Hash])
+	}
+	archive := ovndiagnostics.ArchiveStep(instance, strings.Join(collected, ","), image)
+	if instance.Status.Archive == "" {
+		state, err := ovndiagnostics.OperatorState(ctx, helper, instance.Namespace, r.OperatorPod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ovndiagnostics.OperatorStateConfigMapName(instance),
+				Namespace: instance.Namespace,
+			},
+			Data: state,
+		}
+		err = ovn_common.Apply(ctx, helper, cm)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error storing the operator state in ConfigMap %s: %w", cm.Name, err)
+		}
+	}
+	completed, ctrlResult, err := r.runStep(ctx, instance, helper, archive)
+	if !completed {
+		return ctrlResult, err
+	}
+	instance.Status.Archive = ovndiagnostics.ArchiveName(instance)
+	instance.Status.Conditions.MarkTrue(
+		ovnv1.OVNDiagnosticsReadyCondition,
+		ovnv1.OVNDiagnosticsReadyMessage,
+		instance.Status.Archive,
+		instance.Status.PersistentVolumeClaim)
+
+	Log.Info("Reconciled Service successfully")
+	return ctrl.Result{}, nil
+}
+
+// runStep - run the Job of the step and report whether it completed. The
+// archive is reset until all the steps completed.
+func (r *OVNDiagnosticsReconciler) runStep(
+	ctx context.Context,
+	instance *ovnv1.OVNDiagnostics,
+	helper *helper.Helper,
+	step ovndiagnostics.Step,
+) (bool, ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	stepJob := job.NewJob(
+		step.Job,
+		step.Hash,
+		true,
+		time.Duration(5)*time.Second,
+		instance.Status.Hash[step.Hash],
+	)
+	ctrlResult, err := stepJob.DoJob(ctx, helper)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Archive = ""
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNDiagnosticsReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNDiagnosticsReadyRunningMessage,
+			step.Name))
+		return false, ctrlResult, nil
+	}
+	if err != nil {
+		message, msgErr := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, step.Job.Name)
+		if msgErr != nil || message == "" {
+			message = err.Error()
+		}
+		Log.Info(fmt.Sprintf("Job %s failed: %s", step.Job.Name, message))
+		instance.Status.Archive = ""
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNDiagnosticsReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.OVNDiagnosticsReadyErrorMessage,
+			step.Name,
+			message))
+		// the Job is not retried until the spec changes, the next steps
+		// wait for it
+		return false, ctrl.Result{}, nil
+	}
+	if stepJob.HasChanged() {
+		instance.Status.Hash[step.Hash] = stepJob.GetHash()
+		Log.Info(fmt.Sprintf("Job %s hash added - %s", step.Job.Name, instance.Status.Hash[step.Hash]))
+	}
+	return true, ctrl.Result{}, nil
+}
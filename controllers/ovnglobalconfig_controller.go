@@ -0,0 +1,352 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovnglobalconfig"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// globalConfigDriftInterval - period of the checks of the NB_Global options
+// for manual changes
+const globalConfigDriftInterval = time.Duration(60) * time.Second
+
+// OVNGlobalConfigReconciler reconciles a OVNGlobalConfig object
+type OVNGlobalConfigReconciler struct {
+	client.Client
+	Kclient    kubernetes.Interface
+	RestConfig *rest.Config
+	Scheme     *runtime.Scheme
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
+}
+
+// GetClient -
+func (r *OVNGlobalConfigReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetScheme -
+func (r *OVNGlobalConfigReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *OVNGlobalConfigReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("OVNGlobalConfig")
+}
+
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovnglobalconfigs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovnglobalconfigs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovnglobalconfigs/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create;
+
+// Reconcile - OVN Global Config
+func (r *OVNGlobalConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovnglobalconfig", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
+	Log := r.GetLogger(ctx)
+
+	// Fetch the OVNGlobalConfig instance
+	instance := &ovnv1.OVNGlobalConfig{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovnglobalconfig", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovnglobalconfig", req.NamespacedName, start, _err)
+	}()
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	//
+	// initialize status
+	//
+	if instance.Status.Conditions == nil {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// initialize conditions used later as Status=Unknown
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNGlobalConfigReadyCondition, condition.InitReason, ovnv1.OVNGlobalConfigReadyInitMessage),
+	)
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// Always patch the instance status when exiting this function so we can persist any changes.
+	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		// update the Ready condition based on the sub conditions
+		if instance.Status.Conditions.AllSubConditionIsTrue() {
+			instance.Status.Conditions.MarkTrue(
+				condition.ReadyCondition, condition.ReadyMessage)
+		} else {
+			// something is not ready so reset the Ready condition
+			instance.Status.Conditions.MarkUnknown(
+				condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
+			// and recalculate it based on the state of the rest of the conditions
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		return ctrl.Result{}, nil
+	}
+
+	// Handle service delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	// Handle non-deleted global configs
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OVNGlobalConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	crs := &ovnv1.OVNGlobalConfigList{}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ovnv1.OVNGlobalConfig{}).
+		Watches(&ovnv1.OVNDBCluster{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		WithOptions(r.Options).
+		Complete(r)
+}
+
+// reconcileDelete - remove the options set from the spec from NB_Global,
+// unless the NB DB is gone too
+func (r *OVNGlobalConfigReconciler) reconcileDelete(ctx context.Context, instance *ovnv1.OVNGlobalConfig, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service delete")
+
+	if len(instance.Status.Options) > 0 {
+		nbPod, err := r.getNBPod(ctx, helper, instance)
+		if err != nil {
+			Log.Info(fmt.Sprintf("Not removing the NB_Global options: %s", err.Error()))
+		} else {
+			current, err := ovnglobalconfig.GetNBGlobalOptions(ctx, helper, r.RestConfig, nbPod)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			changes := ovnglobalconfig.GetChanges(map[string]string{}, instance.Status.Options, current)
+			err = ovnglobalconfig.ApplyChanges(ctx, helper, r.RestConfig, nbPod, changes)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			Log.Info("Removed the NB_Global options", "options", changes.Remove)
+		}
+	}
+
+	// Service is deleted so remove the finalizer.
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info("Reconciled Service delete successfully")
+	return ctrl.Result{}, nil
+}
+
+func (r *OVNGlobalConfigReconciler) reconcileNormal(ctx context.Context, instance *ovnv1.OVNGlobalConfig, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service")
+
+	// a single OVNGlobalConfig per namespace manages NB_Global, the oldest
+	owner, err := r.getOwner(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if owner != instance.Name {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNGlobalConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.OVNGlobalConfigReadyConflictMessage,
+			owner))
+		return ctrl.Result{}, nil
+	}
+
+	dbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.NBDBType)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNInputReadyMissingMessage,
+			"OVNDBCluster "+ovnv1.NBDBType))
+		// the OVNDBCluster watch requeues the global config
+		return ctrl.Result{}, nil
+	}
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	nbPod, err := getRunningDBPod(ctx, helper, dbCluster)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNGlobalConfigReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNGlobalConfigReadyWaitingMessage,
+			err.Error()))
+		return ctrl.Result{RequeueAfter: globalConfigDriftInterval}, nil
+	}
+
+	current, err := ovnglobalconfig.GetNBGlobalOptions(ctx, helper, r.RestConfig, nbPod)
+	if err != nil {
+		return ctrl.Result{}, r.applyError(instance, err)
+	}
+	changes := ovnglobalconfig.GetChanges(instance.Spec.NBGlobalOptions, instance.Status.Options, current)
+
+	// the manual changes are only reverted with the Enforce drift policy
+	instance.Status.Conditions.Remove(ovnv1.OVNDriftedCondition)
+	if len(changes.Drifted) > 0 && instance.Spec.DriftPolicy == ovnv1.DriftPolicyReport {
+		for _, name := range changes.Drifted {
+			delete(changes.Set, name)
+		}
+		instance.Status.Conditions.Set(condition.TrueCondition(
+			ovnv1.OVNDriftedCondition,
+			ovnv1.OVNGlobalConfigDriftedMessage,
+			strings.Join(changes.Drifted, ", ")))
+	}
+
+	err = ovnglobalconfig.ApplyChanges(ctx, helper, r.RestConfig, nbPod, changes)
+	if err != nil {
+		return ctrl.Result{}, r.applyError(instance, err)
+	}
+	if len(changes.Drifted) > 0 && instance.Spec.DriftPolicy != ovnv1.DriftPolicyReport {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, ovn_common.EventReasonDriftReverted,
+			"Reverted the manual changes of the NB_Global options %s", strings.Join(changes.Drifted, ", "))
+	}
+	if !changes.Empty() {
+		Log.Info("Applied the NB_Global options", "set", changes.Set, "removed", changes.Remove)
+	}
+
+	instance.Status.Options = map[string]string{}
+	for name, value := range instance.Spec.NBGlobalOptions {
+		instance.Status.Options[name] = value
+	}
+	instance.Status.Conditions.MarkTrue(ovnv1.OVNGlobalConfigReadyCondition, ovnv1.OVNGlobalConfigReadyMessage)
+
+	Log.Info("Reconciled Service successfully")
+	return ctrl.Result{RequeueAfter: globalConfigDriftInterval}, nil
+}
+
+// applyError - report the failure to read or update NB_Global
+func (r *OVNGlobalConfigReconciler) applyError(instance *ovnv1.OVNGlobalConfig, err error) error {
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		ovnv1.OVNGlobalConfigReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		ovnv1.OVNGlobalConfigReadyErrorMessage,
+		err.Error()))
+	return err
+}
+
+// getOwner - the name of the oldest OVNGlobalConfig of the namespace, the
+// one managing the NB_Global options
+func (r *OVNGlobalConfigReconciler) getOwner(ctx context.Context, instance *ovnv1.OVNGlobalConfig) (string, error) {
+	globalConfigs := &ovnv1.OVNGlobalConfigList{}
+	err := r.Client.List(ctx, globalConfigs, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return "", err
+	}
+	owner := instance
+	for i := range globalConfigs.Items {
+		other := &globalConfigs.Items[i]
+		if !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if other.CreationTimestamp.Before(&owner.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&owner.CreationTimestamp) && other.Name < owner.Name) {
+			owner = other
+		}
+	}
+	return owner.Name, nil
+}
+
+// getNBPod - a running pod of the NB DB of the namespace
+func (r *OVNGlobalConfigReconciler) getNBPod(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *ovnv1.OVNGlobalConfig,
+) (*corev1.Pod, error) {
+	dbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.NBDBType)
+	if err != nil {
+		return nil, err
+	}
+	return getRunningDBPod(ctx, helper, dbCluster)
+}
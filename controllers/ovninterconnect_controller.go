@@ -0,0 +1,536 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovninterconnect"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// OVNInterconnectReconciler reconciles a OVNInterconnect object
+type OVNInterconnectReconciler struct {
+	client.Client
+	Kclient    kubernetes.Interface
+	RestConfig *rest.Config
+	Scheme     *runtime.Scheme
+	// RestrictedPodSecurity - render the pods for the restricted PSA profile
+	RestrictedPodSecurity bool
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
+}
+
+// GetClient -
+func (r *OVNInterconnectReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetScheme -
+func (r *OVNInterconnectReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// getlog returns a logger object with a prefix of "conroller.name" and aditional controller context fields
+func (r *OVNInterconnectReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("OVNInterconnect")
+}
+
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovninterconnects,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovninterconnects/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovninterconnects/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters/status,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;patch;update;delete;
+
+// service account, role, rolebinding
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// service account permissions that are needed to grant permission to the above
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
+
+// Reconcile - OVN Interconnect
+func (r *OVNInterconnectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovninterconnect", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
+	Log := r.GetLogger(ctx)
+
+	// Fetch the OVNInterconnect instance
+	instance := &ovnv1.OVNInterconnect{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovninterconnect", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovninterconnect", req.NamespacedName, start, _err)
+	}()
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	//
+	// initialize status
+	//
+	if instance.Status.Conditions == nil {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// initialize conditions used later as Status=Unknown
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
+		condition.UnknownCondition(condition.ServiceAccountReadyCondition, condition.InitReason, condition.ServiceAccountReadyInitMessage),
+		condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
+		condition.UnknownCondition(condition.RoleBindingReadyCondition, condition.InitReason, condition.RoleBindingReadyInitMessage),
+		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+	)
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// Always patch the instance status when exiting this function so we can persist any changes.
+	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		// update the Ready condition based on the sub conditions
+		if instance.Status.Conditions.AllSubConditionIsTrue() {
+			instance.Status.Conditions.MarkTrue(
+				condition.ReadyCondition, condition.ReadyMessage)
+		} else {
+			// something is not ready so reset the Ready condition
+			instance.Status.Conditions.MarkUnknown(
+				condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
+			// and recalculate it based on the state of the rest of the conditions
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		return ctrl.Result{}, nil
+	}
+
+	// Handle service delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	// Leave the owned resources alone while suspended
+	if instance.Spec.Suspend {
+		return r.reconcileSuspended(ctx, instance, helper, savedConditions)
+	}
+	instance.Status.Conditions.Remove(ovnv1.OVNSuspendedCondition)
+
+	// Handle non-deleted clusters
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OVNInterconnectReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	crs := &ovnv1.OVNInterconnectList{}
+	// index caBundleSecretNameField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &ovnv1.OVNInterconnect{}, caBundleSecretNameField, func(rawObj client.Object) []string {
+		// Extract the secret name from the spec, if one is provided
+		cr := rawObj.(*ovnv1.OVNInterconnect)
+		if cr.Spec.TLS.CaBundleSecretName == "" {
+			return nil
+		}
+		return []string{cr.Spec.TLS.CaBundleSecretName}
+	}); err != nil {
+		return err
+	}
+
+	// index tlsField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &ovnv1.OVNInterconnect{}, tlsField, func(rawObj client.Object) []string {
+		// Extract the secret name from the spec, if one is provided
+		cr := rawObj.(*ovnv1.OVNInterconnect)
+		if cr.Spec.TLS.SecretName == nil {
+			return nil
+		}
+		return []string{*cr.Spec.TLS.SecretName}
+	}); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ovnv1.OVNInterconnect{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Watches(&ovnv1.OVNDBCluster{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		WithOptions(r.Options).
+		Complete(r)
+}
+
+func (r *OVNInterconnectReconciler) findObjectsForSrc(ctx context.Context, src client.Object) []reconcile.Request {
+	requests := []reconcile.Request{}
+
+	Log := r.GetLogger(ctx)
+
+	for _, field := range allWatchFields {
+		crList := &ovnv1.OVNInterconnectList{}
+		listOps := &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(field, src.GetName()),
+			Namespace:     src.GetNamespace(),
+		}
+		err := r.Client.List(ctx, crList, listOps)
+		if err != nil {
+			return []reconcile.Request{}
+		}
+
+		for _, item := range crList.Items {
+			Log.Info(fmt.Sprintf("input source %s changed, reconcile: %s - %s", src.GetName(), item.GetName(), item.GetNamespace()))
+
+			requests = append(requests,
+				reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      item.GetName(),
+						Namespace: item.GetNamespace(),
+					},
+				},
+			)
+		}
+	}
+
+	return requests
+}
+
+func (r *OVNInterconnectReconciler) reconcileDelete(ctx context.Context, instance *ovnv1.OVNInterconnect, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service delete")
+
+	// Service is deleted so remove the finalizer.
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info("Reconciled Service delete successfully")
+	return ctrl.Result{}, nil
+}
+
+// reconcileSuspended - only refresh the status from the Deployment, none of
+// the owned resources is modified
+func (r *OVNInterconnectReconciler) reconcileSuspended(
+	ctx context.Context,
+	instance *ovnv1.OVNInterconnect,
+	helper *helper.Helper,
+	savedConditions condition.Conditions,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciliation suspended")
+	suspendReconcile(&instance.Status.Conditions, savedConditions)
+
+	deployment := &appsv1.Deployment{}
+	err := helper.GetClient().Get(ctx, types.NamespacedName{Name: ovnv1.ServiceNameOVNInterconnect, Namespace: instance.Namespace}, deployment)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	instance.Status.ReadyCount = deployment.Status.ReadyReplicas
+
+	return ctrl.Result{}, nil
+}
+
+func (r *OVNInterconnectReconciler) reconcileUpdate(ctx context.Context) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service update")
+
+	Log.Info("Reconciled Service update successfully")
+	return ctrl.Result{}, nil
+}
+
+func (r *OVNInterconnectReconciler) reconcileUpgrade(ctx context.Context) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service upgrade")
+
+	Log.Info("Reconciled Service upgrade successfully")
+	return ctrl.Result{}, nil
+}
+
+func (r *OVNInterconnectReconciler) reconcileNormal(ctx context.Context, instance *ovnv1.OVNInterconnect, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service")
+
+	// Service account, role, binding
+	rbacRules := ovn_common.SCCRules(ovn_common.SCCRestricted)
+	rbacResult, err := common_rbac.ReconcileRbac(ctx, helper, instance, rbacRules)
+	if err != nil {
+		return rbacResult, err
+	} else if (rbacResult != ctrl.Result{}) {
+		return rbacResult, nil
+	}
+
+	missing, err := reconcileInputs(ctx, helper, &instance.Status.Conditions, instance.Spec.TLS)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if missing {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	//
+	// TODO check when/if Init, Update, or Upgrade should/could be skipped
+	//
+
+	serviceLabels := map[string]string{
+		common.AppSelector: ovnv1.ServiceNameOVNInterconnect,
+	}
+
+	// Handle service update
+	ctrlResult, err := r.reconcileUpdate(ctx)
+	if err != nil {
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+
+	// Handle service upgrade
+	ctrlResult, err = r.reconcileUpgrade(ctx)
+	if err != nil {
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+
+	// ovn-ic connects to the local NB and SB and to the interconnection
+	// databases shared with the other availability zones
+	endpoints := ovninterconnect.Endpoints{}
+	endpoints.NB, err = getInternalEndpoint(ctx, helper, instance.Namespace, ovnv1.NBDBType)
+	if err != nil {
+		return ctrlResult, err
+	}
+	endpoints.SB, err = getInternalEndpoint(ctx, helper, instance.Namespace, ovnv1.SBDBType)
+	if err != nil {
+		return ctrlResult, err
+	}
+	endpoints.ICNB, err = getInternalEndpoint(ctx, helper, instance.Namespace, ovnv1.ICNBDBType)
+	if err != nil {
+		return ctrlResult, err
+	}
+	endpoints.ICSB, err = getInternalEndpoint(ctx, helper, instance.Namespace, ovnv1.ICSBDBType)
+	if err != nil {
+		return ctrlResult, err
+	}
+
+	envVars := make(map[string]env.Setter)
+
+	//
+	// TLS input validation
+	//
+	// Validate the CA cert secret if provided
+	if instance.Spec.TLS.CaBundleSecretName != "" {
+		hash, ctrlResult, err := tls.ValidateCACertSecret(
+			ctx,
+			helper.GetClient(),
+			types.NamespacedName{
+				Name:      instance.Spec.TLS.CaBundleSecretName,
+				Namespace: instance.Namespace,
+			},
+		)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			return ctrlResult, err
+		} else if (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, nil
+		}
+
+		if hash != "" {
+			envVars[tls.CABundleKey] = env.SetValue(hash)
+		}
+	}
+
+	// Request the service cert from cert-manager
+	if instance.Spec.TLS.Issuer != "" && instance.Spec.TLS.Enabled() {
+		err := ovn_common.EnsureCertificate(
+			ctx,
+			helper,
+			&instance.Spec.TLS,
+			*instance.Spec.TLS.GenericService.SecretName,
+			ovnv1.ServiceNameOVNInterconnect,
+			ovn_common.ServiceCertDNSNames(&instance.Spec.TLS, ovnv1.ServiceNameOVNInterconnect, instance.Namespace),
+			instance.Spec.TLS.ExtraIPAddresses,
+		)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Certificates requested for a previous Issuer or secret
+	if err := ovn_common.DeleteStaleCertificates(ctx, helper, instance.Spec.TLS.CertificateName()); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Validate service cert secret
+	if instance.Spec.TLS.Enabled() {
+		hash, ctrlResult, err := instance.Spec.TLS.ValidateCertSecret(ctx, helper, instance.Namespace)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		} else if (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, nil
+		}
+		envVars[tls.TLSHashName] = env.SetValue(hash)
+	}
+	// TLS is enabled per database, a cert is needed to reach the TLS ones
+	err = verifyClientTLS(instance.Spec.TLS, endpoints.NB, endpoints.SB, endpoints.ICNB, endpoints.ICSB)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.TLSInputReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.TLSInputErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	// all cert input checks out so report InputReady
+	instance.Status.Conditions.MarkTrue(condition.TLSInputReadyCondition, condition.InputReadyMessage)
+
+	instance.Status.Conditions.Remove(ovnv1.OVNDriftedCondition)
+
+	// Define a new Deployment object
+	depl := ovninterconnect.Deployment(instance, ovninterconnect.DeploymentInputs{
+		Endpoints:             endpoints,
+		InputHashes:           envVars,
+		RestrictedPodSecurity: r.RestrictedPodSecurity,
+	})
+	err = applyWorkload(ctx, helper, r.Recorder, &instance.Status.Conditions, instance.Spec.DriftPolicy, depl)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	instance.Status.ReadyCount = depl.Status.ReadyReplicas
+
+	if instance.Status.ReadyCount > 0 {
+		instance.Status.Conditions.MarkTrue(condition.DeploymentReadyCondition, condition.DeploymentReadyMessage)
+	} else if *instance.Spec.Replicas == 0 {
+		instance.Status.Conditions.Remove(condition.DeploymentReadyCondition)
+	}
+
+	// report the OVN versions the running ovn-ic pods actually run
+	podList := &corev1.PodList{}
+	err = r.Client.List(ctx, podList, client.InNamespace(instance.Namespace), client.MatchingLabels(serviceLabels))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.Versions = ovn_common.RunningVersions(ctx, helper, r.RestConfig, podList.Items,
+		[]string{"ovn-appctl", "-t", "ovn-ic", "version"})
+	if len(instance.Status.Versions) == 0 {
+		instance.Status.Versions = nil
+	}
+	// create Deployment - end
+
+	Log.Info("Reconciled Service successfully")
+	return ctrl.Result{}, nil
+}
@@ -25,9 +25,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -37,15 +40,16 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/deployment"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovnnorthd"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -53,8 +57,15 @@ import (
 // OVNNorthdReconciler reconciles a OVNNorthd object
 type OVNNorthdReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient    kubernetes.Interface
+	RestConfig *rest.Config
+	Scheme     *runtime.Scheme
+	// RestrictedPodSecurity - render the pods for the restricted PSA profile
+	RestrictedPodSecurity bool
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
 }
 
 // GetClient -
@@ -75,12 +86,18 @@ func (r *OVNNorthdReconciler) GetLogger(ctx context.Context) logr.Logger {
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovnnorthds,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovnnorthds/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovnnorthds/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncontrollers,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters/status,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;patch;update;delete;
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create;
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete;
 
 // service account, role, rolebinding
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
@@ -92,6 +109,8 @@ func (r *OVNNorthdReconciler) GetLogger(ctx context.Context) logr.Logger {
 
 // Reconcile - OVN Northd
 func (r *OVNNorthdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovnnorthd", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
 	Log := r.GetLogger(ctx)
 
 	// Fetch the OVNNorthd instance
@@ -102,12 +121,20 @@ func (r *OVNNorthdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovnnorthd", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
 
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovnnorthd", req.NamespacedName, start, _err)
+	}()
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -134,17 +161,28 @@ func (r *OVNNorthdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	cl := condition.CreateList(
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNNorthdReadyCondition, condition.InitReason, ovnv1.OVNNorthdReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceAccountReadyCondition, condition.InitReason, condition.ServiceAccountReadyInitMessage),
 		condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
 		condition.UnknownCondition(condition.RoleBindingReadyCondition, condition.InitReason, condition.RoleBindingReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 	)
 
+	// FIPSProviderReady is only reported when FIPS is requested
+	if instance.Spec.FIPS {
+		cl.Set(condition.UnknownCondition(ovnv1.OVNFIPSProviderReadyCondition, condition.InitReason, ovnv1.OVNFIPSProviderReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(ovnv1.OVNFIPSProviderReadyCondition)
+	}
+
 	instance.Status.Conditions.Init(&cl)
 	instance.Status.ObservedGeneration = instance.Generation
 
 	// Always patch the instance status when exiting this function so we can persist any changes.
 	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
 		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
 		// update the Ready condition based on the sub conditions
 		if instance.Status.Conditions.AllSubConditionIsTrue() {
@@ -175,6 +213,12 @@ func (r *OVNNorthdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return r.reconcileDelete(ctx, instance, helper)
 	}
 
+	// Leave the owned resources alone while suspended
+	if instance.Spec.Suspend {
+		return r.reconcileSuspended(ctx, instance, helper, savedConditions)
+	}
+	instance.Status.Conditions.Remove(ovnv1.OVNSuspendedCondition)
+
 	// Handle non-deleted clusters
 	return r.reconcileNormal(ctx, instance, helper)
 }
@@ -209,6 +253,7 @@ func (r *OVNNorthdReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&ovnv1.OVNNorthd{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
@@ -218,6 +263,7 @@ func (r *OVNNorthdReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		WithOptions(r.Options).
 		Complete(r)
 }
 
@@ -259,12 +305,38 @@ func (r *OVNNorthdReconciler) reconcileDelete(ctx context.Context, instance *ovn
 
 	Log.Info("Reconciling Service delete")
 
+	ovnnorthd.DeleteReplicaMetrics(instance)
+	ovn_common.DeleteTelemetryMetrics(instance.Namespace, instance.Name, ovnv1.ServiceNameOVNNorthd)
+
 	// Service is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
 	Log.Info("Reconciled Service delete successfully")
 	return ctrl.Result{}, nil
 }
 
+// reconcileSuspended - only refresh the status from the Deployment, none of
+// the owned resources is modified
+func (r *OVNNorthdReconciler) reconcileSuspended(
+	ctx context.Context,
+	instance *ovnv1.OVNNorthd,
+	helper *helper.Helper,
+	savedConditions condition.Conditions,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciliation suspended")
+	suspendReconcile(&instance.Status.Conditions, savedConditions)
+
+	deployment := &appsv1.Deployment{}
+	err := helper.GetClient().Get(ctx, types.NamespacedName{Name: ovnv1.ServiceNameOVNNorthd, Namespace: instance.Namespace}, deployment)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	instance.Status.ReadyCount = deployment.Status.ReadyReplicas
+
+	return ctrl.Result{}, nil
+}
+
 func (r *OVNNorthdReconciler) reconcileUpdate(ctx context.Context) (ctrl.Result, error) {
 	Log := r.GetLogger(ctx)
 
@@ -289,19 +361,7 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 	Log.Info("Reconciling Service")
 
 	// Service account, role, binding
-	rbacRules := []rbacv1.PolicyRule{
-		{
-			APIGroups:     []string{"security.openshift.io"},
-			ResourceNames: []string{"restricted-v2"},
-			Resources:     []string{"securitycontextconstraints"},
-			Verbs:         []string{"use"},
-		},
-		{
-			APIGroups: []string{""},
-			Resources: []string{"pods"},
-			Verbs:     []string{"create", "get", "list", "watch", "update", "patch", "delete"},
-		},
-	}
+	rbacRules := ovn_common.SCCRules(ovn_common.SCCRestricted)
 	rbacResult, err := common_rbac.ReconcileRbac(ctx, helper, instance, rbacRules)
 	if err != nil {
 		return rbacResult, err
@@ -309,7 +369,12 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 		return rbacResult, nil
 	}
 
-	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+	missing, err := reconcileInputs(ctx, helper, &instance.Status.Conditions, instance.Spec.TLS)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if missing {
+		return ctrl.Result{Requeue: true}, nil
+	}
 
 	//
 	// TODO check when/if Init, Update, or Upgrade should/could be skipped
@@ -335,11 +400,11 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 		return ctrlResult, nil
 	}
 
-	nbEndpoint, err := getInternalEndpoint(ctx, helper, instance, ovnv1.NBDBType)
+	nbEndpoint, err := getInternalEndpoint(ctx, helper, instance.Namespace, ovnv1.NBDBType)
 	if err != nil {
 		return ctrlResult, err
 	}
-	sbEndpoint, err := getInternalEndpoint(ctx, helper, instance, ovnv1.SBDBType)
+	sbEndpoint, err := getInternalEndpoint(ctx, helper, instance.Namespace, ovnv1.SBDBType)
 	if err != nil {
 		return ctrlResult, err
 	}
@@ -376,6 +441,33 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 		}
 	}
 
+	// Request the service cert from cert-manager
+	if instance.Spec.TLS.Issuer != "" && instance.Spec.TLS.Enabled() {
+		err := ovn_common.EnsureCertificate(
+			ctx,
+			helper,
+			&instance.Spec.TLS,
+			*instance.Spec.TLS.GenericService.SecretName,
+			ovnv1.ServiceNameOVNNorthd,
+			ovn_common.ServiceCertDNSNames(&instance.Spec.TLS, ovnv1.ServiceNameOVNNorthd, instance.Namespace),
+			instance.Spec.TLS.ExtraIPAddresses,
+		)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Certificates requested for a previous Issuer or secret
+	if err := ovn_common.DeleteStaleCertificates(ctx, helper, instance.Spec.TLS.CertificateName()); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Validate service cert secret
 	if instance.Spec.TLS.Enabled() {
 		hash, ctrlResult, err := instance.Spec.TLS.ValidateCertSecret(ctx, helper, instance.Namespace)
@@ -392,16 +484,43 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 		}
 		envVars[tls.TLSHashName] = env.SetValue(hash)
 	}
+	// TLS is enabled per database, a cert is needed to reach the TLS ones
+	err = verifyClientTLS(instance.Spec.TLS, nbEndpoint, sbEndpoint)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.TLSInputReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.TLSInputErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
 	// all cert input checks out so report InputReady
 	instance.Status.Conditions.MarkTrue(condition.TLSInputReadyCondition, condition.InputReadyMessage)
 
-	// Define a new Deployment object
-	depl := deployment.NewDeployment(
-		ovnnorthd.Deployment(instance, serviceLabels, nbEndpoint, sbEndpoint, envVars),
-		time.Duration(5)*time.Second,
-	)
+	// A new image is only rolled out once the OVN databases are upgraded
+	deployInstance := instance
+	instance.Status.Conditions.Remove(ovnv1.OVNUpgradeReadyCondition)
+	if instance.Status.ContainerImage != "" && instance.Status.ContainerImage != instance.Spec.ContainerImage {
+		pending, err := pendingDBClusterUpgrade(ctx, helper, instance.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		deployInstance = instance.DeepCopy()
+		deployInstance.Spec.ContainerImage = upgradeImage(&instance.Status.Conditions,
+			instance.Spec.ContainerImage, instance.Status.ContainerImage, pending)
+	}
+
+	instance.Status.Conditions.Remove(ovnv1.OVNDriftedCondition)
 
-	ctrlResult, err = depl.CreateOrPatch(ctx, helper)
+	// Define a new Deployment object
+	depl := ovnnorthd.Deployment(deployInstance, ovnnorthd.DeploymentInputs{
+		NBEndpoint:            nbEndpoint,
+		SBEndpoint:            sbEndpoint,
+		InputHashes:           envVars,
+		RestrictedPodSecurity: r.RestrictedPodSecurity,
+	})
+	err = applyWorkload(ctx, helper, r.Recorder, &instance.Status.Conditions, instance.Spec.DriftPolicy, depl)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
@@ -409,36 +528,273 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 			condition.SeverityWarning,
 			condition.DeploymentReadyErrorMessage,
 			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
+		return ctrl.Result{}, err
 	}
 
-	instance.Status.ReadyCount = depl.GetDeployment().Status.ReadyReplicas
+	instance.Status.ReadyCount = depl.Status.ReadyReplicas
+	if ovn_common.DeploymentRolledOut(depl) {
+		instance.Status.ContainerImage = deployInstance.Spec.ContainerImage
+	}
 
 	if instance.Status.ReadyCount > 0 {
 		instance.Status.Conditions.MarkTrue(condition.DeploymentReadyCondition, condition.DeploymentReadyMessage)
 	} else if *instance.Spec.Replicas == 0 {
 		instance.Status.Conditions.Remove(condition.DeploymentReadyCondition)
 	}
+	if *instance.Spec.Replicas > 0 || instance.Status.ReadyCount > 0 {
+		setReplicasReadyCondition(
+			&instance.Status.Conditions,
+			ovnv1.OVNNorthdReadyCondition,
+			instance.Status.ReadyCount > 0,
+			ovnv1.OVNNorthdReadyMessage,
+			ovnv1.OVNNorthdReadyRunningMessage,
+			instance.Status.ReadyCount,
+			*instance.Spec.Replicas)
+	} else {
+		instance.Status.Conditions.Remove(ovnv1.OVNNorthdReadyCondition)
+	}
 	// create Deployment - end
 
+	// Keep a replica running during node drains
+	err = ovnnorthd.PodDisruptionBudget(ctx, helper, instance, serviceLabels)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Check the OpenSSL FIPS provider is active in the ovn-northd pods
+	if instance.Spec.FIPS {
+		podList, err := ovnnorthd.OVNNorthdPods(ctx, instance, helper, serviceLabels)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		err = reconcileFIPS(ctx, helper, r.RestConfig, &instance.Status.Conditions, podList.Items)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Apply the connection tuning, it is stored in the NB database. The auto
+	// tuning profile is chosen for the number of chassis first.
+	tuningResult, err := reconcileAutoTuning(ctx, helper, instance.Spec.TuningProfile, &instance.Status.Tuning)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.Tuning.Values = instance.TunedValues()
+	err = r.reconcileNBGlobalOptions(ctx, instance, helper, serviceLabels, nbEndpoint)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Pause or resume ovn-northd as requested
+	err = r.reconcilePause(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNNorthdPausedCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.OVNNorthdPausedErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Only one ovn-northd holds the SB lock, report which one and keep
+	// tracking it as it may fail over to a standby replica. The replica
+	// state and the SB convergence lag are refreshed the same way.
+	r.reconcileReplicaStatus(ctx, instance, helper, serviceLabels, nbEndpoint)
+
+	// Default alerts, based on the replica metrics. They would fire when
+	// ovn-northd is scaled down on purpose.
+	if instance.Spec.Alerts.Enabled && *instance.Spec.Replicas > 0 {
+		err = ovn_common.EnsurePrometheusRule(ctx, helper, ovnnorthd.AlertsName, ovnnorthd.Alerts(instance), instance.Spec.Alerts.Labels)
+	} else {
+		err = ovn_common.DeletePrometheusRule(ctx, helper, ovnnorthd.AlertsName)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if *instance.Spec.Replicas > 0 {
+		Log.Info("Reconciled Service successfully")
+		return ctrl.Result{RequeueAfter: time.Duration(30) * time.Second}, nil
+	}
+
 	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
+	return tuningResult, nil
+}
+
+// reconcileNBGlobalOptions - set the ovn-northd connection tuning from
+// the first running replica, the NB_Global options are shared by all of them
+func (r *OVNNorthdReconciler) reconcileNBGlobalOptions(
+	ctx context.Context,
+	instance *ovnv1.OVNNorthd,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+	nbEndpoint string,
+) error {
+	podList, err := ovnnorthd.OVNNorthdPods(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		return err
+	}
+	for i := range podList.Items {
+		ovnPod := &podList.Items[i]
+		if ovnPod.Status.Phase != corev1.PodRunning || !ovnPod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		return ovnnorthd.SetNBGlobalOptions(ctx, helper, r.RestConfig, ovnPod, instance, nbEndpoint)
+	}
+	return nil
+}
+
+// reconcilePause - pause or resume the running ovn-northd replicas. The
+// NorthdPaused condition is only present while paused, new replicas start
+// unpaused so they are paused on the next reconcile.
+func (r *OVNNorthdReconciler) reconcilePause(
+	ctx context.Context,
+	instance *ovnv1.OVNNorthd,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+) error {
+	if !instance.Spec.Paused && !instance.Status.Conditions.Has(ovnv1.OVNNorthdPausedCondition) {
+		return nil
+	}
+
+	podList, err := ovnnorthd.OVNNorthdPods(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		return err
+	}
+	for i := range podList.Items {
+		ovnPod := &podList.Items[i]
+		if ovnPod.Status.Phase != corev1.PodRunning || !ovnPod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		err := ovnnorthd.SetPaused(ctx, helper, r.RestConfig, ovnPod, instance.Spec.Paused)
+		if err != nil {
+			return err
+		}
+	}
+
+	if instance.Spec.Paused {
+		if !instance.Status.Conditions.Has(ovnv1.OVNNorthdPausedCondition) {
+			r.Recorder.Event(instance, corev1.EventTypeNormal, ovn_common.EventReasonPaused, "ovn-northd paused")
+		}
+		instance.Status.Conditions.Set(condition.TrueCondition(
+			ovnv1.OVNNorthdPausedCondition,
+			ovnv1.OVNNorthdPausedMessage))
+	} else {
+		r.Recorder.Event(instance, corev1.EventTypeNormal, ovn_common.EventReasonResumed, "ovn-northd resumed")
+		instance.Status.Conditions.Remove(ovnv1.OVNNorthdPausedCondition)
+	}
+	return nil
+}
+
+// reconcileReplicaStatus - report the state of every ovn-northd replica,
+// find the active one and label every replica with its role. Pods which
+// can't be queried are skipped, the state is informational only.
+func (r *OVNNorthdReconciler) reconcileReplicaStatus(
+	ctx context.Context,
+	instance *ovnv1.OVNNorthd,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+	nbEndpoint string,
+) {
+	Log := r.GetLogger(ctx)
+
+	podList, err := ovnnorthd.OVNNorthdPods(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		Log.Error(err, "Failed to list pods for active instance")
+		return
+	}
+
+	activeInstance := ""
+	replicas := []ovnv1.OVNNorthdReplicaStatus{}
+	telemetry := map[string]*ovn_common.Telemetry{}
+	for i := range podList.Items {
+		ovnPod := &podList.Items[i]
+		if ovnPod.Status.Phase != corev1.PodRunning || !ovnPod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		status, err := ovnnorthd.GetStatus(ctx, helper, r.RestConfig, ovnPod)
+		if err != nil {
+			Log.Info(err.Error())
+			continue
+		}
+		replica := ovnv1.OVNNorthdReplicaStatus{
+			Name:   ovnPod.Name,
+			Status: status,
+		}
+		replica.NBConnected, err = ovnnorthd.GetConnectionStatus(ctx, helper, r.RestConfig, ovnPod, "nb")
+		if err != nil {
+			Log.Info(err.Error())
+		}
+		replica.SBConnected, err = ovnnorthd.GetConnectionStatus(ctx, helper, r.RestConfig, ovnPod, "sb")
+		if err != nil {
+			Log.Info(err.Error())
+		}
+		replica.Version, err = ovn_common.DaemonVersion(ctx, helper, r.RestConfig, ovnPod,
+			[]string{"ovn-appctl", "-t", "ovn-northd", "version"})
+		if err != nil {
+			Log.Info(err.Error())
+		}
+		replicas = append(replicas, replica)
+
+		if status == "active" {
+			activeInstance = ovnPod.Name
+			cfg, err := ovnnorthd.GetCfgSequence(ctx, helper, r.RestConfig, ovnPod, instance, nbEndpoint)
+			if err != nil {
+				Log.Info(err.Error())
+			} else {
+				instance.Status.NBCfg = cfg.NBCfg
+				instance.Status.SBCfg = cfg.SBCfg
+				instance.Status.HVCfg = cfg.HVCfg
+				ovnnorthd.SetCfgMetrics(instance, cfg, time.Now())
+			}
+		}
+		err = ovnnorthd.SetRoleLabel(ctx, helper, ovnPod, status)
+		if err != nil {
+			Log.Info(fmt.Sprintf("Failed to label pod %s: %s", ovnPod.Name, err.Error()))
+		}
+		if instance.Spec.Telemetry {
+			t, err := ovn_common.CollectTelemetry(ctx, helper, r.RestConfig, ovnPod, ovnv1.ServiceNameOVNNorthd)
+			if err != nil {
+				Log.Info(err.Error())
+				continue
+			}
+			telemetry[ovnPod.Name] = t
+		}
+	}
+	if activeInstance != "" && instance.Status.ActiveInstance != "" && activeInstance != instance.Status.ActiveInstance {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonFailover,
+			"ovn-northd active replica moved from %s to %s", instance.Status.ActiveInstance, activeInstance)
+	}
+	instance.Status.ActiveInstance = activeInstance
+	instance.Status.Replicas = replicas
+	ovnnorthd.SetReplicaMetrics(instance, replicas)
+	if instance.Spec.Telemetry {
+		ovn_common.SetTelemetryMetrics(instance.Namespace, instance.Name, ovnv1.ServiceNameOVNNorthd, telemetry)
+	} else {
+		ovn_common.DeleteTelemetryMetrics(instance.Namespace, instance.Name, ovnv1.ServiceNameOVNNorthd)
+	}
 }
 
 func getInternalEndpoint(
 	ctx context.Context,
 	h *helper.Helper,
-	instance *ovnv1.OVNNorthd,
+	namespace string,
 	dbType string,
 ) (string, error) {
-	cluster, err := ovnv1.GetDBClusterByType(ctx, h, instance.Namespace, map[string]string{}, dbType)
+	cluster, err := ovnv1.GetDBClusterByType(ctx, h, namespace, map[string]string{}, dbType)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// OVNSBJanitorReconciler periodically scans the SB DB of the OVNDBClusters
+// for stale records
+type OVNSBJanitorReconciler struct {
+	client.Client
+	Kclient    kubernetes.Interface
+	RestConfig *rest.Config
+	Scheme     *runtime.Scheme
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
+	// Interval - period of the scans
+	Interval time.Duration
+	// Cleanup - remove the stale records found, otherwise they are only
+	// reported
+	Cleanup bool
+	// Exec - runs the ovn-sbctl and ovn-nbctl commands in the DB pods,
+	// ovn_common.ExecInPod when not set
+	Exec ovn_common.ExecFunc
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *OVNSBJanitorReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("OVNSBJanitor")
+}
+
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create;
+
+// Reconcile - scan the SB DB of the OVNDBCluster for orphaned port
+// bindings, stale MAC bindings and chassis without node, and report or
+// remove them. The scan is repeated every Interval, a chassis is only
+// removed once found by two scans in a row.
+func (r *OVNSBJanitorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovnsbjanitor", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
+	Log := r.GetLogger(ctx)
+
+	instance := &ovnv1.OVNDBCluster{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			ovndbcluster.DeleteStaleSBRecordsMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if instance.Spec.DBType != ovnv1.SBDBType || !instance.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if !instance.IsReady() {
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	sbPod, err := getRunningDBPod(ctx, helper, instance)
+	if err != nil {
+		Log.Info(err.Error())
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+	// without the NB DB leader the port bindings are not checked
+	nbPod, err := r.getNBLeaderPod(ctx, instance.Namespace)
+	if err != nil {
+		Log.Info(err.Error())
+	}
+	nodeList, err := helper.GetKClient().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	exec := r.Exec
+	if exec == nil {
+		exec = ovn_common.ExecInPod
+	}
+	stale, err := ovndbcluster.GetStaleSBRecords(ctx, exec, helper, r.RestConfig, sbPod, nbPod, nodeList.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	stale.HoldNewChassis(instance.Status.StaleChassis)
+	err = r.setStaleChassis(ctx, instance, stale.AllChassis())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	ovndbcluster.SetStaleSBRecordsMetrics(instance, stale)
+	if stale.Count() == 0 {
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	r.Recorder.Eventf(instance, corev1.EventTypeWarning, ovn_common.EventReasonStaleSBRecordsFound,
+		"Found %d orphaned port bindings, %d stale MAC bindings and %d chassis without node in the SB DB, "+
+			"%d of them found for the first time",
+		len(stale.PortBindings), len(stale.MACBindings), len(stale.Chassis)+len(stale.NewChassis), len(stale.NewChassis))
+	// the orphaned port bindings are removed by ovn-northd
+	if !r.Cleanup || stale.Deletable() == 0 {
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+	err = ovndbcluster.DeleteStaleSBRecords(ctx, exec, helper, r.RestConfig, sbPod, stale)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	Log.Info("Deleted the stale SB DB records", "macBindings", stale.MACBindings, "chassis", stale.Chassis)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, ovn_common.EventReasonStaleSBRecordsDeleted,
+		"Deleted %d stale MAC bindings and %d chassis without node from the SB DB",
+		len(stale.MACBindings), len(stale.Chassis))
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// setStaleChassis - remember the stale chassis found by the scan in the
+// status, for the next scan to confirm them
+func (r *OVNSBJanitorReconciler) setStaleChassis(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	chassis []string,
+) error {
+	if len(chassis) == 0 {
+		chassis = nil
+	}
+	if equality.Semantic.DeepEqual(instance.Status.StaleChassis, chassis) {
+		return nil
+	}
+	patch := client.MergeFrom(instance.DeepCopy())
+	instance.Status.StaleChassis = chassis
+	return r.Client.Status().Patch(ctx, instance, patch)
+}
+
+// getNBLeaderPod - the pod of the RAFT leader of a ready NB DB cluster of
+// the namespace, as last reported in its status
+func (r *OVNSBJanitorReconciler) getNBLeaderPod(
+	ctx context.Context,
+	namespace string,
+) (*corev1.Pod, error) {
+	dbClusters := &ovnv1.OVNDBClusterList{}
+	err := r.Client.List(ctx, dbClusters, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+	for i := range dbClusters.Items {
+		if dbClusters.Items[i].Spec.DBType != ovnv1.NBDBType || !dbClusters.Items[i].IsReady() {
+			continue
+		}
+		leader := ovndbcluster.Leader(dbClusters.Items[i].Status.ClusterMembers)
+		if leader == "" {
+			return nil, fmt.Errorf("no RAFT leader of the NB DB %s to query", dbClusters.Items[i].Name)
+		}
+		pod := &corev1.Pod{}
+		err = r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: leader}, pod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the NB DB leader pod %s: %w", leader, err)
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return nil, fmt.Errorf("the NB DB leader pod %s is not running", leader)
+		}
+		return pod, nil
+	}
+	return nil, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OVNSBJanitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the scans are scheduled with RequeueAfter, the status updates of the
+	// OVNDBClusters don't trigger one
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("ovnsbjanitor").
+		For(&ovnv1.OVNDBCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(r.Options).
+		Complete(r)
+}
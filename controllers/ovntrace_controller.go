@@ -0,0 +1,445 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovntrace"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OVNTraceReconciler reconciles a OVNTrace object
+type OVNTraceReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+	// Recorder - records Events on the instances
+	Recorder record.EventRecorder
+	// Options - concurrency and rate limiting of the reconciles
+	Options controller.Options
+}
+
+// GetClient -
+func (r *OVNTraceReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetScheme -
+func (r *OVNTraceReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *OVNTraceReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("OVNTrace")
+}
+
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovntraces,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovntraces/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovntraces/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovncontrollers,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;
+
+// Reconcile - OVN Trace
+func (r *OVNTraceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := ovn_common.StartReconcileSpan(ctx, "ovntrace", req.NamespacedName)
+	defer func() { ovn_common.EndSpan(span, _err) }()
+	Log := r.GetLogger(ctx)
+
+	// Fetch the OVNTrace instance
+	instance := &ovnv1.OVNTrace{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers. Return and don't requeue.
+			ovn_common.DeleteReconcileMetrics("ovntrace", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Record the duration and the error of the reconciliation, after the
+	// status got patched
+	start := time.Now()
+	defer func() {
+		ovn_common.ObserveReconcile("ovntrace", req.NamespacedName, start, _err)
+	}()
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	//
+	// initialize status
+	//
+	if instance.Status.Conditions == nil {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+	if instance.Status.Hash == nil {
+		instance.Status.Hash = map[string]string{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// initialize conditions used later as Status=Unknown
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(ovnv1.OVNTraceReadyCondition, condition.InitReason, ovnv1.OVNTraceReadyInitMessage),
+	)
+	if instance.Spec.OFProtoTrace {
+		cl.Set(condition.UnknownCondition(ovnv1.OVNOFProtoTraceReadyCondition, condition.InitReason, ovnv1.OVNOFProtoTraceReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(ovnv1.OVNOFProtoTraceReadyCondition)
+	}
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// Always patch the instance status when exiting this function so we can persist any changes.
+	defer func() {
+		if _err != nil && !k8s_errors.IsConflict(_err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, ovn_common.EventReasonReconcileError, _err.Error())
+		}
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		// update the Ready condition based on the sub conditions
+		if instance.Status.Conditions.AllSubConditionIsTrue() {
+			instance.Status.Conditions.MarkTrue(
+				condition.ReadyCondition, condition.ReadyMessage)
+		} else {
+			// something is not ready so reset the Ready condition
+			instance.Status.Conditions.MarkUnknown(
+				condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
+			// and recalculate it based on the state of the rest of the conditions
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		return ctrl.Result{}, nil
+	}
+
+	// Handle service delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	// Handle non-deleted traces
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OVNTraceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	crs := &ovnv1.OVNTraceList{}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ovnv1.OVNTrace{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&batchv1.Job{}).
+		Watches(&ovnv1.OVNDBCluster{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		Watches(&ovnv1.OVNController{}, handler.EnqueueRequestsFromMapFunc(ovnv1.OVNDBClusterNamespaceMapFunc(crs, mgr.GetClient()))).
+		WithOptions(r.Options).
+		Complete(r)
+}
+
+func (r *OVNTraceReconciler) reconcileDelete(ctx context.Context, instance *ovnv1.OVNTrace, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service delete")
+
+	// Service is deleted so remove the finalizer.
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info("Reconciled Service delete successfully")
+	return ctrl.Result{}, nil
+}
+
+func (r *OVNTraceReconciler) reconcileNormal(ctx context.Context, instance *ovnv1.OVNTrace, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info("Reconciling Service")
+
+	// ovn-trace reads the logical flows from the SB DB, ofproto/trace needs
+	// the OVS of the chassis deployed by an OVNController
+	missing := []string{}
+	sbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.SBDBType)
+	if err != nil {
+		missing = append(missing, "OVNDBCluster "+ovnv1.SBDBType)
+	}
+	sbEndpoint := ""
+	if sbCluster != nil {
+		sbEndpoint, err = sbCluster.GetInternalEndpoint()
+		if err != nil {
+			missing = append(missing, "OVNDBCluster "+ovnv1.SBDBType+" endpoint")
+		}
+	}
+	ovnControllers := &ovnv1.OVNControllerList{}
+	err = helper.GetClient().List(ctx, ovnControllers, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var ovnController *ovnv1.OVNController
+	if len(ovnControllers.Items) > 0 {
+		ovnController = &ovnControllers.Items[0]
+	} else if instance.Spec.OFProtoTrace {
+		missing = append(missing, "OVNController")
+	}
+	if len(missing) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNInputReadyMissingMessage,
+			fmt.Sprintf("%v", missing)))
+		// the OVNDBCluster and OVNController watches requeue the trace
+		return ctrl.Result{}, nil
+	}
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	image := instance.Spec.ContainerImage
+	if image == "" {
+		image = ovnv1.OVNControllerContainerImage
+		if ovnController != nil {
+			image = ovnController.Spec.OvnContainerImage
+		}
+	}
+
+	//
+	// ovn-trace
+	//
+	jobDef := ovntrace.OVNTraceJob(instance, sbCluster, sbEndpoint, image)
+	traceJob := job.NewJob(
+		jobDef,
+		ovntrace.OVNTraceHash,
+		true,
+		time.Duration(5)*time.Second,
+		instance.Status.Hash[ovntrace.OVNTraceHash],
+	)
+	ctrlResult, err := traceJob.DoJob(ctx, helper)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNTraceReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNTraceReadyRunningMessage,
+			instance.Spec.SourcePort))
+		return ctrlResult, nil
+	}
+	if err != nil {
+		r.setTraceFailed(ctx, instance, helper, ovnv1.OVNTraceReadyCondition, ovnv1.OVNTraceReadyErrorMessage, jobDef.Name, err)
+		return ctrl.Result{}, nil
+	}
+	if traceJob.HasChanged() {
+		output, err := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, jobDef.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		result := ovntrace.ParseOVNTraceResult(output)
+		err = r.saveResult(ctx, instance, helper, ovnv1.OVNTraceResultKey, result.Trace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.Chassis = result.Chassis
+		instance.Status.Flow = result.Flow
+		instance.Status.Hash[ovntrace.OVNTraceHash] = traceJob.GetHash()
+		Log.Info(fmt.Sprintf("Job %s hash added - %s", jobDef.Name, instance.Status.Hash[ovntrace.OVNTraceHash]))
+	}
+	instance.Status.ResultConfigMap = ovntrace.ResultConfigMapName(instance)
+	instance.Status.Conditions.MarkTrue(
+		ovnv1.OVNTraceReadyCondition,
+		ovnv1.OVNTraceReadyMessage,
+		instance.Status.ResultConfigMap)
+
+	//
+	// ofproto/trace
+	//
+	if !instance.Spec.OFProtoTrace {
+		err = job.DeleteJob(ctx, helper, ovntrace.OFProtoTraceJobName(instance), instance.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		err = r.saveResult(ctx, instance, helper, ovnv1.OFProtoTraceResultKey, "")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		delete(instance.Status.Hash, ovntrace.OFProtoTraceHash)
+		instance.Status.DatapathActions = ""
+		return ctrl.Result{}, nil
+	}
+
+	if instance.Status.Chassis == "" {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNOFProtoTraceReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.OVNOFProtoTraceReadyUnboundMessage,
+			instance.Spec.SourcePort))
+		return ctrl.Result{}, nil
+	}
+
+	jobDef = ovntrace.OFProtoTraceJob(instance, ovnController, instance.Status.Chassis, instance.Status.Flow, image)
+	ofprotoJob := job.NewJob(
+		jobDef,
+		ovntrace.OFProtoTraceHash,
+		true,
+		time.Duration(5)*time.Second,
+		instance.Status.Hash[ovntrace.OFProtoTraceHash],
+	)
+	ctrlResult, err = ofprotoJob.DoJob(ctx, helper)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.OVNOFProtoTraceReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.OVNOFProtoTraceReadyRunningMessage,
+			instance.Status.Chassis))
+		return ctrlResult, nil
+	}
+	if err != nil {
+		r.setTraceFailed(ctx, instance, helper, ovnv1.OVNOFProtoTraceReadyCondition, ovnv1.OVNOFProtoTraceReadyErrorMessage, jobDef.Name, err)
+		return ctrl.Result{}, nil
+	}
+	if ofprotoJob.HasChanged() {
+		output, err := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, jobDef.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		err = r.saveResult(ctx, instance, helper, ovnv1.OFProtoTraceResultKey, output)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.DatapathActions = ovntrace.DatapathActions(output)
+		instance.Status.Hash[ovntrace.OFProtoTraceHash] = ofprotoJob.GetHash()
+		Log.Info(fmt.Sprintf("Job %s hash added - %s", jobDef.Name, instance.Status.Hash[ovntrace.OFProtoTraceHash]))
+	}
+	instance.Status.Conditions.MarkTrue(
+		ovnv1.OVNOFProtoTraceReadyCondition,
+		ovnv1.OVNOFProtoTraceReadyMessage,
+		instance.Status.Chassis)
+
+	Log.Info("Reconciled Service successfully")
+	return ctrl.Result{}, nil
+}
+
+// setTraceFailed - report the error of a failed trace Job in its condition,
+// the Job is not retried until the spec changes
+func (r *OVNTraceReconciler) setTraceFailed(
+	ctx context.Context,
+	instance *ovnv1.OVNTrace,
+	helper *helper.Helper,
+	conditionType condition.Type,
+	messageFormat string,
+	jobName string,
+	jobErr error,
+) {
+	Log := r.GetLogger(ctx)
+
+	message, err := ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, jobName)
+	if err != nil || message == "" {
+		message = jobErr.Error()
+	}
+	Log.Info(fmt.Sprintf("Job %s failed: %s", jobName, message))
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		conditionType,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		messageFormat,
+		message))
+}
+
+// saveResult - store the output of a trace in the result ConfigMap, an
+// empty output removes it
+func (r *OVNTraceReconciler) saveResult(
+	ctx context.Context,
+	instance *ovnv1.OVNTrace,
+	helper *helper.Helper,
+	key string,
+	output string,
+) error {
+	current := &corev1.ConfigMap{}
+	err := helper.GetClient().Get(ctx, types.NamespacedName{Name: ovntrace.ResultConfigMapName(instance), Namespace: instance.Namespace}, current)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ovntrace.ResultConfigMapName(instance),
+			Namespace: instance.Namespace,
+		},
+		Data: map[string]string{},
+	}
+	for k, v := range current.Data {
+		cm.Data[k] = v
+	}
+	if output == "" {
+		if _, found := cm.Data[key]; !found {
+			return nil
+		}
+		delete(cm.Data, key)
+	} else {
+		cm.Data[key] = output
+	}
+	err = ovn_common.Apply(ctx, helper, cm)
+	if err != nil {
+		return fmt.Errorf("error storing the trace in ConfigMap %s: %w", cm.Name, err)
+	}
+	return nil
+}
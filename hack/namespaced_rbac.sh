@@ -0,0 +1,79 @@
+#!/bin/bash
+# Print the RBAC of an operator restricted to the WATCH_NAMESPACE namespaces.
+# The manager ClusterRole is bound in each of them with a RoleBinding, only
+# the cluster scoped resources are granted cluster wide. Apply the output and
+# delete the ovn-operator-manager-rolebinding ClusterRoleBinding.
+set -e
+
+WATCH_NAMESPACE=${WATCH_NAMESPACE:?"set WATCH_NAMESPACE to a comma separated list of namespaces"}
+OPERATOR_NAMESPACE=${OPERATOR_NAMESPACE:-"ovn-operator-system"}
+SERVICE_ACCOUNT=${SERVICE_ACCOUNT:-"ovn-operator-controller-manager"}
+NAME_PREFIX=${NAME_PREFIX:-"ovn-operator-"}
+
+cat <<EOF
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: ${NAME_PREFIX}manager-cluster-role
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - nodes
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - rbac.authorization.k8s.io
+  resources:
+  - clusterrolebindings
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - rbac.authorization.k8s.io
+  resourceNames:
+  - system:auth-delegator
+  resources:
+  - clusterroles
+  verbs:
+  - bind
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: ${NAME_PREFIX}manager-cluster-rolebinding
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: ${NAME_PREFIX}manager-cluster-role
+subjects:
+- kind: ServiceAccount
+  name: ${SERVICE_ACCOUNT}
+  namespace: ${OPERATOR_NAMESPACE}
+EOF
+
+for NAMESPACE in ${WATCH_NAMESPACE//,/ }; do
+    cat <<EOF
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: ${NAME_PREFIX}manager-rolebinding
+  namespace: ${NAMESPACE}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: ${NAME_PREFIX}manager-role
+subjects:
+- kind: ServiceAccount
+  name: ${SERVICE_ACCOUNT}
+  namespace: ${OPERATOR_NAMESPACE}
+EOF
+done
@@ -17,22 +17,31 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -42,8 +51,11 @@ import (
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
 
+	ovnapiv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/ovn-operator/controllers"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovnrender"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -55,30 +67,163 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(ovnv1.AddToScheme(scheme))
+	utilruntime.Must(ovnapiv1.AddToScheme(scheme))
 	utilruntime.Must(networkv1.AddToScheme(scheme))
 	utilruntime.Must(infranetworkv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
 func main() {
+	// the render subcommand prints the workloads of custom resources instead
+	// of running the manager
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		os.Exit(render(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var enableHTTP2 bool
+	var restrictedPodSecurity bool
+	var forbidHostNamespaces bool
+	var pprofAddr string
+	var reconcileMetrics bool
+	var controllerLogLevels string
+	var logLevelsAddr string
+	var watchNamespaces string
+	var maxConcurrentReconciles string
+	var rateLimiterBaseDelay time.Duration
+	var rateLimiterMaxDelay time.Duration
+	var syncPeriod time.Duration
+	var sbJanitorInterval time.Duration
+	var sbJanitorCleanup bool
+	var leaseDuration time.Duration
+	var renewDeadline time.Duration
+	var retryPeriod time.Duration
+	var leaderElectionReleaseOnCancel bool
+	var gracefulShutdownTimeout time.Duration
+	var tracingEndpoint string
+	var tracingInsecure bool
 	flag.BoolVar(&enableHTTP2, "enable-http2", enableHTTP2, "If HTTP/2 should be enabled for the metrics and webhook servers.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ovn_common.EnvString("HEALTH_PROBE_BIND_ADDRESS", ":8081"),
+		"The address the probe endpoint binds to. Defaults to the HEALTH_PROBE_BIND_ADDRESS environment variable.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "",
+		"The address the pprof endpoint binds to, e.g. 127.0.0.1:6060. Empty or 0 disables it.")
+	flag.BoolVar(&reconcileMetrics, "reconcile-metrics", true,
+		"Export the reconcile duration and error metrics of every custom resource, "+
+			"in addition to the per controller metrics of controller-runtime.")
+	flag.StringVar(&controllerLogLevels, "controller-log-levels", "",
+		"Comma separated log verbosity per controller overriding --zap-log-level, e.g. ovncontroller=2,ovndbcluster=1. "+
+			"At verbosity 2 the patches of the objects are logged. The levels can be read and replaced at runtime "+
+			"with a GET or PUT of /log-levels on --log-levels-bind-address.")
+	flag.StringVar(&logLevelsAddr, "log-levels-bind-address", "127.0.0.1:8082",
+		"The address the /log-levels endpoint binds to, the loopback by default as a PUT changes the log verbosity. "+
+			"Empty or 0 disables it.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", os.Getenv("WATCH_NAMESPACE"),
+		"Comma separated namespaces the custom resources are reconciled in, all the namespaces when empty. "+
+			"Defaults to the WATCH_NAMESPACE environment variable, set by OLM to the target namespaces of the OperatorGroup.")
+	flag.StringVar(&maxConcurrentReconciles, "max-concurrent-reconciles", "",
+		"Comma separated number of custom resources reconciled in parallel per controller, e.g. ovncontroller=4,ovndbcluster=2. "+
+			"Defaults to 1.")
+	flag.DurationVar(&rateLimiterBaseDelay, "rate-limiter-base-delay", 5*time.Millisecond,
+		"The requeue delay of a custom resource after a failed reconcile, doubled with every following failure.")
+	flag.DurationVar(&rateLimiterMaxDelay, "rate-limiter-max-delay", 1000*time.Second,
+		"The maximum requeue delay of a custom resource after failed reconciles.")
+	flag.DurationVar(&syncPeriod, "sync-period", 10*time.Hour,
+		"The period all the custom resources are reconciled at even without any change.")
+	flag.DurationVar(&sbJanitorInterval, "sb-janitor-interval", 0,
+		"The period the SB DBs are scanned at for orphaned port bindings, stale MAC bindings and chassis without node. "+
+			"0 disables the scans.")
+	flag.BoolVar(&sbJanitorCleanup, "sb-janitor-cleanup", false,
+		"Remove the stale SB DB records found by the scans, otherwise they are only reported with Events and metrics.")
+	flag.BoolVar(&restrictedPodSecurity, "restricted-pod-security", false,
+		"Render the ovn-northd, ovn-ic and OVN DB pods to pass the restricted Pod Security Admission profile. "+
+			"The ovn-controller and OVS DaemonSets keep their privileged settings.")
+	flag.BoolVar(&forbidHostNamespaces, "forbid-host-namespaces", false,
+		"Never share the PID and IPC namespaces of the nodes with the ovn-controller and OVS pods, "+
+			"even when the OVNController spec requests them.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", ovn_common.EnvDuration("LEADER_ELECT_LEASE_DURATION", 15*time.Second),
+		"The duration the other managers wait before taking a lease which is not renewed. "+
+			"Defaults to the LEADER_ELECT_LEASE_DURATION environment variable.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", ovn_common.EnvDuration("LEADER_ELECT_RENEW_DEADLINE", 10*time.Second),
+		"The duration the leader retries renewing its lease before giving up leadership and exiting. "+
+			"Defaults to the LEADER_ELECT_RENEW_DEADLINE environment variable.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", ovn_common.EnvDuration("LEADER_ELECT_RETRY_PERIOD", 2*time.Second),
+		"The period the managers try to acquire or renew the lease at. "+
+			"Defaults to the LEADER_ELECT_RETRY_PERIOD environment variable.")
+	flag.BoolVar(&leaderElectionReleaseOnCancel, "leader-elect-release-on-cancel", true,
+		"Release the lease when the manager stops, so another manager takes over without waiting for the lease duration.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", ovn_common.EnvDuration("GRACEFUL_SHUTDOWN_TIMEOUT", 30*time.Second),
+		"The duration the running reconciles are given to complete when the manager stops. "+
+			"Defaults to the GRACEFUL_SHUTDOWN_TIMEOUT environment variable.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "",
+		"The host:port of the OTLP gRPC collector the spans of the reconcile phases are exported to, "+
+			"e.g. the config generation, the certificates, the workloads applied and the commands run in the OVN pods. "+
+			"Empty disables the tracing.")
+	flag.BoolVar(&tracingInsecure, "tracing-insecure", false,
+		"Export the spans to the OTLP collector without TLS.")
 	opts := zap.Options{
-		Development: true,
 		TimeEncoder: zapcore.ISO8601TimeEncoder,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	// the per controller levels filter the entries, the logger itself
+	// enables all of them
+	defaultLevel := opts.Level
+	if defaultLevel == nil {
+		defaultLevel = zapcore.InfoLevel
+		if opts.Development {
+			defaultLevel = zapcore.DebugLevel
+		}
+	}
+	logLevels := ovn_common.NewLogLevels(defaultLevel)
+	if err := logLevels.Set(controllerLogLevels); err != nil {
+		setupLog.Error(err, "invalid --controller-log-levels")
+		os.Exit(1)
+	}
+	opts.Level = uberzap.LevelEnablerFunc(func(zapcore.Level) bool { return true })
+	opts.ZapOpts = append(opts.ZapOpts, uberzap.WrapCore(logLevels.WrapCore))
+
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	ovn_common.ReconcileMetricsEnabled = reconcileMetrics
+
+	var shutdownTracing func(context.Context) error
+	if tracingEndpoint != "" {
+		var err error
+		shutdownTracing, err = ovn_common.SetupTracing(context.Background(), tracingEndpoint, tracingInsecure)
+		if err != nil {
+			setupLog.Error(err, "invalid --tracing-endpoint")
+			os.Exit(1)
+		}
+		setupLog.Info("exporting the reconcile spans", "endpoint", tracingEndpoint)
+	}
+
+	concurrency, err := ovn_common.ParseMaxConcurrentReconciles(maxConcurrentReconciles,
+		[]string{"ovncontroller", "ovndbcluster", "ovninterconnect", "ovnnorthd", "ovnsbjanitor"})
+	if err != nil {
+		setupLog.Error(err, "invalid --max-concurrent-reconciles")
+		os.Exit(1)
+	}
+	if rateLimiterBaseDelay <= 0 || rateLimiterMaxDelay < rateLimiterBaseDelay {
+		setupLog.Error(nil, "invalid --rate-limiter-base-delay or --rate-limiter-max-delay",
+			"base", rateLimiterBaseDelay, "max", rateLimiterMaxDelay)
+		os.Exit(1)
+	}
+	if err := ovn_common.CheckLeaderElection(leaseDuration, renewDeadline, retryPeriod); err != nil {
+		setupLog.Error(err, "invalid --leader-elect-lease-duration, --leader-elect-renew-deadline or --leader-elect-retry-period")
+		os.Exit(1)
+	}
+	controllerOptions := func(name string) controller.Options {
+		return controller.Options{
+			MaxConcurrentReconciles: concurrency[name],
+			RateLimiter:             ovn_common.ControllerRateLimiter(rateLimiterBaseDelay, rateLimiterMaxDelay),
+		}
+	}
 
 	disableHTTP2 := func(c *tls.Config) {
 		if enableHTTP2 {
@@ -87,14 +232,44 @@ func main() {
 		c.NextProtos = []string{"http/1.1"}
 	}
 
+	// cluster scoped objects like the nodes are still cached cluster wide
+	cacheOptions := cache.Options{SyncPeriod: &syncPeriod}
+	for _, namespace := range strings.Split(watchNamespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace == "" {
+			continue
+		}
+		if cacheOptions.DefaultNamespaces == nil {
+			cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+		}
+		cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+	}
+	if len(cacheOptions.DefaultNamespaces) > 0 {
+		setupLog.Info("watching namespaces", "namespaces", watchNamespaces)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOptions,
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
 		},
+		NewClient: func(config *rest.Config, options client.Options) (client.Client, error) {
+			c, err := client.New(config, options)
+			if err != nil {
+				return nil, err
+			}
+			return ovn_common.NewDiffLoggingClient(c), nil
+		},
 		HealthProbeBindAddress: probeAddr,
+		PprofBindAddress:       pprofAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "90840a60.openstack.org",
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
+		// the manager exits right after it stops
+		LeaderElectionReleaseOnCancel: leaderElectionReleaseOnCancel,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
 		WebhookServer: webhook.NewServer(
 			webhook.Options{
 				Port:    9443,
@@ -116,29 +291,124 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&controllers.OVNNorthdReconciler{
-		Client:  mgr.GetClient(),
-		Scheme:  mgr.GetScheme(),
-		Kclient: kclient,
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		Kclient:               kclient,
+		RestConfig:            cfg,
+		RestrictedPodSecurity: restrictedPodSecurity,
+		Recorder:              mgr.GetEventRecorderFor("ovnnorthd-controller"),
+		Options:               controllerOptions("ovnnorthd"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OVNNorthd")
 		os.Exit(1)
 	}
 	if err = (&controllers.OVNDBClusterReconciler{
-		Client:  mgr.GetClient(),
-		Kclient: kclient,
-		Scheme:  mgr.GetScheme(),
+		Client:                mgr.GetClient(),
+		Kclient:               kclient,
+		RestConfig:            cfg,
+		Scheme:                mgr.GetScheme(),
+		RestrictedPodSecurity: restrictedPodSecurity,
+		Recorder:              mgr.GetEventRecorderFor("ovndbcluster-controller"),
+		Options:               controllerOptions("ovndbcluster"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OVNDBCluster")
 		os.Exit(1)
 	}
+	if err = (&controllers.OVNInterconnectReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		Kclient:               kclient,
+		RestConfig:            cfg,
+		RestrictedPodSecurity: restrictedPodSecurity,
+		Recorder:              mgr.GetEventRecorderFor("ovninterconnect-controller"),
+		Options:               controllerOptions("ovninterconnect"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OVNInterconnect")
+		os.Exit(1)
+	}
+	if err = (&controllers.OVNTraceReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Kclient:  kclient,
+		Recorder: mgr.GetEventRecorderFor("ovntrace-controller"),
+		Options:  controllerOptions("ovntrace"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OVNTrace")
+		os.Exit(1)
+	}
+	if err = (&controllers.OVNCommandReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Kclient:  kclient,
+		Recorder: mgr.GetEventRecorderFor("ovncommand-controller"),
+		Options:  controllerOptions("ovncommand"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OVNCommand")
+		os.Exit(1)
+	}
+	if err = (&controllers.OVNDiagnosticsReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Kclient:  kclient,
+		Recorder: mgr.GetEventRecorderFor("ovndiagnostics-controller"),
+		Options:  controllerOptions("ovndiagnostics"),
+		OperatorPod: types.NamespacedName{
+			Name:      os.Getenv("POD_NAME"),
+			Namespace: os.Getenv("POD_NAMESPACE"),
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OVNDiagnostics")
+		os.Exit(1)
+	}
+	if err = (&controllers.OVNCaptureReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Kclient:  kclient,
+		Recorder: mgr.GetEventRecorderFor("ovncapture-controller"),
+		Options:  controllerOptions("ovncapture"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OVNCapture")
+		os.Exit(1)
+	}
+	if err = (&controllers.OVNGlobalConfigReconciler{
+		Client:     mgr.GetClient(),
+		Kclient:    kclient,
+		RestConfig: cfg,
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("ovnglobalconfig-controller"),
+		Options:    controllerOptions("ovnglobalconfig"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OVNGlobalConfig")
+		os.Exit(1)
+	}
 	if err = (&controllers.OVNControllerReconciler{
-		Client:  mgr.GetClient(),
-		Kclient: kclient,
-		Scheme:  mgr.GetScheme(),
+		Client:     mgr.GetClient(),
+		Kclient:    kclient,
+		RestConfig: cfg,
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("ovncontroller-controller"),
+		Options:    controllerOptions("ovncontroller"),
+
+		ForbidHostNamespaces: forbidHostNamespaces,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OVNController")
 		os.Exit(1)
 	}
+	if sbJanitorInterval > 0 {
+		if err = (&controllers.OVNSBJanitorReconciler{
+			Client:     mgr.GetClient(),
+			Kclient:    kclient,
+			RestConfig: cfg,
+			Scheme:     mgr.GetScheme(),
+			Recorder:   mgr.GetEventRecorderFor("ovnsbjanitor-controller"),
+			Options:    controllerOptions("ovnsbjanitor"),
+			Interval:   sbJanitorInterval,
+			Cleanup:    sbJanitorCleanup,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OVNSBJanitor")
+			os.Exit(1)
+		}
+	}
 
 	// Acquire environmental defaults and initialize operator defaults with them
 	ovnv1.SetupDefaults()
@@ -159,6 +429,18 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OVNController")
 			os.Exit(1)
 		}
+		if err = (&ovnv1.OVNInterconnect{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OVNInterconnect")
+			os.Exit(1)
+		}
+		if err = (&ovnv1.OVNCommand{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OVNCommand")
+			os.Exit(1)
+		}
+		if err = (&ovnv1.OVNGlobalConfig{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OVNGlobalConfig")
+			os.Exit(1)
+		}
 		checker = mgr.GetWebhookServer().StartedChecker()
 	}
 	//+kubebuilder:scaffold:builder
@@ -171,10 +453,78 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// with several replicas, a standby is only ready once it can take over
+	if err := mgr.AddReadyzCheck("informers", ovn_common.CacheSyncedChecker(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&ovn_common.LeaderLabeler{
+		Client: mgr.GetClient(),
+		Pod: types.NamespacedName{
+			Name:      os.Getenv("POD_NAME"),
+			Namespace: os.Getenv("POD_NAMESPACE"),
+		},
+		Elected: mgr.Elected(),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up the leader label")
+		os.Exit(1)
+	}
+
+	if logLevelsAddr != "" && logLevelsAddr != "0" {
+		if err := mgr.Add(&ovn_common.LogLevelsServer{
+			Addr:      logLevelsAddr,
+			LogLevels: logLevels,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the log levels endpoint")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	// the spans of the last reconciles are flushed before exiting
+	if shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := shutdownTracing(ctx); err != nil {
+			setupLog.Error(err, "unable to export the last reconcile spans")
+		}
+		cancel()
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// render - write the workloads the operator would deploy for the custom
+// resources of a file to stdout, without connecting to a cluster
+func render(args []string) int {
+	var file string
+	var opts ovnrender.Options
+	renderFlags := flag.NewFlagSet("render", flag.ContinueOnError)
+	renderFlags.StringVar(&file, "f", "-",
+		"The file holding the OVN custom resources, - for stdin.")
+	renderFlags.BoolVar(&opts.RestrictedPodSecurity, "restricted-pod-security", false,
+		"Render the pods as the manager does with --restricted-pod-security.")
+	renderFlags.BoolVar(&opts.ForbidHostNamespaces, "forbid-host-namespaces", false,
+		"Render the pods as the manager does with --forbid-host-namespaces.")
+	if err := renderFlags.Parse(args); err != nil {
+		return 2
+	}
+
+	in := os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+	if err := ovnrender.Render(in, os.Stdout, scheme, opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,258 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// FieldManager - field manager of the objects applied by the operator
+const FieldManager = "ovn-operator"
+
+// legacyFieldManager - field manager of the objects created or patched
+// before the operator used server-side apply, the apiserver takes it from
+// the user agent
+var legacyFieldManager = strings.Split(rest.DefaultKubernetesUserAgent(), "/")[0]
+
+// Apply - server-side apply obj with the operator field manager. Only the
+// fields set in obj are owned by the operator: the fields set by other
+// controllers are kept, the ones the operator stops setting are removed. obj
+// is controlled by the object of the helper when in the same namespace and
+// is updated with the applied object, including its status.
+func Apply(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+) error {
+	err := apply(ctx, h, obj)
+	if err != nil {
+		return err
+	}
+
+	// The fields set with the previous create or patch are handed over to
+	// the operator field manager, otherwise they would never be removed
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(obj, sets.New(legacyFieldManager), FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	return h.GetClient().Patch(ctx, obj, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// ApplyDetectingDrift - Apply obj and return the fields of obj set by the
+// operator that were modified by another field manager since the previous
+// apply, e.g. manually. They are reverted unless revert is false, then obj
+// is not applied while drifted and is updated with the current object.
+func ApplyDetectingDrift(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	revert bool,
+) ([]string, error) {
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("cannot copy %s", obj.GetName())
+	}
+	err := h.GetClient().Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if k8s_errors.IsNotFound(err) {
+		return nil, Apply(ctx, h, obj)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !revert {
+		dryRun, ok := obj.DeepCopyObject().(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("cannot copy %s", obj.GetName())
+		}
+		err = apply(ctx, h, dryRun, client.DryRunAll)
+		if err != nil {
+			return nil, err
+		}
+		drifted, err := driftedFields(current.GetManagedFields(), dryRun.GetManagedFields())
+		if err != nil {
+			return nil, err
+		} else if len(drifted) > 0 {
+			return drifted, h.GetClient().Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		}
+		return nil, Apply(ctx, h, obj)
+	}
+
+	err = Apply(ctx, h, obj)
+	if err != nil {
+		return nil, err
+	}
+	return driftedFields(current.GetManagedFields(), obj.GetManagedFields())
+}
+
+// ApplyShared - server-side apply the fields of obj set by one of the
+// writers of an object shared by several instances, e.g. the NB and the SB
+// OVNDBClusters publishing their endpoints in one ConfigMap. Each writer
+// owns its fields with its own field manager. The object of the helper is
+// one of the owners of obj without controlling it, so obj is garbage
+// collected with the last of them.
+func ApplyShared(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	writer string,
+) error {
+	gvk, err := apiutil.GVKForObject(obj, h.GetScheme())
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	err = controllerutil.SetOwnerReference(h.GetBeforeObject(), obj, h.GetScheme())
+	if err != nil {
+		return err
+	}
+	return h.GetClient().Patch(ctx, obj, client.Apply,
+		client.FieldOwner(FieldManager+"-"+writer), client.ForceOwnership)
+}
+
+// ReleaseShared - remove the fields of obj set by the writer with
+// ApplyShared, and the owner reference to the object of the helper. obj is
+// deleted once none of its writers owns it anymore.
+func ReleaseShared(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	writer string,
+) error {
+	err := h.GetClient().Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	if k8s_errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, h.GetScheme())
+	if err != nil {
+		return err
+	}
+	// applying no fields removes the ones the writer owned
+	empty := &unstructured.Unstructured{}
+	empty.SetGroupVersionKind(gvk)
+	empty.SetName(obj.GetName())
+	empty.SetNamespace(obj.GetNamespace())
+	err = h.GetClient().Patch(ctx, empty, client.Apply,
+		client.FieldOwner(FieldManager+"-"+writer), client.ForceOwnership)
+	if err != nil {
+		return err
+	}
+	if len(empty.GetOwnerReferences()) > 0 {
+		return nil
+	}
+	err = h.GetClient().Delete(ctx, empty)
+	if k8s_errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// apply - server-side apply obj with the operator field manager
+func apply(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	opts ...client.PatchOption,
+) error {
+	gvk, err := apiutil.GVKForObject(obj, h.GetScheme())
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	if obj.GetNamespace() == h.GetBeforeObject().GetNamespace() {
+		err = controllerutil.SetControllerReference(h.GetBeforeObject(), obj, h.GetScheme())
+		if err != nil {
+			return err
+		}
+	}
+
+	opts = append(opts, client.FieldOwner(FieldManager), client.ForceOwnership)
+	return h.GetClient().Patch(ctx, obj, client.Apply, opts...)
+}
+
+// driftedFields - the fields the operator applies that it owned before and
+// that are now owned by another field manager. A field changed with an
+// update is owned by the updater instead of the previous managers.
+func driftedFields(before []metav1.ManagedFieldsEntry, after []metav1.ManagedFieldsEntry) ([]string, error) {
+	owned, err := appliedFields(before)
+	if err != nil || owned == nil {
+		return nil, err
+	}
+	applied, err := appliedFields(after)
+	if err != nil || applied == nil {
+		return nil, err
+	}
+
+	others := &fieldpath.Set{}
+	for _, entry := range before {
+		if entry.Manager == FieldManager || entry.Manager == legacyFieldManager ||
+			entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		set := &fieldpath.Set{}
+		err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw))
+		if err != nil {
+			return nil, err
+		}
+		others = others.Union(set)
+	}
+
+	drifted := []string{}
+	applied.Difference(owned).Intersection(others).Leaves().Iterate(func(path fieldpath.Path) {
+		drifted = append(drifted, path.String())
+	})
+	sort.Strings(drifted)
+	return drifted, nil
+}
+
+// appliedFields - the fields owned by the operator field manager, nil if it
+// never applied the object
+func appliedFields(entries []metav1.ManagedFieldsEntry) (*fieldpath.Set, error) {
+	for _, entry := range entries {
+		if entry.Manager != FieldManager || entry.Operation != metav1.ManagedFieldsOperationApply ||
+			entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		set := &fieldpath.Set{}
+		err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw))
+		if err != nil {
+			return nil, err
+		}
+		return set, nil
+	}
+	return nil, nil
+}
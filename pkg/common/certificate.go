@@ -0,0 +1,145 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"go.opentelemetry.io/otel/attribute"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CertificateGVK - cert-manager Certificate, handled as unstructured so the
+// operator does not depend on the cert-manager API
+var CertificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// ServiceDNSNames - DNS names of a service in the namespace
+func ServiceDNSNames(serviceName string, namespace string) []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc", serviceName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, namespace),
+	}
+}
+
+// ServiceCertDNSNames - DNS names of the service cert of a service in the
+// namespace, with the extra ones of tlsSection
+func ServiceCertDNSNames(tlsSection *ovnv1.TLSSection, serviceName string, namespace string) []string {
+	return append(ServiceDNSNames(serviceName, namespace), tlsSection.ExtraDNSNames...)
+}
+
+// EnsureCertificate - apply the cert-manager Certificate issuing the OVN dbs
+// cert into secretName from the issuer of tlsSection, with its lifetime.
+// cert-manager issues a new cert when the DNS names or the IP addresses
+// change.
+func EnsureCertificate(
+	ctx context.Context,
+	h *helper.Helper,
+	tlsSection *ovnv1.TLSSection,
+	secretName string,
+	commonName string,
+	dnsNames []string,
+	ipAddresses []string,
+) (err error) {
+	ctx, span := StartSpan(ctx, "EnsureCertificate", attribute.String("secret", secretName))
+	defer func() { EndSpan(span, err) }()
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(CertificateGVK)
+	cert.SetName(secretName)
+	cert.SetNamespace(h.GetBeforeObject().GetNamespace())
+
+	names := make([]interface{}, len(dnsNames))
+	for i, name := range dnsNames {
+		names[i] = name
+	}
+	spec := map[string]interface{}{
+		"secretName": secretName,
+		"commonName": commonName,
+		"dnsNames":   names,
+		"usages": []interface{}{
+			"key encipherment",
+			"digital signature",
+			"server auth",
+			"client auth",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  tlsSection.Issuer,
+			"kind":  tlsSection.IssuerRefKind(),
+			"group": CertificateGVK.Group,
+		},
+	}
+	if len(ipAddresses) > 0 {
+		ips := make([]interface{}, len(ipAddresses))
+		for i, ip := range ipAddresses {
+			ips[i] = ip
+		}
+		spec["ipAddresses"] = ips
+	}
+	if tlsSection.Duration != nil {
+		spec["duration"] = tlsSection.Duration.Duration.String()
+	}
+	if tlsSection.RenewBefore != nil {
+		spec["renewBefore"] = tlsSection.RenewBefore.Duration.String()
+	}
+	err = unstructured.SetNestedMap(cert.Object, spec, "spec")
+	if err != nil {
+		return err
+	}
+	return Apply(ctx, h, cert)
+}
+
+// DeleteStaleCertificates - remove the Certificates of the instance other
+// than the ones named keep, e.g. requested for a previous Issuer or secret,
+// so cert-manager stops renewing them
+func DeleteStaleCertificates(
+	ctx context.Context,
+	h *helper.Helper,
+	keep ...string,
+) error {
+	certs := &unstructured.UnstructuredList{}
+	certs.SetGroupVersionKind(CertificateGVK.GroupVersion().WithKind(CertificateGVK.Kind + "List"))
+	err := h.GetClient().List(ctx, certs, client.InNamespace(h.GetBeforeObject().GetNamespace()))
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("Error listing Certificates: %w", err)
+	}
+
+	for i := range certs.Items {
+		cert := &certs.Items[i]
+		if sets.New(keep...).Has(cert.GetName()) || !metav1.IsControlledBy(cert, h.GetBeforeObject()) {
+			continue
+		}
+		err := h.GetClient().Delete(ctx, cert)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("Error deleting Certificate %s: %w", cert.GetName(), err)
+		}
+		h.GetLogger().Info(fmt.Sprintf("Deleted stale Certificate %s", cert.GetName()))
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package common
 
 const (
-	OVNDbCertPath   string = "/etc/pki/tls/certs/ovndb.crt"
-	OVNDbKeyPath    string = "/etc/pki/tls/private/ovndb.key"
-	OVNDbCaCertPath string = "/etc/pki/tls/certs/ovndbca.crt"
+	// OVNDbCertDir - the OVN dbs cert secret is mounted as a directory, the
+	// kubelet updates its files when the secret is rotated
+	OVNDbCertDir    string = "/etc/pki/tls/ovndb"
+	OVNDbCertPath   string = OVNDbCertDir + "/tls.crt"
+	OVNDbKeyPath    string = OVNDbCertDir + "/tls.key"
+	OVNDbCaCertPath string = OVNDbCertDir + "/ca.crt"
 )
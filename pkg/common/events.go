@@ -0,0 +1,72 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+// Reasons of the Events recorded on the OVN CRs
+const (
+	// EventReasonReconcileError - the reconciliation failed and is retried
+	EventReasonReconcileError = "ReconcileError"
+	// EventReasonLeaderChanged - another RAFT member became the leader
+	EventReasonLeaderChanged = "LeaderChanged"
+	// EventReasonLeaderLost - none of the RAFT members is the leader anymore
+	EventReasonLeaderLost = "LeaderLost"
+	// EventReasonMemberRemoved - a RAFT member was removed on scale down
+	EventReasonMemberRemoved = "MemberRemoved"
+	// EventReasonFailover - another ovn-northd replica took the SB lock
+	EventReasonFailover = "Failover"
+	// EventReasonPaused - ovn-northd was paused
+	EventReasonPaused = "Paused"
+	// EventReasonResumed - ovn-northd was resumed
+	EventReasonResumed = "Resumed"
+	// EventReasonTLSRolledOut - the pods were restarted with a rotated TLS
+	// secret
+	EventReasonTLSRolledOut = "TLSRolledOut"
+	// EventReasonRolledBack - a failed DaemonSet rollout was rolled back
+	EventReasonRolledBack = "RolledBack"
+	// EventReasonMaintenanceStarted - the chassis of a node was cordoned for
+	// a maintenance
+	EventReasonMaintenanceStarted = "MaintenanceStarted"
+	// EventReasonMaintenanceEnded - the chassis of a node was uncordoned
+	// after a maintenance
+	EventReasonMaintenanceEnded = "MaintenanceEnded"
+	// EventReasonChassisDeleted - the stale chassis of a node was removed
+	// from the SB DB
+	EventReasonChassisDeleted = "ChassisDeleted"
+	// EventReasonDecommissionStarted - the gateway ports of the chassis of a
+	// node annotated for a decommission were drained
+	EventReasonDecommissionStarted = "DecommissionStarted"
+	// EventReasonChassisDecommissioned - the chassis of a node annotated for
+	// a decommission was removed from the SB DB
+	EventReasonChassisDecommissioned = "ChassisDecommissioned"
+	// EventReasonDriftReverted - manual changes of the fields set by the
+	// operator on a workload were reverted
+	EventReasonDriftReverted = "DriftReverted"
+	// EventReasonStaleSBRecordsFound - the janitor found stale records in
+	// the SB DB
+	EventReasonStaleSBRecordsFound = "StaleSBRecordsFound"
+	// EventReasonStaleSBRecordsDeleted - the janitor removed the stale
+	// records from the SB DB
+	EventReasonStaleSBRecordsDeleted = "StaleSBRecordsDeleted"
+	// EventReasonNodeFeatureDiscoveryMissing - node capabilities are
+	// required but Node Feature Discovery is not installed to label the nodes
+	EventReasonNodeFeatureDiscoveryMissing = "NodeFeatureDiscoveryMissing"
+	// EventReasonCommandRun - the command of an OVNCommand was run, the
+	// Event records the command line
+	EventReasonCommandRun = "CommandRun"
+	// EventReasonMemoryThresholdExceeded - ovn-controller was restarted by
+	// the memory watchdog
+	EventReasonMemoryThresholdExceeded = "MemoryThresholdExceeded"
+	// EventReasonHostNamespacesForbidden - the namespaces of the node
+	// requested for the pods were not shared, the operator forbids them
+	EventReasonHostNamespacesForbidden = "HostNamespacesForbidden"
+)
@@ -0,0 +1,77 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"go.opentelemetry.io/otel/attribute"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ExecFunc - runs a command in a pod and returns its stdout, ExecInPod
+// unless the callers are tested without pods
+type ExecFunc func(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	cmd []string,
+) (string, error)
+
+// ExecInPod - run a command in the first container of the pod and return
+// its stdout
+func ExecInPod(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	cmd []string,
+) (_ string, err error) {
+	ctx, span := StartSpan(ctx, "ExecInPod", attribute.String("pod", pod.Name), commandAttribute(cmd))
+	defer func() { EndSpan(span, err) }()
+
+	req := helper.GetKClient().CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: pod.Spec.Containers[0].Name,
+			Command:   cmd,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error running %v in pod %s: %w: %s", cmd, pod.Name, err, stderr.String())
+	}
+	return stdout.String(), nil
+}
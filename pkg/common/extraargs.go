@@ -0,0 +1,54 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sort"
+	"strings"
+)
+
+// ExtraArgs - the extra args of a container as command line options sorted
+// by name, --name=value or --name alone when the value is empty
+func ExtraArgs(args map[string]string) []string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	options := make([]string, 0, len(names))
+	for _, name := range names {
+		if args[name] == "" {
+			options = append(options, name)
+		} else {
+			options = append(options, name+"="+args[name])
+		}
+	}
+	return options
+}
+
+// ShellExtraArgs - the extra args quoted for a shell command line. The
+// values are only restricted by the webhooks, which may be off, so they are
+// passed to the daemons as they are and never run by the shell.
+func ShellExtraArgs(args map[string]string) []string {
+	options := ExtraArgs(args)
+	for i, option := range options {
+		options[i] = ShellQuote(option)
+	}
+	return options
+}
+
+// ShellQuote - s as a single quoted word of the shell
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
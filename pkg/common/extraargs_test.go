@@ -0,0 +1,44 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+)
+
+func TestExtraArgs(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ExtraArgs(map[string]string{"-vreconnect:dbg": "", "--n-handler-threads": "4"})).To(
+		Equal([]string{"--n-handler-threads=4", "-vreconnect:dbg"}))
+	g.Expect(ShellExtraArgs(map[string]string{"-vreconnect:dbg": "", "--n-handler-threads": "4"})).To(
+		Equal([]string{"'--n-handler-threads=4'", "'-vreconnect:dbg'"}))
+}
+
+func TestShellExtraArgsNotRun(t *testing.T) {
+	g := NewWithT(t)
+
+	// values the webhooks reject, passed as they are when they are off
+	args := map[string]string{
+		"--remote":  "x;touch injected",
+		"--pidfile": "$(id) `id` 'quoted' \"double\"",
+	}
+	script := `printf '%s\n' ` + strings.Join(ShellExtraArgs(args), " ")
+	out, err := exec.Command("/bin/sh", "-c", script).Output()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")).To(Equal(ExtraArgs(args)))
+}
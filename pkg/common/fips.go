@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// FIPSSSLProtocols - TLS protocols allowed in FIPS mode
+	FIPSSSLProtocols = "TLSv1.2"
+	// FIPSSSLCiphers - FIPS approved cipher suites
+	FIPSSSLCiphers = "ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:" +
+		"ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256"
+)
+
+// FIPSSSLArgs - command line options restricting the OVN daemons to the
+// FIPS approved TLS protocols and ciphers
+func FIPSSSLArgs() []string {
+	return []string{
+		fmt.Sprintf("--ssl-protocols=%s", FIPSSSLProtocols),
+		fmt.Sprintf("--ssl-ciphers=%s", FIPSSSLCiphers),
+	}
+}
+
+// FIPSProviderInactivePods - check the OpenSSL FIPS provider is active in
+// the images of the running pods. The kernel flag only tells the host runs
+// in FIPS mode, the OVN daemons get the approved algorithms from the FIPS
+// provider of the OpenSSL of their image. Returns the number of pods checked
+// and the ones without an active FIPS provider.
+func FIPSProviderInactivePods(
+	ctx context.Context,
+	h *helper.Helper,
+	restConfig *rest.Config,
+	pods []corev1.Pod,
+) (int, []string, error) {
+	checked := 0
+	inactive := []string{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		output, err := ExecInPod(ctx, h, restConfig, pod, []string{
+			"openssl", "list", "-providers",
+		})
+		if err != nil {
+			return checked, inactive, err
+		}
+		checked++
+		if !FIPSProviderActive(output) {
+			inactive = append(inactive, pod.Name)
+		}
+	}
+	return checked, inactive, nil
+}
+
+// FIPSProviderActive - whether the output of openssl list -providers lists
+// an active FIPS provider, e.g.
+//
+//	Providers:
+//	  fips
+//	    name: Red Hat Enterprise Linux 9 - OpenSSL FIPS Provider
+//	    version: 3.0.7-395c1a240fbfffd8
+//	    status: active
+func FIPSProviderActive(output string) bool {
+	fips := false
+	for _, line := range strings.Split(output, "\n") {
+		field := strings.TrimSpace(line)
+		switch {
+		case field == "" || field == "Providers:":
+		case !strings.Contains(field, ":"):
+			// a new provider, by id
+			fips = strings.EqualFold(field, "fips")
+		case strings.HasPrefix(field, "name:"):
+			fips = fips || strings.Contains(strings.ToUpper(field), "FIPS")
+		case strings.HasPrefix(field, "status:"):
+			if fips && strings.TrimSpace(strings.TrimPrefix(field, "status:")) == "active" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+)
+
+func TestFIPSProviderActive(t *testing.T) {
+	g := NewWithT(t)
+
+	// an image in FIPS mode only loads the FIPS and base providers
+	g.Expect(FIPSProviderActive(`Providers:
+  base
+    name: OpenSSL Base Provider
+    version: 3.0.7
+    status: active
+  fips
+    name: Red Hat Enterprise Linux 9 - OpenSSL FIPS Provider
+    version: 3.0.7-395c1a240fbfffd8
+    status: active
+`)).To(BeTrue())
+
+	// a non FIPS image on a FIPS host
+	g.Expect(FIPSProviderActive(`Providers:
+  default
+    name: OpenSSL Default Provider
+    version: 3.0.7
+    status: active
+`)).To(BeFalse())
+
+	g.Expect(FIPSProviderActive(`Providers:
+  fips
+    name: OpenSSL FIPS Provider
+    version: 3.0.7
+    status: inactive
+  default
+    name: OpenSSL Default Provider
+    version: 3.0.7
+    status: active
+`)).To(BeFalse())
+
+	g.Expect(FIPSProviderActive("")).To(BeFalse())
+}
@@ -0,0 +1,52 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
+)
+
+// JobTerminationMessage - return the message the last terminated pod of the
+// Job wrote to its termination log
+func JobTerminationMessage(
+	ctx context.Context,
+	helper *helper.Helper,
+	namespace string,
+	jobName string,
+) (string, error) {
+	podSelectorString := k8s_labels.Set{"job-name": jobName}.String()
+	podList, err := helper.GetKClient().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: podSelectorString})
+	if err != nil {
+		return "", err
+	}
+
+	message := ""
+	var finishedAt metav1.Time
+	for _, pod := range podList.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			state := cs.State.Terminated
+			if state == nil || state.FinishedAt.Before(&finishedAt) {
+				continue
+			}
+			finishedAt = state.FinishedAt
+			message = strings.TrimSpace(state.Message)
+		}
+	}
+	return message, nil
+}
@@ -0,0 +1,92 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// LeaderLabel - set on the pods of the operator, "true" on the leader
+	// and "false" on the standby replicas
+	LeaderLabel = "ovn.openstack.org/operator-leader"
+)
+
+// LeaderLabeler - a Runnable of the manager labeling the pod of the
+// operator with LeaderLabel, false on startup and true once elected. A
+// replica losing its lease exits, so the label never outlives the
+// leadership.
+type LeaderLabeler struct {
+	Client  client.Client
+	Pod     types.NamespacedName
+	Elected <-chan struct{}
+}
+
+// NeedLeaderElection - the standby replicas are labeled too
+func (l *LeaderLabeler) NeedLeaderElection() bool {
+	return false
+}
+
+// Start - label the pod as standby, then as leader once elected. Failures
+// are only logged, the label is informational.
+func (l *LeaderLabeler) Start(ctx context.Context) error {
+	if l.Pod.Name == "" {
+		return nil
+	}
+	l.label(ctx, false)
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-l.Elected:
+	}
+	l.label(ctx, true)
+	return nil
+}
+
+func (l *LeaderLabeler) label(ctx context.Context, leader bool) {
+	// a raw patch, the pods of the operator namespace may not be cached
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:"%t"}}}`, LeaderLabel, leader)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: l.Pod.Name, Namespace: l.Pod.Namespace}}
+	err := l.Client.Patch(ctx, pod, client.RawPatch(types.MergePatchType, []byte(patch)))
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to label the operator pod", "pod", l.Pod.Name, "leader", leader)
+	}
+}
+
+// CacheSyncedChecker - ready once the informers are synced. The caches of
+// the standby replicas are filled too, so a replica is only ready once it
+// can take the lease over without first listing all the objects, and a
+// rollout of the operator never stops the leader before a warm standby is
+// ready.
+func CacheSyncedChecker(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		// the probe doesn't wait for the sync
+		ctx, cancel := context.WithTimeout(req.Context(), 500*time.Millisecond)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return fmt.Errorf("the informers are not synced yet")
+		}
+		return nil
+	}
+}
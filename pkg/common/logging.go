@@ -0,0 +1,218 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LogLevels - logr verbosity per controller, e.g. ovncontroller=2. The
+// controller is taken from the "controller" value controller-runtime adds
+// to the logger of every reconcile, the other loggers use the default
+// level. The levels can be changed at runtime.
+type LogLevels struct {
+	mu           sync.RWMutex
+	defaultLevel zapcore.LevelEnabler
+	levels       map[string]int
+}
+
+// NewLogLevels - per controller verbosity, the loggers of the controllers
+// without a verbosity and the other loggers use defaultLevel
+func NewLogLevels(defaultLevel zapcore.LevelEnabler) *LogLevels {
+	return &LogLevels{
+		defaultLevel: defaultLevel,
+		levels:       map[string]int{},
+	}
+}
+
+// Set - replace the verbosity of the controllers with the given
+// comma separated controller=verbosity list
+func (l *LogLevels) Set(value string) error {
+	levels := map[string]int{}
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+		controller, level, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found {
+			return fmt.Errorf("invalid controller log level %q, expected controller=verbosity", item)
+		}
+		verbosity, err := strconv.Atoi(level)
+		if err != nil || verbosity < 0 {
+			return fmt.Errorf("invalid verbosity %q of controller %s", level, controller)
+		}
+		levels[controller] = verbosity
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levels = levels
+	return nil
+}
+
+// String - the controller=verbosity list
+func (l *LogLevels) String() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	items := []string{}
+	for controller, verbosity := range l.levels {
+		items = append(items, fmt.Sprintf("%s=%d", controller, verbosity))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (l *LogLevels) enabled(controller string, level zapcore.Level) bool {
+	l.mu.RLock()
+	verbosity, found := l.levels[controller]
+	l.mu.RUnlock()
+	if !found || controller == "" {
+		return l.defaultLevel.Enabled(level)
+	}
+	// logr V(n) is logged at zap level -n
+	return level >= zapcore.Level(-verbosity)
+}
+
+// WrapCore - filter the entries of the core by the level of their
+// controller. The core itself must enable all the levels.
+func (l *LogLevels) WrapCore(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: l}
+}
+
+// ServeHTTP - GET returns the controller=verbosity list, PUT replaces it
+func (l *LogLevels) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		body, err := io.ReadAll(io.LimitReader(req.Body, 4096))
+		if err == nil {
+			err = l.Set(string(body))
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "only GET and PUT are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	fmt.Fprintln(w, l.String())
+}
+
+// LogLevelsServer - a Runnable of the manager serving the LogLevels on
+// /log-levels. It has its own listener, bound to the loopback by default,
+// since a PUT changes the verbosity of the manager: the metrics endpoint
+// may be reachable from the whole cluster.
+type LogLevelsServer struct {
+	Addr      string
+	LogLevels *LogLevels
+}
+
+// NeedLeaderElection - the levels of the standby replicas can be changed too
+func (s *LogLevelsServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start - serve /log-levels until the manager stops
+func (s *LogLevelsServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return fmt.Errorf("error listening on %s for the log levels: %w", s.Addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/log-levels", s.LogLevels)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	err = server.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+type levelCore struct {
+	zapcore.Core
+	levels     *LogLevels
+	controller string
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.enabled(c.controller, level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	controller := c.controller
+	for _, field := range fields {
+		if field.Key == "controller" && field.Type == zapcore.StringType {
+			controller = field.String
+		}
+	}
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels, controller: controller}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// diffLogVerbosity - verbosity the changes of the objects are logged at
+const diffLogVerbosity = 2
+
+// NewDiffLoggingClient - client logging the patches and updates of the
+// objects at verbosity 2, to find out which reconcile keeps changing a
+// child object
+func NewDiffLoggingClient(c client.Client) client.Client {
+	return &diffLoggingClient{Client: c}
+}
+
+type diffLoggingClient struct {
+	client.Client
+}
+
+func (c *diffLoggingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if logger := log.FromContext(ctx).V(diffLogVerbosity); logger.Enabled() {
+		data, err := patch.Data(obj)
+		if err == nil {
+			logger.Info("Patching object", "kind", fmt.Sprintf("%T", obj),
+				"namespace", obj.GetNamespace(), "name", obj.GetName(), "patch", string(data))
+		}
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *diffLoggingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	log.FromContext(ctx).V(diffLogVerbosity).Info("Updating object", "kind", fmt.Sprintf("%T", obj),
+		"namespace", obj.GetNamespace(), "name", obj.GetName(), "resourceVersion", obj.GetResourceVersion())
+	return c.Client.Update(ctx, obj, opts...)
+}
@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogLevelsSet(t *testing.T) {
+	g := NewWithT(t)
+	levels := NewLogLevels(zapcore.InfoLevel)
+
+	g.Expect(levels.Set("ovndbcluster=1, ovncontroller=2\novnnorthd=0")).To(Succeed())
+	g.Expect(levels.String()).To(Equal("ovncontroller=2,ovndbcluster=1,ovnnorthd=0"))
+
+	// logr V(n) is logged at zap level -n
+	g.Expect(levels.enabled("ovncontroller", zapcore.Level(-2))).To(BeTrue())
+	g.Expect(levels.enabled("ovncontroller", zapcore.Level(-3))).To(BeFalse())
+	g.Expect(levels.enabled("ovnnorthd", zapcore.DebugLevel)).To(BeFalse())
+	// the other loggers use the default level
+	g.Expect(levels.enabled("ovntrace", zapcore.DebugLevel)).To(BeFalse())
+	g.Expect(levels.enabled("", zapcore.InfoLevel)).To(BeTrue())
+
+	// an invalid list leaves the levels as they are
+	g.Expect(levels.Set("ovncontroller")).ToNot(Succeed())
+	g.Expect(levels.Set("ovncontroller=debug")).ToNot(Succeed())
+	g.Expect(levels.Set("ovncontroller=-1")).ToNot(Succeed())
+	g.Expect(levels.String()).To(Equal("ovncontroller=2,ovndbcluster=1,ovnnorthd=0"))
+
+	g.Expect(levels.Set("")).To(Succeed())
+	g.Expect(levels.String()).To(BeEmpty())
+}
+
+func TestLogLevelsServeHTTP(t *testing.T) {
+	g := NewWithT(t)
+	levels := NewLogLevels(zapcore.InfoLevel)
+	g.Expect(levels.Set("ovncontroller=2")).To(Succeed())
+
+	serve := func(method string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		levels.ServeHTTP(w, httptest.NewRequest(method, "/log-levels", strings.NewReader(body)))
+		return w
+	}
+
+	w := serve(http.MethodGet, "")
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(Equal("ovncontroller=2\n"))
+
+	w = serve(http.MethodPut, "ovndbcluster=1")
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(Equal("ovndbcluster=1\n"))
+
+	w = serve(http.MethodPut, "ovndbcluster")
+	g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+	g.Expect(w.Body.String()).To(ContainSubstring("expected controller=verbosity"))
+	g.Expect(levels.String()).To(Equal("ovndbcluster=1"))
+
+	w = serve(http.MethodPost, "ovndbcluster=2")
+	g.Expect(w.Code).To(Equal(http.StatusMethodNotAllowed))
+	g.Expect(levels.String()).To(Equal("ovndbcluster=1"))
+}
@@ -0,0 +1,50 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// EnvString - the value of the environment variable, def when it is unset.
+// The manager flags default to them, so they can be set through the config
+// of an OLM Subscription.
+func EnvString(name string, def string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+	return def
+}
+
+// EnvDuration - the duration of the environment variable, def when it is
+// unset or not a duration
+func EnvDuration(name string, def time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil {
+		return def
+	}
+	return duration
+}
+
+// CheckLeaderElection - the lease must outlast the renew deadline, which
+// must outlast the retry period, as the leader election of client-go
+// requires
+func CheckLeaderElection(leaseDuration time.Duration, renewDeadline time.Duration, retryPeriod time.Duration) error {
+	if retryPeriod <= 0 || renewDeadline <= retryPeriod || leaseDuration <= renewDeadline {
+		return fmt.Errorf("the lease duration %s must be longer than the renew deadline %s, itself longer than the retry period %s",
+			leaseDuration, renewDeadline, retryPeriod)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SetPodNamespaces - share the namespaces of the node requested in the spec
+// with the pod. The pod keeps its own namespaces by default.
+func SetPodNamespaces(spec *corev1.PodSpec, namespaces ovnv1.PodNamespaces) {
+	spec.HostPID = namespaces.HostPID
+	spec.HostIPC = namespaces.HostIPC
+	if namespaces.ShareProcessNamespace {
+		shareProcessNamespace := true
+		spec.ShareProcessNamespace = &shareProcessNamespace
+	}
+}
+
+// ForbidHostNamespaces - unshare the PID and IPC namespaces of the node from
+// the pod, returns whether any of them was shared
+func ForbidHostNamespaces(spec *corev1.PodSpec) bool {
+	shared := spec.HostPID || spec.HostIPC
+	spec.HostPID = false
+	spec.HostIPC = false
+	return shared
+}
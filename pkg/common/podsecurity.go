@@ -0,0 +1,59 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SetRestrictedPodSecurity - render the pod to pass the restricted Pod
+// Security Admission profile: non-root, no privilege escalation, all
+// capabilities dropped and the runtime default seccomp profile. The user
+// itself is left to the image or the platform, e.g. the restricted-v2 SCC.
+func SetRestrictedPodSecurity(spec *corev1.PodSpec) {
+	trueVal := true
+
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	spec.SecurityContext.RunAsNonRoot = &trueVal
+	spec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{
+		Type: corev1.SeccompProfileTypeRuntimeDefault,
+	}
+
+	for i := range spec.InitContainers {
+		setRestrictedContainerSecurity(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		setRestrictedContainerSecurity(&spec.Containers[i])
+	}
+}
+
+func setRestrictedContainerSecurity(container *corev1.Container) {
+	falseVal := false
+	trueVal := true
+
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	sc := container.SecurityContext
+	sc.Privileged = nil
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		sc.RunAsUser = nil
+	}
+	sc.RunAsNonRoot = &trueVal
+	sc.AllowPrivilegeEscalation = &falseVal
+	sc.Capabilities = &corev1.Capabilities{
+		Drop: []corev1.Capability{"ALL"},
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PrometheusRuleGVK - prometheus-operator PrometheusRule, handled as
+// unstructured like the ServiceMonitor
+var PrometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+// AlertRule - a Prometheus alerting rule
+type AlertRule struct {
+	Alert       string
+	Expr        string
+	For         string
+	Severity    string
+	Summary     string
+	Description string
+}
+
+// EnsurePrometheusRule - apply the PrometheusRule holding the alerts in a
+// single rule group. Nothing is done when the PrometheusRule CRD is not
+// installed.
+func EnsurePrometheusRule(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	alerts []AlertRule,
+	labels map[string]string,
+) error {
+	pr := &unstructured.Unstructured{}
+	pr.SetGroupVersionKind(PrometheusRuleGVK)
+	pr.SetName(name)
+	pr.SetNamespace(h.GetBeforeObject().GetNamespace())
+	pr.SetLabels(labels)
+
+	rules := make([]interface{}, len(alerts))
+	for i, alert := range alerts {
+		rules[i] = map[string]interface{}{
+			"alert": alert.Alert,
+			"expr":  alert.Expr,
+			"for":   alert.For,
+			"labels": map[string]interface{}{
+				"severity": alert.Severity,
+			},
+			"annotations": map[string]interface{}{
+				"summary":     alert.Summary,
+				"description": alert.Description,
+			},
+		}
+	}
+	spec := map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  name,
+				"rules": rules,
+			},
+		},
+	}
+	err := unstructured.SetNestedMap(pr.Object, spec, "spec")
+	if err != nil {
+		return err
+	}
+	err = Apply(ctx, h, pr)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("Error creating PrometheusRule %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeletePrometheusRule - remove the PrometheusRule if it exists
+func DeletePrometheusRule(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+) error {
+	pr := &unstructured.Unstructured{}
+	pr.SetGroupVersionKind(PrometheusRuleGVK)
+	pr.SetName(name)
+	pr.SetNamespace(h.GetBeforeObject().GetNamespace())
+
+	err := h.GetClient().Delete(ctx, pr)
+	if err != nil && !k8s_errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("Error deleting PrometheusRule %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// EnsurePVC - create the ReadWriteOnce PVC the Jobs of the object of the
+// helper write their output to, it is deleted with the object. The spec of
+// an existing PVC is left as is.
+func EnsurePVC(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	namespace string,
+	storageClass string,
+	storageRequest string,
+) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, pvc)
+	if err == nil || !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	request, err := resource.ParseQuantity(storageRequest)
+	if err != nil {
+		return fmt.Errorf("invalid storageRequest %s: %w", storageRequest, err)
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			StorageClassName: &storageClass,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: request,
+				},
+			},
+		},
+	}
+	err = Apply(ctx, h, pvc)
+	if err != nil {
+		return fmt.Errorf("error creating PVC %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	// SCCPrivileged - SCC of the ovn-controller and OVS pods, they manage
+	// the datapath of the node
+	SCCPrivileged = "privileged"
+	// SCCRestricted - SCC of the DB, ovn-northd and interconnect pods
+	SCCRestricted = "restricted-v2"
+)
+
+// SCCRules - the Role rules of the ServiceAccount of a component, only
+// allowed to use its SCC. The pods don't call the Kubernetes API, the
+// operator execs into them and kube-rbac-proxy reviews the tokens through its
+// own ClusterRoleBinding.
+func SCCRules(scc string) []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{"security.openshift.io"},
+			ResourceNames: []string{scc},
+			Resources:     []string{"securitycontextconstraints"},
+			Verbs:         []string{"use"},
+		},
+	}
+}
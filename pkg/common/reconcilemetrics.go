@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ReconcileMetricsEnabled - whether the per custom resource reconcile
+// metrics are recorded, set from the manager flags. The per controller
+// metrics of controller-runtime are always served.
+var ReconcileMetricsEnabled = true
+
+var (
+	reconcileLabels = []string{"controller", "namespace", "name"}
+
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ovn_operator_reconcile_duration_seconds",
+		Help:    "Duration of the reconciliations of the custom resource",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, reconcileLabels)
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ovn_operator_reconcile_errors_total",
+		Help: "Number of reconciliations of the custom resource which returned an error",
+	}, reconcileLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration, reconcileErrors)
+}
+
+// ObserveReconcile - record the duration and the error of a reconciliation
+// of the custom resource started at start
+func ObserveReconcile(controller string, name types.NamespacedName, start time.Time, err error) {
+	if !ReconcileMetricsEnabled {
+		return
+	}
+	labels := prometheus.Labels{
+		"controller": controller,
+		"namespace":  name.Namespace,
+		"name":       name.Name,
+	}
+	reconcileDuration.With(labels).Observe(time.Since(start).Seconds())
+	counter := reconcileErrors.With(labels)
+	if err != nil {
+		counter.Inc()
+	}
+}
+
+// DeleteReconcileMetrics - remove the reconcile metrics of a deleted custom
+// resource
+func DeleteReconcileMetrics(controller string, name types.NamespacedName) {
+	labels := prometheus.Labels{
+		"controller": controller,
+		"namespace":  name.Namespace,
+		"name":       name.Name,
+	}
+	reconcileDuration.Delete(labels)
+	reconcileErrors.Delete(labels)
+}
@@ -0,0 +1,100 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ServiceMonitorGVK - prometheus-operator ServiceMonitor, handled as
+// unstructured so the operator does not depend on the prometheus-operator API
+var ServiceMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// EnsureServiceMonitor - apply the ServiceMonitor scraping the https
+// "metrics" port of the Services matching selectorLabels, served by
+// kube-rbac-proxy with a self signed cert. monitorLabels are added to the
+// ServiceMonitor to match the serviceMonitorSelector of Prometheus. Nothing
+// is done when the ServiceMonitor CRD is not installed.
+func EnsureServiceMonitor(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	selectorLabels map[string]string,
+	monitorLabels map[string]string,
+) error {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(ServiceMonitorGVK)
+	sm.SetName(name)
+	sm.SetNamespace(h.GetBeforeObject().GetNamespace())
+	sm.SetLabels(util.MergeStringMaps(selectorLabels, monitorLabels))
+
+	matchLabels := map[string]interface{}{}
+	for key, value := range selectorLabels {
+		matchLabels[key] = value
+	}
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"port":            "metrics",
+				"scheme":          "https",
+				"bearerTokenFile": "/var/run/secrets/kubernetes.io/serviceaccount/token",
+				"tlsConfig": map[string]interface{}{
+					"insecureSkipVerify": true,
+				},
+			},
+		},
+	}
+	err := unstructured.SetNestedMap(sm.Object, spec, "spec")
+	if err != nil {
+		return err
+	}
+	err = Apply(ctx, h, sm)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("Error creating ServiceMonitor %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteServiceMonitor - remove the ServiceMonitor if it exists
+func DeleteServiceMonitor(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+) error {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(ServiceMonitorGVK)
+	sm.SetName(name)
+	sm.SetNamespace(h.GetBeforeObject().GetNamespace())
+
+	err := h.GetClient().Delete(ctx, sm)
+	if err != nil && !k8s_errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("Error deleting ServiceMonitor %s: %w", name, err)
+	}
+	return nil
+}
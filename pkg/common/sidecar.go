@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// extraVolumePrefix - prefix of the ExtraVolumes in the pods, so they never
+// clash with the volumes of the operator
+const extraVolumePrefix = "extra-"
+
+// AddSidecars - append the sidecar containers and their ExtraVolumes to the
+// pod. The containers of the operator are kept when a sidecar has the same
+// name, the sidecars run unprivileged and only see the ExtraVolumes.
+func AddSidecars(spec *corev1.PodSpec, sidecars ovnv1.SidecarSection) {
+	if len(sidecars.Containers) == 0 {
+		return
+	}
+
+	for _, volume := range sidecars.ExtraVolumes {
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: extraVolumePrefix + volume.Name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: volume.ConfigMap,
+				Secret:    volume.Secret,
+				EmptyDir:  volume.EmptyDir,
+			},
+		})
+	}
+
+	for _, sidecar := range sidecars.Containers {
+		if hasContainer(spec, sidecar.Name) {
+			continue
+		}
+		mounts := []corev1.VolumeMount{}
+		for _, mount := range sidecar.VolumeMounts {
+			mounts = append(mounts, corev1.VolumeMount{
+				Name:      extraVolumePrefix + mount.Name,
+				MountPath: mount.MountPath,
+				SubPath:   mount.SubPath,
+				ReadOnly:  mount.ReadOnly,
+			})
+		}
+		container := corev1.Container{
+			Name:                     sidecar.Name,
+			Image:                    sidecar.Image,
+			Command:                  sidecar.Command,
+			Args:                     sidecar.Args,
+			Env:                      sidecar.Env,
+			Resources:                sidecar.Resources,
+			VolumeMounts:             mounts,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		}
+		setRestrictedContainerSecurity(&container)
+		spec.Containers = append(spec.Containers, container)
+	}
+}
+
+func hasContainer(spec *corev1.PodSpec, name string) bool {
+	for _, container := range spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
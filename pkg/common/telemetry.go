@@ -0,0 +1,153 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// telemetrySeparator - separates the coverage/show and memory/show outputs
+const telemetrySeparator = "---"
+
+// Telemetry - the coverage counters and memory usage of an OVN daemon
+type Telemetry struct {
+	// Coverage - total number of hits per coverage event, the events never
+	// hit are not reported
+	Coverage map[string]int64
+	// Memory - memory/show counters, e.g. idl-cells or lflow-cache-entries
+	Memory map[string]int64
+}
+
+// Coverage counters and memory usage of the OVN daemons, served on the
+// metrics endpoint of the operator. The coverage totals are reset when the
+// daemon restarts, like counters they are meant to be used with rate().
+var (
+	coverageEvents = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_coverage_events",
+		Help: "Total number of hits of the coverage event since the OVN daemon started",
+	}, []string{"namespace", "name", "component", "pod", "event"})
+	memoryUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_memory_usage",
+		Help: "Memory usage counter reported by memory/show of the OVN daemon",
+	}, []string{"namespace", "name", "component", "pod", "counter"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(coverageEvents, memoryUsage)
+}
+
+// CollectTelemetry - query coverage/show and memory/show of the OVN daemon
+// target, e.g. ovn-controller, running in the given pod
+func CollectTelemetry(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	target string,
+) (*Telemetry, error) {
+	cmd := []string{
+		"/bin/bash", "-c", fmt.Sprintf(
+			"ovn-appctl -t %[1]s coverage/show && echo '%[2]s' && ovn-appctl -t %[1]s memory/show",
+			target, telemetrySeparator),
+	}
+
+	output, err := ExecInPod(ctx, helper, restConfig, pod, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTelemetry(output), nil
+}
+
+// ParseTelemetry - parse the output of coverage/show followed by the
+// separator and the output of memory/show
+func ParseTelemetry(output string) *Telemetry {
+	telemetry := &Telemetry{
+		Coverage: map[string]int64{},
+		Memory:   map[string]int64{},
+	}
+	memory := false
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == telemetrySeparator {
+			memory = true
+			continue
+		}
+		if memory {
+			// name:count pairs, e.g. "idl-cells-OVN_Southbound:1234 ofctrl_sb_flow_ref_usage-KB:12"
+			for _, field := range strings.Fields(line) {
+				name, count, found := strings.Cut(field, ":")
+				if n, err := strconv.ParseInt(count, 10, 64); found && err == nil {
+					telemetry.Memory[name] = n
+				}
+			}
+			continue
+		}
+		// e.g. "lflow_run    0.0/sec     0.017/sec        0.0036/sec   total: 14"
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[len(fields)-2] != "total:" {
+			continue
+		}
+		if n, err := strconv.ParseInt(fields[len(fields)-1], 10, 64); err == nil {
+			telemetry.Coverage[fields[0]] = n
+		}
+	}
+	return telemetry
+}
+
+// SetTelemetryMetrics - replace the telemetry metrics of the component of
+// the instance with the given ones, keyed by pod name
+func SetTelemetryMetrics(namespace string, name string, component string, telemetry map[string]*Telemetry) {
+	DeleteTelemetryMetrics(namespace, name, component)
+
+	for pod, t := range telemetry {
+		labels := prometheus.Labels{
+			"namespace": namespace,
+			"name":      name,
+			"component": component,
+			"pod":       pod,
+		}
+		for event, count := range t.Coverage {
+			labels["event"] = event
+			coverageEvents.With(labels).Set(float64(count))
+		}
+		delete(labels, "event")
+		for counter, count := range t.Memory {
+			labels["counter"] = counter
+			memoryUsage.With(labels).Set(float64(count))
+		}
+	}
+}
+
+// DeleteTelemetryMetrics - remove the telemetry metrics of the component of
+// the instance
+func DeleteTelemetryMetrics(namespace string, name string, component string) {
+	labels := prometheus.Labels{
+		"namespace": namespace,
+		"name":      name,
+		"component": component,
+	}
+	coverageEvents.DeletePartialMatch(labels)
+	memoryUsage.DeletePartialMatch(labels)
+}
@@ -0,0 +1,44 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+const (
+	// TLSHashAnnotationPrefix - prefix of the pod template annotations holding
+	// the hash of a TLS secret which the pods only read on startup
+	TLSHashAnnotationPrefix = "tls.ovn.openstack.org/"
+	// TLSCABundleHashAnnotation - hash of the CA bundle secret
+	TLSCABundleHashAnnotation = TLSHashAnnotationPrefix + "ca-bundle"
+)
+
+// RolledOutTLSHashes - the TLS secret hashes all the pods of the DaemonSet
+// were started with, keyed by the annotation name without prefix. Returns
+// nil while a rollout is still in progress.
+func RolledOutTLSHashes(ds *appsv1.DaemonSet) map[string]string {
+	if !DaemonSetRolledOut(ds) {
+		return nil
+	}
+
+	hashes := map[string]string{}
+	for key, hash := range ds.Spec.Template.Annotations {
+		if name, found := strings.CutPrefix(key, TLSHashAnnotationPrefix); found {
+			hashes[name] = hash
+		}
+	}
+	return hashes
+}
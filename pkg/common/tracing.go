@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// tracerName - the instrumentation scope of the reconcile spans
+	tracerName = "github.com/openstack-k8s-operators/ovn-operator"
+	// tracingServiceName - the service the spans are exported for
+	tracingServiceName = "ovn-operator"
+	// maxCommandAttributeLength - the commands run in the pods are cut in
+	// the exec spans, some are whole scripts
+	maxCommandAttributeLength = 256
+)
+
+// SetupTracing - export the reconcile spans to the OTLP gRPC collector at
+// endpoint, host:port. Without it the spans are dropped by the no-op
+// provider of OpenTelemetry. Returns the function flushing the spans left
+// on shutdown.
+func SetupTracing(ctx context.Context, endpoint string, insecure bool) (func(context.Context) error, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(tracingServiceName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// StartSpan - start a span of a reconcile phase, the child of the span of
+// ctx, e.g. the one of the reconcile
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartReconcileSpan - start the span of the reconcile of the custom
+// resource by controller
+func StartReconcileSpan(ctx context.Context, controller string, name types.NamespacedName) (context.Context, trace.Span) {
+	return StartSpan(ctx, controller+".Reconcile",
+		attribute.String("controller", controller),
+		attribute.String("namespace", name.Namespace),
+		attribute.String("name", name.Name),
+	)
+}
+
+// EndSpan - end the span, with the error of the phase if it failed
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// commandAttribute - the command run in a pod, as recorded in the exec spans
+func commandAttribute(cmd []string) attribute.KeyValue {
+	command := strings.Join(cmd, " ")
+	if len(command) > maxCommandAttributeLength {
+		command = command[:maxCommandAttributeLength] + "..."
+	}
+	return attribute.String("command", command)
+}
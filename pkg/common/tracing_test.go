@@ -0,0 +1,69 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileSpans(t *testing.T) {
+	g := NewWithT(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(sdktrace.NewTracerProvider()) })
+
+	ctx, reconcileSpan := StartReconcileSpan(context.TODO(), "ovncontroller",
+		types.NamespacedName{Namespace: "openstack", Name: "ovncontroller"})
+	_, phaseSpan := StartSpan(ctx, "ApplyWorkload", attribute.String("kind", "DaemonSet"))
+	EndSpan(phaseSpan, errors.New("conflict"))
+	EndSpan(reconcileSpan, nil)
+
+	spans := recorder.Ended()
+	g.Expect(spans).To(HaveLen(2))
+	phase, reconcile := spans[0], spans[1]
+
+	g.Expect(reconcile.Name()).To(Equal("ovncontroller.Reconcile"))
+	g.Expect(reconcile.Attributes()).To(ContainElements(
+		attribute.String("controller", "ovncontroller"),
+		attribute.String("namespace", "openstack"),
+		attribute.String("name", "ovncontroller"),
+	))
+	g.Expect(reconcile.Status().Code).To(Equal(codes.Unset))
+
+	g.Expect(phase.Name()).To(Equal("ApplyWorkload"))
+	g.Expect(phase.Parent().SpanID()).To(Equal(reconcile.SpanContext().SpanID()))
+	g.Expect(phase.Status().Code).To(Equal(codes.Error))
+	g.Expect(phase.Status().Description).To(Equal("conflict"))
+}
+
+func TestCommandAttribute(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(commandAttribute([]string{"ovn-nbctl", "show"})).To(Equal(attribute.String("command", "ovn-nbctl show")))
+
+	script := strings.Repeat("x", maxCommandAttributeLength+10)
+	g.Expect(commandAttribute([]string{script}).Value.AsString()).To(HaveLen(maxCommandAttributeLength + len("...")))
+}
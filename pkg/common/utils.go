@@ -12,6 +12,16 @@ limitations under the License.
 
 package common
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
 // Helper function while can't use buildin min
 // TOOD(averdagu) remove when using go1.21.0
 func Min(x, y int) int {
@@ -20,3 +30,32 @@ func Min(x, y int) int {
 	}
 	return y
 }
+
+// CompareVersions - compare the dot separated numeric versions, e.g. OVSDB
+// schema versions, returns -1, 0 or 1. Missing components count as 0.
+func CompareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// InputHash - hash of the hashes of the inputs of the pods, e.g. the
+// ConfigMaps and Secrets they are restarted for
+func InputHash(envVars map[string]env.Setter) (string, error) {
+	return util.ObjectHash(env.MergeEnvs([]corev1.EnvVar{}, envVars))
+}
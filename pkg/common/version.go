@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// DaemonVersion - ask an OVN or OVS daemon running in the pod for its
+// version with the given appctl command, e.g. "ovn-northd 24.03.2" or
+// "ovs-vswitchd (Open vSwitch) 3.3.1" are reported as their last field
+func DaemonVersion(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	cmd []string,
+) (string, error) {
+	output, err := ExecInPod(ctx, helper, restConfig, pod, cmd)
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("no version reported by %v in pod %s", cmd, pod.Name)
+	}
+	return fields[len(fields)-1], nil
+}
+
+// RunningVersions - number of running pods per version of the daemon. The
+// pods running the same image run the same version, so the daemon is only
+// queried in one pod per image. Pods which can't be queried are skipped.
+func RunningVersions(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pods []corev1.Pod,
+	cmd []string,
+) map[string]int32 {
+	imageVersions := map[string]string{}
+	versions := map[string]int32{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		imageID := ""
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == pod.Spec.Containers[0].Name {
+				imageID = cs.ImageID
+			}
+		}
+		version, ok := imageVersions[imageID]
+		if !ok || imageID == "" {
+			var err error
+			version, err = DaemonVersion(ctx, helper, restConfig, pod, cmd)
+			if err != nil {
+				continue
+			}
+			imageVersions[imageID] = version
+		}
+		versions[version]++
+	}
+	return versions
+}
@@ -0,0 +1,40 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CreateOVNDbCertVolume - volume holding the OVN dbs cert, key and CA
+func CreateOVNDbCertVolume(secretName string, serviceID string) corev1.Volume {
+	svc := tls.Service{
+		SecretName: secretName,
+	}
+	return svc.CreateVolume(serviceID)
+}
+
+// CreateOVNDbCertVolumeMounts - mount the OVN dbs cert volume without
+// sub paths, so a rotated cert reaches the running daemons and they can
+// reload it in place
+func CreateOVNDbCertVolumeMounts(serviceID string) []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      serviceID + "-tls-certs",
+			MountPath: OVNDbCertDir,
+			ReadOnly:  true,
+		},
+	}
+}
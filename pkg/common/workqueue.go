@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// ParseMaxConcurrentReconciles - the number of objects reconciled in
+// parallel per controller from a comma separated controller=count list,
+// e.g. ovncontroller=4,ovndbcluster=2. controllers are the valid names.
+func ParseMaxConcurrentReconciles(value string, controllers []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' }) {
+		controller, count, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found {
+			return nil, fmt.Errorf("invalid concurrent reconciles %q, expected controller=count", item)
+		}
+		valid := false
+		for _, name := range controllers {
+			valid = valid || name == controller
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown controller %s, expected one of %s", controller, strings.Join(controllers, ", "))
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid concurrent reconciles %q of controller %s", count, controller)
+		}
+		counts[controller] = n
+	}
+	return counts, nil
+}
+
+// ControllerRateLimiter - the controller-runtime default rate limiter with
+// the given per object backoff. The delay of an object starts at baseDelay
+// and doubles with every failed reconcile up to maxDelay.
+func ControllerRateLimiter(baseDelay time.Duration, maxDelay time.Duration) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay),
+		// 10 qps, 100 bucket size, for all the objects of the controller
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
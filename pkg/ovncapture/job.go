@@ -0,0 +1,173 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncapture
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CaptureHash - hash key of the capture Job
+	CaptureHash = "capture"
+
+	// CaptureCommand - captures the packets of the port, or of all the ports
+	// of the bridge, with ovs-tcpdump until the duration or the size of the
+	// capture file is reached. ovs-tcpdump removes its mirror when
+	// interrupted. The packet counts of tcpdump go to the termination log.
+	CaptureCommand = `set -f
+if [ -n "${PORT}" ]; then
+    ARGS="-i ${PORT}"
+else
+    ARGS="-i ${OVS_BRIDGE} --span"
+fi
+FILE="/capture/${CAPTURE_FILE}"
+rm -f "${FILE}"
+
+ovs-tcpdump ${ARGS} -w "${FILE}" ${FILTER} 2> /tmp/tcpdump.log &
+PID=$!
+MAX_BYTES=$((MAX_SIZE_MB * 1024 * 1024))
+END=$((SECONDS + DURATION))
+while kill -0 ${PID} 2> /dev/null && [ ${SECONDS} -lt ${END} ]; do
+    if [ -f "${FILE}" ] && [ "$(stat -c %s "${FILE}")" -ge ${MAX_BYTES} ]; then
+        break
+    fi
+    sleep 1
+done
+kill -INT ${PID} 2> /dev/null
+wait ${PID}
+
+cat /tmp/tcpdump.log
+if ! grep "packets" /tmp/tcpdump.log > /dev/termination-log; then
+    tail -c 4096 /tmp/tcpdump.log > /dev/termination-log
+    exit 1
+fi
+`
+)
+
+// JobName - name of the Job capturing the packets
+func JobName(instance *ovnv1.OVNCapture) string {
+	return instance.Name + "-capture"
+}
+
+// PVCName - name of the PVC the capture file is written to
+func PVCName(instance *ovnv1.OVNCapture) string {
+	return instance.Name + "-capture"
+}
+
+// CaptureFileName - path of the capture file in the PVC
+func CaptureFileName(instance *ovnv1.OVNCapture) string {
+	return instance.Name + ".pcap"
+}
+
+// Target - what the instance captures, the port or all the ports of the
+// bridge
+func Target(instance *ovnv1.OVNCapture, ovnController *ovnv1.OVNController) string {
+	if instance.Spec.Port != "" {
+		return "port " + instance.Spec.Port
+	}
+	return "bridge " + Bridge(instance, ovnController)
+}
+
+// Bridge - the bridge whose ports are captured when no port is given
+func Bridge(instance *ovnv1.OVNCapture, ovnController *ovnv1.OVNController) string {
+	if instance.Spec.Bridge != "" {
+		return instance.Spec.Bridge
+	}
+	return ovnController.Spec.ExternalIDS.OvnBridge
+}
+
+// Summary - the packet counts tcpdump reported in the termination message
+func Summary(message string) string {
+	lines := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, ", ")
+}
+
+// CaptureJob - prepare the Job capturing the packets on the node of the
+// instance, against the OVS of the OVNController. ovs-tcpdump creates its
+// mirror port in the network namespace of the host, the Job runs there.
+func CaptureJob(
+	instance *ovnv1.OVNCapture,
+	ovnController *ovnv1.OVNController,
+	image string,
+) *batchv1.Job {
+	envVars := map[string]env.Setter{}
+	envVars["PORT"] = env.SetValue(instance.Spec.Port)
+	envVars["OVS_BRIDGE"] = env.SetValue(Bridge(instance, ovnController))
+	envVars["FILTER"] = env.SetValue(instance.Spec.Filter)
+	envVars["DURATION"] = env.SetValue(fmt.Sprintf("%d", instance.Spec.Duration))
+	envVars["MAX_SIZE_MB"] = env.SetValue(fmt.Sprintf("%d", instance.Spec.MaxSizeMB))
+	envVars["CAPTURE_FILE"] = env.SetValue(CaptureFileName(instance))
+
+	podSpec := ovncontroller.OVSClientPodSpec(ovnController, instance.Spec.Node, corev1.Container{
+		Name:    "capture",
+		Image:   image,
+		Command: []string{"/bin/bash", "-c", CaptureCommand},
+		Env:     env.MergeEnvs([]corev1.EnvVar{}, envVars),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "capture",
+				MountPath: "/capture",
+			},
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	})
+	podSpec.HostNetwork = true
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "capture",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: PVCName(instance),
+			},
+		},
+	})
+
+	labels := map[string]string{
+		common.AppSelector: JobName(instance),
+	}
+	backoffLimit := int32(0)
+	// the Job is killed when the capture does not stop by itself
+	activeDeadlineSeconds := int64(instance.Spec.Duration) + 300
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &activeDeadlineSeconds,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
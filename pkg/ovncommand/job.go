@@ -0,0 +1,156 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncommand
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CommandHash - hash key of the command Job
+	CommandHash = "command"
+
+	// RunCommand - runs the command line of the COMMAND env, the output goes
+	// to the pod log and its tail to the termination log
+	RunCommand = `set -eo pipefail
+${COMMAND} > /tmp/output
+cat /tmp/output
+tail -c 4096 /tmp/output > /dev/termination-log
+`
+)
+
+// command - a whitelisted read-only command
+type command struct {
+	// dbType - the OVN DB the command connects to, empty for the commands
+	// run against the OVS of a node
+	dbType string
+	args   []string
+}
+
+var commands = map[string]command{
+	ovnv1.OVNCommandNBShow:        {dbType: ovnv1.NBDBType, args: []string{"ovn-nbctl", "show"}},
+	ovnv1.OVNCommandSBShow:        {dbType: ovnv1.SBDBType, args: []string{"ovn-sbctl", "show"}},
+	ovnv1.OVNCommandSBListChassis: {dbType: ovnv1.SBDBType, args: []string{"ovn-sbctl", "list", "Chassis"}},
+	ovnv1.OVNCommandSBLflowList:   {dbType: ovnv1.SBDBType, args: []string{"ovn-sbctl", "lflow-list"}},
+	ovnv1.OVNCommandOVSShow:       {args: []string{"ovs-vsctl", "show"}},
+	ovnv1.OVNCommandOVSDumpFlows:  {args: []string{"ovs-ofctl", "dump-flows"}},
+}
+
+// DBType - the OVN DB the command of the instance connects to, empty when
+// it runs against the OVS of a node
+func DBType(instance *ovnv1.OVNCommand) string {
+	return commands[instance.Spec.Command].dbType
+}
+
+// CommandLine - the command line of the instance without the connection
+// options. The integration bridge of the OVNController is dumped by
+// ovs-dump-flows.
+func CommandLine(instance *ovnv1.OVNCommand, ovnController *ovnv1.OVNController) string {
+	args := commands[instance.Spec.Command].args
+	if instance.Spec.Command == ovnv1.OVNCommandOVSDumpFlows {
+		args = append(args[:len(args):len(args)], ovnController.Spec.ExternalIDS.OvnBridge)
+	}
+	return strings.Join(args, " ")
+}
+
+// JobName - name of the Job running the command
+func JobName(instance *ovnv1.OVNCommand) string {
+	return instance.Name + "-command"
+}
+
+// DBCommandJob - prepare the Job running the command against the OVN DB.
+// The client cert of the DB cluster is used to connect to it.
+func DBCommandJob(
+	instance *ovnv1.OVNCommand,
+	dbCluster *ovnv1.OVNDBCluster,
+	dbEndpoint string,
+	image string,
+) *batchv1.Job {
+	volumes, volumeMounts, sslArgs := ovndbcluster.ClientTLS(dbCluster)
+
+	// the options go before the command of the ctl utilities
+	args := commands[instance.Spec.Command].args
+	cmd := append([]string{args[0], "--no-leader-only", fmt.Sprintf("--db=%s", dbEndpoint)}, sslArgs...)
+	cmd = append(cmd, args[1:]...)
+
+	return commandJob(instance, corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers: []corev1.Container{
+			commandContainer(image, strings.Join(cmd, " "), volumeMounts),
+		},
+		Volumes: volumes,
+	})
+}
+
+// OVSCommandJob - prepare the Job running the command against the OVS of
+// the OVNController on the node of the instance
+func OVSCommandJob(
+	instance *ovnv1.OVNCommand,
+	ovnController *ovnv1.OVNController,
+	image string,
+) *batchv1.Job {
+	return commandJob(instance, ovncontroller.OVSClientPodSpec(
+		ovnController,
+		instance.Spec.Node,
+		commandContainer(image, CommandLine(instance, ovnController), nil),
+	))
+}
+
+func commandContainer(image string, commandLine string, volumeMounts []corev1.VolumeMount) corev1.Container {
+	envVars := map[string]env.Setter{}
+	envVars["COMMAND"] = env.SetValue(commandLine)
+
+	return corev1.Container{
+		Name:                     "command",
+		Image:                    image,
+		Command:                  []string{"/bin/bash", "-c", RunCommand},
+		Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
+		VolumeMounts:             volumeMounts,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
+
+func commandJob(instance *ovnv1.OVNCommand, podSpec corev1.PodSpec) *batchv1.Job {
+	labels := map[string]string{
+		common.AppSelector: JobName(instance),
+	}
+	backoffLimit := int32(0)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
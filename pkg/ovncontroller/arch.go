@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SetArchitectures - keep the pods off the nodes whose architecture isn't
+// one of the architectures the images are built for, any architecture when
+// none is set
+func SetArchitectures(podSpec *corev1.PodSpec, architectures []string) {
+	if len(architectures) == 0 {
+		return
+	}
+	podSpec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      corev1.LabelArchStable,
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   architectures,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// UnsupportedArchNodes - names of the nodes matching the node selector whose
+// architecture isn't one of the architectures, none when no architecture is
+// set
+func UnsupportedArchNodes(nodes []corev1.Node, nodeSelector map[string]string, architectures []string) []string {
+	if len(architectures) == 0 {
+		return nil
+	}
+	selector := labels.SelectorFromSet(nodeSelector)
+	var names []string
+	for _, node := range nodes {
+		if selector.Matches(labels.Set(node.Labels)) && !slices.Contains(architectures, node.Labels[corev1.LabelArchStable]) {
+			names = append(names, node.Name)
+		}
+	}
+	return names
+}
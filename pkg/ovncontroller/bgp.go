@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// BGPAgentConfigFile - the ovn-bgp-agent configuration in the BGP ConfigMap
+	BGPAgentConfigFile = "bgp-agent.conf"
+	bgpAgentConfigDir  = "/etc/ovn-bgp-agent"
+	// frrSocketDir - where vtysh looks for the vty sockets of FRR
+	frrSocketDir = "/run/frr"
+)
+
+// BGPConfigMapName - ConfigMap holding the ovn-bgp-agent configuration
+func BGPConfigMapName(instance *ovnv1.OVNController) string {
+	return instance.Name + "-bgp-config"
+}
+
+// BGPConfigOptions - parameters of the ovn-bgp-agent configuration template
+func BGPConfigOptions(instance *ovnv1.OVNController) map[string]interface{} {
+	return map[string]interface{}{
+		"ExposingMethod":       instance.Spec.BGP.ExposingMethod,
+		"ExposeTenantNetworks": instance.Spec.BGP.ExposeTenantNetworks,
+		"AS":                   instance.Spec.BGP.AS,
+		"TLS":                  instance.Spec.TLS.Enabled(),
+		"KeyPath":              ovn_common.OVNDbKeyPath,
+		"CertPath":             ovn_common.OVNDbCertPath,
+		"CACertPath":           ovn_common.OVNDbCaCertPath,
+	}
+}
+
+// getBGPAgentVolumes - the ovn-bgp-agent configuration and the FRR sockets
+// of the node
+func getBGPAgentVolumes(instance *ovnv1.OVNController) []corev1.Volume {
+	configVolumeDefaultMode := int32(0644)
+	// FRR is expected to run on the node already
+	directory := corev1.HostPathDirectory
+	return []corev1.Volume{
+		{
+			Name: "bgp-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &configVolumeDefaultMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: BGPConfigMapName(instance),
+					},
+				},
+			},
+		},
+		{
+			Name: "frr-sockets",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: instance.Spec.BGP.FRRSocketPath,
+					Type: &directory,
+				},
+			},
+		},
+	}
+}
+
+// getBGPAgentContainer - ovn-bgp-agent, watching the SB DB with the OVN
+// dbs cert mounted with tlsMounts and programming the routes of the node and
+// its FRR
+func getBGPAgentContainer(
+	instance *ovnv1.OVNController,
+	tlsMounts []corev1.VolumeMount,
+) corev1.Container {
+	runAsUser := int64(0)
+	privileged := true
+
+	mounts := append([]corev1.VolumeMount{
+		{
+			Name:      "var-run",
+			MountPath: "/var/run/openvswitch",
+			ReadOnly:  false,
+		},
+		{
+			Name:      "bgp-config",
+			MountPath: bgpAgentConfigDir,
+			ReadOnly:  true,
+		},
+		{
+			Name:      "frr-sockets",
+			MountPath: frrSocketDir,
+			ReadOnly:  false,
+		},
+	}, tlsMounts...)
+
+	return corev1.Container{
+		Name:    "ovn-bgp-agent",
+		Image:   instance.Spec.BGPAgentContainerImage,
+		Command: []string{"ovn-bgp-agent", "--config-dir", bgpAgentConfigDir},
+		SecurityContext: &corev1.SecurityContext{
+			// the agent adds the routes, rules and VRFs of the node
+			Capabilities: &corev1.Capabilities{
+				Add:  []corev1.Capability{"NET_ADMIN", "SYS_ADMIN"},
+				Drop: []corev1.Capability{},
+			},
+			RunAsUser:  &runAsUser,
+			Privileged: &privileged,
+		},
+		VolumeMounts:             mounts,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpdateStrategy - the DaemonSet update strategy of the given type
+func UpdateStrategy(strategy appsv1.DaemonSetUpdateStrategyType) appsv1.DaemonSetUpdateStrategy {
+	updateStrategy := appsv1.DaemonSetUpdateStrategy{Type: strategy}
+	if strategy == appsv1.RollingUpdateDaemonSetStrategyType {
+		updateStrategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{}
+	}
+	return updateStrategy
+}
+
+// SetUpdateStrategy - switch the update strategy of an existing DaemonSet.
+// During a canary rollout the DaemonSet is updated OnDelete, so the new pod
+// template only reaches the nodes whose pod got deleted.
+func SetUpdateStrategy(
+	ctx context.Context,
+	h *helper.Helper,
+	name types.NamespacedName,
+	strategy appsv1.DaemonSetUpdateStrategyType,
+) error {
+	ds := &appsv1.DaemonSet{}
+	err := h.GetClient().Get(ctx, name, ds)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if ds.Spec.UpdateStrategy.Type == strategy {
+		return nil
+	}
+
+	patch := client.MergeFrom(ds.DeepCopy())
+	ds.Spec.UpdateStrategy = UpdateStrategy(strategy)
+	err = h.GetClient().Patch(ctx, ds, patch)
+	if err != nil {
+		return fmt.Errorf("error setting the update strategy of DaemonSet %s: %w", name.Name, err)
+	}
+	h.GetLogger().Info(fmt.Sprintf("DaemonSet %s update strategy set to %s", name.Name, strategy))
+	return nil
+}
+
+// CanaryNodes - the nodes running an ovn-controller pod the new image is
+// rolled out to first, selected by the labels or the percentage of the
+// canary spec
+func CanaryNodes(
+	ctx context.Context,
+	h *helper.Helper,
+	canary *ovnv1.OVNControllerCanary,
+	pods []corev1.Pod,
+) ([]string, error) {
+	podNodes := map[string]bool{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			podNodes[pod.Spec.NodeName] = true
+		}
+	}
+
+	nodes := []string{}
+	if len(canary.NodeSelector) > 0 {
+		nodeList, err := h.GetKClient().CoreV1().Nodes().List(ctx, metav1.ListOptions{
+			LabelSelector: k8s_labels.Set(canary.NodeSelector).String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing the canary nodes: %w", err)
+		}
+		for _, node := range nodeList.Items {
+			if podNodes[node.Name] {
+				nodes = append(nodes, node.Name)
+			}
+		}
+		sort.Strings(nodes)
+		return nodes, nil
+	}
+
+	for node := range podNodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	count := (len(nodes)*int(canary.Percentage) + 99) / 100
+	if count < 1 && len(nodes) > 0 {
+		count = 1
+	}
+	return nodes[:count], nil
+}
+
+// RolloutCanary - delete the pods on the canary nodes which don't run the
+// latest pod template of their DaemonSet, one per zone with zones, and
+// return the canary nodes without a ready pod running it
+func RolloutCanary(
+	ctx context.Context,
+	h *helper.Helper,
+	daemonSets []*appsv1.DaemonSet,
+	pods []corev1.Pod,
+	nodes []string,
+) ([]string, error) {
+	canaryNodes := map[string]bool{}
+	for _, node := range nodes {
+		canaryNodes[node] = true
+	}
+
+	pending := []string{}
+	for i := range pods {
+		pod := &pods[i]
+		ds := podDaemonSet(daemonSets, pod)
+		if !canaryNodes[pod.Spec.NodeName] || ds == nil {
+			continue
+		}
+		delete(canaryNodes, pod.Spec.NodeName)
+		if pod.Labels[templateGenerationLabel] != ds.Annotations[templateGenerationAnnotation] {
+			pending = append(pending, pod.Spec.NodeName)
+			if !pod.DeletionTimestamp.IsZero() {
+				continue
+			}
+			err := h.GetClient().Delete(ctx, pod)
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return nil, fmt.Errorf("error deleting pod %s for the canary rollout: %w", pod.Name, err)
+			}
+			h.GetLogger().Info(fmt.Sprintf("Deleted pod %s to roll out the canary", pod.Name))
+			continue
+		}
+		if !isPodReady(pod) {
+			pending = append(pending, pod.Spec.NodeName)
+		}
+	}
+	// the pods of the remaining canary nodes are being recreated
+	for node := range canaryNodes {
+		pending = append(pending, node)
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
+
+// podDaemonSet - the DaemonSet whose selector matches the labels of the pod
+func podDaemonSet(daemonSets []*appsv1.DaemonSet, pod *corev1.Pod) *appsv1.DaemonSet {
+	for _, ds := range daemonSets {
+		if k8s_labels.SelectorFromSet(ds.Spec.Selector.MatchLabels).Matches(k8s_labels.Set(pod.Labels)) {
+			return ds
+		}
+	}
+	return nil
+}
+
+// ChassisReady - whether a healthy chassis registered with the hostname and
+// processed the latest SB_Global nb_cfg, i.e. ovn-controller installed the
+// flows of the latest changes
+func (inventory *ChassisInventory) ChassisReady(hostname string) bool {
+	for _, ch := range inventory.Chassis {
+		if ch.Hostname == hostname && inventory.healthy[ch.Name] && ch.CfgLag == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,288 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// chassisCmd - list the Chassis, Encap and Chassis_Private records and the
+// SB_Global nb_cfg from the local SB DB replica, each line prefixed with its
+// table
+const chassisCmd = "SBCTL='ovn-sbctl --no-leader-only --db=unix:/tmp/ovnsb_db.sock -f csv --data=bare --no-headings'; " +
+	"${SBCTL} --columns=name,hostname list Chassis | sed 's/^/Chassis,/' && " +
+	"${SBCTL} --columns=chassis_name,ip list Encap | sed 's/^/Encap,/' && " +
+	"${SBCTL} --columns=name,nb_cfg,nb_cfg_timestamp list Chassis_Private | sed 's/^/Chassis_Private,/' && " +
+	"${SBCTL} get SB_Global . nb_cfg | sed 's/^/SB_Global,nb_cfg,/'"
+
+// ChassisInventory - the chassis registered in the SB DB
+type ChassisInventory struct {
+	Chassis []ovnv1.OVNControllerChassis
+	// healthy - name of the chassis with an encap and a Chassis_Private record
+	healthy map[string]bool
+}
+
+// chassisCfgLag - number of nb_cfg updates the chassis did not process yet,
+// served on the metrics endpoint of the operator with every chassis status
+// refresh
+var chassisCfgLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ovn_chassis_cfg_lag",
+	Help: "Number of SB_Global nb_cfg updates the chassis has not processed yet",
+}, []string{"namespace", "name", "chassis", "hostname"})
+
+// chassisFlowInstallErrors - OpenFlow errors of the flow installations of
+// ovn-controller of the chassis, counted since the chassis status is enabled
+var chassisFlowInstallErrors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ovn_chassis_flow_install_errors",
+	Help: "Number of OpenFlow errors ovn-controller of the chassis logged for its flow installations",
+}, []string{"namespace", "name", "chassis", "hostname"})
+
+// chassisSBTransactionErrors - failed SB DB transactions of ovn-controller
+// of the chassis, counted since the chassis status is enabled
+var chassisSBTransactionErrors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ovn_chassis_sb_transaction_errors",
+	Help: "Number of failed SB DB transactions ovn-controller of the chassis logged",
+}, []string{"namespace", "name", "chassis", "hostname"})
+
+func init() {
+	metrics.Registry.MustRegister(chassisCfgLag, chassisFlowInstallErrors, chassisSBTransactionErrors)
+}
+
+// GetChassisInventory - list the chassis registered in the SB DB, queried
+// from the ovsdb-server running in the given SB DB pod
+func GetChassisInventory(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	sbPod *corev1.Pod,
+) (*ChassisInventory, error) {
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, sbPod, []string{"/bin/bash", "-c", chassisCmd})
+	if err != nil {
+		return nil, err
+	}
+	return ParseChassisInventory(output)
+}
+
+// ParseChassisInventory - parse the table prefixed csv records of chassisCmd
+func ParseChassisInventory(output string) (*ChassisInventory, error) {
+	chassis := map[string]*ovnv1.OVNControllerChassis{}
+	encaps := map[string]string{}
+	heartbeats := map[string]*metav1.Time{}
+	chassisCfg := map[string]int64{}
+	sbCfg := int64(0)
+
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.FieldsPerRecord = -1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 || record[1] == "" {
+			continue
+		}
+		switch record[0] {
+		case "Chassis":
+			chassis[record[1]] = &ovnv1.OVNControllerChassis{
+				Name:     record[1],
+				Hostname: record[2],
+			}
+		case "Encap":
+			encaps[record[1]] = record[2]
+		case "Chassis_Private":
+			if len(record) < 4 {
+				continue
+			}
+			chassisCfg[record[1]], _ = strconv.ParseInt(record[2], 10, 64)
+			heartbeats[record[1]] = nil
+			ms, err := strconv.ParseInt(record[3], 10, 64)
+			if err == nil && ms > 0 {
+				heartbeats[record[1]] = &metav1.Time{Time: time.UnixMilli(ms).UTC()}
+			}
+		case "SB_Global":
+			sbCfg, _ = strconv.ParseInt(record[2], 10, 64)
+		}
+	}
+
+	inventory := &ChassisInventory{
+		Chassis: []ovnv1.OVNControllerChassis{},
+		healthy: map[string]bool{},
+	}
+	for name, ch := range chassis {
+		ch.EncapIP = encaps[name]
+		heartbeat, found := heartbeats[name]
+		ch.LastHeartbeat = heartbeat
+		if found && sbCfg > chassisCfg[name] {
+			ch.CfgLag = sbCfg - chassisCfg[name]
+		}
+		inventory.healthy[name] = found && ch.EncapIP != ""
+		inventory.Chassis = append(inventory.Chassis, *ch)
+	}
+	sort.Slice(inventory.Chassis, func(i, j int) bool {
+		return inventory.Chassis[i].Name < inventory.Chassis[j].Name
+	})
+	return inventory, nil
+}
+
+// NodesWithoutChassis - nodes of the ovn-controller pods without a healthy
+// chassis registered with their hostname
+func (inventory *ChassisInventory) NodesWithoutChassis(pods []corev1.Pod) []string {
+	healthyHosts := map[string]bool{}
+	for _, ch := range inventory.Chassis {
+		if inventory.healthy[ch.Name] {
+			healthyHosts[ch.Hostname] = true
+		}
+	}
+
+	nodes := []string{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if !healthyHosts[pod.Spec.NodeName] {
+			nodes = append(nodes, pod.Spec.NodeName)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// DeleteChassis - remove a chassis with its Encap and Chassis_Private
+// records from the SB DB. The transaction is sent to the ovsdb-server of the
+// given SB DB pod, which forwards it to the RAFT leader.
+func DeleteChassis(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	sbPod *corev1.Pod,
+	name string,
+) error {
+	_, err := ovn_common.ExecInPod(ctx, helper, restConfig, sbPod, []string{
+		"ovn-sbctl", "--no-leader-only", "--db=unix:/tmp/ovnsb_db.sock",
+		"--if-exists", "chassis-del", name,
+		"--", "--if-exists", "destroy", "Chassis_Private", name,
+	})
+	return err
+}
+
+// ChassisOfNodes - the chassis registered with the hostname of one of the
+// nodes
+func (inventory *ChassisInventory) ChassisOfNodes(nodes []string) []ovnv1.OVNControllerChassis {
+	hosts := map[string]bool{}
+	for _, node := range nodes {
+		hosts[node] = true
+	}
+	chassis := []ovnv1.OVNControllerChassis{}
+	for _, ch := range inventory.Chassis {
+		if hosts[ch.Hostname] {
+			chassis = append(chassis, ch)
+		}
+	}
+	return chassis
+}
+
+// Remove - drop a chassis deleted from the SB DB from the inventory
+func (inventory *ChassisInventory) Remove(name string) {
+	chassis := []ovnv1.OVNControllerChassis{}
+	for _, ch := range inventory.Chassis {
+		if ch.Name != name {
+			chassis = append(chassis, ch)
+		}
+	}
+	inventory.Chassis = chassis
+	delete(inventory.healthy, name)
+}
+
+// SelectedNodes - names of the nodes matching the node selector, all of them
+// when it is empty
+func SelectedNodes(nodes []corev1.Node, nodeSelector map[string]string) []string {
+	selector := labels.SelectorFromSet(nodeSelector)
+	names := []string{}
+	for _, node := range nodes {
+		if selector.Matches(labels.Set(node.Labels)) {
+			names = append(names, node.Name)
+		}
+	}
+	return names
+}
+
+// UnselectedNodes - names of the nodes which match none of the node
+// selectors and don't run any of the pods anymore
+func UnselectedNodes(nodes []corev1.Node, nodeSelectors []map[string]string, pods []corev1.Pod) []string {
+	podNodes := map[string]bool{}
+	for _, pod := range pods {
+		podNodes[pod.Spec.NodeName] = true
+	}
+	selected := map[string]bool{}
+	for _, nodeSelector := range nodeSelectors {
+		for _, name := range SelectedNodes(nodes, nodeSelector) {
+			selected[name] = true
+		}
+	}
+	names := []string{}
+	for _, node := range nodes {
+		if !selected[node.Name] && !podNodes[node.Name] {
+			names = append(names, node.Name)
+		}
+	}
+	return names
+}
+
+// SetChassisMetrics - replace the nb_cfg lag metrics of the chassis of the
+// instance with the given chassis
+func SetChassisMetrics(instance *ovnv1.OVNController, chassis []ovnv1.OVNControllerChassis) {
+	DeleteChassisMetrics(instance)
+
+	for _, ch := range chassis {
+		labels := prometheus.Labels{
+			"namespace": instance.Namespace,
+			"name":      instance.Name,
+			"chassis":   ch.Name,
+			"hostname":  ch.Hostname,
+		}
+		chassisCfgLag.With(labels).Set(float64(ch.CfgLag))
+		chassisFlowInstallErrors.With(labels).Set(float64(ch.FlowInstallErrors))
+		chassisSBTransactionErrors.With(labels).Set(float64(ch.SBTransactionErrors))
+	}
+}
+
+// DeleteChassisMetrics - remove the chassis metrics of the instance
+func DeleteChassisMetrics(instance *ovnv1.OVNController) {
+	labels := prometheus.Labels{
+		"namespace": instance.Namespace,
+		"name":      instance.Name,
+	}
+	chassisCfgLag.DeletePartialMatch(labels)
+	chassisFlowInstallErrors.DeletePartialMatch(labels)
+	chassisSBTransactionErrors.DeletePartialMatch(labels)
+}
@@ -0,0 +1,200 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConfigAgentScript - the script of the config-agent container in the
+	// scripts ConfigMap
+	ConfigAgentScript = "config-agent.sh"
+
+	// NodeConfigLabel - label of the node config ConfigMaps, holding the
+	// name of their node
+	NodeConfigLabel = "ovn.openstack.org/node-config"
+
+	// NodeConfigKey - the binaryData key of a node config ConfigMap holding
+	// the config of the chassis. It is base64 encoded in the watch events
+	// read by the config agent, which doesn't need to unescape JSON.
+	NodeConfigKey = "config"
+)
+
+// NodeConfigMapName - ConfigMap holding the config of the chassis of the
+// node, applied by the config-agent container running on it
+func NodeConfigMapName(instance *ovnv1.OVNController, nodeName string) string {
+	return fmt.Sprintf("%s-node-config-%s", instance.Name, nodeName)
+}
+
+// NodeConfigMaps - the config of the chassis of each node running an
+// ovn-controller pod, a ConfigMap per node so that each config agent only
+// watches the config of its own node and no ConfigMap grows with the number
+// of nodes. The NodeConfigKey holds the environment of the config Job of the
+// node as shell assignments, sourced by the config agent.
+func NodeConfigMaps(
+	ctx context.Context,
+	k8sClient client.Client,
+	instance *ovnv1.OVNController,
+	sbCluster *ovnv1.OVNDBCluster,
+	labels map[string]string,
+) ([]*corev1.ConfigMap, error) {
+	ovnPods, err := getOVNControllerPods(
+		ctx,
+		k8sClient,
+		instance,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sbMembers, err := GetSBMembers(ctx, k8sClient, instance, sbCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars := configEnv(instance)
+	cms := []*corev1.ConfigMap{}
+	for _, ovnPod := range ovnPods.Items {
+		nodeName := ovnPod.Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+		nodeEnvVars, err := nodeConfig(ctx, k8sClient, instance, nodeName, sbMembers)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range envVars {
+			nodeEnvVars[name] = value
+		}
+
+		cmLabels := map[string]string{NodeConfigLabel: nodeName}
+		for key, value := range labels {
+			cmLabels[key] = value
+		}
+		cms = append(cms, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      NodeConfigMapName(instance, nodeName),
+				Namespace: instance.Namespace,
+				Labels:    cmLabels,
+			},
+			BinaryData: map[string][]byte{
+				NodeConfigKey: []byte(envFile(env.MergeEnvs([]corev1.EnvVar{}, nodeEnvVars))),
+			},
+		})
+	}
+	return cms, nil
+}
+
+// DeleteNodeConfigMaps - delete the node config ConfigMaps of the instance
+// other than the ones in keep
+func DeleteNodeConfigMaps(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	keep map[string]bool,
+) error {
+	cms := &corev1.ConfigMapList{}
+	err := h.GetClient().List(ctx, cms, client.InNamespace(instance.Namespace), client.HasLabels{NodeConfigLabel})
+	if err != nil {
+		return fmt.Errorf("error listing the node config ConfigMaps: %w", err)
+	}
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		if keep[cm.Name] || !metav1.IsControlledBy(cm, instance) {
+			continue
+		}
+		err := h.GetClient().Delete(ctx, cm)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting ConfigMap %s: %w", cm.Name, err)
+		}
+	}
+	return nil
+}
+
+// ConfigAgentRbacRules - the config agents watch the ConfigMap of their
+// node with the token of the OVS pods
+func ConfigAgentRbacRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+}
+
+// envFile - the env vars as shell assignments, sorted so the ConfigMap only
+// changes with the config
+func envFile(envVars []corev1.EnvVar) string {
+	sort.Slice(envVars, func(i, j int) bool {
+		return envVars[i].Name < envVars[j].Name
+	})
+	var b strings.Builder
+	for _, envVar := range envVars {
+		fmt.Fprintf(&b, "%s='%s'\n", envVar.Name, strings.ReplaceAll(envVar.Value, "'", `'\''`))
+	}
+	return b.String()
+}
+
+// getConfigAgentContainer - the config agent applying the config of the
+// chassis of its node with ovs-vsctl whenever the node config ConfigMap of
+// the node changes. The node is selected with the downward API. It runs the
+// OVS image, so the OVS pods are not restarted with a new ovn-controller
+// image.
+func getConfigAgentContainer(instance *ovnv1.OVNController) corev1.Container {
+	runAsUser := int64(0)
+	privileged := true
+
+	envVars := map[string]env.Setter{}
+	envVars["OVNHostName"] = EnvDownwardAPI("spec.nodeName")
+	envVars["NODE_CONFIG_MAP_PREFIX"] = env.SetValue(NodeConfigMapName(instance, ""))
+
+	return corev1.Container{
+		Name:    "config-agent",
+		Image:   instance.Spec.OvsContainerImage,
+		Command: []string{"/usr/local/bin/container-scripts/" + ConfigAgentScript},
+		SecurityContext: &corev1.SecurityContext{
+			// the MTU of the bridges is set with ip
+			RunAsUser:  &runAsUser,
+			Privileged: &privileged,
+		},
+		Env: env.MergeEnvs([]corev1.EnvVar{}, envVars),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "var-run",
+				MountPath: "/var/run/openvswitch",
+				ReadOnly:  false,
+			},
+			{
+				Name:      "scripts",
+				MountPath: "/usr/local/bin/container-scripts",
+				ReadOnly:  true,
+			},
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
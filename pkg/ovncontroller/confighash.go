@@ -0,0 +1,130 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ScriptsHashAnnotation - pod template annotation with the hash of the
+	// scripts the containers of the pod run
+	ScriptsHashAnnotation = "ovn.openstack.org/scripts-hash"
+	// OVSTuningHashAnnotation - pod template annotation with the hash of the
+	// tunnel MTU and the extra args of ovs-vswitchd
+	OVSTuningHashAnnotation = "ovn.openstack.org/ovs-tuning-hash"
+
+	// inputHashAnnotationPrefix - prefix of the pod template annotations
+	// with the hash of an input of the pods, ovn.openstack.org/<input>-hash
+	inputHashAnnotationPrefix = "ovn.openstack.org/"
+	// inputHashAnnotationSuffix - suffix of the pod template annotations with
+	// the hash of an input of the pods
+	inputHashAnnotationSuffix = "-hash"
+)
+
+// OVNScripts - the files of the scripts ConfigMap the ovn-controller pods run
+var OVNScripts = []string{"drain-gateway.sh", "functions"}
+
+// OVSScripts - the files of the scripts ConfigMap the OVS pods run
+var OVSScripts = []string{
+	"functions",
+	"start-ovsdb-server.sh",
+	"start-vswitchd.sh",
+	"stop-ovsdb-server.sh",
+	"stop-vswitchd.sh",
+}
+
+// ScriptsHash - hash of the files of the scripts ConfigMap, so the pods are
+// only restarted for the scripts they run
+func ScriptsHash(cm *corev1.ConfigMap, files []string) (string, error) {
+	scripts := make(map[string]string, len(files))
+	for _, file := range files {
+		scripts[file] = cm.Data[file]
+	}
+	hash, err := util.ObjectHash(scripts)
+	if err != nil {
+		return "", fmt.Errorf("error hashing the scripts of ConfigMap %s: %w", cm.Name, err)
+	}
+	return hash, nil
+}
+
+// ScriptsHashes - hashes of the scripts the ovn-controller and the OVS pods
+// run, read from the scripts ConfigMap
+func ScriptsHashes(cm *corev1.ConfigMap, instance *ovnv1.OVNController) (string, string, error) {
+	ovnHash, err := ScriptsHash(cm, OVNScripts)
+	if err != nil {
+		return "", "", err
+	}
+	ovsScripts := append([]string{}, OVSScripts...)
+	if instance.Spec.ConfigAgent {
+		ovsScripts = append(ovsScripts, ConfigAgentScript)
+	}
+	ovsHash, err := ScriptsHash(cm, ovsScripts)
+	if err != nil {
+		return "", "", err
+	}
+	return ovnHash, ovsHash, nil
+}
+
+// OVSTuningHash - hash of the settings of ovs-vswitchd passed to the OVS pods
+func OVSTuningHash(instance *ovnv1.OVNController) (string, error) {
+	return util.ObjectHash(map[string]interface{}{
+		"tunnelMTU": instance.Spec.TunnelMTU,
+		"extraArgs": ovn_common.ExtraArgs(instance.Spec.ExtraArgs.OVSVswitchd),
+	})
+}
+
+// InputHashes - the hashes of the inputs the pods of the DaemonSet are
+// started with, keyed by input, e.g. scripts, ovs-tuning or tls-ca-bundle
+func InputHashes(ds *appsv1.DaemonSet) map[string]string {
+	hashes := map[string]string{}
+	for key, hash := range ds.Spec.Template.Annotations {
+		if name, found := strings.CutPrefix(key, ovn_common.TLSHashAnnotationPrefix); found {
+			hashes["tls-"+name] = hash
+			continue
+		}
+		name, found := strings.CutPrefix(key, inputHashAnnotationPrefix)
+		if !found || !strings.HasSuffix(name, inputHashAnnotationSuffix) {
+			continue
+		}
+		hashes[strings.TrimSuffix(name, inputHashAnnotationSuffix)] = hash
+	}
+	return hashes
+}
+
+// ChangedInputs - the inputs whose hash differs between the two sets of
+// hashes, sorted
+func ChangedInputs(before map[string]string, after map[string]string) []string {
+	changed := []string{}
+	for name, hash := range after {
+		if before[name] != hash {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, found := after[name]; !found {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -15,6 +15,7 @@ package ovncontroller
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
@@ -23,10 +24,23 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 )
 
-// ConfigJob - prepare job to configure ovn-controller
+const (
+	// EncapIPAnnotation - set on a Node to the IP of the tunnel endpoint of
+	// its chassis, instead of the IP of the NIC of the network attachment
+	EncapIPAnnotation = "ovn.openstack.org/encap-ip"
+)
+
+// ConfigJob - prepare job to configure ovn-controller. The jobs apply the
+// external-ids of the chassis in place with ovs-vsctl, against the running
+// ovsdb-server of the node: the reconciler re-runs the job of a node when its
+// hash changes, and none of these settings are part of the pod templates, so
+// changing them doesn't roll the DaemonSets out.
 func ConfigJob(
 	ctx context.Context,
 	k8sClient client.Client,
@@ -51,21 +65,24 @@ func ConfigJob(
 		return nil, err
 	}
 
-	internalEndpoint, err := sbCluster.GetInternalEndpoint()
+	sbMembers, err := GetSBMembers(ctx, k8sClient, instance, sbCluster)
 	if err != nil {
 		return nil, err
 	}
 
-	envVars := map[string]env.Setter{}
-	envVars["OVNBridge"] = env.SetValue(instance.Spec.ExternalIDS.OvnBridge)
-	envVars["OVNRemote"] = env.SetValue(internalEndpoint)
-	envVars["OVNEncapType"] = env.SetValue(instance.Spec.ExternalIDS.OvnEncapType)
-	envVars["OVNAvailabilityZones"] = env.SetValue(strings.Join(instance.Spec.ExternalIDS.OvnAvailabilityZones, ":"))
-	envVars["EnableChassisAsGateway"] = env.SetValue(fmt.Sprintf("%t", *instance.Spec.ExternalIDS.EnableChassisAsGateway))
-	envVars["PhysicalNetworks"] = env.SetValue(getPhysicalNetworks(instance))
+	envVars := configEnv(instance)
 	envVars["OVNHostName"] = EnvDownwardAPI("spec.nodeName")
 
 	for _, ovnPod := range ovnPods.Items {
+		// the settings of the node only change its own job, and the jobs
+		// don't restart the pods
+		nodeEnvVars, err := nodeConfig(ctx, k8sClient, instance, ovnPod.Spec.NodeName, sbMembers)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range envVars {
+			nodeEnvVars[name] = value
+		}
 		jobs = append(
 			jobs,
 			&batchv1.Job{
@@ -79,7 +96,7 @@ func ConfigJob(
 					Template: corev1.PodTemplateSpec{
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyOnFailure,
-							ServiceAccountName: instance.RbacResourceName(),
+							ServiceAccountName: instance.ServiceAccountName(),
 							Containers: []corev1.Container{
 								{
 									Name:  "ovn-config",
@@ -92,7 +109,7 @@ func ConfigJob(
 										RunAsUser:  &runAsUser,
 										Privileged: &privileged,
 									},
-									Env:          env.MergeEnvs([]corev1.EnvVar{}, envVars),
+									Env:          env.MergeEnvs([]corev1.EnvVar{}, nodeEnvVars),
 									VolumeMounts: GetOVNControllerVolumeMounts(),
 									Resources:    instance.Spec.Resources,
 								},
@@ -108,3 +125,60 @@ func ConfigJob(
 
 	return jobs, nil
 }
+
+// configEnv - the environment of the config of the chassis shared by all the
+// nodes, used by the config Jobs and the node config ConfigMap
+func configEnv(instance *ovnv1.OVNController) map[string]env.Setter {
+	envVars := map[string]env.Setter{}
+	envVars["OVNBridge"] = env.SetValue(instance.Spec.ExternalIDS.OvnBridge)
+	envVars["OVNEncapType"] = env.SetValue(instance.Spec.ExternalIDS.OvnEncapType)
+	if instance.Spec.ExternalIDS.OvnEncapDstPort != 0 {
+		envVars["OVNEncapDstPort"] = env.SetValue(fmt.Sprintf("%d", instance.Spec.ExternalIDS.OvnEncapDstPort))
+	}
+	if instance.Spec.TunnelMTU != 0 {
+		envVars["TunnelMTU"] = env.SetValue(fmt.Sprintf("%d", instance.Spec.TunnelMTU))
+	}
+	envVars["OVNAvailabilityZones"] = env.SetValue(strings.Join(instance.Spec.ExternalIDS.OvnAvailabilityZones, ":"))
+	envVars["OVNIsInterconn"] = env.SetValue(fmt.Sprintf("%t", instance.Spec.ExternalIDS.OvnIsInterconn))
+	envVars["PhysicalNetworks"] = env.SetValue(getPhysicalNetworks(instance))
+	if preset, ok := instance.TuningPreset(); ok {
+		envVars["OVNRemoteProbeInterval"] = env.SetValue(fmt.Sprintf("%d", preset.RemoteProbeInterval))
+		envVars["OVNMonitorAll"] = env.SetValue(fmt.Sprintf("%t", preset.MonitorAll))
+		envVars["OVNLflowCacheMemLimitKB"] = env.SetValue(fmt.Sprintf("%d", preset.LflowCacheMemLimitKB))
+	} else if instance.Spec.ControlPlane {
+		envVars["OVNRemoteProbeInterval"] = env.SetValue(fmt.Sprintf("%d", ControlPlaneRemoteProbeInterval))
+	}
+	return envVars
+}
+
+// nodeConfig - the environment of the config job setting the gateway, the
+// encap IP and the SB DB remote of the chassis of the node from its labels
+// and annotations
+func nodeConfig(
+	ctx context.Context,
+	k8sClient client.Client,
+	instance *ovnv1.OVNController,
+	nodeName string,
+	sbMembers []SBMember,
+) (map[string]env.Setter, error) {
+	node := &corev1.Node{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting node %s: %w", nodeName, err)
+	}
+
+	gateway := *instance.Spec.ExternalIDS.EnableChassisAsGateway
+	if selector := instance.Spec.ExternalIDS.GatewayNodeSelector; len(selector) > 0 {
+		gateway = gateway && k8s_labels.SelectorFromSet(selector).Matches(k8s_labels.Set(node.Labels))
+	}
+	envVars := map[string]env.Setter{}
+	envVars["EnableChassisAsGateway"] = env.SetValue(fmt.Sprintf("%t", gateway))
+
+	encapIP := node.Annotations[EncapIPAnnotation]
+	if encapIP != "" && net.ParseIP(encapIP) == nil {
+		return nil, fmt.Errorf("invalid %s annotation %q of node %s", EncapIPAnnotation, encapIP, nodeName)
+	}
+	envVars["OVNEncapIP"] = env.SetValue(encapIP)
+	envVars["OVNRemote"] = env.SetValue(OVNRemote(instance, sbMembers, node.Labels[corev1.LabelTopologyZone]))
+	return envVars, nil
+}
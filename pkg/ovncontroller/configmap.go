@@ -0,0 +1,150 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"go.opentelemetry.io/otel/attribute"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// MetricsConfigTemplate - template of the ovn-controller exporter configuration
+	MetricsConfigTemplate = "/ovncontroller/metrics/openstack-network-exporter.yaml"
+	// OVSMetricsConfigTemplate - template of the OVS exporter configuration
+	OVSMetricsConfigTemplate = "/ovncontroller/metrics/openstack-network-exporter-ovs.yaml"
+	// bgpConfigTemplate - template of the ovn-bgp-agent configuration
+	bgpConfigTemplate = "/ovncontroller/bgp/bgp-agent.conf"
+)
+
+// ScriptsConfigMapName - ConfigMap holding the scripts of the ovn-controller
+// and OVS pods
+func ScriptsConfigMapName(instance *ovnv1.OVNController) string {
+	return fmt.Sprintf("%s-scripts", instance.Name)
+}
+
+// configMapLabels - labels of the ConfigMaps rendered from templates
+func configMapLabels(instance *ovnv1.OVNController) map[string]string {
+	return labels.GetLabels(instance, labels.GetGroupLabel(ovnv1.ServiceNameOVNController), map[string]string{})
+}
+
+// EnsureScriptsConfigMap - create or update the scripts ConfigMap, its hash
+// is added to envVars
+func EnsureScriptsConfigMap(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	envVars *map[string]env.Setter,
+) (err error) {
+	ctx, span := ovn_common.StartSpan(ctx, "EnsureScriptsConfigMap")
+	defer func() { ovn_common.EndSpan(span, err) }()
+
+	templateParameters := make(map[string]interface{})
+	if instance.Spec.NetworkAttachment != "" {
+		templateParameters["OVNEncapNIC"] = nad.GetNetworkIFName(instance.Spec.NetworkAttachment)
+	} else {
+		templateParameters["OVNEncapNIC"] = "eth0"
+	}
+	templateParameters["OVNDB_CERT_PATH"] = ovn_common.OVNDbCertPath
+	templateParameters["OVNDB_KEY_PATH"] = ovn_common.OVNDbKeyPath
+	templateParameters["OVNDB_CACERT_PATH"] = ovn_common.OVNDbCaCertPath
+	cms := []util.Template{
+		// ScriptsConfigMap
+		{
+			Name:          ScriptsConfigMapName(instance),
+			Namespace:     instance.Namespace,
+			Type:          util.TemplateTypeScripts,
+			InstanceType:  instance.Kind,
+			Labels:        configMapLabels(instance),
+			ConfigOptions: templateParameters,
+		},
+	}
+	return configmap.EnsureConfigMaps(ctx, h, instance, cms, envVars)
+}
+
+// EnsureMetricsConfigMap - create or update an exporter ConfigMap rendered
+// from configTemplate. Returns the hash of the exporter configuration.
+func EnsureMetricsConfigMap(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	configMapName string,
+	configTemplate string,
+) (_ string, err error) {
+	ctx, span := ovn_common.StartSpan(ctx, "EnsureMetricsConfigMap", attribute.String("name", configMapName))
+	defer func() { ovn_common.EndSpan(span, err) }()
+
+	cms := []util.Template{
+		{
+			Name:         configMapName,
+			Namespace:    instance.Namespace,
+			Type:         util.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			Labels:       configMapLabels(instance),
+			AdditionalTemplate: map[string]string{
+				ExporterConfigFile: configTemplate,
+			},
+			ConfigOptions: map[string]interface{}{
+				"ExporterPort": ExporterPort,
+			},
+		},
+	}
+	metricsVars := make(map[string]env.Setter)
+	err = configmap.EnsureConfigMaps(ctx, h, instance, cms, &metricsVars)
+	if err != nil {
+		return "", err
+	}
+	return util.ObjectHash(env.MergeEnvs([]corev1.EnvVar{}, metricsVars))
+}
+
+// EnsureBGPConfigMap - create or update the ovn-bgp-agent ConfigMap. Returns
+// the hash of the ovn-bgp-agent configuration.
+func EnsureBGPConfigMap(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+) (_ string, err error) {
+	ctx, span := ovn_common.StartSpan(ctx, "EnsureBGPConfigMap")
+	defer func() { ovn_common.EndSpan(span, err) }()
+
+	cms := []util.Template{
+		{
+			Name:         BGPConfigMapName(instance),
+			Namespace:    instance.Namespace,
+			Type:         util.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			Labels:       configMapLabels(instance),
+			AdditionalTemplate: map[string]string{
+				BGPAgentConfigFile: bgpConfigTemplate,
+			},
+			ConfigOptions: BGPConfigOptions(instance),
+		},
+	}
+	bgpVars := make(map[string]env.Setter)
+	err = configmap.EnsureConfigMaps(ctx, h, instance, cms, &bgpVars)
+	if err != nil {
+		return "", err
+	}
+	return util.ObjectHash(env.MergeEnvs([]corev1.EnvVar{}, bgpVars))
+}
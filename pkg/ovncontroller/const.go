@@ -1 +1,17 @@
 package ovncontroller
+
+const (
+	// MetricsPort - port of the metrics endpoint, served by kube-rbac-proxy
+	MetricsPort int32 = 1981
+	// ExporterPort - loopback port of the exporter behind kube-rbac-proxy
+	ExporterPort int32 = 1982
+	// MetricsConfigHashAnnotation - pod template annotation with the hash of
+	// the exporter configuration
+	MetricsConfigHashAnnotation = "ovn.openstack.org/metrics-config-hash"
+	// BGPConfigHashAnnotation - pod template annotation with the hash of the
+	// ovn-bgp-agent configuration
+	BGPConfigHashAnnotation = "ovn.openstack.org/bgp-config-hash"
+	// GatewayDrainCommand - moves the gateway ports off the node, or restores
+	// the chassis priorities
+	GatewayDrainCommand = "/usr/local/bin/container-scripts/drain-gateway.sh"
+)
@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ControlPlaneRemoteProbeInterval - probe interval of ovn-controller on
+	// its SB DB connection on the control plane nodes, in milliseconds, unless
+	// a tuning profile sets it
+	ControlPlaneRemoteProbeInterval = 120000
+
+	// controlPlaneProbeTimeout - timeout of the OVS liveness probes on the
+	// control plane nodes, in seconds
+	controlPlaneProbeTimeout = 10
+	// controlPlaneProbePeriod - period of the OVS liveness probes on the
+	// control plane nodes, in seconds
+	controlPlaneProbePeriod = 10
+	// controlPlaneProbeFailureThreshold - failed OVS liveness probes before
+	// the container is restarted on the control plane nodes
+	controlPlaneProbeFailureThreshold = 6
+)
+
+// controlPlaneTaints - the taints of the control plane nodes, the master one
+// is still set by older installers
+var controlPlaneTaints = []string{
+	"node-role.kubernetes.io/control-plane",
+	"node-role.kubernetes.io/master",
+}
+
+// SetControlPlane - let the pods run on the control plane nodes of compact
+// deployments: they tolerate the control plane taints, and their liveness
+// probes give ovsdb-server and ovs-vswitchd more time on busy nodes
+func SetControlPlane(podSpec *corev1.PodSpec) {
+	for _, taint := range controlPlaneTaints {
+		podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+			Key:      taint,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+	}
+	for i := range podSpec.Containers {
+		probe := podSpec.Containers[i].LivenessProbe
+		if probe == nil {
+			continue
+		}
+		probe.TimeoutSeconds = controlPlaneProbeTimeout
+		probe.PeriodSeconds = controlPlaneProbePeriod
+		probe.FailureThreshold = controlPlaneProbeFailureThreshold
+	}
+}
@@ -16,21 +16,101 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/utils/ptr"
 )
 
+// DaemonSetInputs - what the ovn-controller and OVS DaemonSets are built
+// from besides the spec, read from the cluster by the reconcile
+type DaemonSetInputs struct {
+	// Scripts - the scripts ConfigMap
+	Scripts *corev1.ConfigMap
+	// CABundleHash - hash of the CA bundle secret, empty without one
+	CABundleHash string
+	// MetricsConfigHash - hash of the ovn-controller exporter configuration,
+	// empty with its metrics disabled
+	MetricsConfigHash string
+	// OVSMetricsConfigHash - hash of the OVS exporter configuration, empty
+	// with its metrics disabled
+	OVSMetricsConfigHash string
+	// BGPConfigHash - hash of the ovn-bgp-agent configuration, empty without BGP
+	BGPConfigHash string
+	// NBEndpoint - internal endpoint of the NB DB, empty until it is ready
+	NBEndpoint string
+	// OVNUpdateStrategy - update strategy of the ovn-controller DaemonSet
+	OVNUpdateStrategy appsv1.DaemonSetUpdateStrategyType
+	// OVSUpdateStrategy - update strategy of the OVS DaemonSet
+	OVSUpdateStrategy appsv1.DaemonSetUpdateStrategyType
+}
+
+// DaemonSets - the ovn-controller and OVS DaemonSets of the instance, their
+// pods annotated with the hash of each of their inputs instead of a single
+// config hash, so a DaemonSet is only rolled out for the inputs it uses
+func DaemonSets(instance *ovnv1.OVNController, inputs DaemonSetInputs) (*appsv1.DaemonSet, *appsv1.DaemonSet, error) {
+	ovnScriptsHash, ovsScriptsHash, err := ScriptsHashes(inputs.Scripts, instance)
+	if err != nil {
+		return nil, nil, err
+	}
+	ovsTuningHash, err := OVSTuningHash(instance)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the inputs only used by the ovn-controller pods, e.g. the TLS secrets
+	// read on startup, don't restart the OVS pods
+	ovnAnnotations := map[string]string{
+		ScriptsHashAnnotation: ovnScriptsHash,
+	}
+	if inputs.CABundleHash != "" {
+		ovnAnnotations[ovn_common.TLSCABundleHashAnnotation] = inputs.CABundleHash
+	}
+	if inputs.MetricsConfigHash != "" {
+		ovnAnnotations[MetricsConfigHashAnnotation] = inputs.MetricsConfigHash
+	}
+	if inputs.BGPConfigHash != "" {
+		ovnAnnotations[BGPConfigHashAnnotation] = inputs.BGPConfigHash
+	}
+
+	networkAttachments := NetworkAttachments(instance)
+	ovsAnnotations, err := nad.CreateNetworksAnnotation(instance.Namespace, networkAttachments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create network annotation from %s: %w",
+			networkAttachments, err)
+	}
+	ovsAnnotations[ScriptsHashAnnotation] = ovsScriptsHash
+	ovsAnnotations[OVSTuningHashAnnotation] = ovsTuningHash
+	if inputs.OVSMetricsConfigHash != "" {
+		ovsAnnotations[MetricsConfigHashAnnotation] = inputs.OVSMetricsConfigHash
+	}
+
+	ovnDaemonSet := CreateOVNDaemonSet(
+		instance,
+		map[string]string{common.AppSelector: ovnv1.ServiceNameOVNController},
+		ovnAnnotations,
+		inputs.NBEndpoint)
+	ovnDaemonSet.Spec.UpdateStrategy = UpdateStrategy(inputs.OVNUpdateStrategy)
+
+	ovsDaemonSet := CreateOVSDaemonSet(
+		instance,
+		map[string]string{common.AppSelector: ovnv1.ServiceNameOVS},
+		ovsAnnotations)
+	ovsDaemonSet.Spec.UpdateStrategy = UpdateStrategy(inputs.OVSUpdateStrategy)
+
+	return ovnDaemonSet, ovsDaemonSet, nil
+}
+
 func CreateOVNDaemonSet(
 	instance *ovnv1.OVNController,
-	configHash string,
 	labels map[string]string,
+	annotations map[string]string,
+	nbEndpoint string,
 ) *appsv1.DaemonSet {
 	volumes := GetOVNControllerVolumes(instance.Name, instance.Namespace)
 	mounts := GetOVNControllerVolumeMounts()
@@ -41,14 +121,9 @@ func CreateOVNDaemonSet(
 
 	// add OVN dbs cert and CA
 	if instance.Spec.TLS.Enabled() {
-		svc := tls.Service{
-			SecretName: *instance.Spec.TLS.GenericService.SecretName,
-			CertMount:  ptr.To(ovn_common.OVNDbCertPath),
-			KeyMount:   ptr.To(ovn_common.OVNDbKeyPath),
-			CaMount:    ptr.To(ovn_common.OVNDbCaCertPath),
-		}
-		volumes = append(volumes, svc.CreateVolume(ovnv1.ServiceNameOVNController))
-		mounts = append(mounts, svc.CreateVolumeMounts(ovnv1.ServiceNameOVNController)...)
+		volumes = append(volumes, ovn_common.CreateOVNDbCertVolume(
+			*instance.Spec.TLS.GenericService.SecretName, ovnv1.ServiceNameOVNController))
+		mounts = append(mounts, ovn_common.CreateOVNDbCertVolumeMounts(ovnv1.ServiceNameOVNController)...)
 
 		// add CA bundle if defined
 		if instance.Spec.TLS.CaBundleSecretName != "" {
@@ -61,13 +136,41 @@ func CreateOVNDaemonSet(
 			fmt.Sprintf("--private-key=%s", ovn_common.OVNDbKeyPath),
 			fmt.Sprintf("--ca-cert=%s", ovn_common.OVNDbCaCertPath),
 		}...)
+
+		setSSL := fmt.Sprintf("ovs-vsctl set-ssl %s %s %s",
+			ovn_common.OVNDbKeyPath,
+			ovn_common.OVNDbCertPath,
+			ovn_common.OVNDbCaCertPath,
+		)
+		if instance.Spec.FIPS {
+			args = append(args, ovn_common.FIPSSSLArgs()...)
+			setSSL = fmt.Sprintf("%s %s %s", setSSL, ovn_common.FIPSSSLProtocols, ovn_common.FIPSSSLCiphers)
+		}
+
+		// ovn-controller checks the files referenced by the SSL table on
+		// every iteration and reloads them when they change, so a rotated
+		// cert is picked up without restarting the pod
+		args = append([]string{setSSL + " && exec"}, args...)
 	}
+	args = append(args, ovn_common.ShellExtraArgs(instance.Spec.ExtraArgs.OVNController)...)
 
 	runAsUser := int64(0)
 	privileged := true
 
 	envVars := map[string]env.Setter{}
-	envVars["CONFIG_HASH"] = env.SetValue(configHash)
+
+	preStopCmd := []string{"/usr/share/ovn/scripts/ovn-ctl", "stop_controller"}
+	var terminationGracePeriod *int64
+	if instance.Spec.GatewayDrain {
+		// the gateway ports are moved off the node before ovn-controller
+		// stops, and the chassis priorities restored before it starts
+		envVars["NB_REMOTE"] = env.SetValue(nbEndpoint)
+		envVars["GATEWAY_DRAIN_TIMEOUT"] = env.SetValue(fmt.Sprintf("%d", instance.Spec.GatewayDrainTimeout))
+		preStopCmd = []string{"/bin/bash", "-c", fmt.Sprintf("%s drain; %s", GatewayDrainCommand, strings.Join(preStopCmd, " "))}
+		args = append([]string{fmt.Sprintf("timeout 30 %s restore;", GatewayDrainCommand)}, args...)
+		gracePeriod := int64(instance.Spec.GatewayDrainTimeout) + 30
+		terminationGracePeriod = &gracePeriod
+	}
 
 	containers := []corev1.Container{
 		{
@@ -77,7 +180,7 @@ func CreateOVNDaemonSet(
 			Lifecycle: &corev1.Lifecycle{
 				PreStop: &corev1.LifecycleHandler{
 					Exec: &corev1.ExecAction{
-						Command: []string{"/usr/share/ovn/scripts/ovn-ctl", "stop_controller"},
+						Command: preStopCmd,
 					},
 				},
 			},
@@ -93,11 +196,39 @@ func CreateOVNDaemonSet(
 			Env:          env.MergeEnvs([]corev1.EnvVar{}, envVars),
 			VolumeMounts: mounts,
 			// TODO: consider the fact that resources are now double booked
-			Resources:                instance.Spec.Resources,
+			Resources:                instance.Spec.OvnControllerResources(),
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		},
 	}
 
+	if instance.Spec.Metrics.Enabled {
+		volumes = append(volumes, getMetricsVolume(MetricsConfigMapName(instance)))
+		containers = append(containers, getMetricsContainers(instance, []corev1.VolumeMount{
+			{
+				Name:      "var-run",
+				MountPath: "/var/run/openvswitch",
+				ReadOnly:  true,
+			},
+			{
+				Name:      "var-run-ovn",
+				MountPath: "/var/run/ovn",
+				ReadOnly:  true,
+			},
+		})...)
+	}
+
+	if instance.Spec.BGP != nil {
+		var tlsMounts []corev1.VolumeMount
+		if instance.Spec.TLS.Enabled() {
+			tlsMounts = ovn_common.CreateOVNDbCertVolumeMounts(ovnv1.ServiceNameOVNController)
+			if instance.Spec.TLS.CaBundleSecretName != "" {
+				tlsMounts = append(tlsMounts, instance.Spec.TLS.CreateVolumeMounts(nil)...)
+			}
+		}
+		volumes = append(volumes, getBGPAgentVolumes(instance)...)
+		containers = append(containers, getBGPAgentContainer(instance, tlsMounts))
+	}
+
 	daemonset := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ovnv1.ServiceNameOVNController,
@@ -109,27 +240,36 @@ func CreateOVNDaemonSet(
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: instance.RbacResourceName(),
-					Containers:         containers,
-					Volumes:            volumes,
+					ServiceAccountName:            instance.ServiceAccountName(),
+					Containers:                    containers,
+					Volumes:                       volumes,
+					TerminationGracePeriodSeconds: terminationGracePeriod,
 				},
 			},
 		},
 	}
 
-	if instance.Spec.NodeSelector != nil && len(instance.Spec.NodeSelector) > 0 {
-		daemonset.Spec.Template.Spec.NodeSelector = instance.Spec.NodeSelector
+	if nodeSelector := instance.Spec.NodesSelector(); len(nodeSelector) > 0 {
+		daemonset.Spec.Template.Spec.NodeSelector = nodeSelector
+	}
+
+	if instance.Spec.ControlPlane {
+		SetControlPlane(&daemonset.Spec.Template.Spec)
 	}
 
+	SetArchitectures(&daemonset.Spec.Template.Spec, instance.Spec.Architectures)
+	ovn_common.SetPodNamespaces(&daemonset.Spec.Template.Spec, instance.Spec.PodNamespaces.OVNController)
+	ovn_common.AddSidecars(&daemonset.Spec.Template.Spec, instance.Spec.Sidecars)
+
 	return daemonset
 }
 
 func CreateOVSDaemonSet(
 	instance *ovnv1.OVNController,
-	configHash string,
 	labels map[string]string,
 	annotations map[string]string,
 ) *appsv1.DaemonSet {
@@ -166,8 +306,15 @@ func CreateOVSDaemonSet(
 	runAsUser := int64(0)
 	privileged := true
 
-	envVars := map[string]env.Setter{}
-	envVars["CONFIG_HASH"] = env.SetValue(configHash)
+	// the tuning of ovs-vswitchd is passed to its start script in env vars,
+	// the scripts ConfigMap only changes with the scripts
+	vswitchdEnvVars := map[string]env.Setter{}
+	if instance.Spec.TunnelMTU > 0 {
+		vswitchdEnvVars["OVS_TUNNEL_MTU"] = env.SetValue(fmt.Sprintf("%d", instance.Spec.TunnelMTU))
+	}
+	if extraArgs := ovn_common.ShellExtraArgs(instance.Spec.ExtraArgs.OVSVswitchd); len(extraArgs) > 0 {
+		vswitchdEnvVars["OVS_VSWITCHD_EXTRA_ARGS"] = env.SetValue(strings.Join(extraArgs, " "))
+	}
 
 	containers := []corev1.Container{
 		{
@@ -190,10 +337,9 @@ func CreateOVSDaemonSet(
 				RunAsUser:  &runAsUser,
 				Privileged: &privileged,
 			},
-			Env:          env.MergeEnvs([]corev1.EnvVar{}, envVars),
 			VolumeMounts: GetOVSDbVolumeMounts(),
 			// TODO: consider the fact that resources are now double booked
-			Resources:                instance.Spec.Resources,
+			Resources:                instance.Spec.OvsdbServerResources(),
 			LivenessProbe:            ovsDbLivenessProbe,
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		},
@@ -216,15 +362,30 @@ func CreateOVSDaemonSet(
 				RunAsUser:  &runAsUser,
 				Privileged: &privileged,
 			},
-			Env:          env.MergeEnvs([]corev1.EnvVar{}, envVars),
+			Env:          env.MergeEnvs([]corev1.EnvVar{}, vswitchdEnvVars),
 			VolumeMounts: GetVswitchdVolumeMounts(),
 			// TODO: consider the fact that resources are now double booked
-			Resources:                instance.Spec.Resources,
+			Resources:                instance.Spec.OvsVswitchdResources(),
 			LivenessProbe:            ovsVswitchdLivenessProbe,
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		},
 	}
 
+	volumes := GetOVSVolumes(instance.Name, instance.Namespace)
+	if instance.Spec.ConfigAgent {
+		containers = append(containers, getConfigAgentContainer(instance))
+	}
+	if instance.Spec.Metrics.OVSEnabled {
+		volumes = append(volumes, getMetricsVolume(OVSMetricsConfigMapName(instance)))
+		containers = append(containers, getMetricsContainers(instance, []corev1.VolumeMount{
+			{
+				Name:      "var-run",
+				MountPath: "/var/run/openvswitch",
+				ReadOnly:  true,
+			},
+		})...)
+	}
+
 	daemonset := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ovnv1.ServiceNameOVS,
@@ -239,21 +400,28 @@ func CreateOVSDaemonSet(
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: instance.RbacResourceName(),
+					ServiceAccountName: instance.OVSServiceAccountName(),
 					Containers:         containers,
-					Volumes:            GetOVSVolumes(instance.Name, instance.Namespace),
+					Volumes:            volumes,
 				},
 			},
 		},
 	}
 
-	if instance.Spec.NodeSelector != nil && len(instance.Spec.NodeSelector) > 0 {
-		daemonset.Spec.Template.Spec.NodeSelector = instance.Spec.NodeSelector
+	if nodeSelector := instance.Spec.NodesSelector(); len(nodeSelector) > 0 {
+		daemonset.Spec.Template.Spec.NodeSelector = nodeSelector
 	}
 
 	if len(annotations) > 0 {
 		daemonset.Spec.Template.ObjectMeta.Annotations = annotations
 	}
 
+	if instance.Spec.ControlPlane {
+		SetControlPlane(&daemonset.Spec.Template.Spec)
+	}
+
+	SetArchitectures(&daemonset.Spec.Template.Spec, instance.Spec.Architectures)
+	ovn_common.SetPodNamespaces(&daemonset.Spec.Template.Spec, instance.Spec.PodNamespaces.OVS)
+
 	return daemonset
 }
@@ -0,0 +1,53 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DecommissionAnnotation - set to "true" on a Node before removing it
+	// from the NodeSelector or the cluster. Its gateway ports are drained
+	// right away, and its chassis is deleted from the SB DB once its
+	// ovn-controller pod is gone.
+	DecommissionAnnotation = "ovn.openstack.org/decommission"
+)
+
+// DecommissionNodes - the names of the existing nodes annotated for a
+// decommission, and the set of the names of all the existing nodes
+func DecommissionNodes(
+	ctx context.Context,
+	h *helper.Helper,
+) ([]string, map[string]bool, error) {
+	nodeList, err := h.GetKClient().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing the nodes: %w", err)
+	}
+	nodes := []string{}
+	existing := map[string]bool{}
+	for _, node := range nodeList.Items {
+		existing[node.Name] = true
+		if node.Annotations[DecommissionAnnotation] == "true" {
+			nodes = append(nodes, node.Name)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes, existing, nil
+}
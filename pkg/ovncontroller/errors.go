@@ -0,0 +1,98 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// flowInstallErrorLog - logged by ofctrl for the errors ovs-vswitchd
+	// replies to the flow modifications of ovn-controller
+	flowInstallErrorLog = "|ofctrl|"
+	// openFlowErrorMessage - message of the OpenFlow error replies
+	openFlowErrorMessage = "OpenFlow error"
+	// transactionErrorLog - logged by the IDL for failed transactions. Next
+	// to the SB DB ovn-controller only commits the few bridge and interface
+	// updates of the local OVS DB, which hardly ever fail.
+	transactionErrorLog = "|ovsdb_idl|"
+	// transactionErrorMessage - message of the failed transactions
+	transactionErrorMessage = "transaction error"
+)
+
+// ChassisErrors - errors ovn-controller logged on its node
+type ChassisErrors struct {
+	// FlowInstall - OpenFlow errors replied to the flow installations
+	FlowInstall int64
+	// SBTransaction - failed transactions with the SB DB
+	SBTransaction int64
+}
+
+// GetChassisErrors - count the errors in the log of the ovn-controller
+// container of the pod, logged from since (from the start when nil) until
+// before until
+func GetChassisErrors(
+	ctx context.Context,
+	helper *helper.Helper,
+	pod *corev1.Pod,
+	since *metav1.Time,
+	until time.Time,
+) (ChassisErrors, error) {
+	stream, err := helper.GetKClient().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  ovnv1.ServiceNameOVNController,
+		Timestamps: true,
+		SinceTime:  since,
+	}).Stream(ctx)
+	if err != nil {
+		return ChassisErrors{}, err
+	}
+	defer stream.Close()
+	return ParseChassisErrors(stream, since, until)
+}
+
+// ParseChassisErrors - count the errors in the log lines prefixed with their
+// timestamp in the window. The lines logged during the last second of the
+// previous window are returned again by the API, as SinceTime is truncated
+// to the second, and filtered out here.
+func ParseChassisErrors(log io.Reader, since *metav1.Time, until time.Time) (ChassisErrors, error) {
+	errors := ChassisErrors{}
+	scanner := bufio.NewScanner(log)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		timestamp, line, found := strings.Cut(scanner.Text(), " ")
+		if !found {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil || (since != nil && t.Before(since.Time)) || !t.Before(until) {
+			continue
+		}
+		switch {
+		case strings.Contains(line, flowInstallErrorLog) && strings.Contains(line, openFlowErrorMessage):
+			errors.FlowInstall++
+		case strings.Contains(line, transactionErrorLog) && strings.Contains(line, transactionErrorMessage):
+			errors.SBTransaction++
+		}
+	}
+	return errors, scanner.Err()
+}
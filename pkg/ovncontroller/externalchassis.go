@@ -0,0 +1,179 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ExternalChassisLabel - label of the ConfigMaps of the external chassis,
+	// set to the name of the chassis
+	ExternalChassisLabel = "ovn.openstack.org/external-chassis"
+
+	// ExternalChassisConfigKey - key of the ConfigMap of an external chassis
+	// holding the external-ids to set in the OVS DB of the node
+	ExternalChassisConfigKey = "ovsdb-config"
+	// ExternalChassisCertSecretKey - key of the ConfigMap of an external
+	// chassis holding the secret of its client cert
+	ExternalChassisCertSecretKey = "cert-secret"
+	// ExternalChassisCABundleSecretKey - key of the ConfigMap of an external
+	// chassis holding the secret of the CA bundle to verify the SB DB with
+	ExternalChassisCABundleSecretKey = "ca-bundle-secret"
+)
+
+// ExternalChassisConfigMapName - name of the ConfigMap of the ovn-controller
+// configuration of an external chassis
+func ExternalChassisConfigMapName(instance *ovnv1.OVNController, chassis string) string {
+	return fmt.Sprintf("%s-%s-config", instance.Name, chassis)
+}
+
+// ExternalChassisCertificateNames - names of the Certificates of the client
+// certs of the external chassis, issued into the secrets of the same name.
+// Empty when the certs are not requested by the operator.
+func ExternalChassisCertificateNames(instance *ovnv1.OVNController) []string {
+	if instance.Spec.TLS.CertificateName() == "" {
+		return nil
+	}
+	names := make([]string, len(instance.Spec.ExternalChassis))
+	for i, chassis := range instance.Spec.ExternalChassis {
+		names[i] = fmt.Sprintf("cert-%s-%s", instance.Name, chassis.Name)
+	}
+	return names
+}
+
+// externalChassisConfig - the external-ids of an external chassis, in the
+// format of the ovsdb-config of the ovn-controller ConfigMap
+func externalChassisConfig(
+	instance *ovnv1.OVNController,
+	chassis ovnv1.OVNExternalChassis,
+	ovnRemote string,
+) string {
+	config := []string{
+		fmt.Sprintf("ovn-remote: %s", ovnRemote),
+		fmt.Sprintf("ovn-encap-type: %s", instance.Spec.ExternalIDS.OvnEncapType),
+		fmt.Sprintf("system-id: %s", chassis.Name),
+	}
+	if instance.Spec.ExternalIDS.OvnEncapDstPort != 0 {
+		config = append(config, fmt.Sprintf("ovn-encap-dst-port: %d", instance.Spec.ExternalIDS.OvnEncapDstPort))
+	}
+	if chassis.EncapIP != "" {
+		config = append(config, fmt.Sprintf("ovn-encap-ip: %s", chassis.EncapIP))
+	}
+	return strings.Join(config, "\n") + "\n"
+}
+
+// EnsureExternalChassis - request the client certs of the external chassis
+// and publish their ovn-controller configuration, connecting them to the
+// external endpoint of the SB DB. The ConfigMaps of the chassis removed from
+// the spec are deleted.
+func EnsureExternalChassis(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	sbCluster *ovnv1.OVNDBCluster,
+) ([]ovnv1.OVNExternalChassisStatus, error) {
+	ovnRemote, err := sbCluster.GetExternalEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	certNames := ExternalChassisCertificateNames(instance)
+
+	statuses := []ovnv1.OVNExternalChassisStatus{}
+	keep := map[string]bool{}
+	for i, chassis := range instance.Spec.ExternalChassis {
+		status := ovnv1.OVNExternalChassisStatus{
+			Name:      chassis.Name,
+			ConfigMap: ExternalChassisConfigMapName(instance, chassis.Name),
+		}
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      status.ConfigMap,
+				Namespace: instance.Namespace,
+				Labels:    map[string]string{ExternalChassisLabel: chassis.Name},
+			},
+			Data: map[string]string{
+				ExternalChassisConfigKey: externalChassisConfig(instance, chassis, ovnRemote),
+			},
+		}
+		if certNames != nil {
+			status.CertSecretName = certNames[i]
+			err := ovn_common.EnsureCertificate(
+				ctx,
+				h,
+				&instance.Spec.TLS,
+				status.CertSecretName,
+				chassis.Name,
+				[]string{chassis.Name},
+				nil,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("error requesting the client cert of external chassis %s: %w", chassis.Name, err)
+			}
+			cm.Data[ExternalChassisCertSecretKey] = status.CertSecretName
+		}
+		if instance.Spec.TLS.CaBundleSecretName != "" {
+			cm.Data[ExternalChassisCABundleSecretKey] = instance.Spec.TLS.CaBundleSecretName
+		}
+		err := ovn_common.Apply(ctx, h, cm)
+		if err != nil {
+			return nil, fmt.Errorf("error publishing the configuration of external chassis %s: %w", chassis.Name, err)
+		}
+		statuses = append(statuses, status)
+		keep[cm.Name] = true
+	}
+
+	err = DeleteExternalChassisConfigMaps(ctx, h, instance, keep)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// DeleteExternalChassisConfigMaps - delete the ConfigMaps of the external
+// chassis of the instance other than the ones in keep
+func DeleteExternalChassisConfigMaps(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	keep map[string]bool,
+) error {
+	cms := &corev1.ConfigMapList{}
+	err := h.GetClient().List(ctx, cms, client.InNamespace(instance.Namespace), client.HasLabels{ExternalChassisLabel})
+	if err != nil {
+		return fmt.Errorf("error listing the ConfigMaps of the external chassis: %w", err)
+	}
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		if keep[cm.Name] || !metav1.IsControlledBy(cm, instance) {
+			continue
+		}
+		err := h.GetClient().Delete(ctx, cm)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting ConfigMap %s: %w", cm.Name, err)
+		}
+		h.GetLogger().Info(fmt.Sprintf("Deleted the ConfigMap of removed external chassis %s", cm.Labels[ExternalChassisLabel]))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// MaintenanceAnnotation - set to "true" on a Node to cordon its chassis
+	// for a maintenance. Its gateway ports are drained, it is not offered as
+	// a gateway anymore and its ovn-controller and OVS pods are not updated
+	// until the annotation is removed.
+	MaintenanceAnnotation = "ovn.openstack.org/maintenance"
+)
+
+// MaintenanceNodes - the nodes running one of the pods which are annotated
+// for a maintenance
+func MaintenanceNodes(
+	ctx context.Context,
+	h *helper.Helper,
+	pods []corev1.Pod,
+) ([]string, error) {
+	podNodes := map[string]bool{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			podNodes[pod.Spec.NodeName] = true
+		}
+	}
+
+	nodeList, err := h.GetKClient().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing the nodes: %w", err)
+	}
+	nodes := []string{}
+	for _, node := range nodeList.Items {
+		if podNodes[node.Name] && node.Annotations[MaintenanceAnnotation] == "true" {
+			nodes = append(nodes, node.Name)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+// CordonChassis - cordon or uncordon the chassis of the node of the
+// ovn-controller pod, the gateway priorities are changed through the NB DB
+// of nbEndpoint when set
+func CordonChassis(
+	ctx context.Context,
+	h *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	nbEndpoint string,
+	cordon bool,
+) error {
+	mode := "uncordon"
+	if cordon {
+		mode = "cordon"
+	}
+	_, err := ovn_common.ExecInPod(ctx, h, restConfig, pod, []string{
+		"/bin/bash", "-c", fmt.Sprintf("NB_REMOTE=%s %s %s", nbEndpoint, GatewayDrainCommand, mode),
+	})
+	return err
+}
+
+// RolloutNodes - roll the latest pod template of a DaemonSet updated OnDelete
+// out to the pods which are not on the excluded nodes. One outdated pod is
+// deleted at a time, once all the other pods are ready.
+func RolloutNodes(
+	ctx context.Context,
+	h *helper.Helper,
+	ds *appsv1.DaemonSet,
+	pods []corev1.Pod,
+	excluded []string,
+) error {
+	skip := map[string]bool{}
+	for _, node := range excluded {
+		skip[node] = true
+	}
+
+	templateGeneration := ds.Annotations[templateGenerationAnnotation]
+	scheduled := len(excluded)
+	var outdated *corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if skip[pod.Spec.NodeName] {
+			continue
+		}
+		scheduled++
+		if !pod.DeletionTimestamp.IsZero() || !isPodReady(pod) {
+			// a pod is being replaced
+			return nil
+		}
+		if outdated == nil && pod.Labels[templateGenerationLabel] != templateGeneration {
+			outdated = pod
+		}
+	}
+	if outdated == nil || scheduled < int(ds.Status.DesiredNumberScheduled) {
+		return nil
+	}
+
+	err := h.GetClient().Delete(ctx, outdated)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting pod %s for the rollout: %w", outdated.Name, err)
+	}
+	h.GetLogger().Info(fmt.Sprintf("Deleted pod %s to roll out DaemonSet %s", outdated.Name, ds.Name))
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"fmt"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// MetricsServiceName - headless Service with an endpoint per ovn-controller pod
+	MetricsServiceName = ovnv1.ServiceNameOVNController + "-metrics"
+	// OVSMetricsServiceName - headless Service with an endpoint per OVS pod
+	OVSMetricsServiceName = ovnv1.ServiceNameOVS + "-metrics"
+
+	// ExporterConfigFile - the exporter configuration in the metrics ConfigMaps
+	ExporterConfigFile = "openstack-network-exporter.yaml"
+	exporterConfigDir  = "/etc/openstack-network-exporter"
+)
+
+// MetricsConfigMapName - ConfigMap holding the ovn-controller exporter configuration
+func MetricsConfigMapName(instance *ovnv1.OVNController) string {
+	return instance.Name + "-metrics-config"
+}
+
+// OVSMetricsConfigMapName - ConfigMap holding the OVS exporter configuration
+func OVSMetricsConfigMapName(instance *ovnv1.OVNController) string {
+	return instance.Name + "-ovs-metrics-config"
+}
+
+// AuthDelegatorBindingName - ClusterRoleBinding allowing kube-rbac-proxy to
+// review tokens and access with the service account of the pods. It is
+// cluster scoped, so the name includes the namespace.
+func AuthDelegatorBindingName(instance *ovnv1.OVNController) string {
+	return fmt.Sprintf("%s-%s-auth-delegator", instance.Namespace, instance.RbacResourceName())
+}
+
+// MetricsService - headless Service exposing the metrics endpoint of every
+// pod of a DaemonSet
+func MetricsService(
+	serviceName string,
+	instance *ovnv1.OVNController,
+	serviceLabels map[string]string,
+	selectorLabels map[string]string,
+) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: instance.Namespace,
+			Labels:    serviceLabels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selectorLabels,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "metrics",
+					Port:       MetricsPort,
+					TargetPort: intstr.FromString("metrics"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			ClusterIP: "None",
+		},
+	}
+}
+
+// getMetricsVolume - the exporter configuration
+func getMetricsVolume(configMapName string) corev1.Volume {
+	configVolumeDefaultMode := int32(0644)
+	return corev1.Volume{
+		Name: "metrics-config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				DefaultMode: &configVolumeDefaultMode,
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configMapName,
+				},
+			},
+		},
+	}
+}
+
+// getMetricsContainers - the exporter reading the control sockets mounted
+// with runMounts, and kube-rbac-proxy in front of it
+func getMetricsContainers(
+	instance *ovnv1.OVNController,
+	runMounts []corev1.VolumeMount,
+) []corev1.Container {
+	runAsUser := int64(0)
+	falseVal := false
+	trueVal := true
+
+	exporterMounts := append(runMounts, corev1.VolumeMount{
+		Name:      "metrics-config",
+		MountPath: exporterConfigDir,
+		ReadOnly:  true,
+	})
+
+	return []corev1.Container{
+		{
+			Name:  "openstack-network-exporter",
+			Image: instance.Spec.ExporterContainerImage,
+			Env: []corev1.EnvVar{
+				{
+					Name:  "OPENSTACK_NETWORK_EXPORTER_YAML",
+					Value: exporterConfigDir + "/" + ExporterConfigFile,
+				},
+			},
+			SecurityContext: &corev1.SecurityContext{
+				// the control sockets are owned by root
+				RunAsUser:                &runAsUser,
+				AllowPrivilegeEscalation: &falseVal,
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+			},
+			VolumeMounts:             exporterMounts,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+		{
+			Name:  "kube-rbac-proxy",
+			Image: instance.Spec.RbacProxyContainerImage,
+			Args: []string{
+				fmt.Sprintf("--secure-listen-address=0.0.0.0:%d", MetricsPort),
+				fmt.Sprintf("--upstream=http://127.0.0.1:%d/", ExporterPort),
+				"--logtostderr=true",
+				"--v=0",
+			},
+			Ports: []corev1.ContainerPort{
+				{
+					Name:          "metrics",
+					ContainerPort: MetricsPort,
+					Protocol:      corev1.ProtocolTCP,
+				},
+			},
+			SecurityContext: &corev1.SecurityContext{
+				RunAsNonRoot:             &trueVal,
+				AllowPrivilegeEscalation: &falseVal,
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+			},
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+	}
+}
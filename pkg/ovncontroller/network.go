@@ -15,6 +15,7 @@ package ovncontroller
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
@@ -23,8 +24,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 )
 
+// NetworkAttachments - the network attachments of the OVS pods, the one of
+// each physical network and the one of the spec, sorted
+func NetworkAttachments(instance *ovnv1.OVNController) []string {
+	networkAttachments := []string{}
+	for physNet := range instance.Spec.NicMappings {
+		networkAttachments = append(networkAttachments, physNet)
+	}
+	if instance.Spec.NetworkAttachment != "" {
+		networkAttachments = append(networkAttachments, instance.Spec.NetworkAttachment)
+	}
+	sort.Strings(networkAttachments)
+	return networkAttachments
+}
+
 // CreateOrUpdateAdditionalNetworks - create or update network attachment definitions based on the provided mappings
 func CreateOrUpdateAdditionalNetworks(
 	ctx context.Context,
@@ -51,42 +67,27 @@ func CreateOrUpdateAdditionalNetworks(
 			},
 			nad,
 		)
-		if err != nil {
-			if !k8s_errors.IsNotFound(err) {
-				return nil, fmt.Errorf("cannot get NetworkAttachmentDefinition %s/%s: %w",
-					physNet, interfaceName, err)
-			}
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return nil, fmt.Errorf("cannot get NetworkAttachmentDefinition %s/%s: %w",
+				physNet, interfaceName, err)
+		}
 
-			ownerRef := metav1.NewControllerRef(instance, instance.GroupVersionKind())
-			nad = &netattdefv1.NetworkAttachmentDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            physNet,
-					Namespace:       instance.Namespace,
-					Labels:          labels,
-					OwnerReferences: []metav1.OwnerReference{*ownerRef},
-				},
-				Spec: nadSpec,
-			}
-			// Request object not found, lets create it
-			if err := h.GetClient().Create(ctx, nad); err != nil {
-				return nil, fmt.Errorf("cannot create NetworkAttachmentDefinition %s/%s: %w",
-					physNet, interfaceName, err)
-			}
-		} else {
-			owned := false
-			for _, owner := range nad.GetOwnerReferences() {
-				if owner.Name == instance.Name {
-					owned = true
-					break
-				}
-			}
-			if owned {
-				nad.Spec = nadSpec
-				if err := h.GetClient().Update(ctx, nad); err != nil {
-					return nil, fmt.Errorf("cannot update NetworkAttachmentDefinition %s/%s: %w",
-						physNet, interfaceName, err)
-				}
-			}
+		// A NetworkAttachmentDefinition created by someone else is left as is
+		if err == nil && !metav1.IsControlledBy(nad, instance) {
+			networkAttachments = append(networkAttachments, physNet)
+			continue
+		}
+		nad = &netattdefv1.NetworkAttachmentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      physNet,
+				Namespace: instance.Namespace,
+				Labels:    labels,
+			},
+			Spec: nadSpec,
+		}
+		if err := ovn_common.Apply(ctx, h, nad); err != nil {
+			return nil, fmt.Errorf("cannot apply NetworkAttachmentDefinition %s/%s: %w",
+				physNet, interfaceName, err)
 		}
 
 		networkAttachments = append(networkAttachments, physNet)
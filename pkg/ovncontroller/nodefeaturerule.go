@@ -0,0 +1,111 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NodeFeatureRuleName - the cluster scoped NodeFeatureRule labeling the
+// nodes with their capabilities, shared by all the OVNControllers
+const NodeFeatureRuleName = "ovn-operator-node-capabilities"
+
+// NodeFeatureRuleGVK - Node Feature Discovery NodeFeatureRule, handled as
+// unstructured so the operator does not depend on the NFD API
+var NodeFeatureRuleGVK = schema.GroupVersionKind{
+	Group:   "nfd.k8s-sigs.io",
+	Version: "v1alpha1",
+	Kind:    "NodeFeatureRule",
+}
+
+// nodeCapabilityFeatures - the features of the nodes NFD matches for each
+// capability
+var nodeCapabilityFeatures = map[string][]interface{}{
+	// the openvswitch kernel module is loaded
+	ovnv1.NodeCapabilityKernelDatapath: {
+		map[string]interface{}{
+			"feature": "kernel.loadedmodule",
+			"matchExpressions": map[string]interface{}{
+				"openvswitch": map[string]interface{}{"op": "Exists"},
+			},
+		},
+	},
+	// the NICs can be bound to vfio-pci for the DPDK PMDs
+	ovnv1.NodeCapabilityDPDK: {
+		map[string]interface{}{
+			"feature": "kernel.loadedmodule",
+			"matchExpressions": map[string]interface{}{
+				"vfio_pci": map[string]interface{}{"op": "Exists"},
+			},
+		},
+	},
+	// Mellanox/NVIDIA Ethernet controllers, switchdev capable
+	ovnv1.NodeCapabilityHWOffload: {
+		map[string]interface{}{
+			"feature": "pci.device",
+			"matchExpressions": map[string]interface{}{
+				"vendor": map[string]interface{}{"op": "In", "value": []interface{}{"15b3"}},
+				"class":  map[string]interface{}{"op": "In", "value": []interface{}{"0200"}},
+			},
+		},
+	},
+}
+
+// EnsureNodeFeatureRule - apply the NodeFeatureRule labeling the nodes with
+// the NodeCapabilityLabel of their capabilities. Returns false when Node
+// Feature Discovery is not installed.
+func EnsureNodeFeatureRule(
+	ctx context.Context,
+	h *helper.Helper,
+) (bool, error) {
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(NodeFeatureRuleGVK)
+	rule.SetName(NodeFeatureRuleName)
+
+	rules := []interface{}{}
+	for _, capability := range []string{
+		ovnv1.NodeCapabilityDPDK,
+		ovnv1.NodeCapabilityHWOffload,
+		ovnv1.NodeCapabilityKernelDatapath,
+	} {
+		rules = append(rules, map[string]interface{}{
+			"name": "ovn " + capability,
+			"labels": map[string]interface{}{
+				ovnv1.NodeCapabilityLabel(capability): "true",
+			},
+			"matchFeatures": nodeCapabilityFeatures[capability],
+		})
+	}
+	err := unstructured.SetNestedSlice(rule.Object, rules, "spec", "rules")
+	if err != nil {
+		return false, err
+	}
+
+	// cluster scoped, it is not owned by the OVNController
+	err = ovn_common.Apply(ctx, h, rule)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("Error applying NodeFeatureRule %s: %w", NodeFeatureRuleName, err)
+	}
+	return true, nil
+}
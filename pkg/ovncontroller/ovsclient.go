@@ -0,0 +1,49 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OVSClientPodSpec - the spec of a pod running container against the OVS of
+// the instance on the given node. It talks to OVS through its run directory
+// on the host, so it runs with the privileges of the OVS pods.
+func OVSClientPodSpec(
+	instance *ovnv1.OVNController,
+	nodeName string,
+	container corev1.Container,
+) corev1.PodSpec {
+	runAsUser := int64(0)
+	privileged := true
+
+	container.SecurityContext = &corev1.SecurityContext{
+		RunAsUser:  &runAsUser,
+		Privileged: &privileged,
+	}
+	for _, mount := range GetOVNControllerVolumeMounts() {
+		if mount.Name == "var-run" {
+			container.VolumeMounts = append(container.VolumeMounts, mount)
+		}
+	}
+
+	return corev1.PodSpec{
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: instance.ServiceAccountName(),
+		Containers:         []corev1.Container{container},
+		Volumes:            GetOVNControllerVolumes(instance.Name, instance.Namespace),
+		NodeName:           nodeName,
+	}
+}
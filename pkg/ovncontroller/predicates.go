@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// DaemonSetChangedPredicate - the updates of the DaemonSets which change
+// their spec, labels or annotations or one of the status counters reported
+// on the OVNController. The other status updates, e.g. of the conditions or
+// of the number of misscheduled pods, are skipped.
+func DaemonSetChangedPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldDs, ok := e.ObjectOld.(*appsv1.DaemonSet)
+				if !ok {
+					return false
+				}
+				newDs, ok := e.ObjectNew.(*appsv1.DaemonSet)
+				if !ok {
+					return false
+				}
+				return daemonSetCounters(oldDs) != daemonSetCounters(newDs)
+			},
+		},
+	)
+}
+
+// daemonSetCounters - the status fields of the DaemonSet read by the
+// reconcile
+func daemonSetCounters(ds *appsv1.DaemonSet) [5]int64 {
+	return [5]int64{
+		ds.Status.ObservedGeneration,
+		int64(ds.Status.DesiredNumberScheduled),
+		int64(ds.Status.UpdatedNumberScheduled),
+		int64(ds.Status.NumberReady),
+		int64(ds.Status.NumberAvailable),
+	}
+}
+
+// NodeChangedPredicate - the updates of the Nodes which change their labels,
+// selecting them for ovn-controller or as gateways, their
+// MaintenanceAnnotation, DecommissionAnnotation or EncapIPAnnotation, or
+// their addresses, e.g. when a node is re-addressed. The heartbeats and the
+// other status or annotation updates are skipped.
+func NodeChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) ||
+				oldNode.Annotations[MaintenanceAnnotation] != newNode.Annotations[MaintenanceAnnotation] ||
+				oldNode.Annotations[DecommissionAnnotation] != newNode.Annotations[DecommissionAnnotation] ||
+				oldNode.Annotations[EncapIPAnnotation] != newNode.Annotations[EncapIPAnnotation] ||
+				!equality.Semantic.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses)
+		},
+	}
+}
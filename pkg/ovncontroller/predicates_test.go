@@ -0,0 +1,169 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// testNode - a Node selected for ovn-controller with an encap IP
+func testNode() *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "node-0",
+			ResourceVersion: "1",
+			Labels:          map[string]string{"ovn": "true"},
+			Annotations: map[string]string{
+				EncapIPAnnotation:              "172.19.0.100",
+				"node.alpha.kubernetes.io/ttl": "0",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "192.168.122.100"},
+				{Type: corev1.NodeHostName, Address: "node-0"},
+			},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func TestNodeChangedPredicate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		update  func(node *corev1.Node)
+		trigger bool
+	}{
+		"label added": {
+			update:  func(node *corev1.Node) { node.Labels["ovn-gateway"] = "true" },
+			trigger: true,
+		},
+		"label removed": {
+			update:  func(node *corev1.Node) { delete(node.Labels, "ovn") },
+			trigger: true,
+		},
+		"maintenance annotation": {
+			update:  func(node *corev1.Node) { node.Annotations[MaintenanceAnnotation] = "true" },
+			trigger: true,
+		},
+		"decommission annotation": {
+			update:  func(node *corev1.Node) { node.Annotations[DecommissionAnnotation] = "true" },
+			trigger: true,
+		},
+		"encap IP annotation": {
+			update:  func(node *corev1.Node) { node.Annotations[EncapIPAnnotation] = "172.19.0.101" },
+			trigger: true,
+		},
+		"address changed": {
+			update:  func(node *corev1.Node) { node.Status.Addresses[0].Address = "192.168.122.101" },
+			trigger: true,
+		},
+		"address added": {
+			update: func(node *corev1.Node) {
+				node.Status.Addresses = append(node.Status.Addresses,
+					corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "10.0.0.100"})
+			},
+			trigger: true,
+		},
+		"heartbeat": {
+			update: func(node *corev1.Node) {
+				node.ResourceVersion = "2"
+				node.Status.Conditions[0].LastHeartbeatTime = metav1.Now()
+			},
+			trigger: false,
+		},
+		"other annotation": {
+			update:  func(node *corev1.Node) { node.Annotations["node.alpha.kubernetes.io/ttl"] = "30" },
+			trigger: false,
+		},
+		"images": {
+			update: func(node *corev1.Node) {
+				node.Status.Images = []corev1.ContainerImage{{Names: []string{"ovn-controller"}}}
+			},
+			trigger: false,
+		},
+		"taint": {
+			update: func(node *corev1.Node) {
+				node.Spec.Taints = []corev1.Taint{{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoSchedule}}
+			},
+			trigger: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			oldNode := testNode()
+			newNode := testNode()
+			tc.update(newNode)
+
+			g.Expect(NodeChangedPredicate().Update(event.UpdateEvent{
+				ObjectOld: oldNode,
+				ObjectNew: newNode,
+			})).To(Equal(tc.trigger))
+		})
+	}
+}
+
+func TestNodeChangedPredicateOtherObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(NodeChangedPredicate().Update(event.UpdateEvent{
+		ObjectOld: &appsv1.DaemonSet{},
+		ObjectNew: &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "b"}}},
+	})).To(BeFalse())
+}
+
+func TestDaemonSetChangedPredicate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		update  func(ds *appsv1.DaemonSet)
+		trigger bool
+	}{
+		"spec": {
+			update:  func(ds *appsv1.DaemonSet) { ds.Generation = 2 },
+			trigger: true,
+		},
+		"pods ready": {
+			update:  func(ds *appsv1.DaemonSet) { ds.Status.NumberReady = 3 },
+			trigger: true,
+		},
+		"pods updated": {
+			update:  func(ds *appsv1.DaemonSet) { ds.Status.UpdatedNumberScheduled = 3 },
+			trigger: true,
+		},
+		"misscheduled pods": {
+			update:  func(ds *appsv1.DaemonSet) { ds.Status.NumberMisscheduled = 1 },
+			trigger: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			oldDs := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "ovn-controller", Generation: 1}}
+			newDs := oldDs.DeepCopy()
+			tc.update(newDs)
+
+			g.Expect(DaemonSetChangedPredicate().Update(event.UpdateEvent{
+				ObjectOld: oldDs,
+				ObjectNew: newDs,
+			})).To(Equal(tc.trigger))
+		})
+	}
+}
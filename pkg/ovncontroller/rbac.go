@@ -0,0 +1,29 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+)
+
+// OVSRbacInstance - the OVNController as the owner of the ServiceAccount,
+// Role and RoleBinding of the OVS pods, apart from the ones of the
+// ovn-controller pods
+type OVSRbacInstance struct {
+	*ovnv1.OVNController
+}
+
+// RbacResourceName - return the name of the rbac objects of the OVS pods
+func (instance OVSRbacInstance) RbacResourceName() string {
+	return instance.OVNController.OVSRbacResourceName()
+}
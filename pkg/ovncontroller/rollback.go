@@ -0,0 +1,117 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TemplateHash - hash of the pod template of the DaemonSet
+func TemplateHash(ds *appsv1.DaemonSet) (string, error) {
+	return util.ObjectHash(ds.Spec.Template)
+}
+
+// FailedNodes - nodes running a pod with the latest pod template of the
+// DaemonSet which is not ready
+func FailedNodes(
+	ctx context.Context,
+	k8sClient client.Client,
+	ds *appsv1.DaemonSet,
+) ([]string, error) {
+	podList := &corev1.PodList{}
+	err := k8sClient.List(ctx, podList,
+		client.InNamespace(ds.Namespace),
+		client.MatchingLabels(ds.Spec.Selector.MatchLabels))
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods of DaemonSet %s: %w", ds.Name, err)
+	}
+
+	templateGeneration := ds.Annotations[templateGenerationAnnotation]
+	failedNodes := []string{}
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName == "" || pod.Labels[templateGenerationLabel] != templateGeneration {
+			continue
+		}
+		if !isPodReady(&pod) {
+			failedNodes = append(failedNodes, pod.Spec.NodeName)
+		}
+	}
+	sort.Strings(failedNodes)
+	return failedNodes, nil
+}
+
+// PreviousRevision - name of the ControllerRevision of the pod template the
+// DaemonSet ran before its latest one, empty if there is none
+func PreviousRevision(
+	ctx context.Context,
+	h *helper.Helper,
+	ds *appsv1.DaemonSet,
+) (string, error) {
+	revisionList, err := h.GetKClient().AppsV1().ControllerRevisions(ds.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(ds.Spec.Selector.MatchLabels).String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error listing revisions of DaemonSet %s: %w", ds.Name, err)
+	}
+
+	revisions := []appsv1.ControllerRevision{}
+	for _, revision := range revisionList.Items {
+		if owner := metav1.GetControllerOf(&revision); owner != nil && owner.UID == ds.UID {
+			revisions = append(revisions, revision)
+		}
+	}
+	if len(revisions) < 2 {
+		return "", nil
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	return revisions[1].Name, nil
+}
+
+// RevisionTemplate - the pod template recorded in the ControllerRevision of
+// a DaemonSet
+func RevisionTemplate(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	name string,
+) (*corev1.PodTemplateSpec, error) {
+	revision, err := h.GetKClient().AppsV1().ControllerRevisions(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting revision %s: %w", name, err)
+	}
+
+	// the DaemonSet controller records the pod template as a patch of the
+	// DaemonSet spec
+	patch := struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(revision.Data.Raw, &patch); err != nil {
+		return nil, fmt.Errorf("error decoding revision %s: %w", name, err)
+	}
+	return &patch.Spec.Template, nil
+}
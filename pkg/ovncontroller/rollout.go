@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxPendingNodes - number of pending nodes reported in the rollout status
+	maxPendingNodes = 10
+
+	// templateGenerationAnnotation - pod template generation of the DaemonSet,
+	// copied by the DaemonSet controller to the pod-template-generation label
+	// of its pods
+	templateGenerationAnnotation = "deprecated.daemonset.template.generation"
+	templateGenerationLabel      = "pod-template-generation"
+)
+
+// GetRolloutStatus - the progress of the rollout of the pod template of the
+// DaemonSet, which was last updated for the given OVNController generation
+func GetRolloutStatus(
+	ctx context.Context,
+	k8sClient client.Client,
+	ds *appsv1.DaemonSet,
+	generation int64,
+) (ovnv1.OVNControllerRolloutStatus, error) {
+	rollout := ovnv1.OVNControllerRolloutStatus{
+		ObservedGeneration:     generation,
+		DesiredNumberScheduled: ds.Status.DesiredNumberScheduled,
+		UpdatedNumberScheduled: ds.Status.UpdatedNumberScheduled,
+		NumberReady:            ds.Status.NumberReady,
+		NumberAvailable:        ds.Status.NumberAvailable,
+	}
+
+	podList := &corev1.PodList{}
+	err := k8sClient.List(ctx, podList,
+		client.InNamespace(ds.Namespace),
+		client.MatchingLabels(ds.Spec.Selector.MatchLabels))
+	if err != nil {
+		return rollout, fmt.Errorf("error listing pods of DaemonSet %s: %w", ds.Name, err)
+	}
+
+	templateGeneration := ds.Annotations[templateGenerationAnnotation]
+	pendingNodes := []string{}
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		if pod.Labels[templateGenerationLabel] != templateGeneration || !isPodReady(&pod) {
+			pendingNodes = append(pendingNodes, pod.Spec.NodeName)
+		}
+	}
+	sort.Strings(pendingNodes)
+	if len(pendingNodes) > maxPendingNodes {
+		pendingNodes = pendingNodes[:maxPendingNodes]
+	}
+	if len(pendingNodes) > 0 {
+		rollout.PendingNodes = pendingNodes
+	}
+
+	rollout.Complete = ovn_common.DaemonSetRolledOut(ds) &&
+		ds.Status.NumberReady == ds.Status.DesiredNumberScheduled &&
+		len(pendingNodes) == 0
+	return rollout, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SBMember - a member of the internal endpoint of the SB DB
+type SBMember struct {
+	// Name - name of the pod and of the Service of the member
+	Name string
+	// Remote - connection string of the member
+	Remote string
+	// Zone - topology.kubernetes.io/zone of the node of the pod, only looked
+	// up when the nodes prefer the members of their zone
+	Zone string
+}
+
+// GetSBMembers - split the internal endpoint of the SB DB into its members
+func GetSBMembers(
+	ctx context.Context,
+	k8sClient client.Client,
+	instance *ovnv1.OVNController,
+	sbCluster *ovnv1.OVNDBCluster,
+) ([]SBMember, error) {
+	internalEndpoint, err := sbCluster.GetInternalEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	members := []SBMember{}
+	for _, remote := range strings.Split(internalEndpoint, ",") {
+		// e.g. ssl:ovsdbserver-sb-0.openstack.svc.cluster.local:6642
+		_, host, _ := strings.Cut(remote, ":")
+		name, _, _ := strings.Cut(host, ".")
+		members = append(members, SBMember{Name: name, Remote: remote})
+	}
+	if instance.Spec.SBRemote == nil || instance.Spec.SBRemote.SameZoneWeight <= 1 {
+		return members, nil
+	}
+
+	for i := range members {
+		pod := &corev1.Pod{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: sbCluster.Namespace, Name: members[i].Name}, pod)
+		if k8s_errors.IsNotFound(err) || (err == nil && pod.Spec.NodeName == "") {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("error getting SB DB pod %s: %w", members[i].Name, err)
+		}
+		node := &corev1.Node{}
+		err = k8sClient.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node)
+		if k8s_errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("error getting node %s: %w", pod.Spec.NodeName, err)
+		}
+		members[i].Zone = node.Labels[corev1.LabelTopologyZone]
+	}
+	return members, nil
+}
+
+// OVNRemote - the ovn-remote of a node of the zone, each member listed as
+// many times as its weight. The most preferred members come first, so the
+// list also reads as the preference of the node.
+func OVNRemote(instance *ovnv1.OVNController, members []SBMember, zone string) string {
+	weights := make([]int32, len(members))
+	order := make([]int, len(members))
+	for i, member := range members {
+		order[i] = i
+		weights[i] = 1
+		if sbRemote := instance.Spec.SBRemote; sbRemote != nil {
+			if weight, ok := sbRemote.Weights[member.Name]; ok {
+				weights[i] = weight
+			}
+			if zone != "" && member.Zone == zone {
+				weights[i] *= sbRemote.SameZoneWeight
+			}
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return weights[order[i]] > weights[order[j]]
+	})
+
+	remotes := []string{}
+	for _, i := range order {
+		for n := int32(0); n < weights[i]; n++ {
+			remotes = append(remotes, members[i].Remote)
+		}
+	}
+	return strings.Join(remotes, ",")
+}
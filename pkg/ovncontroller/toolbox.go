@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ToolboxDaemonSetName - name of the DaemonSet of the debug toolbox
+const ToolboxDaemonSetName = "ovn-controller-toolbox"
+
+// ToolboxNodeSelector - the nodes of the instance selected by the toolbox
+func ToolboxNodeSelector(instance *ovnv1.OVNController) map[string]string {
+	nodeSelector := map[string]string{}
+	for k, v := range instance.Spec.NodesSelector() {
+		nodeSelector[k] = v
+	}
+	if instance.Spec.Toolbox != nil {
+		for k, v := range instance.Spec.Toolbox.NodeSelector {
+			nodeSelector[k] = v
+		}
+	}
+	return nodeSelector
+}
+
+// ToolboxDaemonSet - the debug toolbox of the nodes. The container only
+// waits to be exec'ed into, with the sockets of OVS and ovn-controller and
+// their logs, in the network namespace of the host so tcpdump and conntrack
+// see the traffic of the node.
+func ToolboxDaemonSet(instance *ovnv1.OVNController) *appsv1.DaemonSet {
+	runAsUser := int64(0)
+	privileged := true
+
+	volumeMounts := []corev1.VolumeMount{}
+	for _, mount := range GetOVNControllerVolumeMounts() {
+		if mount.Name != "scripts" {
+			volumeMounts = append(volumeMounts, mount)
+		}
+	}
+
+	labels := map[string]string{
+		common.AppSelector: ToolboxDaemonSetName,
+	}
+
+	daemonset := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ToolboxDaemonSetName,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: instance.ServiceAccountName(),
+					HostNetwork:        true,
+					Containers: []corev1.Container{
+						{
+							Name:    "toolbox",
+							Image:   instance.Spec.ToolboxContainerImage,
+							Command: []string{"/bin/bash", "-c", "trap : TERM INT; sleep infinity & wait"},
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser:  &runAsUser,
+								Privileged: &privileged,
+							},
+							VolumeMounts:             volumeMounts,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						},
+					},
+					Volumes: GetOVNControllerVolumes(instance.Name, instance.Namespace),
+				},
+			},
+		},
+	}
+
+	if nodeSelector := ToolboxNodeSelector(instance); len(nodeSelector) > 0 {
+		daemonset.Spec.Template.Spec.NodeSelector = nodeSelector
+	}
+	SetArchitectures(&daemonset.Spec.Template.Spec, instance.Spec.Architectures)
+
+	return daemonset
+}
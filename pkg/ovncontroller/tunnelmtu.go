@@ -0,0 +1,90 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// TunnelMTUFailedNodes - ping the chassis of each node from the one of the
+// previous node, in the order of the node names, with packets of the tunnel
+// MTU which can't be fragmented. The pings run in the running OVS pods, which
+// hold the NIC of the tunnels. Returns the number of chassis checked and the
+// nodes whose packets are dropped.
+func TunnelMTUFailedNodes(
+	ctx context.Context,
+	h *helper.Helper,
+	restConfig *rest.Config,
+	instance *ovnv1.OVNController,
+	pods []corev1.Pod,
+) (int, []string, error) {
+	running := []*corev1.Pod{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero() {
+			running = append(running, pod)
+		}
+	}
+	// a chassis needs a peer
+	if len(running) < 2 {
+		return 0, nil, nil
+	}
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].Spec.NodeName < running[j].Spec.NodeName
+	})
+
+	encapIPs := make([]string, len(running))
+	for i, pod := range running {
+		output, err := ovn_common.ExecInPod(ctx, h, restConfig, pod, []string{
+			"ovs-vsctl", "--if-exists", "get", "open", ".", "external_ids:ovn-encap-ip",
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		encapIPs[i] = strings.Trim(strings.TrimSpace(output), "\"")
+		if encapIPs[i] == "" {
+			// not configured yet, checked on a later reconcile
+			return 0, nil, nil
+		}
+	}
+
+	failed := []string{}
+	for i, pod := range running {
+		peerIP := encapIPs[(i+1)%len(running)]
+		// the payload excludes the IP and ICMP headers
+		size := instance.Spec.TunnelMTU - 28
+		if strings.Contains(peerIP, ":") {
+			size = instance.Spec.TunnelMTU - 48
+		}
+		output, err := ovn_common.ExecInPod(ctx, h, restConfig, pod, []string{
+			"sh", "-c", fmt.Sprintf("ping -M do -c 3 -W 1 -s %d %s > /dev/null 2>&1; echo $?", size, peerIP),
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		if strings.TrimSpace(output) != "0" {
+			failed = append(failed, pod.Spec.NodeName)
+		}
+	}
+	return len(running), failed, nil
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
@@ -75,3 +76,14 @@ func EnvDownwardAPI(field string) env.Setter {
 		env.ValueFrom.FieldRef.FieldPath = field
 	}
 }
+
+// NBEndpoint - internal endpoint of the NB DB, empty until the NB
+// OVNDBCluster is ready
+func NBEndpoint(ctx context.Context, h *helper.Helper, namespace string) string {
+	nbCluster, err := ovnv1.GetDBClusterByType(ctx, h, namespace, map[string]string{}, ovnv1.NBDBType)
+	if err != nil {
+		return ""
+	}
+	nbEndpoint, _ := nbCluster.GetInternalEndpoint()
+	return nbEndpoint
+}
@@ -0,0 +1,95 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VersionProbeHash - hash key of the version probe Job
+	VersionProbeHash = "versionprobe"
+
+	// VersionProbeCommand - prints the OVN version and the SB DB schema
+	// version ovn-controller was built with to the termination log
+	VersionProbeCommand = "ovn-controller --version | tee /dev/termination-log"
+)
+
+// VersionProbeJobName - name of the Job probing the version of a new
+// ovn-controller image
+func VersionProbeJobName(instance *ovnv1.OVNController) string {
+	return instance.Name + "-version-probe"
+}
+
+// VersionProbeJob - prepare the Job which reports the versions of the given
+// ovn-controller image before it is rolled out
+func VersionProbeJob(instance *ovnv1.OVNController, image string) *batchv1.Job {
+	// the Job pods must not be selected as ovn-controller pods
+	labels := map[string]string{
+		common.AppSelector: VersionProbeJobName(instance),
+	}
+	backoffLimit := int32(0)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      VersionProbeJobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: instance.ServiceAccountName(),
+					Containers: []corev1.Container{
+						{
+							Name:    "version-probe",
+							Image:   image,
+							Command: []string{"/bin/bash", "-c", VersionProbeCommand},
+						},
+					},
+					NodeSelector: instance.Spec.NodesSelector(),
+				},
+			},
+		},
+	}
+	// the image is probed on a node of one of its architectures
+	SetArchitectures(&job.Spec.Template.Spec, instance.Spec.Architectures)
+	return job
+}
+
+// ParseVersion - parse the output of ovn-controller --version
+func ParseVersion(image string, output string) *ovnv1.OVNControllerVersion {
+	version := &ovnv1.OVNControllerVersion{ContainerImage: image}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "ovn-controller "):
+			version.Version = strings.TrimSpace(strings.TrimPrefix(line, "ovn-controller "))
+		case strings.HasPrefix(line, "SB DB Schema "):
+			version.SBSchemaVersion = strings.TrimSpace(strings.TrimPrefix(line, "SB DB Schema "))
+		}
+	}
+	return version
+}
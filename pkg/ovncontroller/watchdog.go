@@ -0,0 +1,64 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// rssCmd - print the RSS (in kB) of ovn-controller, found by the pidfile it
+// writes to the default OVN run directory
+const rssCmd = "awk '/^VmRSS:/ {print $2}' /proc/$(cat /run/ovn/ovn-controller.pid)/status"
+
+// restartCmd - stop ovn-controller without removing its flows and chassis
+// records, the container is then restarted by the kubelet
+const restartCmd = "ovn-appctl -t ovn-controller exit --restart"
+
+// GetRSS - the RSS (in kB) of ovn-controller running in the pod
+func GetRSS(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+) (int64, error) {
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, []string{"/bin/bash", "-c", rssCmd})
+	if err != nil {
+		return 0, err
+	}
+	rss, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid RSS %q of ovn-controller pod %s: %w", output, pod.Name, err)
+	}
+	return rss, nil
+}
+
+// Restart - restart ovn-controller running in the pod, keeping the flows
+// of the node in place until it has recomputed them
+func Restart(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+) error {
+	_, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, []string{"/bin/bash", "-c", restartCmd})
+	return err
+}
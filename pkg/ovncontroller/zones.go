@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"golang.org/x/exp/slices"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// ZoneLabel - label of the DaemonSets of a zone and of their pods, with the
+// zone as value. It keeps the selectors of the DaemonSets of the zones apart.
+const ZoneLabel = "ovn.openstack.org/zone"
+
+// ZoneDaemonSetName - name of the DaemonSet of the zone
+func ZoneDaemonSetName(name string, zone string) string {
+	return name + "-" + zone
+}
+
+// DaemonSetNames - names of the DaemonSets rendered from the DaemonSet of
+// the name, one per zone with zones
+func DaemonSetNames(name string, zones []string) []string {
+	if len(zones) == 0 {
+		return []string{name}
+	}
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, ZoneDaemonSetName(name, zone))
+	}
+	return names
+}
+
+// ZoneDaemonSets - the DaemonSet as it is without zones, otherwise a copy
+// of it per zone, running on the nodes of the NodeSelector in the zone
+func ZoneDaemonSets(ds *appsv1.DaemonSet, zones []string) []*appsv1.DaemonSet {
+	if len(zones) == 0 {
+		return []*appsv1.DaemonSet{ds}
+	}
+	daemonSets := make([]*appsv1.DaemonSet, 0, len(zones))
+	for _, zone := range zones {
+		zoneDaemonSet := ds.DeepCopy()
+		zoneDaemonSet.Name = ZoneDaemonSetName(ds.Name, zone)
+
+		labels := map[string]string{ZoneLabel: zone}
+		for label, value := range ds.Spec.Selector.MatchLabels {
+			labels[label] = value
+		}
+		zoneDaemonSet.Labels = labels
+		zoneDaemonSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+		zoneDaemonSet.Spec.Template.Labels[ZoneLabel] = zone
+
+		podSpec := &zoneDaemonSet.Spec.Template.Spec
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		podSpec.NodeSelector[corev1.LabelTopologyZone] = zone
+		daemonSets = append(daemonSets, zoneDaemonSet)
+	}
+	return daemonSets
+}
+
+// DeleteUnusedDaemonSets - delete the DaemonSets of the instance rendered
+// from the DaemonSet of the name which are not listed in keep, i.e. the one
+// without zone once zones are set and the ones of the zones removed since
+func DeleteUnusedDaemonSets(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	name string,
+	keep []string,
+) error {
+	zoned, err := k8s_labels.NewRequirement(ZoneLabel, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector := k8s_labels.SelectorFromSet(map[string]string{common.AppSelector: name}).Add(*zoned)
+	dsList, err := h.GetKClient().AppsV1().DaemonSets(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing the DaemonSets of the zones: %w", err)
+	}
+	daemonSets := dsList.Items
+
+	ds, err := h.GetKClient().AppsV1().DaemonSets(instance.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		daemonSets = append(daemonSets, *ds)
+	}
+
+	for i := range daemonSets {
+		ds := &daemonSets[i]
+		if slices.Contains(keep, ds.Name) || !metav1.IsControlledBy(ds, instance) || !ds.DeletionTimestamp.IsZero() {
+			continue
+		}
+		err := h.GetClient().Delete(ctx, ds)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting DaemonSet %s: %w", ds.Name, err)
+		}
+		h.GetLogger().Info(fmt.Sprintf("Deleted DaemonSet %s", ds.Name))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestZoneDaemonSets(t *testing.T) {
+	g := NewWithT(t)
+
+	labels := map[string]string{"service": "ovn-controller"}
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-controller"},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"service": "ovn-controller"}},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"openstack.org/compute": "true"},
+				},
+			},
+		},
+	}
+
+	g.Expect(ZoneDaemonSets(ds, nil)).To(Equal([]*appsv1.DaemonSet{ds}))
+
+	daemonSets := ZoneDaemonSets(ds, []string{"zone-a", "zone-b"})
+	g.Expect(daemonSets).To(HaveLen(2))
+	g.Expect(daemonSets[0].Name).To(Equal("ovn-controller-zone-a"))
+	g.Expect(daemonSets[0].Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{
+		"openstack.org/compute":       "true",
+		"topology.kubernetes.io/zone": "zone-a",
+	}))
+	g.Expect(daemonSets[1].Name).To(Equal("ovn-controller-zone-b"))
+	// the DaemonSet the ones of the zones are rendered from is left as it is
+	g.Expect(ds.Spec.Selector.MatchLabels).To(Equal(map[string]string{"service": "ovn-controller"}))
+	g.Expect(ds.Spec.Template.Spec.NodeSelector).ToNot(HaveKey("topology.kubernetes.io/zone"))
+
+	// each pod is matched to the DaemonSet of its zone only
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: daemonSets[1].Spec.Template.Labels}}
+	g.Expect(podDaemonSet(daemonSets, pod)).To(Equal(daemonSets[1]))
+	pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: ds.Spec.Template.Labels}}
+	g.Expect(podDaemonSet(daemonSets, pod)).To(BeNil())
+}
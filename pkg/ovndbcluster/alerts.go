@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"fmt"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+)
+
+// AlertsName - PrometheusRule holding the alerts of the database
+func AlertsName(instance *ovnv1.OVNDBCluster) string {
+	return instance.GetServiceName() + "-alerts"
+}
+
+// Alerts - the default alerts of the database, based on the member metrics
+func Alerts(instance *ovnv1.OVNDBCluster) []ovn_common.AlertRule {
+	selector := fmt.Sprintf("namespace=%q,name=%q", instance.Namespace, instance.Name)
+
+	return []ovn_common.AlertRule{
+		{
+			Alert: "OVNDBClusterNoLeader",
+			Expr: fmt.Sprintf(
+				"sum(ovn_db_cluster_member_is_leader{%[1]s}) == 0 or absent(ovn_db_cluster_member_is_leader{%[1]s})",
+				selector),
+			For:      "2m",
+			Severity: "critical",
+			Summary:  fmt.Sprintf("OVN %s database %s has no RAFT leader", instance.Spec.DBType, instance.Name),
+			Description: "No reachable member of the RAFT cluster is the leader, the cluster lost its quorum " +
+				"and the database does not accept any write.",
+		},
+		{
+			Alert: "OVNDBClusterSizeGrowth",
+			Expr: fmt.Sprintf(
+				"ovn_db_cluster_member_db_size_bytes{%[1]s} > 2 * min_over_time(ovn_db_cluster_member_db_size_bytes{%[1]s}[6h])",
+				selector),
+			For:      "1h",
+			Severity: "warning",
+			Summary: fmt.Sprintf("OVN %s database file of {{ $labels.member }} doubled in size",
+				instance.Spec.DBType),
+			Description: "The database file is more than twice its size of the last 6 hours and is not " +
+				"compacted back, check for leaked objects or a stuck RAFT log compaction.",
+		},
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"fmt"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ClientTLS - the volumes and the SSL options of a pod connecting to the
+// DB with the cert of the DB, e.g. the Jobs of the operator
+func ClientTLS(instance *ovnv1.OVNDBCluster) ([]corev1.Volume, []corev1.VolumeMount, []string) {
+	volumes := []corev1.Volume{}
+	volumeMounts := []corev1.VolumeMount{}
+	sslArgs := []string{}
+
+	// add CA bundle if defined
+	if instance.Spec.TLS.CaBundleSecretName != "" {
+		volumes = append(volumes, instance.Spec.TLS.CreateVolume())
+		volumeMounts = append(volumeMounts, instance.Spec.TLS.CreateVolumeMounts(nil)...)
+	}
+
+	// add OVN dbs cert and CA
+	if instance.Spec.TLS.Enabled() {
+		serviceName := instance.GetServiceName()
+		volumes = append(volumes, ovn_common.CreateOVNDbCertVolume(
+			*instance.Spec.TLS.GenericService.SecretName, serviceName))
+		volumeMounts = append(volumeMounts, ovn_common.CreateOVNDbCertVolumeMounts(serviceName)...)
+
+		sslArgs = append(sslArgs,
+			fmt.Sprintf("--certificate=%s", ovn_common.OVNDbCertPath),
+			fmt.Sprintf("--private-key=%s", ovn_common.OVNDbKeyPath),
+			fmt.Sprintf("--ca-cert=%s", ovn_common.OVNDbCaCertPath),
+		)
+	}
+	return volumes, volumeMounts, sslArgs
+}
@@ -0,0 +1,185 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// ClusterStatus - RAFT state of a member as reported by cluster/status
+type ClusterStatus struct {
+	ClusterID string
+	Member    ovnv1.OVNDBClusterMember
+}
+
+// GetClusterStatus - query the RAFT cluster status, the memory usage, the
+// database size and the versions from the ovsdb-server running in the given
+// pod. Only cluster/status is required, the other probes run on their own
+// and their fields are left empty when they fail.
+func GetClusterStatus(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	instance *ovnv1.OVNDBCluster,
+	pod *corev1.Pod,
+) (*ClusterStatus, error) {
+	dbFile := DBFileName(instance.Spec.DBType)
+	dbName := DBName(instance.Spec.DBType)
+
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, []string{
+		"ovn-appctl", "-t", fmt.Sprintf("/tmp/%s.ctl", dbFile), "cluster/status", dbName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	probes := []struct {
+		name string
+		cmd  []string
+	}{
+		{"memory/show", []string{
+			"ovn-appctl", "-t", fmt.Sprintf("/tmp/%s.ctl", dbFile), "memory/show",
+		}},
+		{"database size", []string{
+			"stat", "-c", "Database size: %s", fmt.Sprintf("/etc/ovn/%s.db", dbFile),
+		}},
+		{"version", []string{
+			"ovn-appctl", "-t", fmt.Sprintf("/tmp/%s.ctl", dbFile), "version",
+		}},
+		{"schema version", []string{
+			"/bin/bash", "-c", fmt.Sprintf(
+				"version=$(ovsdb-client get-schema-version unix:/tmp/%s.sock %s) && "+
+					"echo \"Schema version: ${version}\"", dbFile, dbName),
+		}},
+	}
+	for _, probe := range probes {
+		probeOutput, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, probe.cmd)
+		if err != nil {
+			helper.GetLogger().Info(fmt.Sprintf("Failed to probe the %s of %s: %s", probe.name, pod.Name, err))
+			continue
+		}
+		output += "\n" + probeOutput
+	}
+
+	status := ParseClusterStatus(output)
+	status.Member.Name = pod.Name
+	return status, nil
+}
+
+// ParseClusterStatus - parse the output of ovsdb-server cluster/status,
+// optionally followed by the memory/show counters, the database size and
+// the versions
+func ParseClusterStatus(output string) *ClusterStatus {
+	status := &ClusterStatus{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, "sessions:") {
+			parseMemoryShow(line, &status.Member)
+			continue
+		}
+		if version, found := strings.CutPrefix(line, "ovsdb-server (Open vSwitch) "); found {
+			status.Member.Version = strings.TrimSpace(version)
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Cluster ID":
+			status.ClusterID = fullID(value)
+		case "Server ID":
+			status.Member.ServerID = fullID(value)
+		case "Address":
+			status.Member.Address = value
+		case "Role":
+			status.Member.Role = value
+		case "Entries not yet applied":
+			status.Member.Lag = parseInt(value)
+		case "Term":
+			status.Member.Term = parseInt(value)
+		case "Log":
+			status.Member.LogIndex = parseLogIndex(value)
+		case "Election timer":
+			status.Member.ElectionTimer = parseInt(value)
+		case "Database size":
+			status.Member.DBSize = parseInt(value)
+		case "Schema version":
+			status.Member.SchemaVersion = value
+		}
+	}
+	return status
+}
+
+// parseMemoryShow - parse the "name:count" pairs of ovsdb-server memory/show
+func parseMemoryShow(line string, member *ovnv1.OVNDBClusterMember) {
+	for _, field := range strings.Fields(line) {
+		name, count, _ := strings.Cut(field, ":")
+		switch name {
+		case "sessions":
+			member.Sessions = parseInt(count)
+		case "monitors":
+			member.Monitors = parseInt(count)
+		}
+	}
+}
+
+// parseLogIndex - the end index of a "[start, end]" RAFT log
+func parseLogIndex(value string) int64 {
+	_, end, found := strings.Cut(strings.Trim(value, "[]"), ",")
+	if !found {
+		return 0
+	}
+	return parseInt(strings.TrimSpace(end))
+}
+
+// parseInt - parse a counter, 0 when it is not a number
+func parseInt(value string) int64 {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// Leader - name of the member reporting to be the RAFT leader, empty when
+// there is none
+func Leader(members []ovnv1.OVNDBClusterMember) string {
+	for _, member := range members {
+		if member.Role == "leader" {
+			return member.Name
+		}
+	}
+	return ""
+}
+
+// fullID - extract the full UUID from a "abcd (abcd1234-...)" formatted ID
+func fullID(value string) string {
+	if _, id, found := strings.Cut(value, "("); found {
+		return strings.TrimSuffix(id, ")")
+	}
+	return value
+}
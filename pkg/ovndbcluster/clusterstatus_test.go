@@ -0,0 +1,193 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+)
+
+const leaderStatus = `b8a8
+Name: OVN_Southbound
+Cluster ID: 3f4a (3f4a6c0e-0a3b-4c1d-9e3f-6a7b8c9d0e1f)
+Server ID: b8a8 (b8a8c5d0-1b2c-4d3e-8f4a-5b6c7d8e9f0a)
+Address: ssl:ovsdbserver-sb-0.ovsdbserver-sb.openstack.svc:6644
+Status: cluster member
+Role: leader
+Term: 3
+Leader: self
+Vote: self
+
+Last Election started 86400000 ms ago, reason: timeout
+Last Election won: 86400000 ms ago
+Election timer: 10000
+Log: [2, 1234]
+Entries not yet committed: 0
+Entries not yet applied: 0
+Connections: ->1a2b ->2c3d <-1a2b <-2c3d
+Disconnections: 0
+Servers:
+    b8a8 (b8a8 at ssl:ovsdbserver-sb-0.ovsdbserver-sb.openstack.svc:6644) (self) next_index=2 match_index=1233
+    1a2b (1a2b at ssl:ovsdbserver-sb-1.ovsdbserver-sb.openstack.svc:6644) next_index=1234 match_index=1233
+`
+
+const followerStatus = `1a2b
+Name: OVN_Southbound
+Cluster ID: 3f4a (3f4a6c0e-0a3b-4c1d-9e3f-6a7b8c9d0e1f)
+Server ID: 1a2b (1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d)
+Address: ssl:ovsdbserver-sb-1.ovsdbserver-sb.openstack.svc:6644
+Status: cluster member
+Role: follower
+Term: 3
+Leader: b8a8
+Vote: b8a8
+
+Election timer: 10000
+Log: [2, 1230]
+Entries not yet committed: 2
+Entries not yet applied: 4
+Connections: ->b8a8 <-b8a8
+Disconnections: 1
+Servers:
+    b8a8 (b8a8 at ssl:ovsdbserver-sb-0.ovsdbserver-sb.openstack.svc:6644)
+    1a2b (1a2b at ssl:ovsdbserver-sb-1.ovsdbserver-sb.openstack.svc:6644) (self)
+`
+
+const candidateStatus = `2c3d
+Name: OVN_Southbound
+Cluster ID: 3f4a (3f4a6c0e-0a3b-4c1d-9e3f-6a7b8c9d0e1f)
+Server ID: 2c3d (2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f)
+Address: ssl:ovsdbserver-sb-2.ovsdbserver-sb.openstack.svc:6644
+Status: cluster member
+Role: candidate
+Term: 5
+Leader: unknown
+Vote: self
+
+Election timer: 10000
+Log: [2, 1220]
+Entries not yet committed: 0
+Entries not yet applied: 0
+Connections:
+Disconnections: 2
+Servers:
+    2c3d (2c3d at ssl:ovsdbserver-sb-2.ovsdbserver-sb.openstack.svc:6644) (self)
+`
+
+const probesOutput = `
+cells:123456 monitors:3 n-weak-refs:0 raft-connections:4 raft-log:1232 sessions:12 txn-history:100 txn-history-fields:2000
+Database size: 4194304
+ovsdb-server (Open vSwitch) 3.1.2
+Schema version: 20.27.0
+`
+
+func TestParseClusterStatusLeader(t *testing.T) {
+	g := NewWithT(t)
+
+	status := ParseClusterStatus(leaderStatus + probesOutput)
+
+	g.Expect(status.ClusterID).To(Equal("3f4a6c0e-0a3b-4c1d-9e3f-6a7b8c9d0e1f"))
+	g.Expect(status.Member).To(Equal(ovnv1.OVNDBClusterMember{
+		ServerID:      "b8a8c5d0-1b2c-4d3e-8f4a-5b6c7d8e9f0a",
+		Address:       "ssl:ovsdbserver-sb-0.ovsdbserver-sb.openstack.svc:6644",
+		Role:          "leader",
+		Term:          3,
+		LogIndex:      1234,
+		ElectionTimer: 10000,
+		DBSize:        4194304,
+		Sessions:      12,
+		Monitors:      3,
+		Version:       "3.1.2",
+		SchemaVersion: "20.27.0",
+	}))
+}
+
+func TestParseClusterStatusFollower(t *testing.T) {
+	g := NewWithT(t)
+
+	status := ParseClusterStatus(followerStatus + probesOutput)
+
+	g.Expect(status.Member.ServerID).To(Equal("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"))
+	g.Expect(status.Member.Role).To(Equal("follower"))
+	g.Expect(status.Member.Term).To(Equal(int64(3)))
+	g.Expect(status.Member.LogIndex).To(Equal(int64(1230)))
+	g.Expect(status.Member.Lag).To(Equal(int64(4)))
+}
+
+func TestParseClusterStatusCandidate(t *testing.T) {
+	g := NewWithT(t)
+
+	status := ParseClusterStatus(candidateStatus + probesOutput)
+
+	g.Expect(status.Member.Role).To(Equal("candidate"))
+	g.Expect(status.Member.Term).To(Equal(int64(5)))
+	g.Expect(status.Member.LogIndex).To(Equal(int64(1220)))
+	g.Expect(Leader([]ovnv1.OVNDBClusterMember{status.Member})).To(BeEmpty())
+}
+
+func TestParseClusterStatusWithoutProbes(t *testing.T) {
+	g := NewWithT(t)
+
+	// the optional probes failed, only cluster/status was returned
+	status := ParseClusterStatus(leaderStatus)
+
+	g.Expect(status.Member.Role).To(Equal("leader"))
+	g.Expect(status.Member.Term).To(Equal(int64(3)))
+	g.Expect(status.Member.LogIndex).To(Equal(int64(1234)))
+	g.Expect(status.Member.DBSize).To(BeZero())
+	g.Expect(status.Member.Sessions).To(BeZero())
+	g.Expect(status.Member.Version).To(BeEmpty())
+	g.Expect(status.Member.SchemaVersion).To(BeEmpty())
+}
+
+func TestParseClusterStatusPartial(t *testing.T) {
+	g := NewWithT(t)
+
+	// cut while the server was still joining the cluster
+	status := ParseClusterStatus(`b8a8
+Name: OVN_Southbound
+Cluster ID: not yet known
+Server ID: b8a8 (b8a8c5d0-1b2c-4d3e-8f4a-5b6c7d8e9f0a)
+Address: ssl:ovsdbserver-sb-0.ovsdbserver-sb.openstack.svc:6644
+Status: joining cluster
+Role: follower
+Term: unknown
+Log: [0,
+`)
+
+	g.Expect(status.ClusterID).To(Equal("not yet known"))
+	g.Expect(status.Member.ServerID).To(Equal("b8a8c5d0-1b2c-4d3e-8f4a-5b6c7d8e9f0a"))
+	g.Expect(status.Member.Role).To(Equal("follower"))
+	g.Expect(status.Member.Term).To(BeZero())
+	g.Expect(status.Member.LogIndex).To(BeZero())
+	g.Expect(status.Member.ElectionTimer).To(BeZero())
+}
+
+func TestLeader(t *testing.T) {
+	g := NewWithT(t)
+
+	members := []ovnv1.OVNDBClusterMember{
+		ParseClusterStatus(followerStatus).Member,
+		ParseClusterStatus(leaderStatus).Member,
+		ParseClusterStatus(candidateStatus).Member,
+	}
+	members[0].Name = "ovsdbserver-sb-1"
+	members[1].Name = "ovsdbserver-sb-0"
+	members[2].Name = "ovsdbserver-sb-2"
+
+	g.Expect(Leader(members)).To(Equal("ovsdbserver-sb-0"))
+	g.Expect(Leader(members[:1])).To(BeEmpty())
+}
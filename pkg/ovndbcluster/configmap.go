@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+)
+
+// ScriptsConfigMapName - ConfigMap holding the scripts of the ovsdb-server pods
+func ScriptsConfigMapName(instance *ovnv1.OVNDBCluster) string {
+	return fmt.Sprintf("%s-scripts", instance.Name)
+}
+
+// EnsureScriptsConfigMap - create or update the scripts ConfigMap, its hash
+// is added to envVars
+func EnsureScriptsConfigMap(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNDBCluster,
+	envVars *map[string]env.Setter,
+) (err error) {
+	ctx, span := ovn_common.StartSpan(ctx, "EnsureScriptsConfigMap")
+	defer func() { ovn_common.EndSpan(span, err) }()
+
+	serviceName := instance.GetServiceName()
+	// Create/update configmaps from templates
+	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(serviceName), map[string]string{})
+
+	templateParameters := make(map[string]interface{})
+
+	templateParameters["OVN_LOG_LEVEL"] = instance.Spec.LogLevel
+	templateParameters["OVN_LOG_MODULES"] = instance.Spec.LogModules
+	templateParameters["OVN_EXTRA_ARGS"] = ovn_common.ShellExtraArgs(instance.Spec.ExtraArgs)
+	templateParameters["OVN_LOG_FILE"] = ""
+	if instance.Spec.LogFile.Enabled {
+		templateParameters["OVN_LOG_FILE"] = fmt.Sprintf("%s/ovsdb-server-%s.log", LogDir, strings.ToLower(instance.Spec.DBType))
+		templateParameters["OVN_LOG_MAX_SIZE"] = int64(instance.Spec.LogFile.MaxSizeMB) * 1024 * 1024
+		templateParameters["OVN_LOG_MAX_FILES"] = instance.Spec.LogFile.MaxFiles
+	}
+	templateParameters["SERVICE_NAME"] = serviceName
+	templateParameters["NAMESPACE"] = instance.GetNamespace()
+	templateParameters["DB_TYPE"] = CtlDBType(instance.Spec.DBType)
+	templateParameters["DB_NAME"] = DBName(instance.Spec.DBType)
+	templateParameters["DB_FILE"] = DBFileName(instance.Spec.DBType)
+	templateParameters["DB_PORT"], templateParameters["RAFT_PORT"] = DBPorts(instance.Spec.DBType)
+	templateParameters["IC"] = instance.Spec.DBType == ovnv1.ICNBDBType || instance.Spec.DBType == ovnv1.ICSBDBType
+	templateParameters["OVN_ELECTION_TIMER"] = instance.TunedElectionTimer()
+	templateParameters["OVN_INACTIVITY_PROBE"] = instance.TunedInactivityProbe()
+	templateParameters["OVN_PROBE_INTERVAL_TO_ACTIVE"] = instance.TunedProbeIntervalToActive()
+	templateParameters["TLS"] = instance.Spec.TLS.Enabled()
+	templateParameters["FIPS"] = instance.Spec.FIPS
+	templateParameters["SSL_PROTOCOLS"] = ovn_common.FIPSSSLProtocols
+	templateParameters["SSL_CIPHERS"] = ovn_common.FIPSSSLCiphers
+	templateParameters["OVNDB_CERT_PATH"] = ovn_common.OVNDbCertPath
+	templateParameters["OVNDB_KEY_PATH"] = ovn_common.OVNDbKeyPath
+	templateParameters["OVNDB_CACERT_PATH"] = ovn_common.OVNDbCaCertPath
+	templateParameters["DB_INTEGRITY_ERROR"] = DBIntegrityError
+	templateParameters["DB_SCHEMA"] = DBSchemaFile(instance.Spec.DBType)
+	templateParameters["SCHEMA_CONVERSION_ERROR"] = SchemaConversionError
+
+	cms := []util.Template{
+		// ScriptsConfigMap
+		{
+			Name:          ScriptsConfigMapName(instance),
+			Namespace:     instance.Namespace,
+			Type:          util.TemplateTypeScripts,
+			InstanceType:  instance.Kind,
+			Labels:        cmLabels,
+			ConfigOptions: templateParameters,
+		},
+	}
+	return configmap.EnsureConfigMaps(ctx, h, instance, cms, envVars)
+}
@@ -0,0 +1,240 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// checkClusterFailed - prefix of the output of checkClusterCmd when the
+// database file of the member is inconsistent
+const checkClusterFailed = "check-cluster failed: "
+
+// checkClusterCmd - check the RAFT log of the database file of the member,
+// the command itself only fails when it can't be run
+const checkClusterCmd = "if ! OUT=$(ovsdb-tool check-cluster /etc/ovn/%s.db 2>&1); then echo \"" + checkClusterFailed + "${OUT}\"; fi"
+
+// nbDatapathsCmd - list the UUID of the logical switches and routers from
+// the local NB DB replica
+const nbDatapathsCmd = "NBCTL='ovn-nbctl --no-leader-only --db=unix:/tmp/ovnnb_db.sock -f csv --data=bare --no-headings --columns=_uuid'; " +
+	"${NBCTL} list Logical_Switch && ${NBCTL} list Logical_Router"
+
+// sbDatapathsCmd - list the datapath bindings with their external IDs and
+// the number of logical flows per datapath from the local SB DB replica,
+// each line prefixed with its table
+const sbDatapathsCmd = "SBCTL='ovn-sbctl --no-leader-only --db=unix:/tmp/ovnsb_db.sock -f csv --data=bare --no-headings'; " +
+	"${SBCTL} --columns=_uuid,external_ids list Datapath_Binding | sed 's/^/Datapath_Binding,/' && " +
+	"${SBCTL} --columns=logical_datapath list Logical_Flow | sort | uniq -c | awk '{print \"Logical_Flow,\" $2 \",\" $1}'"
+
+// Consistency - the problems found by a consistency check
+type Consistency struct {
+	// CorruptedMembers - pods whose database file failed ovsdb-tool
+	// check-cluster
+	CorruptedMembers []string
+	// MissingDatapathBindings - UUID of the NB logical switches and routers
+	// without datapath binding in the SB DB, nil when the datapaths were not
+	// cross-checked
+	MissingDatapathBindings []string
+	// OrphanedDatapathBindings - UUID of the SB datapath bindings of logical
+	// switches and routers missing in the NB DB
+	OrphanedDatapathBindings []string
+	// OrphanedLogicalFlows - number of logical flows of the orphaned datapath
+	// bindings
+	OrphanedLogicalFlows int64
+}
+
+// Problems - the problems found, in the words of the ConsistencyReady
+// condition
+func (c *Consistency) Problems() []string {
+	problems := []string{}
+	if len(c.CorruptedMembers) > 0 {
+		problems = append(problems, fmt.Sprintf("check-cluster failed on pods %s", strings.Join(c.CorruptedMembers, ", ")))
+	}
+	if len(c.MissingDatapathBindings) > 0 {
+		problems = append(problems, fmt.Sprintf("%d NB logical switches and routers without datapath binding", len(c.MissingDatapathBindings)))
+	}
+	if len(c.OrphanedDatapathBindings) > 0 {
+		problems = append(problems, fmt.Sprintf("%d datapath bindings with %d logical flows missing in the NB DB",
+			len(c.OrphanedDatapathBindings), c.OrphanedLogicalFlows))
+	}
+	return problems
+}
+
+// consistencyProblems - number of problems per check found by the last
+// consistency check of the DB
+var consistencyProblems = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ovn_db_consistency_problems",
+	Help: "Number of problems found by the last consistency check of the DB",
+}, []string{"namespace", "name", "db_type", "check"})
+
+func init() {
+	metrics.Registry.MustRegister(consistencyProblems)
+}
+
+// CheckCluster - run ovsdb-tool check-cluster on the database file of the
+// member, the error of an inconsistent file is returned as its output
+func CheckCluster(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	instance *ovnv1.OVNDBCluster,
+	pod *corev1.Pod,
+) (string, error) {
+	cmd := []string{"/bin/bash", "-c", fmt.Sprintf(checkClusterCmd, DBFileName(instance.Spec.DBType))}
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, cmd)
+	if err != nil {
+		return "", err
+	}
+	return ParseCheckCluster(output), nil
+}
+
+// ParseCheckCluster - the error reported by checkClusterCmd, empty when the
+// database file is consistent
+func ParseCheckCluster(output string) string {
+	if _, failure, found := strings.Cut(output, checkClusterFailed); found {
+		return strings.TrimSpace(failure)
+	}
+	return ""
+}
+
+// CheckDatapaths - cross-check the datapath bindings of the SB DB served by
+// sbPod and their logical flows with the logical switches and routers of the
+// NB DB served by nbPod. The NB DB is listed first, so that only the
+// switches and routers ovn-northd did not process are reported missing.
+func CheckDatapaths(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	sbPod *corev1.Pod,
+	nbPod *corev1.Pod,
+	consistency *Consistency,
+) error {
+	nbOutput, err := ovn_common.ExecInPod(ctx, helper, restConfig, nbPod, []string{"/bin/bash", "-c", nbDatapathsCmd})
+	if err != nil {
+		return err
+	}
+	sbOutput, err := ovn_common.ExecInPod(ctx, helper, restConfig, sbPod, []string{"/bin/bash", "-c", sbDatapathsCmd})
+	if err != nil {
+		return err
+	}
+	return ParseDatapaths(nbOutput, sbOutput, consistency)
+}
+
+// ParseDatapaths - compare the output of nbDatapathsCmd with the table
+// prefixed csv records of sbDatapathsCmd
+func ParseDatapaths(nbOutput string, sbOutput string, consistency *Consistency) error {
+	nbDatapaths := map[string]bool{}
+	for _, uuid := range strings.Fields(nbOutput) {
+		nbDatapaths[uuid] = true
+	}
+
+	// NB UUID of the switch or router of each datapath binding
+	bindings := map[string]string{}
+	flows := map[string]int64{}
+	reader := csv.NewReader(strings.NewReader(sbOutput))
+	reader.FieldsPerRecord = -1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(record) < 3 || record[1] == "" {
+			continue
+		}
+		switch record[0] {
+		case "Datapath_Binding":
+			for _, externalID := range strings.Fields(record[2]) {
+				key, value, _ := strings.Cut(externalID, "=")
+				if key == "logical-switch" || key == "logical-router" {
+					bindings[record[1]] = value
+				}
+			}
+		case "Logical_Flow":
+			count, err := strconv.ParseInt(record[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid logical flow count %q: %w", record[2], err)
+			}
+			flows[record[1]] = count
+		}
+	}
+
+	bound := map[string]bool{}
+	consistency.OrphanedDatapathBindings = []string{}
+	consistency.OrphanedLogicalFlows = 0
+	for binding, datapath := range bindings {
+		bound[datapath] = true
+		if !nbDatapaths[datapath] {
+			consistency.OrphanedDatapathBindings = append(consistency.OrphanedDatapathBindings, binding)
+			consistency.OrphanedLogicalFlows += flows[binding]
+		}
+	}
+	consistency.MissingDatapathBindings = []string{}
+	for datapath := range nbDatapaths {
+		if !bound[datapath] {
+			consistency.MissingDatapathBindings = append(consistency.MissingDatapathBindings, datapath)
+		}
+	}
+	sort.Strings(consistency.OrphanedDatapathBindings)
+	sort.Strings(consistency.MissingDatapathBindings)
+	return nil
+}
+
+// SetConsistencyMetrics - publish the number of problems per check of the
+// last consistency check of the instance. The datapaths are only reported
+// once they were cross-checked.
+func SetConsistencyMetrics(instance *ovnv1.OVNDBCluster, consistency *Consistency) {
+	DeleteConsistencyMetrics(instance)
+
+	checks := map[string]int{
+		"check_cluster": len(consistency.CorruptedMembers),
+	}
+	if consistency.MissingDatapathBindings != nil {
+		checks["missing_datapath_bindings"] = len(consistency.MissingDatapathBindings)
+		checks["orphaned_datapath_bindings"] = len(consistency.OrphanedDatapathBindings)
+		checks["orphaned_logical_flows"] = int(consistency.OrphanedLogicalFlows)
+	}
+	for check, count := range checks {
+		consistencyProblems.With(prometheus.Labels{
+			"namespace": instance.Namespace,
+			"name":      instance.Name,
+			"db_type":   instance.Spec.DBType,
+			"check":     check,
+		}).Set(float64(count))
+	}
+}
+
+// DeleteConsistencyMetrics - remove the consistency metrics of the instance
+func DeleteConsistencyMetrics(instance *ovnv1.OVNDBCluster) {
+	consistencyProblems.DeletePartialMatch(prometheus.Labels{
+		"namespace": instance.Namespace,
+		"name":      instance.Name,
+	})
+}
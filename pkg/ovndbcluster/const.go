@@ -8,4 +8,31 @@ const (
 	// ServiceNameSB -
 	DbPortSB   int32 = 6642
 	RaftPortSB int32 = 6644
+
+	// ServiceNameICNB -
+	DbPortICNB   int32 = 6645
+	RaftPortICNB int32 = 6647
+
+	// ServiceNameICSB -
+	DbPortICSB   int32 = 6646
+	RaftPortICSB int32 = 6648
+)
+
+const (
+	// DBIntegrityError - prefix of the termination message written by the
+	// setup script when ovsdb-tool check-cluster fails on the database file
+	DBIntegrityError = "OVSDB integrity check failed"
+
+	// SchemaConversionError - prefix of the termination message written by
+	// the schema upgrade script when ovsdb-tool convert fails
+	SchemaConversionError = "OVSDB schema conversion failed"
+
+	// SchemaUpgradeHash - key of the schema upgrade Job hash in the status
+	SchemaUpgradeHash = "schemaupgrade"
+)
+
+const (
+	// LogDir - directory holding the ovsdb-server log files when file
+	// logging is enabled
+	LogDir = "/var/log/ovn"
 )
@@ -0,0 +1,88 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"strings"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+)
+
+// DBName - return the name of the database schema served for the DBType
+func DBName(dbType string) string {
+	switch dbType {
+	case ovnv1.SBDBType:
+		return "OVN_Southbound"
+	case ovnv1.ICNBDBType:
+		return "OVN_IC_Northbound"
+	case ovnv1.ICSBDBType:
+		return "OVN_IC_Southbound"
+	}
+	return "OVN_Northbound"
+}
+
+// CtlDBType - return the database name as used by the ovn-ctl run_<db>_ovsdb
+// commands
+func CtlDBType(dbType string) string {
+	switch dbType {
+	case ovnv1.SBDBType:
+		return "sb"
+	case ovnv1.ICNBDBType:
+		return "ic_nb"
+	case ovnv1.ICSBDBType:
+		return "ic_sb"
+	}
+	return "nb"
+}
+
+// DBFileName - return the base name ovn-ctl uses for the database file and
+// the ovsdb-server control socket of the DBType
+func DBFileName(dbType string) string {
+	switch dbType {
+	case ovnv1.ICNBDBType, ovnv1.ICSBDBType:
+		return "ovn_" + CtlDBType(dbType) + "_db"
+	}
+	return "ovn" + CtlDBType(dbType) + "_db"
+}
+
+// DBSchemaFile - return the name of the schema file shipped in the image
+// for the DBType
+func DBSchemaFile(dbType string) string {
+	return "ovn-" + strings.ReplaceAll(CtlDBType(dbType), "_", "-") + ".ovsschema"
+}
+
+// DBPorts - return the database and RAFT ports of the DBType
+func DBPorts(dbType string) (int32, int32) {
+	switch dbType {
+	case ovnv1.SBDBType:
+		return DbPortSB, RaftPortSB
+	case ovnv1.ICNBDBType:
+		return DbPortICNB, RaftPortICNB
+	case ovnv1.ICSBDBType:
+		return DbPortICSB, RaftPortICSB
+	}
+	return DbPortNB, RaftPortNB
+}
+
+// portName - return the prefix of the Service port names of the DBType
+func portName(dbType string) string {
+	switch dbType {
+	case ovnv1.SBDBType:
+		return "south"
+	case ovnv1.ICNBDBType:
+		return "ic-north"
+	case ovnv1.ICSBDBType:
+		return "ic-south"
+	}
+	return "north"
+}
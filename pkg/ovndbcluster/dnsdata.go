@@ -7,9 +7,11 @@ import (
 	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // DNSData - Create DNS entry that openstack dnsmasq will resolve
@@ -42,24 +44,45 @@ func DNSData(
 			Namespace: instance.Namespace,
 			Labels:    serviceLabels,
 		},
+		Spec: infranetworkv1.DNSDataSpec{
+			Hosts: dnsHosts,
+			// TODO: use value from DNSMasq instance instead of hardcode
+			DNSDataLabelSelectorValue: "dnsdata",
+		},
 	}
 
-	_, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), dnsData, func() error {
-		dnsData.Spec.Hosts = dnsHosts
-		// TODO: use value from DNSMasq instance instead of hardcode
-		dnsData.Spec.DNSDataLabelSelectorValue = "dnsdata"
-		err := controllerutil.SetControllerReference(helper.GetBeforeObject(), dnsData, helper.GetScheme())
-		if err != nil {
-			return err
-		}
-		return nil
-	})
+	err := ovn_common.Apply(ctx, helper, dnsData)
 	if err != nil {
 		return fmt.Errorf("Error creating DNSData %s: %w", dnsData.Name, err)
 	}
 	return nil
 }
 
+// DeleteDNSData - remove the DNS entry of a cluster not attached to an
+// external network anymore, so the dataplane nodes don't resolve stale IPs
+func DeleteDNSData(
+	ctx context.Context,
+	helper *helper.Helper,
+	serviceName string,
+	instance *ovnv1.OVNDBCluster,
+) error {
+	dnsData := &infranetworkv1.DNSData{}
+	err := helper.GetClient().Get(ctx, types.NamespacedName{Name: serviceName, Namespace: instance.Namespace}, dnsData)
+	if k8s_errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Error getting DNSData %s: %w", serviceName, err)
+	}
+	if !metav1.IsControlledBy(dnsData, instance) {
+		return nil
+	}
+	err = helper.GetClient().Delete(ctx, dnsData)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("Error deleting DNSData %s: %w", serviceName, err)
+	}
+	return nil
+}
+
 // GetDBAddress - return string connection for the given service
 func GetDBAddress(svc *corev1.Service, serviceName string, namespace string, scheme string) string {
 	if svc == nil {
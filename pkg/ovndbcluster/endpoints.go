@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PublishesEndpoints - whether the DB type is published in the endpoints
+// ConfigMap, the OVN Interconnect DBs are not
+func PublishesEndpoints(dbType string) bool {
+	return dbType == ovnv1.NBDBType || dbType == ovnv1.SBDBType
+}
+
+// EnsureEndpoints - publish the connection details of the DB in the
+// endpoints ConfigMap shared by the NB and SB OVNDBClusters of the namespace
+func EnsureEndpoints(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNDBCluster,
+) error {
+	dbType := instance.Spec.DBType
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ovnv1.EndpointsConfigMapName,
+			Namespace: instance.Namespace,
+		},
+		Data: map[string]string{
+			ovnv1.EndpointsSchemaVersionKey:                               ovnv1.EndpointsSchemaVersion,
+			ovnv1.EndpointsKey(dbType, ovnv1.ConnectionInternalURLKey):    instance.Status.InternalDBAddress,
+			ovnv1.EndpointsKey(dbType, ovnv1.ConnectionExternalURLKey):    instance.Status.DBAddress,
+			ovnv1.EndpointsKey(dbType, ovnv1.ConnectionTLSKey):            strconv.FormatBool(instance.Spec.TLS.Enabled()),
+			ovnv1.EndpointsKey(dbType, ovnv1.ConnectionCABundleSecretKey): instance.Spec.TLS.CaBundleSecretName,
+		},
+	}
+	err := ovn_common.ApplyShared(ctx, h, cm, strings.ToLower(dbType))
+	if err != nil {
+		return fmt.Errorf("error publishing the %s endpoints in ConfigMap %s: %w", dbType, cm.Name, err)
+	}
+	return nil
+}
+
+// DeleteEndpoints - remove the connection details of the DB from the
+// endpoints ConfigMap, which is deleted with the last of the DBs
+func DeleteEndpoints(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNDBCluster,
+) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ovnv1.EndpointsConfigMapName,
+			Namespace: instance.Namespace,
+		},
+	}
+	err := ovn_common.ReleaseShared(ctx, h, cm, strings.ToLower(instance.Spec.DBType))
+	if err != nil {
+		return fmt.Errorf("error removing the %s endpoints from ConfigMap %s: %w", instance.Spec.DBType, cm.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// staleSBRecordsCmd - list the Port_Binding, MAC_Binding, Chassis, Encap
+// and Chassis_Private records from the local SB DB replica, each line
+// prefixed with its table
+const staleSBRecordsCmd = "SBCTL='ovn-sbctl --no-leader-only --db=unix:/tmp/ovnsb_db.sock -f csv --data=bare --no-headings'; " +
+	"${SBCTL} --columns=_uuid,logical_port,type list Port_Binding | sed 's/^/Port_Binding,/' && " +
+	"${SBCTL} --columns=_uuid,logical_port list MAC_Binding | sed 's/^/MAC_Binding,/' && " +
+	"${SBCTL} --columns=name,hostname list Chassis | sed 's/^/Chassis,/' && " +
+	"${SBCTL} --columns=chassis_name list Encap | sed 's/^/Encap,/' && " +
+	"${SBCTL} --columns=name list Chassis_Private | sed 's/^/Chassis_Private,/'"
+
+// logicalSwitchPortsCmd - list the names of the Logical_Switch_Ports from
+// the NB DB, only when the local ovsdb-server is the RAFT leader so that the
+// ports committed since the SB DB was listed are not missed
+const logicalSwitchPortsCmd = "ovn-nbctl --db=unix:/tmp/ovnnb_db.sock -f csv --data=bare --no-headings " +
+	"--columns=name list Logical_Switch_Port"
+
+// staleRecordsBatch - number of records deleted per SB DB transaction
+const staleRecordsBatch = 100
+
+// StaleSBRecords - the SB DB records left behind, e.g. by a northd outage or
+// by nodes removed without stopping their ovn-controller
+type StaleSBRecords struct {
+	// PortBindings - UUID of the VIF port bindings without a logical switch
+	// port in the NB DB. They are only reported, ovn-northd removes them
+	// once it catches up with the NB DB.
+	PortBindings []string
+	// MACBindings - UUID of the MAC bindings of ports without a port binding
+	MACBindings []string
+	// Chassis - name of the chassis lacking their encap or Chassis_Private
+	// record which are not registered by any of the nodes
+	Chassis []string
+	// NewChassis - name of the chassis of Chassis the previous scan didn't
+	// find. ovn-controller leaves its chassis half registered for a moment
+	// while it registers, they are only deleted once found by two scans in a
+	// row.
+	NewChassis []string
+}
+
+// Count - the number of stale records
+func (stale *StaleSBRecords) Count() int {
+	return len(stale.PortBindings) + len(stale.MACBindings) + len(stale.Chassis) + len(stale.NewChassis)
+}
+
+// HoldNewChassis - move the chassis the previous scan didn't find to
+// NewChassis, they are not deleted until the next scan
+func (stale *StaleSBRecords) HoldNewChassis(previous []string) {
+	found := map[string]bool{}
+	for _, name := range previous {
+		found[name] = true
+	}
+	confirmed := []string{}
+	for _, name := range stale.Chassis {
+		if found[name] {
+			confirmed = append(confirmed, name)
+		} else {
+			stale.NewChassis = append(stale.NewChassis, name)
+		}
+	}
+	stale.Chassis = confirmed
+}
+
+// AllChassis - the stale chassis found by the scan, held or not
+func (stale *StaleSBRecords) AllChassis() []string {
+	all := append(append([]string{}, stale.Chassis...), stale.NewChassis...)
+	sort.Strings(all)
+	return all
+}
+
+// Deletable - the number of stale records the janitor removes itself
+func (stale *StaleSBRecords) Deletable() int {
+	return len(stale.MACBindings) + len(stale.Chassis)
+}
+
+// staleSBRecords - number of stale records per table found by the last scan
+// of the SB DB
+var staleSBRecords = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ovn_sb_stale_records",
+	Help: "Number of stale SB DB records found by the last scan of the janitor",
+}, []string{"namespace", "name", "table"})
+
+func init() {
+	metrics.Registry.MustRegister(staleSBRecords)
+}
+
+// GetStaleSBRecords - scan the SB DB served by sbPod for stale records. The
+// port bindings are checked against the NB DB served by nbPod, the RAFT
+// leader of the NB DB, and only when it is given. The SB DB is listed first
+// so that the ports created meanwhile are not reported.
+func GetStaleSBRecords(
+	ctx context.Context,
+	exec ovn_common.ExecFunc,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	sbPod *corev1.Pod,
+	nbPod *corev1.Pod,
+	nodes []corev1.Node,
+) (*StaleSBRecords, error) {
+	output, err := exec(ctx, helper, restConfig, sbPod, []string{"/bin/bash", "-c", staleSBRecordsCmd})
+	if err != nil {
+		return nil, err
+	}
+	var switchPorts map[string]bool
+	if nbPod != nil {
+		nbOutput, err := exec(ctx, helper, restConfig, nbPod, []string{"/bin/bash", "-c", logicalSwitchPortsCmd})
+		if err != nil {
+			return nil, err
+		}
+		switchPorts = map[string]bool{}
+		for _, name := range strings.Fields(nbOutput) {
+			switchPorts[name] = true
+		}
+	}
+	nodeNames := map[string]bool{}
+	for _, node := range nodes {
+		nodeNames[node.Name] = true
+	}
+	return ParseStaleSBRecords(output, switchPorts, nodeNames)
+}
+
+// ParseStaleSBRecords - parse the table prefixed csv records of
+// staleSBRecordsCmd. The port bindings are not checked when switchPorts is
+// nil.
+func ParseStaleSBRecords(output string, switchPorts map[string]bool, nodes map[string]bool) (*StaleSBRecords, error) {
+	vifPorts := map[string]string{}
+	boundPorts := map[string]bool{}
+	macBindings := map[string]string{}
+	chassisHosts := map[string]string{}
+	encaps := map[string]bool{}
+	privates := map[string]bool{}
+
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.FieldsPerRecord = -1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 || record[1] == "" {
+			continue
+		}
+		switch record[0] {
+		case "Port_Binding":
+			if len(record) < 4 {
+				continue
+			}
+			boundPorts[record[2]] = true
+			if record[3] == "" {
+				vifPorts[record[1]] = record[2]
+			}
+		case "MAC_Binding":
+			if len(record) < 3 {
+				continue
+			}
+			macBindings[record[1]] = record[2]
+		case "Chassis":
+			if len(record) < 3 {
+				continue
+			}
+			chassisHosts[record[1]] = record[2]
+		case "Encap":
+			encaps[record[1]] = true
+		case "Chassis_Private":
+			privates[record[1]] = true
+		}
+	}
+
+	stale := &StaleSBRecords{
+		PortBindings: []string{},
+		MACBindings:  []string{},
+		Chassis:      []string{},
+		NewChassis:   []string{},
+	}
+	if switchPorts != nil {
+		for uuid, port := range vifPorts {
+			if !switchPorts[port] {
+				stale.PortBindings = append(stale.PortBindings, uuid)
+			}
+		}
+	}
+	for uuid, port := range macBindings {
+		if !boundPorts[port] {
+			stale.MACBindings = append(stale.MACBindings, uuid)
+		}
+	}
+	// a chassis of a node outside of the cluster, e.g. an EDPM compute, is
+	// only stale once ovn-controller left it half deleted
+	for name, hostname := range chassisHosts {
+		if !nodes[hostname] && (!encaps[name] || !privates[name]) {
+			stale.Chassis = append(stale.Chassis, name)
+		}
+	}
+	sort.Strings(stale.PortBindings)
+	sort.Strings(stale.MACBindings)
+	sort.Strings(stale.Chassis)
+	return stale, nil
+}
+
+// DeleteStaleSBRecords - remove the stale MAC bindings and chassis from the
+// SB DB, in batches of transactions sent to the ovsdb-server of the given SB
+// DB pod, which forwards them to the RAFT leader. The port bindings are
+// owned by ovn-northd and left to it.
+func DeleteStaleSBRecords(
+	ctx context.Context,
+	exec ovn_common.ExecFunc,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	sbPod *corev1.Pod,
+	stale *StaleSBRecords,
+) error {
+	commands := [][]string{}
+	for _, uuid := range stale.MACBindings {
+		commands = append(commands, []string{"--if-exists", "destroy", "MAC_Binding", uuid})
+	}
+	for _, name := range stale.Chassis {
+		commands = append(commands,
+			[]string{"--if-exists", "chassis-del", name},
+			[]string{"--if-exists", "destroy", "Chassis_Private", name})
+	}
+
+	for start := 0; start < len(commands); start += staleRecordsBatch {
+		end := start + staleRecordsBatch
+		if end > len(commands) {
+			end = len(commands)
+		}
+		cmd := []string{"ovn-sbctl", "--no-leader-only", "--db=unix:/tmp/ovnsb_db.sock"}
+		for i, command := range commands[start:end] {
+			if i > 0 {
+				cmd = append(cmd, "--")
+			}
+			cmd = append(cmd, command...)
+		}
+		_, err := exec(ctx, helper, restConfig, sbPod, cmd)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetStaleSBRecordsMetrics - replace the stale record metrics of the
+// instance with the given scan results
+func SetStaleSBRecordsMetrics(instance *ovnv1.OVNDBCluster, stale *StaleSBRecords) {
+	for table, count := range map[string]int{
+		"Port_Binding": len(stale.PortBindings),
+		"MAC_Binding":  len(stale.MACBindings),
+		"Chassis":      len(stale.Chassis) + len(stale.NewChassis),
+	} {
+		staleSBRecords.With(prometheus.Labels{
+			"namespace": instance.Namespace,
+			"name":      instance.Name,
+			"table":     table,
+		}).Set(float64(count))
+	}
+}
+
+// DeleteStaleSBRecordsMetrics - remove the stale record metrics of the
+// instance
+func DeleteStaleSBRecordsMetrics(namespace string, name string) {
+	staleSBRecords.DeletePartialMatch(prometheus.Labels{
+		"namespace": namespace,
+		"name":      name,
+	})
+}
@@ -0,0 +1,207 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const staleSBRecordsOutput = `Port_Binding,pb-vif-bound,port-a,
+Port_Binding,pb-vif-orphaned,port-b,
+Port_Binding,pb-patch,patch-lr0-ls0,patch
+Port_Binding,pb-router,lrp-0,l3gateway
+MAC_Binding,mb-bound,port-a
+MAC_Binding,mb-router,lrp-0
+MAC_Binding,mb-stale,port-gone
+Chassis,chassis-node-0,node-0
+Chassis,chassis-edpm-0,edpm-compute-0
+Chassis,chassis-edpm-1,edpm-compute-1
+Chassis,chassis-edpm-2,edpm-compute-2
+Chassis,chassis-node-gone,node-gone
+Encap,chassis-node-0
+Encap,chassis-edpm-0
+Encap,chassis-edpm-2
+Chassis_Private,chassis-node-0
+Chassis_Private,chassis-edpm-0
+Chassis_Private,chassis-edpm-1
+`
+
+func TestParseStaleSBRecords(t *testing.T) {
+	g := NewWithT(t)
+
+	stale, err := ParseStaleSBRecords(staleSBRecordsOutput,
+		map[string]bool{"port-a": true},
+		map[string]bool{"node-0": true})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	// only the VIF ports are checked against the NB DB
+	g.Expect(stale.PortBindings).To(Equal([]string{"pb-vif-orphaned"}))
+	g.Expect(stale.MACBindings).To(Equal([]string{"mb-stale"}))
+	// edpm-compute-0 is complete, edpm-compute-1 lacks its encap,
+	// edpm-compute-2 its Chassis_Private and node-gone both of them
+	g.Expect(stale.Chassis).To(Equal([]string{"chassis-edpm-1", "chassis-edpm-2", "chassis-node-gone"}))
+	g.Expect(stale.Count()).To(Equal(5))
+	g.Expect(stale.Deletable()).To(Equal(4))
+}
+
+func TestParseStaleSBRecordsWithoutNB(t *testing.T) {
+	g := NewWithT(t)
+
+	stale, err := ParseStaleSBRecords(staleSBRecordsOutput, nil, map[string]bool{"node-0": true})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stale.PortBindings).To(BeEmpty())
+	g.Expect(stale.MACBindings).To(Equal([]string{"mb-stale"}))
+}
+
+func TestParseStaleSBRecordsOfNodes(t *testing.T) {
+	g := NewWithT(t)
+
+	// a half deleted chassis of a node of the cluster is left to its
+	// ovn-controller
+	stale, err := ParseStaleSBRecords(staleSBRecordsOutput, nil, map[string]bool{
+		"node-0":         true,
+		"edpm-compute-1": true,
+		"edpm-compute-2": true,
+		"node-gone":      true,
+	})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stale.Chassis).To(BeEmpty())
+}
+
+func TestParseStaleSBRecordsEmpty(t *testing.T) {
+	g := NewWithT(t)
+
+	stale, err := ParseStaleSBRecords("", map[string]bool{}, map[string]bool{})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stale.Count()).To(BeZero())
+}
+
+func TestParseStaleSBRecordsIncomplete(t *testing.T) {
+	g := NewWithT(t)
+
+	// records with missing columns are skipped
+	stale, err := ParseStaleSBRecords("Port_Binding,pb-short\nMAC_Binding,mb-short\nChassis,chassis-short\nEncap,\n",
+		map[string]bool{}, map[string]bool{})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stale.Count()).To(BeZero())
+}
+
+func TestParseStaleSBRecordsInvalid(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := ParseStaleSBRecords("MAC_Binding,\"mb-unterminated,port-a\n", nil, map[string]bool{})
+
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestHoldNewChassis(t *testing.T) {
+	g := NewWithT(t)
+
+	scan := func() *StaleSBRecords {
+		stale, err := ParseStaleSBRecords(staleSBRecordsOutput, nil, map[string]bool{"node-0": true})
+		g.Expect(err).ToNot(HaveOccurred())
+		return stale
+	}
+
+	// an EDPM compute caught registering by a single scan is not deleted
+	stale := scan()
+	stale.HoldNewChassis(nil)
+	g.Expect(stale.Chassis).To(BeEmpty())
+	g.Expect(stale.NewChassis).To(Equal([]string{"chassis-edpm-1", "chassis-edpm-2", "chassis-node-gone"}))
+	g.Expect(stale.Deletable()).To(Equal(1))
+	g.Expect(stale.Count()).To(Equal(5))
+	g.Expect(stale.AllChassis()).To(Equal([]string{"chassis-edpm-1", "chassis-edpm-2", "chassis-node-gone"}))
+
+	// the chassis found by the previous scan are deleted, edpm-compute-1
+	// wasn't and is held until the next one
+	stale = scan()
+	stale.HoldNewChassis([]string{"chassis-edpm-2", "chassis-node-gone", "chassis-registered"})
+	g.Expect(stale.Chassis).To(Equal([]string{"chassis-edpm-2", "chassis-node-gone"}))
+	g.Expect(stale.NewChassis).To(Equal([]string{"chassis-edpm-1"}))
+	g.Expect(stale.Deletable()).To(Equal(3))
+	g.Expect(stale.AllChassis()).To(Equal([]string{"chassis-edpm-1", "chassis-edpm-2", "chassis-node-gone"}))
+}
+
+// recordExec - an ExecFunc recording the commands instead of running them
+func recordExec(commands *[][]string) func(context.Context, *helper.Helper, *rest.Config, *corev1.Pod, []string) (string, error) {
+	return func(_ context.Context, _ *helper.Helper, _ *rest.Config, _ *corev1.Pod, cmd []string) (string, error) {
+		*commands = append(*commands, cmd)
+		return "", nil
+	}
+}
+
+func TestDeleteStaleSBRecords(t *testing.T) {
+	g := NewWithT(t)
+
+	commands := [][]string{}
+	err := DeleteStaleSBRecords(context.TODO(), recordExec(&commands), nil, nil,
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ovsdbserver-sb-0"}},
+		&StaleSBRecords{
+			PortBindings: []string{"pb-vif-orphaned"},
+			MACBindings:  []string{"mb-stale"},
+			Chassis:      []string{"chassis-edpm-1"},
+		})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(commands).To(Equal([][]string{{
+		"ovn-sbctl", "--no-leader-only", "--db=unix:/tmp/ovnsb_db.sock",
+		"--if-exists", "destroy", "MAC_Binding", "mb-stale",
+		"--", "--if-exists", "chassis-del", "chassis-edpm-1",
+		"--", "--if-exists", "destroy", "Chassis_Private", "chassis-edpm-1",
+	}}))
+}
+
+func TestDeleteStaleSBRecordsBatches(t *testing.T) {
+	g := NewWithT(t)
+
+	stale := &StaleSBRecords{}
+	for i := 0; i < 2*staleRecordsBatch+1; i++ {
+		stale.PortBindings = append(stale.PortBindings, fmt.Sprintf("pb-%d", i))
+		stale.MACBindings = append(stale.MACBindings, fmt.Sprintf("mb-%d", i))
+	}
+	commands := [][]string{}
+	err := DeleteStaleSBRecords(context.TODO(), recordExec(&commands), nil, nil, &corev1.Pod{}, stale)
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(commands).To(HaveLen(3))
+	for _, cmd := range commands {
+		g.Expect(strings.Join(cmd, " ")).ToNot(ContainSubstring("Port_Binding"))
+	}
+	g.Expect(commands[2]).To(HaveLen(3 + 4))
+}
+
+func TestDeleteStaleSBRecordsOnlyPortBindings(t *testing.T) {
+	g := NewWithT(t)
+
+	commands := [][]string{}
+	err := DeleteStaleSBRecords(context.TODO(), recordExec(&commands), nil, nil, &corev1.Pod{},
+		&StaleSBRecords{PortBindings: []string{"pb-vif-orphaned"}})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(commands).To(BeEmpty())
+}
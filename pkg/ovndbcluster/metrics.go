@@ -0,0 +1,114 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Per member RAFT and ovsdb-server metrics, served on the metrics endpoint
+// of the operator and refreshed every ClusterStatusInterval
+var (
+	memberLabels = []string{"namespace", "name", "db_type", "member"}
+
+	memberIsLeader = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_db_cluster_member_is_leader",
+		Help: "Whether the member is the RAFT leader",
+	}, memberLabels)
+	memberTerm = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_db_cluster_member_term",
+		Help: "Current RAFT term of the member, it increases with every election",
+	}, memberLabels)
+	memberLogIndex = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_db_cluster_member_log_index",
+		Help: "End index of the RAFT log of the member",
+	}, memberLabels)
+	memberElectionTimer = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_db_cluster_member_election_timer_milliseconds",
+		Help: "RAFT election timer of the member",
+	}, memberLabels)
+	memberLogLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_db_cluster_member_log_entries_not_applied",
+		Help: "Number of RAFT log entries the member has not yet applied",
+	}, memberLabels)
+	memberDBSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_db_cluster_member_db_size_bytes",
+		Help: "Size of the database file of the member",
+	}, memberLabels)
+	memberSessions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_db_cluster_member_sessions",
+		Help: "Number of clients connected to the member",
+	}, memberLabels)
+	memberMonitors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_db_cluster_member_monitors",
+		Help: "Number of monitors the clients registered on the member",
+	}, memberLabels)
+
+	memberGauges = []*prometheus.GaugeVec{
+		memberIsLeader,
+		memberTerm,
+		memberLogIndex,
+		memberElectionTimer,
+		memberLogLag,
+		memberDBSize,
+		memberSessions,
+		memberMonitors,
+	}
+)
+
+func init() {
+	for _, gauge := range memberGauges {
+		metrics.Registry.MustRegister(gauge)
+	}
+}
+
+// SetMemberMetrics - replace the member metrics of the instance with the
+// given cluster members
+func SetMemberMetrics(instance *ovnv1.OVNDBCluster, members []ovnv1.OVNDBClusterMember) {
+	DeleteMemberMetrics(instance)
+
+	for _, member := range members {
+		labels := prometheus.Labels{
+			"namespace": instance.Namespace,
+			"name":      instance.Name,
+			"db_type":   instance.Spec.DBType,
+			"member":    member.Name,
+		}
+		isLeader := 0.0
+		if member.Role == "leader" {
+			isLeader = 1.0
+		}
+		memberIsLeader.With(labels).Set(isLeader)
+		memberTerm.With(labels).Set(float64(member.Term))
+		memberLogIndex.With(labels).Set(float64(member.LogIndex))
+		memberElectionTimer.With(labels).Set(float64(member.ElectionTimer))
+		memberLogLag.With(labels).Set(float64(member.Lag))
+		memberDBSize.With(labels).Set(float64(member.DBSize))
+		memberSessions.With(labels).Set(float64(member.Sessions))
+		memberMonitors.With(labels).Set(float64(member.Monitors))
+	}
+}
+
+// DeleteMemberMetrics - remove the member metrics of the instance
+func DeleteMemberMetrics(instance *ovnv1.OVNDBCluster) {
+	labels := prometheus.Labels{
+		"namespace": instance.Namespace,
+		"name":      instance.Name,
+	}
+	for _, gauge := range memberGauges {
+		gauge.DeletePartialMatch(labels)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NetworkPolicy - restrict the ingress to the DB pods to the OVN clients and
+// the other members of the RAFT cluster. The policy is removed when it is
+// not enabled in the spec.
+func NetworkPolicy(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *ovnv1.OVNDBCluster,
+	serviceName string,
+	labels map[string]string,
+) error {
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+	}
+
+	if !instance.Spec.NetworkPolicy.Enabled {
+		err := helper.GetClient().Delete(ctx, np)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("Error deleting NetworkPolicy %s: %w", np.Name, err)
+		}
+		return nil
+	}
+
+	dbPort, raftPort := DBPorts(instance.Spec.DBType)
+	np.Spec.PodSelector = metav1.LabelSelector{
+		MatchLabels: labels,
+	}
+	np.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	np.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{policyPort(dbPort)},
+			From:  dbClientPeers(instance),
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{policyPort(raftPort)},
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: labels,
+					},
+				},
+			},
+		},
+	}
+	err := ovn_common.Apply(ctx, helper, np)
+	if err != nil {
+		return fmt.Errorf("Error creating NetworkPolicy %s: %w", np.Name, err)
+	}
+	return nil
+}
+
+// dbClientPeers - the pods and networks allowed to connect to the DB port
+func dbClientPeers(instance *ovnv1.OVNDBCluster) []networkingv1.NetworkPolicyPeer {
+	services := []string{
+		ovnv1.ServiceNameOVNNorthd,
+		ovnv1.ServiceNameOVNInterconnect,
+		ovnv1.ServiceNameOVNController,
+	}
+	services = append(services, instance.Spec.NetworkPolicy.AllowedServices...)
+
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      common.AppSelector,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   services,
+					},
+				},
+			},
+		},
+	}
+	for _, cidr := range instance.Spec.NetworkPolicy.AllowedCIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+	return peers
+}
+
+func policyPort(port int32) networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	p := intstr.FromInt(int(port))
+	return networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &p,
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// octaviaProviderCommonName - common name of the client cert of the Octavia
+// OVN provider driver
+const octaviaProviderCommonName = "octavia-ovn-provider"
+
+// OctaviaProviderCertificateName - name of the Certificate of the client
+// cert of the Octavia OVN provider driver, issued into the secret of the same
+// name. Empty when the cert is not requested by the operator.
+func OctaviaProviderCertificateName(instance *ovnv1.OVNDBCluster) string {
+	if !instance.Spec.OctaviaProvider || instance.Spec.TLS.CertificateName() == "" {
+		return ""
+	}
+	return fmt.Sprintf("cert-%s-octavia-provider", instance.Name)
+}
+
+// EnsureOctaviaProvider - request the client cert of the Octavia OVN
+// provider driver and publish it along with the internal address of the DB.
+// Returns false while the cert is not issued yet.
+func EnsureOctaviaProvider(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNDBCluster,
+) (bool, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ovnv1.OctaviaProviderSecretName,
+			Namespace: instance.Namespace,
+		},
+		Data: map[string][]byte{
+			ovnv1.OctaviaProviderNBConnectionKey: []byte(instance.Status.InternalDBAddress),
+		},
+	}
+
+	if certName := OctaviaProviderCertificateName(instance); certName != "" {
+		err := ovn_common.EnsureCertificate(
+			ctx,
+			h,
+			&instance.Spec.TLS,
+			certName,
+			octaviaProviderCommonName,
+			[]string{octaviaProviderCommonName},
+			nil,
+		)
+		if err != nil {
+			return false, fmt.Errorf("error requesting the client cert of the Octavia OVN provider driver: %w", err)
+		}
+
+		certSecret := &corev1.Secret{}
+		err = h.GetClient().Get(ctx, types.NamespacedName{Name: certName, Namespace: instance.Namespace}, certSecret)
+		if k8s_errors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		secret.Data[tls.CertKey] = certSecret.Data[tls.CertKey]
+		secret.Data[tls.PrivateKey] = certSecret.Data[tls.PrivateKey]
+		secret.Data[tls.CAKey] = certSecret.Data[tls.CAKey]
+
+		// the CA bundle verifies the cert of the DB in place of the issuer CA
+		if instance.Spec.TLS.CaBundleSecretName != "" {
+			caBundle := &corev1.Secret{}
+			err = h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Spec.TLS.CaBundleSecretName, Namespace: instance.Namespace}, caBundle)
+			if err != nil {
+				return false, err
+			}
+			secret.Data[tls.CAKey] = caBundle.Data[tls.CABundleKey]
+		}
+	}
+
+	err := ovn_common.Apply(ctx, h, secret)
+	if err != nil {
+		return false, fmt.Errorf("error publishing the Octavia OVN provider driver connection in Secret %s: %w", secret.Name, err)
+	}
+	return true, nil
+}
+
+// DeleteOctaviaProvider - delete the Secret of the Octavia OVN provider
+// driver when published by the instance
+func DeleteOctaviaProvider(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNDBCluster,
+) error {
+	secret := &corev1.Secret{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: ovnv1.OctaviaProviderSecretName, Namespace: instance.Namespace}, secret)
+	if k8s_errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(secret, instance) {
+		return nil
+	}
+	err = h.GetClient().Delete(ctx, secret)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting Secret %s: %w", secret.Name, err)
+	}
+	return nil
+}
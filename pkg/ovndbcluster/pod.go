@@ -17,10 +17,15 @@ package ovndbcluster
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
@@ -36,3 +41,70 @@ func OVNDBPods(
 	podSelectorString := k8s_labels.Set(serviceLabels).String()
 	return helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{LabelSelector: podSelectorString})
 }
+
+// CorruptedDBPods - return the names of the pods whose database container
+// terminated because the startup integrity check failed
+func CorruptedDBPods(podList *corev1.PodList) []string {
+	pods := []string{}
+	for _, pod := range podList.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if isDBIntegrityFailure(cs.State.Terminated) ||
+				isDBIntegrityFailure(cs.LastTerminationState.Terminated) {
+				pods = append(pods, pod.Name)
+				break
+			}
+		}
+	}
+	return pods
+}
+
+func isDBIntegrityFailure(state *corev1.ContainerStateTerminated) bool {
+	return state != nil && strings.Contains(state.Message, DBIntegrityError)
+}
+
+// PodTerminatedPredicate - the updates of the pods in which a container
+// restarted or terminated, e.g. a database container failing the startup
+// integrity check. The other pod updates, and the pods created or deleted,
+// are skipped.
+func PodTerminatedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(containerTerminations(oldPod), containerTerminations(newPod))
+		},
+	}
+}
+
+// containerTerminations - the restart count and the last termination of
+// each container of the pod
+func containerTerminations(pod *corev1.Pod) map[string]string {
+	terminations := map[string]string{}
+	for _, cs := range pod.Status.ContainerStatuses {
+		terminated := cs.State.Terminated
+		if terminated == nil {
+			terminated = cs.LastTerminationState.Terminated
+		}
+		termination := ""
+		if terminated != nil {
+			termination = fmt.Sprintf("%d %s %s", terminated.ExitCode, terminated.Reason, terminated.Message)
+		}
+		terminations[cs.Name] = fmt.Sprintf("%d %s", cs.RestartCount, termination)
+	}
+	return terminations
+}
@@ -0,0 +1,113 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// integrityFailure - the termination of a DB container failing the startup
+// integrity check
+var integrityFailure = &corev1.ContainerStateTerminated{
+	ExitCode: 1,
+	Reason:   "Error",
+	Message:  DBIntegrityError + ": /etc/ovn/ovnnb_db.db",
+}
+
+// testDBPod - a running DB pod
+func testDBPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovsdbserver-nb-0"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "ovsdbserver-nb",
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+}
+
+func TestPodTerminatedPredicate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		update  func(pod *corev1.Pod)
+		trigger bool
+	}{
+		"container restarted": {
+			update: func(pod *corev1.Pod) {
+				pod.Status.ContainerStatuses[0].RestartCount = 1
+				pod.Status.ContainerStatuses[0].LastTerminationState.Terminated = integrityFailure
+			},
+			trigger: true,
+		},
+		"container terminated": {
+			update: func(pod *corev1.Pod) {
+				pod.Status.ContainerStatuses[0].State = corev1.ContainerState{Terminated: integrityFailure}
+			},
+			trigger: true,
+		},
+		"container not ready": {
+			update:  func(pod *corev1.Pod) { pod.Status.ContainerStatuses[0].Ready = false },
+			trigger: false,
+		},
+		"pod IP": {
+			update:  func(pod *corev1.Pod) { pod.Status.PodIP = "10.0.0.10" },
+			trigger: false,
+		},
+		"labels": {
+			update:  func(pod *corev1.Pod) { pod.Labels = map[string]string{"a": "b"} },
+			trigger: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			oldPod := testDBPod()
+			newPod := testDBPod()
+			tc.update(newPod)
+
+			g.Expect(PodTerminatedPredicate().Update(event.UpdateEvent{
+				ObjectOld: oldPod,
+				ObjectNew: newPod,
+			})).To(Equal(tc.trigger))
+		})
+	}
+}
+
+func TestPodTerminatedPredicateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(PodTerminatedPredicate().Create(event.CreateEvent{Object: testDBPod()})).To(BeFalse())
+}
+
+func TestCorruptedDBPods(t *testing.T) {
+	g := NewWithT(t)
+
+	restarted := testDBPod()
+	restarted.Name = "ovsdbserver-nb-1"
+	restarted.Status.ContainerStatuses[0].LastTerminationState.Terminated = integrityFailure
+	terminated := testDBPod()
+	terminated.Name = "ovsdbserver-nb-2"
+	terminated.Status.ContainerStatuses[0].State = corev1.ContainerState{Terminated: integrityFailure}
+
+	g.Expect(CorruptedDBPods(&corev1.PodList{
+		Items: []corev1.Pod{*testDBPod(), *restarted, *terminated},
+	})).To(Equal([]string{"ovsdbserver-nb-1", "ovsdbserver-nb-2"}))
+}
@@ -0,0 +1,69 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OrphanPVCs - drop the OVNDBCluster and StatefulSet owner references from
+// the database PVCs so they are not garbage collected with the CR
+func OrphanPVCs(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+	serviceName string,
+) error {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	err := helper.GetClient().List(
+		ctx,
+		pvcList,
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels(serviceLabels),
+	)
+	if err != nil {
+		return fmt.Errorf("error listing PVCs for %s: %w", instance.Name, err)
+	}
+
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		ownerRefs := []metav1.OwnerReference{}
+		for _, ref := range pvc.GetOwnerReferences() {
+			if ref.UID == instance.UID || (ref.Kind == "StatefulSet" && ref.Name == serviceName) {
+				continue
+			}
+			ownerRefs = append(ownerRefs, ref)
+		}
+		if len(ownerRefs) == len(pvc.GetOwnerReferences()) {
+			continue
+		}
+
+		patch := client.MergeFrom(pvc.DeepCopy())
+		pvc.SetOwnerReferences(ownerRefs)
+		if err := helper.GetClient().Patch(ctx, pvc, patch); err != nil {
+			return fmt.Errorf("error removing owner references from PVC %s: %w", pvc.Name, err)
+		}
+		helper.GetLogger().Info(fmt.Sprintf("PVC %s retained", pvc.Name))
+	}
+
+	return nil
+}
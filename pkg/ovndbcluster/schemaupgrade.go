@@ -0,0 +1,134 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// SchemaUpgradeCommand -
+	SchemaUpgradeCommand = "/usr/local/bin/container-scripts/schema-upgrade.sh"
+)
+
+// SchemaUpgradeJobName - name of the Job validating the schema conversion
+func SchemaUpgradeJobName(instance *ovnv1.OVNDBCluster) string {
+	return instance.Name + "-schema-upgrade"
+}
+
+// SchemaUpgradeJob - prepare the Job which converts a copy of the deployed
+// database to the schema shipped in the image of the spec. The members are
+// only rolled out to the new image once it succeeded.
+func SchemaUpgradeJob(instance *ovnv1.OVNDBCluster) *batchv1.Job {
+	serviceName := instance.GetServiceName()
+	// the Job pods must not be selected by the services of the members
+	labels := map[string]string{
+		common.AppSelector: SchemaUpgradeJobName(instance),
+	}
+	backoffLimit := int32(0)
+
+	envVars := map[string]env.Setter{}
+	envVars["DB_REMOTE"] = env.SetValue(instance.Status.InternalDBAddress)
+
+	volumes := GetDBClusterVolumes(instance.Name)
+	volumeMounts := []corev1.VolumeMount{GetDBClusterScriptsVolumeMount()}
+
+	// add CA bundle if defined
+	if instance.Spec.TLS.CaBundleSecretName != "" {
+		volumes = append(volumes, instance.Spec.TLS.CreateVolume())
+		volumeMounts = append(volumeMounts, instance.Spec.TLS.CreateVolumeMounts(nil)...)
+	}
+
+	// add OVN dbs cert and CA
+	if instance.Spec.TLS.Enabled() {
+		volumes = append(volumes, ovn_common.CreateOVNDbCertVolume(
+			*instance.Spec.TLS.GenericService.SecretName, serviceName))
+		volumeMounts = append(volumeMounts, ovn_common.CreateOVNDbCertVolumeMounts(serviceName)...)
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SchemaUpgradeJobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: instance.RbacResourceName(),
+					Containers: []corev1.Container{
+						{
+							Name:         "schema-upgrade",
+							Image:        instance.Spec.ContainerImage,
+							Command:      []string{"/bin/bash", "-c", SchemaUpgradeCommand},
+							Env:          env.MergeEnvs([]corev1.EnvVar{}, envVars),
+							VolumeMounts: volumeMounts,
+							Resources:    instance.Spec.Resources,
+						},
+					},
+					Volumes:      volumes,
+					NodeSelector: instance.Spec.NodeSelector,
+				},
+			},
+		},
+	}
+}
+
+// SchemaUpgradeResult - return the message the schema upgrade script wrote
+// to the termination log of the last Job pod, it holds the schema versions
+// or the conversion error
+func SchemaUpgradeResult(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *ovnv1.OVNDBCluster,
+) (string, error) {
+	return ovn_common.JobTerminationMessage(ctx, helper, instance.Namespace, SchemaUpgradeJobName(instance))
+}
+
+// GetSchemaVersion - return the schema version of the database served by
+// the ovsdb-server running in the given pod
+func GetSchemaVersion(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	instance *ovnv1.OVNDBCluster,
+	pod *corev1.Pod,
+) (string, error) {
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, []string{
+		"ovsdb-client", "get-schema-version",
+		fmt.Sprintf("unix:/tmp/%s.sock", DBFileName(instance.Spec.DBType)),
+		DBName(instance.Spec.DBType),
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
@@ -1,29 +1,26 @@
 package ovndbcluster
 
 import (
+	"encoding/json"
+
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 )
 
-// Service - Service for ovndbcluster per pod
+// Service - Service for ovndbcluster per pod, with the override of the
+// Services of the instance applied
 func Service(
 	serviceName string,
 	instance *ovnv1.OVNDBCluster,
 	serviceLabels map[string]string,
 	selectorLabels map[string]string,
-) *corev1.Service {
-	dbPortName := "north"
-	raftPortName := "north-raft"
-	dbPort := DbPortNB
-	raftPort := RaftPortNB
-	if instance.Spec.DBType == ovnv1.SBDBType {
-		dbPortName = "south"
-		raftPortName = "south-raft"
-		dbPort = DbPortSB
-		raftPort = RaftPortSB
-	}
-	return &corev1.Service{
+) (*corev1.Service, error) {
+	dbPortName := portName(instance.Spec.DBType)
+	raftPortName := dbPortName + "-raft"
+	dbPort, raftPort := DBPorts(instance.Spec.DBType)
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
 			Namespace: instance.Namespace,
@@ -45,6 +42,30 @@ func Service(
 			},
 		},
 	}
+
+	override := instance.Spec.Override.Services
+	if override == nil {
+		return svc, nil
+	}
+	// The override has the layout of a Service, merge it as a patch
+	original, err := json.Marshal(svc)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := json.Marshal(override)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, corev1.Service{})
+	if err != nil {
+		return nil, err
+	}
+	overridden := &corev1.Service{}
+	err = json.Unmarshal(patched, overridden)
+	if err != nil {
+		return nil, err
+	}
+	return overridden, nil
 }
 
 // HeadlessService - Headless Service for ovndbcluster pods to get DNS names in pods
@@ -54,12 +75,8 @@ func HeadlessService(
 	serviceLabels map[string]string,
 	selectorLabels map[string]string,
 ) *corev1.Service {
-	raftPortName := "north-raft"
-	raftPort := RaftPortNB
-	if instance.Spec.DBType == ovnv1.SBDBType {
-		raftPortName = "south-raft"
-		raftPort = RaftPortSB
-	}
+	raftPortName := portName(instance.Spec.DBType) + "-raft"
+	_, raftPort := DBPorts(instance.Spec.DBType)
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
@@ -13,10 +13,12 @@ limitations under the License.
 package ovndbcluster
 
 import (
+	"fmt"
+
 	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 
@@ -24,7 +26,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/utils/ptr"
 )
 
 const (
@@ -35,13 +36,36 @@ const (
 	PVCSuffixEtcOVN = "-etc-ovn"
 )
 
-// StatefulSet func
+// StatefulSetInputs - what the StatefulSet is built from besides the spec
+type StatefulSetInputs struct {
+	// InputHash - hash of the hashes of the ConfigMaps and Secrets the pods
+	// are restarted for
+	InputHash string
+	// RestrictedPodSecurity - render the pods for the restricted PSA profile
+	RestrictedPodSecurity bool
+}
+
+// StatefulSet - the StatefulSet of the ovsdb-server pods of the instance
 func StatefulSet(
 	instance *ovnv1.OVNDBCluster,
-	configHash string,
-	labels map[string]string,
-	annotations map[string]string,
-) *appsv1.StatefulSet {
+	inputs StatefulSetInputs,
+) (*appsv1.StatefulSet, error) {
+	serviceName := instance.GetServiceName()
+	labels := map[string]string{
+		common.AppSelector: serviceName,
+	}
+
+	// network to attach to
+	networkAttachments := []string{}
+	if instance.Spec.NetworkAttachment != "" {
+		networkAttachments = append(networkAttachments, instance.Spec.NetworkAttachment)
+	}
+	annotations, err := nad.CreateNetworksAnnotation(instance.Namespace, networkAttachments)
+	if err != nil {
+		return nil, fmt.Errorf("failed create network annotation from %s: %w",
+			instance.Spec.NetworkAttachment, err)
+	}
+
 	livenessProbe := &corev1.Probe{
 		// TODO might need tuning
 		TimeoutSeconds:      5,
@@ -79,12 +103,8 @@ func StatefulSet(
 			},
 		},
 	}
-	serviceName := ovnv1.ServiceNameNB
-	if instance.Spec.DBType == ovnv1.SBDBType {
-		serviceName = ovnv1.ServiceNameSB
-	}
 	envVars := map[string]env.Setter{}
-	envVars["CONFIG_HASH"] = env.SetValue(configHash)
+	envVars["CONFIG_HASH"] = env.SetValue(inputs.InputHash)
 	// TODO: Make confs customizable
 	envVars["OVN_RUNDIR"] = env.SetValue("/tmp")
 	// we have to set LOGDIR even though we don't want to log to file. This is
@@ -97,6 +117,12 @@ func StatefulSet(
 	volumes := GetDBClusterVolumes(instance.Name)
 	volumeMounts := GetDBClusterVolumeMounts(instance.Name + PVCSuffixEtcOVN)
 
+	// keep the ovsdb-server log files outside of the database volume
+	if instance.Spec.LogFile.Enabled {
+		volumes = append(volumes, GetDBClusterLogVolume())
+		volumeMounts = append(volumeMounts, GetDBClusterLogVolumeMount())
+	}
+
 	// add CA bundle if defined
 	if instance.Spec.TLS.CaBundleSecretName != "" {
 		volumes = append(volumes, instance.Spec.TLS.CreateVolume())
@@ -105,14 +131,9 @@ func StatefulSet(
 
 	// add OVN dbs cert and CA
 	if instance.Spec.TLS.Enabled() {
-		svc := tls.Service{
-			SecretName: *instance.Spec.TLS.GenericService.SecretName,
-			CertMount:  ptr.To(ovn_common.OVNDbCertPath),
-			KeyMount:   ptr.To(ovn_common.OVNDbKeyPath),
-			CaMount:    ptr.To(ovn_common.OVNDbCaCertPath),
-		}
-		volumes = append(volumes, svc.CreateVolume(serviceName))
-		volumeMounts = append(volumeMounts, svc.CreateVolumeMounts(serviceName)...)
+		volumes = append(volumes, ovn_common.CreateOVNDbCertVolume(
+			*instance.Spec.TLS.GenericService.SecretName, serviceName))
+		volumeMounts = append(volumeMounts, ovn_common.CreateOVNDbCertVolumeMounts(serviceName)...)
 	}
 
 	// NOTE(ihar) ovndb pods leave the raft cluster on delete; it's important
@@ -169,8 +190,12 @@ func StatefulSet(
 	}
 
 	// https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#persistentvolumeclaim-retention
+	whenDeleted := appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+	if instance.Spec.StorageRetention == ovnv1.StorageRetentionRetain {
+		whenDeleted = appsv1.RetainPersistentVolumeClaimRetentionPolicyType
+	}
 	statefulset.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
-		WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		WhenDeleted: whenDeleted,
 		WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
 	}
 
@@ -213,6 +238,9 @@ func StatefulSet(
 	if instance.Spec.NodeSelector != nil && len(instance.Spec.NodeSelector) > 0 {
 		statefulset.Spec.Template.Spec.NodeSelector = instance.Spec.NodeSelector
 	}
+	if inputs.RestrictedPodSecurity {
+		ovn_common.SetRestrictedPodSecurity(&statefulset.Spec.Template.Spec)
+	}
 
-	return statefulset
+	return statefulset, nil
 }
@@ -27,11 +27,7 @@ func GetDBClusterVolumes(name string) []corev1.Volume {
 // GetDBClusterVolumeMounts - OVN DBCluster VolumeMounts
 func GetDBClusterVolumeMounts(name string) []corev1.VolumeMount {
 	return []corev1.VolumeMount{
-		{
-			Name:      "scripts",
-			MountPath: "/usr/local/bin/container-scripts",
-			ReadOnly:  true,
-		},
+		GetDBClusterScriptsVolumeMount(),
 		{
 			Name:      name,
 			MountPath: "/etc/ovn",
@@ -40,3 +36,31 @@ func GetDBClusterVolumeMounts(name string) []corev1.VolumeMount {
 	}
 
 }
+
+// GetDBClusterScriptsVolumeMount - container scripts VolumeMount
+func GetDBClusterScriptsVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "scripts",
+		MountPath: "/usr/local/bin/container-scripts",
+		ReadOnly:  true,
+	}
+}
+
+// GetDBClusterLogVolume - emptyDir holding the ovsdb-server log files
+func GetDBClusterLogVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: "var-log-ovn",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+// GetDBClusterLogVolumeMount - ovsdb-server log files VolumeMount
+func GetDBClusterLogVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "var-log-ovn",
+		MountPath: LogDir,
+		ReadOnly:  false,
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndiagnostics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArchiveHash - hash key of the Job archiving the diagnostics
+	ArchiveHash = "archive"
+
+	// diagnosticsPath - mount path of the diagnostics PVC in the Jobs
+	diagnosticsPath = "/diagnostics"
+	// operatorStatePath - mount path of the operator state ConfigMap
+	operatorStatePath = "/operator"
+
+	// NodeCommand - dumps the OVS configuration, the flows of the integration
+	// bridge and the coverage counters of ovs-vswitchd and ovn-controller
+	NodeCommand = `set -e
+DIR="/diagnostics/${NAME}/nodes/${NODE}"
+mkdir -p "${DIR}"
+ovs-vsctl show > "${DIR}/ovs-vsctl-show"
+ovs-ofctl dump-flows "${OVS_BRIDGE}" > "${DIR}/dump-flows"
+ovs-appctl coverage/show > "${DIR}/ovs-vswitchd-coverage"
+OVN_RUNDIR=/var/run/ovn ovn-appctl -t ovn-controller coverage/show > "${DIR}/ovn-controller-coverage"
+`
+
+	// DBCommand - dumps the OVN DB, its contents as shown by the ctl
+	// utility and the RAFT state of the members in the _Server DB
+	DBCommand = `set -e
+DIR="/diagnostics/${NAME}/${DB}"
+mkdir -p "${DIR}"
+ovsdb-client ${SSL_ARGS} backup "${DB_REMOTE}" "${DB_NAME}" > "${DIR}/${DB_FILE}.db"
+ovsdb-client ${SSL_ARGS} dump "${DB_REMOTE}" _Server Database > "${DIR}/cluster-status"
+ovn-${DB}ctl --no-leader-only --db="${DB_REMOTE}" ${SSL_ARGS} show > "${DIR}/show"
+`
+
+	// ArchiveCommand - archives the collected diagnostics with the operator
+	// state, the path and the size of the archive go to the termination log
+	ArchiveCommand = `set -e
+mkdir -p "/diagnostics/${NAME}/operator"
+cp -L /operator/* "/diagnostics/${NAME}/operator/"
+tar -czf "/diagnostics/${NAME}.tar.gz" -C /diagnostics "${NAME}"
+du -h "/diagnostics/${NAME}.tar.gz" > /dev/termination-log
+`
+)
+
+// Step - a Job collecting a part of the diagnostics
+type Step struct {
+	// Name - what the Job collects, e.g. the node, in the condition messages
+	Name string
+	// Hash - hash key of the Job
+	Hash string
+	Job  *batchv1.Job
+}
+
+// PVCName - name of the PVC the diagnostics are collected in
+func PVCName(instance *ovnv1.OVNDiagnostics) string {
+	return instance.Name + "-diagnostics"
+}
+
+// OperatorStateConfigMapName - name of the ConfigMap holding the operator
+// state added to the archive
+func OperatorStateConfigMapName(instance *ovnv1.OVNDiagnostics) string {
+	return instance.Name + "-operator"
+}
+
+// ArchiveName - path of the archive in the PVC
+func ArchiveName(instance *ovnv1.OVNDiagnostics) string {
+	return instance.Name + ".tar.gz"
+}
+
+// NodeStep - the Job collecting the OVS and ovn-controller state of the
+// node with the given index in the spec
+func NodeStep(
+	instance *ovnv1.OVNDiagnostics,
+	ovnController *ovnv1.OVNController,
+	index int,
+	image string,
+) Step {
+	node := instance.Spec.Nodes[index]
+	envVars := map[string]env.Setter{}
+	envVars["NAME"] = env.SetValue(instance.Name)
+	envVars["NODE"] = env.SetValue(node)
+	envVars["OVS_BRIDGE"] = env.SetValue(ovnController.Spec.ExternalIDS.OvnBridge)
+
+	// ovn-appctl finds ovn-controller through its pid file in the OVN run
+	// directory of the host
+	volumeMounts := []corev1.VolumeMount{diagnosticsVolumeMount()}
+	for _, mount := range ovncontroller.GetOVNControllerVolumeMounts() {
+		if mount.Name == "var-run-ovn" {
+			volumeMounts = append(volumeMounts, mount)
+		}
+	}
+
+	podSpec := ovncontroller.OVSClientPodSpec(ovnController, node, corev1.Container{
+		Name:                     "diagnostics",
+		Image:                    image,
+		Command:                  []string{"/bin/bash", "-c", NodeCommand},
+		Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
+		VolumeMounts:             volumeMounts,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	})
+	podSpec.Volumes = append(podSpec.Volumes, diagnosticsVolume(instance))
+
+	// node names can be longer than a Job name, the index identifies the
+	// Job and the node is in its env
+	name := fmt.Sprintf("%s-node-%d", instance.Name, index)
+	return Step{
+		Name: "node " + node,
+		Hash: "node-" + node,
+		Job:  diagnosticsJob(instance, name, podSpec),
+	}
+}
+
+// DBStep - the Job collecting the state of the OVN DB of the cluster. The
+// client cert of the DB cluster is used to connect to it.
+func DBStep(
+	instance *ovnv1.OVNDiagnostics,
+	dbCluster *ovnv1.OVNDBCluster,
+	dbEndpoint string,
+	image string,
+) Step {
+	db := ovndbcluster.CtlDBType(dbCluster.Spec.DBType)
+	volumes, volumeMounts, sslArgs := ovndbcluster.ClientTLS(dbCluster)
+
+	envVars := map[string]env.Setter{}
+	envVars["NAME"] = env.SetValue(instance.Name)
+	envVars["DB"] = env.SetValue(db)
+	envVars["DB_NAME"] = env.SetValue(ovndbcluster.DBName(dbCluster.Spec.DBType))
+	envVars["DB_FILE"] = env.SetValue(ovndbcluster.DBFileName(dbCluster.Spec.DBType))
+	envVars["DB_REMOTE"] = env.SetValue(dbEndpoint)
+	envVars["SSL_ARGS"] = env.SetValue(strings.Join(sslArgs, " "))
+
+	return Step{
+		Name: "OVNDBCluster " + dbCluster.Name,
+		Hash: db,
+		Job: diagnosticsJob(instance, instance.Name+"-"+db, corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:                     "diagnostics",
+					Image:                    image,
+					Command:                  []string{"/bin/bash", "-c", DBCommand},
+					Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
+					VolumeMounts:             append(volumeMounts, diagnosticsVolumeMount()),
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			Volumes: append(volumes, diagnosticsVolume(instance)),
+		}),
+	}
+}
+
+// ArchiveStep - the Job archiving the diagnostics with the operator state.
+// collected identifies the Jobs of the previous steps, so the archive is
+// created again when one of them ran again.
+func ArchiveStep(
+	instance *ovnv1.OVNDiagnostics,
+	collected string,
+	image string,
+) Step {
+	envVars := map[string]env.Setter{}
+	envVars["NAME"] = env.SetValue(instance.Name)
+	envVars["COLLECTED"] = env.SetValue(collected)
+
+	return Step{
+		Name: "the archive",
+		Hash: ArchiveHash,
+		Job: diagnosticsJob(instance, instance.Name+"-archive", corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:    "diagnostics",
+					Image:   image,
+					Command: []string{"/bin/bash", "-c", ArchiveCommand},
+					Env:     env.MergeEnvs([]corev1.EnvVar{}, envVars),
+					VolumeMounts: []corev1.VolumeMount{
+						diagnosticsVolumeMount(),
+						{
+							Name:      "operator-state",
+							MountPath: operatorStatePath,
+							ReadOnly:  true,
+						},
+					},
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			Volumes: []corev1.Volume{
+				diagnosticsVolume(instance),
+				{
+					Name: "operator-state",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: OperatorStateConfigMapName(instance),
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func diagnosticsVolume(instance *ovnv1.OVNDiagnostics) corev1.Volume {
+	return corev1.Volume{
+		Name: "diagnostics",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: PVCName(instance),
+			},
+		},
+	}
+}
+
+func diagnosticsVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "diagnostics",
+		MountPath: diagnosticsPath,
+	}
+}
+
+func diagnosticsJob(instance *ovnv1.OVNDiagnostics, name string, podSpec corev1.PodSpec) *batchv1.Job {
+	labels := map[string]string{
+		common.AppSelector: name,
+	}
+	backoffLimit := int32(0)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndiagnostics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// operatorLogLines - lines of the operator log added to the archive
+	operatorLogLines = int64(5000)
+	// operatorLogBytes - limit of the operator log added to the archive, it
+	// has to fit in the operator state ConfigMap with the resources
+	operatorLogBytes = int64(512 * 1024)
+)
+
+// OperatorState - the OVN resources and their Events in the namespace and
+// the tail of the log of the operator pod, keyed by file name. The log is
+// only collected when the operator knows its pod.
+func OperatorState(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	operatorPod types.NamespacedName,
+) (map[string]string, error) {
+	state := map[string]string{}
+
+	lists := map[string]client.ObjectList{
+		"ovndbclusters.yaml":    &ovnv1.OVNDBClusterList{},
+		"ovnnorthds.yaml":       &ovnv1.OVNNorthdList{},
+		"ovncontrollers.yaml":   &ovnv1.OVNControllerList{},
+		"ovninterconnects.yaml": &ovnv1.OVNInterconnectList{},
+	}
+	for file, list := range lists {
+		err := h.GetClient().List(ctx, list, client.InNamespace(namespace))
+		if err != nil {
+			return nil, err
+		}
+		out, err := marshalList(list)
+		if err != nil {
+			return nil, err
+		}
+		state[file] = out
+	}
+
+	events := &corev1.EventList{}
+	err := h.GetClient().List(ctx, events, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+	ovnEvents := &corev1.EventList{}
+	for _, event := range events.Items {
+		if event.InvolvedObject.APIVersion == ovnv1.GroupVersion.String() {
+			ovnEvents.Items = append(ovnEvents.Items, event)
+		}
+	}
+	out, err := marshalList(ovnEvents)
+	if err != nil {
+		return nil, err
+	}
+	state["events.yaml"] = out
+
+	state["operator.log"] = operatorLog(ctx, h, operatorPod)
+	return state, nil
+}
+
+// operatorLog - the tail of the log of the operator pod, or why it is
+// missing
+func operatorLog(ctx context.Context, h *helper.Helper, operatorPod types.NamespacedName) string {
+	if operatorPod.Name == "" {
+		return "the operator pod is unknown, POD_NAME and POD_NAMESPACE are not set\n"
+	}
+	tailLines := operatorLogLines
+	limitBytes := operatorLogBytes
+	log, err := h.GetKClient().CoreV1().Pods(operatorPod.Namespace).GetLogs(operatorPod.Name, &corev1.PodLogOptions{
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+	}).DoRaw(ctx)
+	if err != nil {
+		return fmt.Sprintf("error getting the log of the operator pod %s: %s\n", operatorPod, err)
+	}
+	return string(log)
+}
+
+func marshalList(list client.ObjectList) (string, error) {
+	// the managed fields only add noise to the archive
+	err := meta.EachListItem(list, func(obj runtime.Object) error {
+		if o, ok := obj.(metav1.Object); ok {
+			o.SetManagedFields(nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	out, err := yaml.Marshal(list)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling %T: %w", list, err)
+	}
+	return string(out), nil
+}
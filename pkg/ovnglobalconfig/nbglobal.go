@@ -0,0 +1,150 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnglobalconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// nbctlCommand - ovn-nbctl command line reaching the local NB DB replica of
+// a DB pod, the writes are forwarded to the RAFT leader
+var nbctlCommand = []string{"ovn-nbctl", "--no-leader-only", "--db=unix:/tmp/ovnnb_db.sock"}
+
+// Changes - the NB_Global options to set and to remove to apply the spec
+type Changes struct {
+	// Set - the options to set, by name
+	Set map[string]string
+	// Remove - the options removed from the spec
+	Remove []string
+	// Drifted - the options set from the spec whose value was changed
+	// manually, they are part of Set
+	Drifted []string
+}
+
+// Empty - whether NB_Global already matches the spec
+func (c *Changes) Empty() bool {
+	return len(c.Set) == 0 && len(c.Remove) == 0
+}
+
+// GetChanges - compare the NB_Global options with the spec. The manual
+// changes are told apart from the spec changes with the options applied
+// last, only the options set from the spec are ever removed.
+func GetChanges(spec map[string]string, applied map[string]string, current map[string]string) *Changes {
+	changes := &Changes{Set: map[string]string{}}
+	for name, value := range spec {
+		currentValue, ok := current[name]
+		if ok && currentValue == value {
+			continue
+		}
+		changes.Set[name] = value
+		if appliedValue, wasApplied := applied[name]; wasApplied && appliedValue == value {
+			changes.Drifted = append(changes.Drifted, name)
+		}
+	}
+	for name := range applied {
+		if _, inSpec := spec[name]; inSpec {
+			continue
+		}
+		if _, ok := current[name]; ok {
+			changes.Remove = append(changes.Remove, name)
+		}
+	}
+	sort.Strings(changes.Remove)
+	sort.Strings(changes.Drifted)
+	return changes
+}
+
+// GetNBGlobalOptions - the options of the NB_Global record of the NB DB
+// served by the pod
+func GetNBGlobalOptions(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+) (map[string]string, error) {
+	command := append(append([]string{}, nbctlCommand...), "--format=json", "--columns=options", "list", "NB_Global")
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, command)
+	if err != nil {
+		return nil, err
+	}
+	return ParseNBGlobalOptions(output)
+}
+
+// ParseNBGlobalOptions - parse the options column of the json formatted
+// NB_Global table, the map is encoded as ["map", [[key, value], ...]]
+func ParseNBGlobalOptions(output string) (map[string]string, error) {
+	table := struct {
+		Data [][]json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal([]byte(output), &table); err != nil {
+		return nil, fmt.Errorf("unexpected NB_Global output: %w", err)
+	}
+	if len(table.Data) != 1 || len(table.Data[0]) != 1 {
+		return nil, fmt.Errorf("unexpected NB_Global output: %d records", len(table.Data))
+	}
+	datum := []json.RawMessage{}
+	if err := json.Unmarshal(table.Data[0][0], &datum); err != nil {
+		return nil, fmt.Errorf("unexpected NB_Global options: %w", err)
+	}
+	var kind string
+	if len(datum) != 2 || json.Unmarshal(datum[0], &kind) != nil || kind != "map" {
+		return nil, fmt.Errorf("unexpected NB_Global options: %s", table.Data[0][0])
+	}
+	pairs := [][2]string{}
+	if err := json.Unmarshal(datum[1], &pairs); err != nil {
+		return nil, fmt.Errorf("unexpected NB_Global options: %w", err)
+	}
+	options := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		options[pair[0]] = pair[1]
+	}
+	return options, nil
+}
+
+// ApplyChanges - set and remove the NB_Global options in a single
+// transaction. The values are validated by the webhook, they hold no quotes.
+func ApplyChanges(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	changes *Changes,
+) error {
+	if changes.Empty() {
+		return nil
+	}
+	command := append([]string{}, nbctlCommand...)
+	names := make([]string, 0, len(changes.Set))
+	for name := range changes.Set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		command = append(command, "--", "set", "NB_Global", ".",
+			fmt.Sprintf("options:%s=\"%s\"", name, changes.Set[name]))
+	}
+	for _, name := range changes.Remove {
+		command = append(command, "--", "remove", "NB_Global", ".", "options", name)
+	}
+	_, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, command)
+	return err
+}
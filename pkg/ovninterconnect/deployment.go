@@ -0,0 +1,200 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovninterconnect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ServiceCommand -
+	ServiceCommand = "/usr/bin/ovn-ic"
+)
+
+// Endpoints - addresses of the local OVN databases and of the interconnection
+// databases shared by all the availability zones
+type Endpoints struct {
+	NB   string
+	SB   string
+	ICNB string
+	ICSB string
+}
+
+// DeploymentInputs - what the Deployment is built from besides the spec
+type DeploymentInputs struct {
+	// Endpoints - the endpoints of the OVN databases
+	Endpoints Endpoints
+	// InputHashes - hashes of the Secrets the pods are restarted for, set
+	// as env vars of the pods
+	InputHashes map[string]env.Setter
+	// RestrictedPodSecurity - render the pods for the restricted PSA profile
+	RestrictedPodSecurity bool
+}
+
+// Deployment - the Deployment of the ovn-ic pods of the instance
+func Deployment(
+	instance *ovnv1.OVNInterconnect,
+	inputs DeploymentInputs,
+) *appsv1.Deployment {
+	labels := map[string]string{
+		common.AppSelector: ovnv1.ServiceNameOVNInterconnect,
+	}
+	endpoints := inputs.Endpoints
+
+	livenessProbe := &corev1.Probe{
+		// TODO might need tuning
+		TimeoutSeconds:      5,
+		PeriodSeconds:       3,
+		InitialDelaySeconds: 3,
+	}
+	readinessProbe := &corev1.Probe{
+		// TODO might need tuning
+		TimeoutSeconds:      5,
+		PeriodSeconds:       5,
+		InitialDelaySeconds: 5,
+	}
+
+	sslArgs := []string{}
+
+	// create Volume and VolumeMounts
+	volumes := []corev1.Volume{}
+	volumeMounts := []corev1.VolumeMount{}
+
+	// add CA bundle if defined
+	if instance.Spec.TLS.CaBundleSecretName != "" {
+		volumes = append(volumes, instance.Spec.TLS.CreateVolume())
+		volumeMounts = append(volumeMounts, instance.Spec.TLS.CreateVolumeMounts(nil)...)
+	}
+
+	// add OVN dbs cert and CA, the same certificate is used to connect to
+	// the local and the interconnection databases
+	if instance.Spec.TLS.Enabled() {
+		volumes = append(volumes, ovn_common.CreateOVNDbCertVolume(
+			*instance.Spec.TLS.GenericService.SecretName, ovnv1.ServiceNameOVNInterconnect))
+		volumeMounts = append(volumeMounts, ovn_common.CreateOVNDbCertVolumeMounts(ovnv1.ServiceNameOVNInterconnect)...)
+
+		sslArgs = append(sslArgs,
+			fmt.Sprintf("--certificate=%s", ovn_common.OVNDbCertPath),
+			fmt.Sprintf("--private-key=%s", ovn_common.OVNDbKeyPath),
+			fmt.Sprintf("--ca-cert=%s", ovn_common.OVNDbCaCertPath),
+		)
+	}
+
+	// ovn-ic registers the zone in the interconnection databases with the
+	// NB_Global name, set it before starting the daemon
+	nbctlArgs := append([]string{fmt.Sprintf("--db=%s", endpoints.NB)}, sslArgs...)
+	icArgs := []string{
+		"-vfile:off",
+		fmt.Sprintf("-vconsole:%s", instance.Spec.LogLevel),
+		"--pidfile",
+		fmt.Sprintf("--ovnnb-db=%s", endpoints.NB),
+		fmt.Sprintf("--ovnsb-db=%s", endpoints.SB),
+		fmt.Sprintf("--ic-nb-db=%s", endpoints.ICNB),
+		fmt.Sprintf("--ic-sb-db=%s", endpoints.ICSB),
+	}
+	icArgs = append(icArgs, sslArgs...)
+	cmd := []string{"/bin/bash", "-c"}
+	args := []string{
+		fmt.Sprintf("/usr/bin/ovn-nbctl %s set NB_Global . name=%s && exec %s %s",
+			strings.Join(nbctlArgs, " "),
+			instance.Spec.AvailabilityZone,
+			ServiceCommand,
+			strings.Join(icArgs, " "),
+		),
+	}
+
+	//
+	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/
+	//
+	livenessProbe.Exec = &corev1.ExecAction{
+		Command: []string{
+			"/usr/bin/ovn-appctl", "-t", ovnv1.ServiceNameOVNInterconnect, "version",
+		},
+	}
+	readinessProbe.Exec = livenessProbe.Exec
+
+	envVars := map[string]env.Setter{}
+	for name, hash := range inputs.InputHashes {
+		envVars[name] = hash
+	}
+	// TODO: Make confs customizable
+	envVars["OVN_RUNDIR"] = env.SetValue("/tmp")
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ovnv1.ServiceNameOVNInterconnect,
+			Namespace: instance.Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Replicas: instance.Spec.Replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: instance.RbacResourceName(),
+					Containers: []corev1.Container{
+						{
+							Name:                     ovnv1.ServiceNameOVNInterconnect,
+							Command:                  cmd,
+							Args:                     args,
+							Image:                    instance.Spec.ContainerImage,
+							SecurityContext:          getOVNInterconnectSecurityContext(),
+							Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
+							Resources:                instance.Spec.Resources,
+							ReadinessProbe:           readinessProbe,
+							LivenessProbe:            livenessProbe,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+							VolumeMounts:             volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+	// If possible two pods of the same service should not
+	// run on the same worker node. If this is not possible
+	// the get still created on the same worker node.
+	deployment.Spec.Template.Spec.Affinity = affinity.DistributePods(
+		common.AppSelector,
+		[]string{
+			ovnv1.ServiceNameOVNInterconnect,
+		},
+		corev1.LabelHostname,
+	)
+	if instance.Spec.NodeSelector != nil && len(instance.Spec.NodeSelector) > 0 {
+		deployment.Spec.Template.Spec.NodeSelector = instance.Spec.NodeSelector
+	}
+
+	ovn_common.AddSidecars(&deployment.Spec.Template.Spec, instance.Spec.Sidecars)
+	if inputs.RestrictedPodSecurity {
+		ovn_common.SetRestrictedPodSecurity(&deployment.Spec.Template.Spec)
+	}
+
+	return deployment
+}
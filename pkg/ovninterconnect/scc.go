@@ -0,0 +1,18 @@
+package ovninterconnect
+
+import corev1 "k8s.io/api/core/v1"
+
+func getOVNInterconnectSecurityContext() *corev1.SecurityContext {
+	falseVal := false
+	trueVal := true
+
+	return &corev1.SecurityContext{
+		RunAsNonRoot:             &trueVal,
+		AllowPrivilegeEscalation: &falseVal,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{
+				"ALL",
+			},
+		},
+	}
+}
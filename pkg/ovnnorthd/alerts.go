@@ -0,0 +1,43 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	"fmt"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+)
+
+// AlertsName - PrometheusRule holding the alerts of ovn-northd
+const AlertsName = ovnv1.ServiceNameOVNNorthd + "-alerts"
+
+// Alerts - the default alerts of ovn-northd, based on the replica metrics.
+// Paused replicas are expected not to be active.
+func Alerts(instance *ovnv1.OVNNorthd) []ovn_common.AlertRule {
+	selector := fmt.Sprintf("namespace=%q,name=%q", instance.Namespace, instance.Name)
+
+	return []ovn_common.AlertRule{
+		{
+			Alert: "OVNNorthdNotActive",
+			Expr: fmt.Sprintf(
+				"ovn_northd_replicas{%[1]s,status=\"active\"} == 0 and ignoring(status) ovn_northd_replicas{%[1]s,status=\"paused\"} == 0",
+				selector),
+			For:      "5m",
+			Severity: "critical",
+			Summary:  fmt.Sprintf("No ovn-northd replica of %s is active", instance.Name),
+			Description: "None of the ovn-northd replicas holds the SB lock, logical changes of the NB " +
+				"database are not translated into logical flows.",
+		},
+	}
+}
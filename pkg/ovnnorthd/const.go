@@ -1 +1,7 @@
 package ovnnorthd
+
+const (
+	// RoleLabel - pod label reporting whether ovn-northd is active, on
+	// standby or paused
+	RoleLabel = "ovn-northd-role"
+)
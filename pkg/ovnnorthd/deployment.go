@@ -18,14 +18,12 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/utils/ptr"
 )
 
 const (
@@ -33,14 +31,27 @@ const (
 	ServiceCommand = "/usr/bin/ovn-northd"
 )
 
-// Deployment func
+// DeploymentInputs - what the Deployment is built from besides the spec
+type DeploymentInputs struct {
+	// NBEndpoint - internal endpoint of the NB DB
+	NBEndpoint string
+	// SBEndpoint - internal endpoint of the SB DB
+	SBEndpoint string
+	// InputHashes - hashes of the Secrets the pods are restarted for, set
+	// as env vars of the pods
+	InputHashes map[string]env.Setter
+	// RestrictedPodSecurity - render the pods for the restricted PSA profile
+	RestrictedPodSecurity bool
+}
+
+// Deployment - the Deployment of the ovn-northd pods of the instance
 func Deployment(
 	instance *ovnv1.OVNNorthd,
-	labels map[string]string,
-	nbEndpoint string,
-	sbEndpoint string,
-	envVars map[string]env.Setter,
+	inputs DeploymentInputs,
 ) *appsv1.Deployment {
+	labels := map[string]string{
+		common.AppSelector: ovnv1.ServiceNameOVNNorthd,
+	}
 
 	livenessProbe := &corev1.Probe{
 		// TODO might need tuning
@@ -58,10 +69,19 @@ func Deployment(
 	args := []string{
 		"-vfile:off",
 		fmt.Sprintf("-vconsole:%s", instance.Spec.LogLevel),
+		// the pidfile lets ovn-appctl find the daemon to query its status
+		"--pidfile",
 		fmt.Sprintf("--n-threads=%d", *instance.Spec.NThreads),
-		fmt.Sprintf("--ovnnb-db=%s", nbEndpoint),
-		fmt.Sprintf("--ovnsb-db=%s", sbEndpoint),
+		fmt.Sprintf("--ovnnb-db=%s", inputs.NBEndpoint),
+		fmt.Sprintf("--ovnsb-db=%s", inputs.SBEndpoint),
+	}
+	for _, logModule := range instance.Spec.LogModules {
+		args = append(args, fmt.Sprintf("-v%s", logModule))
 	}
+	if instance.Spec.DryRun {
+		args = append(args, "--dry-run")
+	}
+	args = append(args, ovn_common.ExtraArgs(instance.Spec.ExtraArgs)...)
 
 	// create Volume and VolumeMounts
 	volumes := []corev1.Volume{}
@@ -75,32 +95,43 @@ func Deployment(
 
 	// add OVN dbs cert and CA
 	if instance.Spec.TLS.Enabled() {
-		svc := tls.Service{
-			SecretName: *instance.Spec.TLS.GenericService.SecretName,
-			CertMount:  ptr.To(ovn_common.OVNDbCertPath),
-			KeyMount:   ptr.To(ovn_common.OVNDbKeyPath),
-			CaMount:    ptr.To(ovn_common.OVNDbCaCertPath),
-		}
-		volumes = append(volumes, svc.CreateVolume(ovnv1.ServiceNameOVNNorthd))
-		volumeMounts = append(volumeMounts, svc.CreateVolumeMounts(ovnv1.ServiceNameOVNNorthd)...)
+		volumes = append(volumes, ovn_common.CreateOVNDbCertVolume(
+			*instance.Spec.TLS.GenericService.SecretName, ovnv1.ServiceNameOVNNorthd))
+		volumeMounts = append(volumeMounts, ovn_common.CreateOVNDbCertVolumeMounts(ovnv1.ServiceNameOVNNorthd)...)
 
 		args = append(args,
 			fmt.Sprintf("--certificate=%s", ovn_common.OVNDbCertPath),
 			fmt.Sprintf("--private-key=%s", ovn_common.OVNDbKeyPath),
 			fmt.Sprintf("--ca-cert=%s", ovn_common.OVNDbCaCertPath),
 		)
+		if instance.Spec.FIPS {
+			args = append(args, ovn_common.FIPSSSLArgs()...)
+		}
 	}
 
 	//
 	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/
 	//
+	// the daemon is alive as long as it answers on its control socket,
+	// active and standby instances are both ready once connected to the dbs
 	livenessProbe.Exec = &corev1.ExecAction{
 		Command: []string{
-			"/usr/bin/pidof", "ovn-northd",
+			"/usr/bin/ovn-appctl", "-t", ovnv1.ServiceNameOVNNorthd, "status",
+		},
+	}
+	readinessProbe.Exec = &corev1.ExecAction{
+		Command: []string{
+			"/bin/bash", "-c",
+			"/usr/bin/ovn-appctl -t ovn-northd status && " +
+				"/usr/bin/ovn-appctl -t ovn-northd nb-connection-status | grep -q ^connected && " +
+				"/usr/bin/ovn-appctl -t ovn-northd sb-connection-status | grep -q ^connected",
 		},
 	}
-	readinessProbe.Exec = livenessProbe.Exec
 
+	envVars := map[string]env.Setter{}
+	for name, hash := range inputs.InputHashes {
+		envVars[name] = hash
+	}
 	// TODO: Make confs customizable
 	envVars["OVN_RUNDIR"] = env.SetValue("/tmp")
 
@@ -154,5 +185,10 @@ func Deployment(
 		deployment.Spec.Template.Spec.NodeSelector = instance.Spec.NodeSelector
 	}
 
+	ovn_common.AddSidecars(&deployment.Spec.Template.Spec, instance.Spec.Sidecars)
+	if inputs.RestrictedPodSecurity {
+		ovn_common.SetRestrictedPodSecurity(&deployment.Spec.Template.Spec)
+	}
+
 	return deployment
 }
@@ -0,0 +1,109 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	"time"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// replicaStates - the states ovn-northd reports in status
+var replicaStates = []string{"active", "standby", "paused"}
+
+// replicasByState - number of ovn-northd replicas per reported state, served
+// on the metrics endpoint of the operator with every replica status refresh
+var replicasByState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ovn_northd_replicas",
+	Help: "Number of ovn-northd replicas by reported state",
+}, []string{"namespace", "name", "status"})
+
+// cfgLag - number of nb_cfg updates the SB database and the chassis did
+// not catch up with yet, and the time the last update took to reach all
+// the chassis
+var (
+	cfgLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_northd_cfg_lag",
+		Help: "Number of nb_cfg updates not yet propagated to the SB database (stage sb) or processed by all the chassis (stage hv)",
+	}, []string{"namespace", "name", "stage"})
+	hvConvergence = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ovn_northd_hv_convergence_seconds",
+		Help: "Time the latest nb_cfg update took to be processed by all the chassis, or has been pending for",
+	}, []string{"namespace", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(replicasByState, cfgLag, hvConvergence)
+}
+
+// SetReplicaMetrics - count the replicas of the instance per state
+func SetReplicaMetrics(instance *ovnv1.OVNNorthd, replicas []ovnv1.OVNNorthdReplicaStatus) {
+	for _, state := range replicaStates {
+		count := 0
+		for _, replica := range replicas {
+			if replica.Status == state {
+				count++
+			}
+		}
+		replicasByState.With(prometheus.Labels{
+			"namespace": instance.Namespace,
+			"name":      instance.Name,
+			"status":    state,
+		}).Set(float64(count))
+	}
+}
+
+// SetCfgMetrics - publish the nb_cfg lag of the SB database and the
+// chassis, and the time it took the chassis to process the latest nb_cfg
+// update. While the update is not processed, the time since ovn-northd
+// started processing it.
+func SetCfgMetrics(instance *ovnv1.OVNNorthd, cfg *CfgSequence, now time.Time) {
+	labels := prometheus.Labels{
+		"namespace": instance.Namespace,
+		"name":      instance.Name,
+	}
+	hvConvergence.With(labels).Set(hvConvergenceSeconds(cfg, now))
+
+	labels["stage"] = "sb"
+	cfgLag.With(labels).Set(float64(cfg.NBCfg - cfg.SBCfg))
+	labels["stage"] = "hv"
+	cfgLag.With(labels).Set(float64(cfg.NBCfg - cfg.HVCfg))
+}
+
+func hvConvergenceSeconds(cfg *CfgSequence, now time.Time) float64 {
+	if cfg.NBCfgTimestamp == 0 {
+		return 0
+	}
+	if cfg.HVCfg >= cfg.NBCfg {
+		if cfg.HVCfgTimestamp < cfg.NBCfgTimestamp {
+			return 0
+		}
+		return float64(cfg.HVCfgTimestamp-cfg.NBCfgTimestamp) / 1000
+	}
+	return now.Sub(time.UnixMilli(cfg.NBCfgTimestamp)).Seconds()
+}
+
+// DeleteReplicaMetrics - remove the replica and nb_cfg metrics of the
+// instance
+func DeleteReplicaMetrics(instance *ovnv1.OVNNorthd) {
+	labels := prometheus.Labels{
+		"namespace": instance.Namespace,
+		"name":      instance.Name,
+	}
+	replicasByState.DeletePartialMatch(labels)
+	cfgLag.DeletePartialMatch(labels)
+	hvConvergence.DeletePartialMatch(labels)
+}
@@ -0,0 +1,65 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	policyv1 "k8s.io/api/policy/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PodDisruptionBudget - keep ovn-northd replicas running during drains and
+// upgrades. The budget is removed when there are not more replicas than the
+// minimum to keep, otherwise it would block the drain of the node.
+func PodDisruptionBudget(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *ovnv1.OVNNorthd,
+	labels map[string]string,
+) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ovnv1.ServiceNameOVNNorthd,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+	}
+
+	minAvailable := instance.GetMinAvailable()
+	if minAvailable == 0 || *instance.Spec.Replicas <= minAvailable {
+		err := helper.GetClient().Delete(ctx, pdb)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("Error deleting PodDisruptionBudget %s: %w", pdb.Name, err)
+		}
+		return nil
+	}
+
+	pdbMinAvailable := intstr.FromInt(int(minAvailable))
+	pdb.Spec.MinAvailable = &pdbMinAvailable
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: labels,
+	}
+	err := ovn_common.Apply(ctx, helper, pdb)
+	if err != nil {
+		return fmt.Errorf("Error creating PodDisruptionBudget %s: %w", pdb.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OVNNorthdPods - Query current ovn-northd pods managed by the deployment
+func OVNNorthdPods(
+	ctx context.Context,
+	instance *ovnv1.OVNNorthd,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+) (*corev1.PodList, error) {
+	podSelectorString := k8s_labels.Set(serviceLabels).String()
+	return helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{LabelSelector: podSelectorString})
+}
+
+// GetStatus - ask the ovn-northd running in the pod for its status: active
+// when it holds the SB lock and computes the logical flows, standby or paused
+func GetStatus(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+) (string, error) {
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, []string{
+		"ovn-appctl", "-t", ovnv1.ServiceNameOVNNorthd, "status",
+	})
+	if err != nil {
+		return "", err
+	}
+	_, status, _ := strings.Cut(strings.TrimSpace(output), "Status: ")
+	return status, nil
+}
+
+// SetRoleLabel - label the pod with the ovn-northd status so services and
+// metrics can tell the active instance apart
+func SetRoleLabel(
+	ctx context.Context,
+	helper *helper.Helper,
+	pod *corev1.Pod,
+	role string,
+) error {
+	if pod.Labels[RoleLabel] == role {
+		return nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[RoleLabel] = role
+	return helper.GetClient().Patch(ctx, pod, patch)
+}
+
+// SetPaused - pause or resume the ovn-northd running in the pod
+func SetPaused(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	paused bool,
+) error {
+	command := "resume"
+	if paused {
+		command = "pause"
+	}
+	_, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, []string{
+		"ovn-appctl", "-t", ovnv1.ServiceNameOVNNorthd, command,
+	})
+	return err
+}
+
+// SetNBGlobalOptions - store the ovn-northd connection tuning and the aging
+// of the SB DB records in the NB_Global options, ovn-northd picks them up
+// without a restart. Unset values are removed so ovn-northd falls back to its
+// defaults.
+func SetNBGlobalOptions(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	instance *ovnv1.OVNNorthd,
+	nbEndpoint string,
+) error {
+	command := nbctlCommand(instance, nbEndpoint)
+	options := []struct {
+		key   string
+		value *int32
+	}{
+		{"northd_probe_interval", instance.TunedProbeInterval()},
+		{"northd-backoff-interval-ms", instance.Spec.BackoffInterval},
+		{"mac_binding_age_threshold", instance.Spec.DataRetention.MACBindingAgeThreshold},
+		{"mac_binding_removal_limit", instance.Spec.DataRetention.MACBindingRemovalLimit},
+		{"fdb_age_threshold", instance.Spec.DataRetention.FDBAgeThreshold},
+		{"fdb_removal_limit", instance.Spec.DataRetention.FDBRemovalLimit},
+	}
+	for _, option := range options {
+		if option.value != nil {
+			command = append(command, "--", "set", "NB_Global", ".",
+				fmt.Sprintf("options:%s=%d", option.key, *option.value))
+		} else {
+			command = append(command, "--", "remove", "NB_Global", ".", "options", option.key)
+		}
+	}
+	_, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, command)
+	return err
+}
+
+// GetConnectionStatus - check if the ovn-northd running in the pod is
+// connected to the database, dbType is either nb or sb
+func GetConnectionStatus(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	dbType string,
+) (bool, error) {
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, []string{
+		"ovn-appctl", "-t", ovnv1.ServiceNameOVNNorthd, dbType + "-connection-status",
+	})
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) == "connected", nil
+}
+
+// CfgSequence - the nb_cfg sequence numbers of NB_Global
+type CfgSequence struct {
+	// NBCfg - requested by the NB clients
+	NBCfg int64
+	// SBCfg - propagated by ovn-northd to the SB database
+	SBCfg int64
+	// HVCfg - processed by all the chassis
+	HVCfg int64
+	// NBCfgTimestamp - when ovn-northd started processing NBCfg, in
+	// milliseconds since the epoch
+	NBCfgTimestamp int64
+	// HVCfgTimestamp - when all the chassis caught up with HVCfg, in
+	// milliseconds since the epoch
+	HVCfgTimestamp int64
+}
+
+// GetCfgSequence - read the nb_cfg sequence number requested by the NB
+// clients, the sb_cfg one ovn-northd has propagated to the SB database and
+// the hv_cfg one all the chassis have processed. The differences show how
+// far behind the SB database and the data plane are.
+func GetCfgSequence(
+	ctx context.Context,
+	helper *helper.Helper,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	instance *ovnv1.OVNNorthd,
+	nbEndpoint string,
+) (*CfgSequence, error) {
+	command := append(nbctlCommand(instance, nbEndpoint), "get", "NB_Global", ".",
+		"nb_cfg", "sb_cfg", "hv_cfg", "nb_cfg_timestamp", "hv_cfg_timestamp")
+	output, err := ovn_common.ExecInPod(ctx, helper, restConfig, pod, command)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(output)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("unexpected NB_Global cfg output: %q", output)
+	}
+	values := make([]int64, len(fields))
+	for i, field := range fields {
+		values[i], err = strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &CfgSequence{
+		NBCfg:          values[0],
+		SBCfg:          values[1],
+		HVCfg:          values[2],
+		NBCfgTimestamp: values[3],
+		HVCfgTimestamp: values[4],
+	}, nil
+}
+
+// nbctlCommand - ovn-nbctl command line to reach the NB database from an
+// ovn-northd pod
+func nbctlCommand(instance *ovnv1.OVNNorthd, nbEndpoint string) []string {
+	command := []string{"ovn-nbctl", fmt.Sprintf("--db=%s", nbEndpoint)}
+	if instance.Spec.TLS.Enabled() {
+		command = append(command,
+			fmt.Sprintf("--certificate=%s", ovn_common.OVNDbCertPath),
+			fmt.Sprintf("--private-key=%s", ovn_common.OVNDbKeyPath),
+			fmt.Sprintf("--ca-cert=%s", ovn_common.OVNDbCaCertPath),
+		)
+	}
+	return command
+}
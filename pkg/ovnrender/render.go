@@ -0,0 +1,488 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnrender
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	ovnapiv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovninterconnect"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovnnorthd"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// Options - the manager flags changing the rendered pods
+type Options struct {
+	RestrictedPodSecurity bool
+	ForbidHostNamespaces  bool
+}
+
+// endpoint - the placeholder of the internal endpoint of an OVN database
+// missing from the input
+func endpoint(dbType string) string {
+	return fmt.Sprintf("<%s endpoint>", dbType)
+}
+
+// Rendered - the objects the operator applies for a custom resource, with
+// notes on the inputs missing from the rendering
+type Rendered struct {
+	Objects []client.Object
+	Notes   []string
+}
+
+// inputs - reads the inputs of the pods the operator reads from the cluster,
+// e.g. Secrets or the OVNDBClusters, from the objects of the rendering
+type inputs struct {
+	ctx   context.Context
+	h     *helper.Helper
+	notes []string
+}
+
+// caBundleHash - hash of the CA bundle secret, empty without one or when it
+// is not in the input
+func (in *inputs) caBundleHash(namespace string, tlsSpec *ovnv1.TLSSection) (string, error) {
+	if tlsSpec.CaBundleSecretName == "" {
+		return "", nil
+	}
+	hash, _, err := tls.ValidateCACertSecret(
+		in.ctx,
+		in.h.GetClient(),
+		types.NamespacedName{
+			Name:      tlsSpec.CaBundleSecretName,
+			Namespace: namespace,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	if hash == "" {
+		in.notes = append(in.notes, fmt.Sprintf(
+			"Secret %s is not in the input, the hash of the CA bundle is left out", tlsSpec.CaBundleSecretName))
+	}
+	return hash, nil
+}
+
+// certHash - hash of the service cert secret, empty without TLS or when it
+// is not in the input
+func (in *inputs) certHash(namespace string, tlsSpec *ovnv1.TLSSection) (string, error) {
+	if !tlsSpec.Enabled() {
+		return "", nil
+	}
+	hash, _, err := tlsSpec.ValidateCertSecret(in.ctx, in.h, namespace)
+	if err != nil {
+		return "", err
+	}
+	if hash == "" {
+		in.notes = append(in.notes, fmt.Sprintf(
+			"Secret %s is not in the input, the hash of the service cert is left out", *tlsSpec.GenericService.SecretName))
+	}
+	return hash, nil
+}
+
+// dbEndpoint - internal endpoint of an OVN database, a placeholder when its
+// ready OVNDBCluster is not in the input
+func (in *inputs) dbEndpoint(namespace string, dbType string) string {
+	cluster, err := ovnv1.GetDBClusterByType(in.ctx, in.h, namespace, map[string]string{}, dbType)
+	if err == nil {
+		internalEndpoint, err := cluster.GetInternalEndpoint()
+		if err == nil && internalEndpoint != "" {
+			return internalEndpoint
+		}
+	}
+	in.notes = append(in.notes, fmt.Sprintf(
+		"No ready %s OVNDBCluster in the input, its endpoint is a placeholder", dbType))
+	return endpoint(dbType)
+}
+
+// configMaps - the ConfigMaps rendered into the input, without the fields
+// only set by the API server
+func (in *inputs) configMaps(namespace string, names ...string) ([]*corev1.ConfigMap, error) {
+	cms := []*corev1.ConfigMap{}
+	for _, name := range names {
+		cm := &corev1.ConfigMap{}
+		err := in.h.GetClient().Get(in.ctx, types.NamespacedName{Name: name, Namespace: namespace}, cm)
+		if err != nil {
+			return nil, err
+		}
+		cm.ResourceVersion = ""
+		cm.OwnerReferences = nil
+		cms = append(cms, cm)
+	}
+	return cms, nil
+}
+
+// Workloads - the ConfigMaps, DaemonSets, StatefulSets and Deployments the
+// operator deploys for the custom resource, built like the reconcile does.
+// The spec is defaulted like the webhooks do. The inputs the operator reads
+// from the cluster are read from c instead, e.g. the Secrets of the pods or
+// the OVNDBClusters with their status.
+func Workloads(ctx context.Context, c client.Client, scheme *runtime.Scheme, obj client.Object, opts Options) (*Rendered, error) {
+	// the templates of the ConfigMaps are looked up by the kind of the
+	// instance, which the objects read with a client have no
+	obj = obj.DeepCopyObject().(client.Object)
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	h, err := helper.NewHelper(obj, c, nil, scheme, logr.Discard())
+	if err != nil {
+		return nil, err
+	}
+	in := &inputs{ctx: ctx, h: h, notes: []string{}}
+
+	var objs []client.Object
+	switch instance := obj.(type) {
+	case *ovnv1.OVNController:
+		objs, err = ovnControllerWorkloads(in, instance, opts)
+	case *ovnv1.OVNDBCluster:
+		objs, err = ovnDBClusterWorkloads(in, instance, opts)
+	case *ovnv1.OVNNorthd:
+		objs, err = ovnNorthdWorkloads(in, instance, opts)
+	case *ovnv1.OVNInterconnect:
+		objs, err = ovnInterconnectWorkloads(in, instance, opts)
+	default:
+		return nil, fmt.Errorf("unsupported kind %s, only the OVN custom resources are rendered",
+			obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Rendered{Objects: objs, Notes: in.notes}, nil
+}
+
+func ovnControllerWorkloads(in *inputs, instance *ovnv1.OVNController, opts Options) ([]client.Object, error) {
+	instance.Default()
+
+	daemonSetInputs := ovncontroller.DaemonSetInputs{
+		NBEndpoint:        ovncontroller.NBEndpoint(in.ctx, in.h, instance.Namespace),
+		OVNUpdateStrategy: appsv1.RollingUpdateDaemonSetStrategyType,
+		OVSUpdateStrategy: appsv1.RollingUpdateDaemonSetStrategyType,
+	}
+	if daemonSetInputs.NBEndpoint == "" && instance.Spec.GatewayDrain {
+		in.notes = append(in.notes, "No ready NB OVNDBCluster in the input, the gateway ports are not drained")
+	}
+	var err error
+	daemonSetInputs.CABundleHash, err = in.caBundleHash(instance.Namespace, &instance.Spec.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ovncontroller.EnsureScriptsConfigMap(in.ctx, in.h, instance, &map[string]env.Setter{})
+	if err != nil {
+		return nil, err
+	}
+	configMapNames := []string{ovncontroller.ScriptsConfigMapName(instance)}
+	if instance.Spec.Metrics.Enabled {
+		daemonSetInputs.MetricsConfigHash, err = ovncontroller.EnsureMetricsConfigMap(in.ctx, in.h, instance,
+			ovncontroller.MetricsConfigMapName(instance), ovncontroller.MetricsConfigTemplate)
+		if err != nil {
+			return nil, err
+		}
+		configMapNames = append(configMapNames, ovncontroller.MetricsConfigMapName(instance))
+	}
+	if instance.Spec.Metrics.OVSEnabled {
+		daemonSetInputs.OVSMetricsConfigHash, err = ovncontroller.EnsureMetricsConfigMap(in.ctx, in.h, instance,
+			ovncontroller.OVSMetricsConfigMapName(instance), ovncontroller.OVSMetricsConfigTemplate)
+		if err != nil {
+			return nil, err
+		}
+		configMapNames = append(configMapNames, ovncontroller.OVSMetricsConfigMapName(instance))
+	}
+	if instance.Spec.BGP != nil {
+		daemonSetInputs.BGPConfigHash, err = ovncontroller.EnsureBGPConfigMap(in.ctx, in.h, instance)
+		if err != nil {
+			return nil, err
+		}
+		configMapNames = append(configMapNames, ovncontroller.BGPConfigMapName(instance))
+	}
+	cms, err := in.configMaps(instance.Namespace, configMapNames...)
+	if err != nil {
+		return nil, err
+	}
+	daemonSetInputs.Scripts = cms[0]
+
+	ovnDaemonSet, ovsDaemonSet, err := ovncontroller.DaemonSets(instance, daemonSetInputs)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ForbidHostNamespaces {
+		ovn_common.ForbidHostNamespaces(&ovnDaemonSet.Spec.Template.Spec)
+		ovn_common.ForbidHostNamespaces(&ovsDaemonSet.Spec.Template.Spec)
+	}
+
+	objs := []client.Object{}
+	for _, cm := range cms {
+		objs = append(objs, cm)
+	}
+	return append(objs, ovnDaemonSet, ovsDaemonSet), nil
+}
+
+func ovnDBClusterWorkloads(in *inputs, instance *ovnv1.OVNDBCluster, opts Options) ([]client.Object, error) {
+	instance.Default()
+
+	configMapVars := make(map[string]env.Setter)
+	hash, err := in.caBundleHash(instance.Namespace, &instance.Spec.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if hash != "" {
+		configMapVars[tls.CABundleKey] = env.SetValue(hash)
+	}
+	err = ovndbcluster.EnsureScriptsConfigMap(in.ctx, in.h, instance, &configMapVars)
+	if err != nil {
+		return nil, err
+	}
+	cms, err := in.configMaps(instance.Namespace, ovndbcluster.ScriptsConfigMapName(instance))
+	if err != nil {
+		return nil, err
+	}
+	inputHash, err := ovn_common.InputHash(configMapVars)
+	if err != nil {
+		return nil, err
+	}
+
+	sfset, err := ovndbcluster.StatefulSet(instance, ovndbcluster.StatefulSetInputs{
+		InputHash:             inputHash,
+		RestrictedPodSecurity: opts.RestrictedPodSecurity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []client.Object{cms[0], sfset}, nil
+}
+
+// inputHashes - the hashes of the TLS secrets the ovn-northd and ovn-ic pods
+// are restarted for
+func (in *inputs) inputHashes(namespace string, tlsSpec *ovnv1.TLSSection) (map[string]env.Setter, error) {
+	envVars := make(map[string]env.Setter)
+	hash, err := in.caBundleHash(namespace, tlsSpec)
+	if err != nil {
+		return nil, err
+	}
+	if hash != "" {
+		envVars[tls.CABundleKey] = env.SetValue(hash)
+	}
+	hash, err = in.certHash(namespace, tlsSpec)
+	if err != nil {
+		return nil, err
+	}
+	if tlsSpec.Enabled() {
+		envVars[tls.TLSHashName] = env.SetValue(hash)
+	}
+	return envVars, nil
+}
+
+func ovnNorthdWorkloads(in *inputs, instance *ovnv1.OVNNorthd, opts Options) ([]client.Object, error) {
+	instance.Default()
+
+	envVars, err := in.inputHashes(instance.Namespace, &instance.Spec.TLS)
+	if err != nil {
+		return nil, err
+	}
+	depl := ovnnorthd.Deployment(instance, ovnnorthd.DeploymentInputs{
+		NBEndpoint:            in.dbEndpoint(instance.Namespace, ovnv1.NBDBType),
+		SBEndpoint:            in.dbEndpoint(instance.Namespace, ovnv1.SBDBType),
+		InputHashes:           envVars,
+		RestrictedPodSecurity: opts.RestrictedPodSecurity,
+	})
+	return []client.Object{depl}, nil
+}
+
+func ovnInterconnectWorkloads(in *inputs, instance *ovnv1.OVNInterconnect, opts Options) ([]client.Object, error) {
+	instance.Default()
+
+	envVars, err := in.inputHashes(instance.Namespace, &instance.Spec.TLS)
+	if err != nil {
+		return nil, err
+	}
+	depl := ovninterconnect.Deployment(instance, ovninterconnect.DeploymentInputs{
+		Endpoints: ovninterconnect.Endpoints{
+			NB:   in.dbEndpoint(instance.Namespace, ovnv1.NBDBType),
+			SB:   in.dbEndpoint(instance.Namespace, ovnv1.SBDBType),
+			ICNB: in.dbEndpoint(instance.Namespace, ovnv1.ICNBDBType),
+			ICSB: in.dbEndpoint(instance.Namespace, ovnv1.ICSBDBType),
+		},
+		InputHashes:           envVars,
+		RestrictedPodSecurity: opts.RestrictedPodSecurity,
+	})
+	return []client.Object{depl}, nil
+}
+
+// hub - the object as read by the operator, the v1 resources converted to
+// v1beta1
+func hub(obj runtime.Object) (client.Object, error) {
+	var dst client.Object
+	switch src := obj.(type) {
+	case *ovnapiv1.OVNController:
+		dst = &ovnv1.OVNController{}
+		if err := src.ConvertTo(dst.(*ovnv1.OVNController)); err != nil {
+			return nil, err
+		}
+	case *ovnapiv1.OVNDBCluster:
+		dst = &ovnv1.OVNDBCluster{}
+		if err := src.ConvertTo(dst.(*ovnv1.OVNDBCluster)); err != nil {
+			return nil, err
+		}
+	case *ovnapiv1.OVNNorthd:
+		dst = &ovnv1.OVNNorthd{}
+		if err := src.ConvertTo(dst.(*ovnv1.OVNNorthd)); err != nil {
+			return nil, err
+		}
+	case client.Object:
+		dst = src
+	default:
+		return nil, fmt.Errorf("unsupported object %T", obj)
+	}
+	return dst, nil
+}
+
+// read - the objects of the YAML or JSON documents of in, the items of the
+// Lists included
+func read(in io.Reader, scheme *runtime.Scheme) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+	objs := []client.Object{}
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading the custom resources: %w", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding a custom resource: %w", err)
+		}
+		docObjs := []runtime.Object{obj}
+		if list, ok := obj.(*corev1.List); ok {
+			docObjs = []runtime.Object{}
+			for _, item := range list.Items {
+				itemObj, _, err := decoder.Decode(item.Raw, nil, nil)
+				if err != nil {
+					return nil, fmt.Errorf("error decoding a custom resource: %w", err)
+				}
+				docObjs = append(docObjs, itemObj)
+			}
+		}
+		for _, docObj := range docObjs {
+			hubObj, err := hub(docObj)
+			if err != nil {
+				return nil, err
+			}
+			gvk, err := apiutil.GVKForObject(hubObj, scheme)
+			if err != nil {
+				return nil, err
+			}
+			hubObj.GetObjectKind().SetGroupVersionKind(gvk)
+			objs = append(objs, hubObj)
+		}
+	}
+}
+
+// rendered - whether the operator deploys workloads for the object
+func rendered(obj client.Object) bool {
+	switch obj.(type) {
+	case *ovnv1.OVNController, *ovnv1.OVNDBCluster, *ovnv1.OVNNorthd, *ovnv1.OVNInterconnect:
+		return true
+	}
+	return false
+}
+
+// Render - write the workloads of the custom resources read from in to out,
+// as YAML documents. The input holds YAML or JSON documents, e.g. the
+// manifests of a GitOps repository or the output of kubectl get -o yaml,
+// Lists included. The other objects of the input stand in for the cluster,
+// e.g. the Secrets hashed into the pods or the OVNDBClusters with the
+// endpoints in their status. Nothing is read from or applied to a cluster.
+func Render(in io.Reader, out io.Writer, scheme *runtime.Scheme, opts Options) error {
+	objs, err := read(in, scheme)
+	if err != nil {
+		return err
+	}
+	inputObjs := make([]client.Object, 0, len(objs))
+	for _, obj := range objs {
+		inputObjs = append(inputObjs, obj.DeepCopyObject().(client.Object))
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(inputObjs...).Build()
+	for _, obj := range objs {
+		if !rendered(obj) {
+			continue
+		}
+		r, err := Workloads(context.TODO(), c, scheme, obj, opts)
+		if err != nil {
+			return fmt.Errorf("error rendering %s %s: %w",
+				obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		err = write(r, out, scheme)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write - write the rendered objects of a custom resource to out, the notes
+// as comments
+func write(r *Rendered, out io.Writer, scheme *runtime.Scheme) error {
+	for _, note := range r.Notes {
+		_, err := fmt.Fprintf(out, "# %s\n", note)
+		if err != nil {
+			return err
+		}
+	}
+	for _, obj := range r.Objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "---\n%s", data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
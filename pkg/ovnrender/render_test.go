@@ -0,0 +1,184 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnrender
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	ovnapiv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	testNamespace  = "openstack"
+	testCABundle   = "combined-ca-bundle"
+	testNBEndpoint = "tcp:ovsdbserver-nb-0.openstack.svc:6641"
+)
+
+func testScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(ovnv1.AddToScheme(scheme))
+	utilruntime.Must(ovnapiv1.AddToScheme(scheme))
+	return scheme
+}
+
+// testOVNController - a sample OVNController with a CA bundle, draining the
+// gateway ports through the NB DB
+func testOVNController() *ovnv1.OVNController {
+	instance := &ovnv1.OVNController{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ovnv1.GroupVersion.String(),
+			Kind:       "OVNController",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-controller", Namespace: testNamespace},
+	}
+	instance.Spec.NicMappings = map[string]string{"physnet1": "eth1"}
+	instance.Spec.GatewayDrain = true
+	instance.Spec.TLS.CaBundleSecretName = testCABundle
+	return instance
+}
+
+// testInputs - the CA bundle Secret and the ready NB OVNDBCluster
+func testInputs() []client.Object {
+	nb := &ovnv1.OVNDBCluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ovnv1.GroupVersion.String(),
+			Kind:       "OVNDBCluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "ovndbcluster-nb", Namespace: testNamespace},
+	}
+	nb.Spec.DBType = ovnv1.NBDBType
+	nb.Status.InternalDBAddress = testNBEndpoint
+	return []client.Object{
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: testCABundle, Namespace: testNamespace},
+			Data:       map[string][]byte{tls.CABundleKey: []byte("CA")},
+		},
+		nb,
+	}
+}
+
+func envValue(container corev1.Container, name string) string {
+	for _, e := range container.Env {
+		if e.Name == name {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+func TestOVNControllerWorkloads(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("OPERATOR_TEMPLATES", "../../templates")
+
+	scheme := testScheme()
+	instance := testOVNController()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(testInputs(), instance)...).Build()
+
+	r, err := Workloads(context.TODO(), c, scheme, instance, Options{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(r.Notes).To(BeEmpty())
+	g.Expect(r.Objects).To(HaveLen(3))
+
+	scripts, ok := r.Objects[0].(*corev1.ConfigMap)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(scripts.Name).To(Equal(ovncontroller.ScriptsConfigMapName(instance)))
+	ovnDaemonSet, ok := r.Objects[1].(*appsv1.DaemonSet)
+	g.Expect(ok).To(BeTrue())
+	ovsDaemonSet, ok := r.Objects[2].(*appsv1.DaemonSet)
+	g.Expect(ok).To(BeTrue())
+
+	// the DaemonSets are the ones the controller builds from the same inputs
+	caHash, _, err := tls.ValidateCACertSecret(context.TODO(), c, client.ObjectKey{
+		Name: testCABundle, Namespace: testNamespace})
+	g.Expect(err).NotTo(HaveOccurred())
+	defaulted := instance.DeepCopy()
+	defaulted.Default()
+	wantOVN, wantOVS, err := ovncontroller.DaemonSets(defaulted, ovncontroller.DaemonSetInputs{
+		Scripts:           scripts,
+		CABundleHash:      caHash,
+		NBEndpoint:        testNBEndpoint,
+		OVNUpdateStrategy: appsv1.RollingUpdateDaemonSetStrategyType,
+		OVSUpdateStrategy: appsv1.RollingUpdateDaemonSetStrategyType,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ovnDaemonSet).To(Equal(wantOVN))
+	g.Expect(ovsDaemonSet).To(Equal(wantOVS))
+
+	ovnScriptsHash, _, err := ovncontroller.ScriptsHashes(scripts, defaulted)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ovnDaemonSet.Spec.Template.Annotations).To(HaveKeyWithValue(
+		ovncontroller.ScriptsHashAnnotation, ovnScriptsHash))
+	g.Expect(ovnDaemonSet.Spec.Template.Annotations).To(HaveKeyWithValue(
+		ovn_common.TLSCABundleHashAnnotation, caHash))
+	g.Expect(envValue(ovnDaemonSet.Spec.Template.Spec.Containers[0], "NB_REMOTE")).To(Equal(testNBEndpoint))
+	g.Expect(ovsDaemonSet.Spec.Template.Annotations).To(HaveKey(ovncontroller.OVSTuningHashAnnotation))
+}
+
+func TestOVNControllerWorkloadsMissingInputs(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("OPERATOR_TEMPLATES", "../../templates")
+
+	scheme := testScheme()
+	instance := testOVNController()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).Build()
+
+	r, err := Workloads(context.TODO(), c, scheme, instance, Options{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(r.Notes).To(ContainElement(ContainSubstring(testCABundle)))
+	ovnDaemonSet, ok := r.Objects[1].(*appsv1.DaemonSet)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(ovnDaemonSet.Spec.Template.Annotations).NotTo(HaveKey(ovn_common.TLSCABundleHashAnnotation))
+	g.Expect(ovnDaemonSet.Spec.Template.Annotations).To(HaveKey(ovncontroller.ScriptsHashAnnotation))
+}
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("OPERATOR_TEMPLATES", "../../templates")
+
+	input := &bytes.Buffer{}
+	for _, obj := range append(testInputs(), testOVNController()) {
+		data, err := yaml.Marshal(obj)
+		g.Expect(err).NotTo(HaveOccurred())
+		input.WriteString("---\n")
+		input.Write(data)
+	}
+
+	out := &bytes.Buffer{}
+	g.Expect(Render(input, out, testScheme(), Options{})).To(Succeed())
+	g.Expect(out.String()).NotTo(HavePrefix("#"))
+	g.Expect(out.String()).To(ContainSubstring("kind: ConfigMap"))
+	g.Expect(out.String()).To(ContainSubstring("kind: DaemonSet"))
+	g.Expect(out.String()).To(ContainSubstring(ovncontroller.ScriptsHashAnnotation))
+	g.Expect(out.String()).To(ContainSubstring(ovn_common.TLSCABundleHashAnnotation))
+	g.Expect(out.String()).To(ContainSubstring(testNBEndpoint))
+}
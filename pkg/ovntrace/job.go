@@ -0,0 +1,233 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovntrace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// OVNTraceHash - hash key of the ovn-trace Job
+	OVNTraceHash = "ovntrace"
+	// OFProtoTraceHash - hash key of the ofproto/trace Job
+	OFProtoTraceHash = "ofprototrace"
+
+	// OVNTraceCommand - builds the microflow of the packet from the addresses
+	// of the logical ports in the SB DB and traces it. The detailed trace
+	// goes to the pod log, the termination log holds the chassis of the
+	// source port, the packet as an OpenFlow flow for ofproto/trace and the
+	// summary of the trace.
+	OVNTraceCommand = `set -e
+SBCTL="ovn-sbctl --no-leader-only --db=${SB_REMOTE} ${SSL_ARGS}"
+
+port_addresses() {
+    ${SBCTL} --bare --columns=mac find Port_Binding logical_port="$1" | head -n 1
+}
+
+read -r SRC_MAC SRC_IP _ <<< "$(port_addresses "${SOURCE_PORT}")"
+if [ -z "${SRC_MAC}" ]; then
+    echo "logical port ${SOURCE_PORT} not found" >&2
+    exit 1
+fi
+MATCH="inport == \"${SOURCE_PORT}\" && eth.src == ${SRC_MAC}"
+FLOW="dl_src=${SRC_MAC}"
+
+if [ -n "${DESTINATION_PORT}" ]; then
+    read -r DST_MAC DST_IP _ <<< "$(port_addresses "${DESTINATION_PORT}")"
+    if [ -z "${DST_MAC}" ]; then
+        echo "logical port ${DESTINATION_PORT} not found" >&2
+        exit 1
+    fi
+    MATCH="${MATCH} && eth.dst == ${DST_MAC}"
+    FLOW="${FLOW},dl_dst=${DST_MAC}"
+fi
+
+if [ -n "${SRC_IP}" ] && [ -n "${DST_IP}" ]; then
+    if [[ "${SRC_IP}" == *:* ]]; then
+        IP=ip6
+        ICMP="icmp6 && icmp6.type == 128"
+        OF_IP="ipv6_src=${SRC_IP},ipv6_dst=${DST_IP}"
+        OF_ICMP="icmp6,icmp_type=128"
+        OF_SUFFIX=6
+    else
+        IP=ip4
+        ICMP="icmp4 && icmp4.type == 8"
+        OF_IP="nw_src=${SRC_IP},nw_dst=${DST_IP}"
+        OF_ICMP="icmp,icmp_type=8"
+        OF_SUFFIX=""
+    fi
+    MATCH="${MATCH} && ${IP}.src == ${SRC_IP} && ${IP}.dst == ${DST_IP} && ip.ttl == 64"
+    FLOW="${FLOW},${OF_IP},nw_ttl=64"
+    case "${PROTOCOL}" in
+    tcp|udp)
+        MATCH="${MATCH} && ${PROTOCOL}"
+        FLOW="${FLOW},${PROTOCOL}${OF_SUFFIX}"
+        if [ -n "${L4_DESTINATION_PORT}" ]; then
+            MATCH="${MATCH} && ${PROTOCOL}.dst == ${L4_DESTINATION_PORT}"
+            FLOW="${FLOW},tp_dst=${L4_DESTINATION_PORT}"
+        fi
+        ;;
+    *)
+        MATCH="${MATCH} && ${ICMP}"
+        FLOW="${FLOW},${OF_ICMP}"
+        ;;
+    esac
+fi
+
+if [ -n "${MICROFLOW}" ]; then
+    MATCH="${MATCH} && (${MICROFLOW})"
+fi
+
+NODE=""
+CHASSIS=$(${SBCTL} --bare --columns=chassis find Port_Binding logical_port="${SOURCE_PORT}")
+if [ -n "${CHASSIS}" ]; then
+    NODE=$(${SBCTL} --bare --columns=hostname list Chassis "${CHASSIS}")
+fi
+
+ovn-trace --db="${SB_REMOTE}" ${SSL_ARGS} --detailed "${MATCH}"
+{
+    echo "chassis=${NODE}"
+    echo "flow=${FLOW}"
+    echo
+    ovn-trace --db="${SB_REMOTE}" ${SSL_ARGS} --summary "${MATCH}" | tail -c 3584
+} > /dev/termination-log
+`
+
+	// OFProtoTraceCommand - traces the packet through the OpenFlow tables of
+	// br-int from the OVS interface of the source port
+	OFProtoTraceCommand = `set -e
+OFPORT=$(ovs-vsctl --bare --columns=ofport find Interface external_ids:iface-id="${SOURCE_PORT}")
+if [ -z "${OFPORT}" ]; then
+    echo "no OVS interface of logical port ${SOURCE_PORT} on ${OVS_BRIDGE}" >&2
+    exit 1
+fi
+ovs-appctl ofproto/trace "${OVS_BRIDGE}" "in_port=${OFPORT},${FLOW}" > /tmp/trace
+cat /tmp/trace
+tail -c 4096 /tmp/trace > /dev/termination-log
+`
+)
+
+// OVNTraceJobName - name of the Job running ovn-trace
+func OVNTraceJobName(instance *ovnv1.OVNTrace) string {
+	return instance.Name + "-ovn-trace"
+}
+
+// OFProtoTraceJobName - name of the Job running ofproto/trace
+func OFProtoTraceJobName(instance *ovnv1.OVNTrace) string {
+	return instance.Name + "-ofproto-trace"
+}
+
+// ResultConfigMapName - name of the ConfigMap holding the output of the
+// traces
+func ResultConfigMapName(instance *ovnv1.OVNTrace) string {
+	return instance.Name + "-result"
+}
+
+// OVNTraceJob - prepare the Job tracing the packet with ovn-trace against
+// the SB DB. The client cert of the SB DB cluster is used to connect to it.
+func OVNTraceJob(
+	instance *ovnv1.OVNTrace,
+	sbCluster *ovnv1.OVNDBCluster,
+	sbEndpoint string,
+	image string,
+) *batchv1.Job {
+	envVars := map[string]env.Setter{}
+	envVars["SB_REMOTE"] = env.SetValue(sbEndpoint)
+	envVars["SOURCE_PORT"] = env.SetValue(instance.Spec.SourcePort)
+	envVars["DESTINATION_PORT"] = env.SetValue(instance.Spec.DestinationPort)
+	envVars["PROTOCOL"] = env.SetValue(instance.Spec.Protocol)
+	envVars["MICROFLOW"] = env.SetValue(instance.Spec.Microflow)
+	if instance.Spec.L4DestinationPort != 0 {
+		envVars["L4_DESTINATION_PORT"] = env.SetValue(fmt.Sprintf("%d", instance.Spec.L4DestinationPort))
+	}
+
+	volumes, volumeMounts, sslArgs := ovndbcluster.ClientTLS(sbCluster)
+	envVars["SSL_ARGS"] = env.SetValue(strings.Join(sslArgs, " "))
+
+	return traceJob(instance, OVNTraceJobName(instance), corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers: []corev1.Container{
+			{
+				Name:                     "ovn-trace",
+				Image:                    image,
+				Command:                  []string{"/bin/bash", "-c", OVNTraceCommand},
+				Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
+				VolumeMounts:             volumeMounts,
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			},
+		},
+		Volumes: volumes,
+	})
+}
+
+// OFProtoTraceJob - prepare the Job tracing the packet with ofproto/trace on
+// the given node, against the OVS of the OVNController
+func OFProtoTraceJob(
+	instance *ovnv1.OVNTrace,
+	ovnController *ovnv1.OVNController,
+	nodeName string,
+	flow string,
+	image string,
+) *batchv1.Job {
+	envVars := map[string]env.Setter{}
+	envVars["SOURCE_PORT"] = env.SetValue(instance.Spec.SourcePort)
+	envVars["OVS_BRIDGE"] = env.SetValue(ovnController.Spec.ExternalIDS.OvnBridge)
+	envVars["FLOW"] = env.SetValue(flow)
+
+	return traceJob(instance, OFProtoTraceJobName(instance), ovncontroller.OVSClientPodSpec(
+		ovnController,
+		nodeName,
+		corev1.Container{
+			Name:                     "ofproto-trace",
+			Image:                    image,
+			Command:                  []string{"/bin/bash", "-c", OFProtoTraceCommand},
+			Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+	))
+}
+
+func traceJob(instance *ovnv1.OVNTrace, name string, podSpec corev1.PodSpec) *batchv1.Job {
+	labels := map[string]string{
+		common.AppSelector: name,
+	}
+	backoffLimit := int32(0)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
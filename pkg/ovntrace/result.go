@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovntrace
+
+import (
+	"strings"
+)
+
+// Result - the output of the ovn-trace Job
+type Result struct {
+	// Chassis - node of the chassis the source port is bound to, empty
+	// when unbound
+	Chassis string
+	// Flow - the packet as an OpenFlow flow, without the in_port
+	Flow string
+	// Trace - the summary of the trace
+	Trace string
+}
+
+// ParseOVNTraceResult - parse the termination message of the ovn-trace Job,
+// the header lines are separated from the trace by an empty line
+func ParseOVNTraceResult(output string) Result {
+	result := Result{}
+	header, trace, _ := strings.Cut(output, "\n\n")
+	for _, line := range strings.Split(header, "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), "=")
+		switch key {
+		case "chassis":
+			result.Chassis = value
+		case "flow":
+			result.Flow = value
+		}
+	}
+	result.Trace = trace
+	return result
+}
+
+// DatapathActions - the datapath actions in the output of ofproto/trace
+func DatapathActions(output string) string {
+	actions := ""
+	for _, line := range strings.Split(output, "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), "Datapath actions:"); found {
+			actions = strings.TrimSpace(value)
+		}
+	}
+	return actions
+}
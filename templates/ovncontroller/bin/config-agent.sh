@@ -0,0 +1,80 @@
+#!/bin/bash
+#
+# Copyright 2024 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+
+# Applies the config of the chassis of this node whenever the operator
+# changes it in the node config ConfigMap of the node, without restarting the
+# pods. The config is the environment of the config Job of the node, and it
+# is applied with the same functions.
+#
+# The ConfigMap is watched through the API server with the token of the pod
+# rather than mounted, as a DaemonSet can't mount a ConfigMap per node. Each
+# agent keeps a single watch filtered on the name of its ConfigMap open, so
+# nothing is polled: an idle node costs one watch on the API server, renewed
+# every NODE_CONFIG_WATCH_TIMEOUT seconds, and a change reaches the node as
+# soon as the operator writes it.
+
+NODE_CONFIG_MAP=${NODE_CONFIG_MAP_PREFIX}${OVNHostName}
+NODE_CONFIG_FILE=${NODE_CONFIG_FILE:-"/tmp/ovn-node-config"}
+NODE_CONFIG_WATCH_TIMEOUT=${NODE_CONFIG_WATCH_TIMEOUT:-600}
+NODE_CONFIG_RETRY_INTERVAL=${NODE_CONFIG_RETRY_INTERVAL:-5}
+SERVICE_ACCOUNT_DIR=/var/run/secrets/kubernetes.io/serviceaccount
+CONFIGMAPS_URL="https://${KUBERNETES_SERVICE_HOST}:${KUBERNETES_SERVICE_PORT}/api/v1/namespaces/$(cat ${SERVICE_ACCOUNT_DIR}/namespace)/configmaps"
+
+# watch_config - print the base64 encoded config of the node, a line on each
+# change of its ConfigMap. The watch starts with the current config, if the
+# ConfigMap exists, and ends after NODE_CONFIG_WATCH_TIMEOUT seconds.
+function watch_config {
+    curl -sSfN --cacert ${SERVICE_ACCOUNT_DIR}/ca.crt \
+        -H "Authorization: Bearer $(cat ${SERVICE_ACCOUNT_DIR}/token)" \
+        "${CONFIGMAPS_URL}?watch=true&fieldSelector=metadata.name%3D${NODE_CONFIG_MAP}&timeoutSeconds=${NODE_CONFIG_WATCH_TIMEOUT}" |
+        sed -un 's/^{"type":"\(ADDED\|MODIFIED\)".*"binaryData":{"config":"\([^"]*\)".*$/\2/p'
+}
+
+# apply_config - apply the config in NODE_CONFIG_FILE, in a subshell so the
+# settings removed from the config are unset
+function apply_config {
+    (
+        set -a
+        source "$NODE_CONFIG_FILE"
+        set +a
+        source $(dirname $0)/functions
+        wait_for_ovsdb_server
+        set -ex
+        configure_external_ids
+        configure_physical_networks
+        configure_tunnel_mtu
+    )
+}
+
+applied=""
+while true; do
+    while read -r config; do
+        echo "$config" | base64 -d > "$NODE_CONFIG_FILE"
+        checksum=$(md5sum "$NODE_CONFIG_FILE" | cut -d ' ' -f 1)
+        if [ "$checksum" == "$applied" ]; then
+            continue
+        fi
+        echo "Applying config ${checksum} of node ${OVNHostName}"
+        if ! apply_config; then
+            # the next watch starts with the current config again
+            echo "Failed to apply config ${checksum} of node ${OVNHostName}, retrying"
+            break
+        fi
+        applied=$checksum
+    done < <(watch_config)
+    # the watch timed out, or failed e.g. while the API server is unreachable
+    sleep $NODE_CONFIG_RETRY_INTERVAL
+done
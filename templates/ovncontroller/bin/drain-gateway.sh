@@ -0,0 +1,148 @@
+#!/bin/bash
+#
+# Copyright 2024 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+
+# Moves the gateway ports off the chassis of this node before ovn-controller
+# stops, so a planned drain doesn't blackhole the external traffic.
+#
+#   drain   - lower the priority of the chassis in the Gateway_Chassis and
+#             HA_Chassis rows of the NB DB to 0, and wait until the
+#             chassisredirect ports are bound to other chassis
+#   restore - restore the priorities lowered by a previous drain, unless the
+#             chassis is cordoned
+#   cordon  - cordon the chassis for a node maintenance, the gateway ports are
+#             drained without waiting and the chassis is not offered as a
+#             gateway anymore, i.e. enable-chassis-as-gw is removed from its
+#             ovn-cms-options
+#   uncordon - undo cordon
+#
+# NB_REMOTE and GATEWAY_DRAIN_TIMEOUT are obtained from ENV variables.
+
+set -x
+source $(dirname $0)/functions
+
+MODE=${1:-drain}
+NB_REMOTE=${NB_REMOTE:-""}
+GATEWAY_DRAIN_TIMEOUT=${GATEWAY_DRAIN_TIMEOUT:-60}
+PRIORITY_KEY="ovn-operator-priority"
+
+CHASSIS=$(ovs-vsctl --if-exists get open . external_ids:system-id | tr -d '"')
+SB_REMOTE=$(ovs-vsctl --if-exists get open . external_ids:ovn-remote | tr -d '"')
+if [ -z "$CHASSIS" ]; then
+    echo "The chassis of this node is not configured"
+    exit 0
+fi
+
+SSL_ARGS=""
+if [ -f {{.OVNDB_CERT_PATH}} ]; then
+    SSL_ARGS="-p {{.OVNDB_KEY_PATH}} -c {{.OVNDB_CERT_PATH}} -C {{.OVNDB_CACERT_PATH}}"
+fi
+NBCTL="ovn-nbctl --timeout=10 --db=$NB_REMOTE $SSL_ARGS"
+SBCTL="ovn-sbctl --timeout=10 --db=$SB_REMOTE $SSL_ARGS"
+
+function drain {
+    local table=$1
+    if [ -z "$NB_REMOTE" ]; then
+        echo "No NB DB to drain the gateway ports from"
+        return
+    fi
+    for row in $($NBCTL --bare --columns=_uuid find $table chassis_name=$CHASSIS); do
+        priority=$($NBCTL get $table $row priority)
+        if [ "$priority" != "0" ]; then
+            $NBCTL set $table $row priority=0 external_ids:${PRIORITY_KEY}=$priority
+        fi
+    done
+}
+
+function restore {
+    local table=$1
+    if [ -z "$NB_REMOTE" ]; then
+        return
+    fi
+    for row in $($NBCTL --bare --columns=_uuid find $table chassis_name=$CHASSIS); do
+        priority=$($NBCTL --if-exists get $table $row external_ids:${PRIORITY_KEY} | tr -d '"')
+        if [ -n "$priority" ]; then
+            $NBCTL set $table $row priority=$priority -- remove $table $row external_ids ${PRIORITY_KEY}
+        fi
+    done
+}
+
+function cordoned {
+    [ "$(ovs-vsctl --if-exists get open . external_ids:${MAINTENANCE_KEY} | tr -d '"')" == "true" ]
+}
+
+case "$MODE" in
+    drain)
+        drain Gateway_Chassis
+        drain HA_Chassis
+        if [ -z "$NB_REMOTE" ]; then
+            exit 0
+        fi
+
+        chassis_uuid=$($SBCTL --bare --columns=_uuid find Chassis name=$CHASSIS)
+        if [ -z "$chassis_uuid" ]; then
+            exit 0
+        fi
+        for i in $(seq $GATEWAY_DRAIN_TIMEOUT); do
+            ports=$($SBCTL --bare --columns=logical_port find Port_Binding type=chassisredirect chassis=$chassis_uuid)
+            if [ -z "$ports" ]; then
+                echo "Gateway ports drained from chassis $CHASSIS"
+                exit 0
+            fi
+            sleep 1
+        done
+        echo "Timed out draining the gateway ports from chassis $CHASSIS: $ports"
+        ;;
+    restore)
+        if cordoned; then
+            echo "Chassis $CHASSIS is cordoned for maintenance"
+            exit 0
+        fi
+        restore Gateway_Chassis
+        restore HA_Chassis
+        ;;
+    cordon)
+        if ! cordoned; then
+            cms_options=$(ovs-vsctl --if-exists get open . external_ids:ovn-cms-options | tr -d '"')
+            if [ -n "$cms_options" ]; then
+                ovs-vsctl set open . external_ids:${CMS_OPTIONS_KEY}=${cms_options}
+            fi
+            ovs-vsctl set open . external_ids:${MAINTENANCE_KEY}=true
+            cms_options=$(echo ${cms_options} | tr ',' '\n' | grep -v '^enable-chassis-as-gw$' | paste -sd ',')
+            if [ -n "$cms_options" ]; then
+                ovs-vsctl set open . external_ids:ovn-cms-options=${cms_options}
+            else
+                ovs-vsctl --if-exists remove open . external_ids ovn-cms-options
+            fi
+        fi
+        drain Gateway_Chassis
+        drain HA_Chassis
+        ;;
+    uncordon)
+        if cordoned; then
+            cms_options=$(ovs-vsctl --if-exists get open . external_ids:${CMS_OPTIONS_KEY} | tr -d '"')
+            if [ -n "$cms_options" ]; then
+                ovs-vsctl set open . external_ids:ovn-cms-options=${cms_options}
+            else
+                ovs-vsctl --if-exists remove open . external_ids ovn-cms-options
+            fi
+            ovs-vsctl --if-exists remove open . external_ids ${CMS_OPTIONS_KEY} -- \
+                --if-exists remove open . external_ids ${MAINTENANCE_KEY}
+        fi
+        restore Gateway_Chassis
+        restore HA_Chassis
+        ;;
+esac
+exit 0
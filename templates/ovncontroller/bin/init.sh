@@ -23,3 +23,4 @@ set -ex
 
 configure_external_ids
 configure_physical_networks
+configure_tunnel_mtu
@@ -21,16 +21,27 @@ wait_for_ovsdb_server
 # wait_for_ovsdb_server interrim check would make the script exit.
 set -ex
 
-# Configure encap IP.
+if [ -n "${OVS_TUNNEL_MTU}" ]; then
+    # The tunnels go through the NIC of the network attachment
+    ip link set dev {{ .OVNEncapNIC }} mtu ${OVS_TUNNEL_MTU}
+fi
+
+# Configure encap IP, unless the node sets its own one.
 OVNEncapIP=$(ip -o addr show dev {{ .OVNEncapNIC }} scope global | awk '{print $4}' | cut -d/ -f1)
-ovs-vsctl --no-wait set open . external-ids:ovn-encap-ip=${OVNEncapIP}
+ovs-vsctl --no-wait set open . external-ids:${NIC_ENCAP_IP_KEY}=${OVNEncapIP}
+NodeEncapIP=$(ovs-vsctl --no-wait --if-exists get open . external_ids:${NODE_ENCAP_IP_KEY} | tr -d '"')
+ovs-vsctl --no-wait set open . external-ids:ovn-encap-ip=${NodeEncapIP:-${OVNEncapIP}}
 
 # Before starting vswitchd, block it from flushing existing datapath flows.
 ovs-vsctl --no-wait set open_vswitch . other_config:flow-restore-wait=true
 
+# The extra args are shell quoted words, split them into the positional
+# parameters without running anything they hold.
+eval "set -- ${OVS_VSWITCHD_EXTRA_ARGS}"
+
 # It's safe to start vswitchd now. Do it.
 # --detach to allow the execution to continue to restoring the flows.
-/usr/sbin/ovs-vswitchd --pidfile --mlockall --detach
+/usr/sbin/ovs-vswitchd --pidfile --mlockall --detach "$@"
 
 # Restore saved flows.
 if [ -f $FLOWS_RESTORE_SCRIPT ]; then
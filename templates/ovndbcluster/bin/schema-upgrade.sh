@@ -0,0 +1,42 @@
+#!/usr/bin/env bash
+#
+# Copyright 2024 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+set -ex
+source $(dirname $0)/functions
+
+# Runs with the new image before it is rolled out to the RAFT cluster
+# members, the result is reported through the termination log.
+SCHEMA=/usr/share/ovn/{{ .DB_SCHEMA }}
+OPTS=""
+{{- if .TLS }}
+OPTS="--private-key={{.OVNDB_KEY_PATH}} --certificate={{.OVNDB_CERT_PATH}} --ca-cert={{.OVNDB_CACERT_PATH}}"
+{{- end }}
+
+TARGET=$(ovsdb-tool schema-version ${SCHEMA})
+CURRENT=$(ovsdb-client ${OPTS} get-schema-version ${DB_REMOTE} ${DB_NAME})
+
+if [ "$(ovsdb-client ${OPTS} needs-conversion ${DB_REMOTE} ${SCHEMA})" = "no" ]; then
+    echo "${DB_NAME} schema ${CURRENT} needs no conversion to ${TARGET}" > /dev/termination-log
+    exit 0
+fi
+
+# Convert a standalone copy of the clustered database. The members convert
+# the database through the leader once they restart with the new image.
+ovsdb-client ${OPTS} backup ${DB_REMOTE} ${DB_NAME} > /tmp/backup.db
+if ! ovsdb-tool convert /tmp/backup.db ${SCHEMA} /tmp/converted.db 2> /tmp/convert.err; then
+    echo "{{ .SCHEMA_CONVERSION_ERROR }}: ${DB_NAME} schema ${CURRENT} to ${TARGET}: $(tail -n 1 /tmp/convert.err)" > /dev/termination-log
+    exit 1
+fi
+echo "${DB_NAME} schema ${CURRENT} converts to ${TARGET}" > /dev/termination-log
@@ -25,10 +25,6 @@ DB_SCHEME="ptcp"
 RAFT_PORT="{{ .RAFT_PORT }}"
 NAMESPACE="{{ .NAMESPACE }}"
 OPTS=""
-DB_NAME="OVN_Northbound"
-if [[ "${DB_TYPE}" == "sb" ]]; then
-    DB_NAME="OVN_Southbound"
-fi
 
 PODNAME=$(hostname -f | cut -d. -f1,2)
 PODIPV6=$(grep "${PODNAME}" /etc/hosts | grep ':' | cut -d$'\t' -f1)
@@ -44,8 +40,8 @@ fi
 # Later, cli arguments are still passed, but raft membership hints are already
 # stored in the databases, and hence the arguments are of no effect.
 if [[ "$(hostname)" != "{{ .SERVICE_NAME }}-0" ]]; then
-    #ovsdb-tool join-cluster /etc/ovn/ovn${DB_TYPE}_db.db ${DB_NAME} tcp:$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT} tcp:{{ .SERVICE_NAME }}-0.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT}
-    OPTS="--db-${DB_TYPE}-cluster-remote-addr={{ .SERVICE_NAME }}-0.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local --db-${DB_TYPE}-cluster-remote-port=${RAFT_PORT}"
+    #ovsdb-tool join-cluster ${DB_FILE} ${DB_NAME} tcp:$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT} tcp:{{ .SERVICE_NAME }}-0.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT}
+    OPTS="--db-${DB_OPT}-cluster-remote-addr={{ .SERVICE_NAME }}-0.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local --db-${DB_OPT}-cluster-remote-port=${RAFT_PORT}"
 fi
 
 
@@ -53,33 +49,48 @@ fi
 # extra_args after --
 set /usr/share/ovn/scripts/ovn-ctl --no-monitor
 
-set "$@" --db-${DB_TYPE}-election-timer={{ .OVN_ELECTION_TIMER }}
-set "$@" --db-${DB_TYPE}-cluster-local-addr=$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local
-set "$@" --db-${DB_TYPE}-cluster-local-port=${RAFT_PORT}
-set "$@" --db-${DB_TYPE}-probe-interval-to-active={{ .OVN_PROBE_INTERVAL_TO_ACTIVE }}
-set "$@" --db-${DB_TYPE}-addr=${DB_ADDR}
-set "$@" --db-${DB_TYPE}-port=${DB_PORT}
+set "$@" --db-${DB_OPT}-election-timer={{ .OVN_ELECTION_TIMER }}
+set "$@" --db-${DB_OPT}-cluster-local-addr=$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local
+set "$@" --db-${DB_OPT}-cluster-local-port=${RAFT_PORT}
+set "$@" --db-${DB_OPT}-probe-interval-to-active={{ .OVN_PROBE_INTERVAL_TO_ACTIVE }}
+set "$@" --db-${DB_OPT}-addr=${DB_ADDR}
+set "$@" --db-${DB_OPT}-port=${DB_PORT}
 {{- if .TLS }}
-set "$@" --ovn-${DB_TYPE}-db-ssl-key={{.OVNDB_KEY_PATH}}
-set "$@" --ovn-${DB_TYPE}-db-ssl-cert={{.OVNDB_CERT_PATH}}
-set "$@" --ovn-${DB_TYPE}-db-ssl-ca-cert={{.OVNDB_CACERT_PATH}}
-set "$@" --db-${DB_TYPE}-cluster-local-proto=ssl
-set "$@" --db-${DB_TYPE}-cluster-remote-proto=ssl
-set "$@" --db-${DB_TYPE}-create-insecure-remote=no
+set "$@" --ovn-${DB_OPT}-db-ssl-key={{.OVNDB_KEY_PATH}}
+set "$@" --ovn-${DB_OPT}-db-ssl-cert={{.OVNDB_CERT_PATH}}
+set "$@" --ovn-${DB_OPT}-db-ssl-ca-cert={{.OVNDB_CACERT_PATH}}
+set "$@" --db-${DB_OPT}-cluster-local-proto=ssl
+set "$@" --db-${DB_OPT}-cluster-remote-proto=ssl
+set "$@" --db-${DB_OPT}-create-insecure-remote=no
 {{- else }}
-set "$@" --db-${DB_TYPE}-cluster-local-proto=tcp
-set "$@" --db-${DB_TYPE}-cluster-remote-proto=tcp
+set "$@" --db-${DB_OPT}-cluster-local-proto=tcp
+set "$@" --db-${DB_OPT}-cluster-remote-proto=tcp
 {{- end }}
 
 # log to console
-set "$@" --ovn-${DB_TYPE}-log=-vconsole:{{ .OVN_LOG_LEVEL }}
+set "$@" --ovn-${DB_OPT}-log=-vconsole:{{ .OVN_LOG_LEVEL }}
 
+{{- if .OVN_LOG_FILE }}
+# log to file as well, the file is rotated by rotate_log_file
+set "$@" --ovn-${DB_OPT}-logfile={{ .OVN_LOG_FILE }}
+EXTRA_ARGS="-vfile:{{ .OVN_LOG_LEVEL }}"
+{{- else }}
 # if server attempts to log to file, ignore
 #
 # note: even with -vfile:off (see below), the server sometimes attempts to
 # create a log file -> this argument makes sure it doesn't polute OVN_LOGDIR
 # with a nearly empty log file
-set "$@" --ovn-${DB_TYPE}-logfile=/dev/null
+set "$@" --ovn-${DB_OPT}-logfile=/dev/null
+
+# don't log to file (we already log to console)
+EXTRA_ARGS="-vfile:off"
+{{- end }}
+{{- range .OVN_LOG_MODULES }}
+EXTRA_ARGS="${EXTRA_ARGS} -v{{ . }}"
+{{- end }}
+{{- range .OVN_EXTRA_ARGS }}
+EXTRA_ARGS="${EXTRA_ARGS} "{{ . }}
+{{- end }}
 
 # If db file is empty, remove it; otherwise service won't start.
 # See https://issues.redhat.com/browse/FDP-689 for more details.
@@ -87,22 +98,40 @@ if ! [ -s $DB_FILE ]; then
     cleanup_db_file
 fi
 
-# don't log to file (we already log to console)
-$@ ${OPTS} run_${DB_TYPE}_ovsdb -- -vfile:off &
+# Refuse to start on top of a corrupted database file
+check_db_integrity
+
+$@ ${OPTS} run_${DB_TYPE}_ovsdb -- ${EXTRA_ARGS} &
+{{- if .OVN_LOG_FILE }}
+rotate_log_file $! {{ .OVN_LOG_FILE }} {{ .OVN_LOG_MAX_SIZE }} {{ .OVN_LOG_MAX_FILES }} &
+{{- end }}
 
 # Once the database is running, we will attempt to configure db options
-CTLCMD="ovn-${DB_TYPE}ctl --no-leader-only"
+CTLCMD="ovn-${DB_OPT}ctl --no-leader-only"
 
 # Nothing special about the first pod, we just know that it always exists with
 # replicas > 0 and use it for configuration. In theory, this could be executed
 # in any other pod.
 if [[ "$(hostname)" == "{{ .SERVICE_NAME }}-0" ]]; then
+{{- if .IC }}
+    # ovn-ic-nbctl and ovn-ic-sbctl have no daemon mode, use the local DB replica
+    CTLCMD="${CTLCMD} --db=unix:/tmp/{{ .DB_FILE }}.sock"
+    while ! ${CTLCMD} list connection > /dev/null 2>&1; do
+        sleep 1
+    done
+{{- else }}
     # The command will wait until the daemon is connected and the DB is available
     # All following ctl invocation will use the local DB replica in the daemon
     export OVN_${DB_TYPE^^}_DAEMON=$(${CTLCMD} --pidfile --detach)
+{{- end }}
 
 {{- if .TLS }}
+{{- if .FIPS }}
+    # restrict all connections of ovsdb-server to FIPS approved TLS settings
+    ${CTLCMD} set-ssl {{.OVNDB_KEY_PATH}} {{.OVNDB_CERT_PATH}} {{.OVNDB_CACERT_PATH}} {{.SSL_PROTOCOLS}} {{.SSL_CIPHERS}}
+{{- else }}
     ${CTLCMD} set-ssl {{.OVNDB_KEY_PATH}} {{.OVNDB_CERT_PATH}} {{.OVNDB_CACERT_PATH}}
+{{- end }}
     ${CTLCMD} set-connection ${DB_SCHEME}:${DB_PORT}:${DB_ADDR}
 {{- end }}
 
@@ -124,9 +153,11 @@ if [[ "$(hostname)" == "{{ .SERVICE_NAME }}-0" ]]; then
     done
     ${CTLCMD} list connection
 
+{{- if not .IC }}
     # The daemon is no longer needed, kill it
     kill $(cat $OVN_RUNDIR/ovn-${DB_TYPE}ctl.pid)
     unset OVN_${DB_TYPE^^}_DAEMON
+{{- end }}
 fi
 
 wait
@@ -38,8 +38,9 @@ import (
 )
 
 const (
-	timeout  = time.Second * 10
-	interval = timeout / 100
+	timeout            = time.Second * 10
+	interval           = timeout / 100
+	consistencyTimeout = timeout / 5
 )
 
 func GetDefaultOVNNorthdSpec() ovnv1.OVNNorthdSpec {
@@ -50,12 +51,14 @@ func GetDefaultOVNNorthdSpec() ovnv1.OVNNorthdSpec {
 
 func GetTLSOVNNorthdSpec() ovnv1.OVNNorthdSpec {
 	spec := GetDefaultOVNNorthdSpec()
-	spec.TLS = tls.SimpleService{
-		Ca: tls.Ca{
-			CaBundleSecretName: CABundleSecretName,
-		},
-		GenericService: tls.GenericService{
-			SecretName: ptr.To(OvnDbCertSecretName),
+	spec.TLS = ovnv1.TLSSection{
+		SimpleService: tls.SimpleService{
+			Ca: tls.Ca{
+				CaBundleSecretName: CABundleSecretName,
+			},
+			GenericService: tls.GenericService{
+				SecretName: ptr.To(OvnDbCertSecretName),
+			},
 		},
 	}
 	return spec
@@ -70,6 +73,88 @@ func OVNNorthdConditionGetter(name types.NamespacedName) condition.Conditions {
 	return instance.Status.Conditions
 }
 
+func GetDefaultOVNInterconnectSpec() ovnv1.OVNInterconnectSpec {
+	return ovnv1.OVNInterconnectSpec{
+		OVNInterconnectSpecCore: ovnv1.OVNInterconnectSpecCore{
+			AvailabilityZone: "az1",
+		},
+	}
+}
+
+func GetOVNInterconnect(name types.NamespacedName) *ovnv1.OVNInterconnect {
+	return ovn.GetOVNInterconnect(name)
+}
+
+func OVNInterconnectConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := ovn.GetOVNInterconnect(name)
+	return instance.Status.Conditions
+}
+
+func GetDefaultOVNTraceSpec() ovnv1.OVNTraceSpec {
+	return ovnv1.OVNTraceSpec{
+		SourcePort:      "vm1-port",
+		DestinationPort: "vm2-port",
+	}
+}
+
+func GetOVNTrace(name types.NamespacedName) *ovnv1.OVNTrace {
+	return ovn.GetOVNTrace(name)
+}
+
+func OVNTraceConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := ovn.GetOVNTrace(name)
+	return instance.Status.Conditions
+}
+
+func GetOVNCommand(name types.NamespacedName) *ovnv1.OVNCommand {
+	return ovn.GetOVNCommand(name)
+}
+
+func OVNCommandConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := ovn.GetOVNCommand(name)
+	return instance.Status.Conditions
+}
+
+func GetOVNGlobalConfig(name types.NamespacedName) *ovnv1.OVNGlobalConfig {
+	return ovn.GetOVNGlobalConfig(name)
+}
+
+func OVNGlobalConfigConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := ovn.GetOVNGlobalConfig(name)
+	return instance.Status.Conditions
+}
+
+func GetDefaultOVNDiagnosticsSpec() ovnv1.OVNDiagnosticsSpec {
+	return ovnv1.OVNDiagnosticsSpec{
+		StorageClass: "local-storage",
+	}
+}
+
+func GetOVNDiagnostics(name types.NamespacedName) *ovnv1.OVNDiagnostics {
+	return ovn.GetOVNDiagnostics(name)
+}
+
+func OVNDiagnosticsConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := ovn.GetOVNDiagnostics(name)
+	return instance.Status.Conditions
+}
+
+func GetDefaultOVNCaptureSpec() ovnv1.OVNCaptureSpec {
+	return ovnv1.OVNCaptureSpec{
+		Node:         "worker-0",
+		StorageClass: "local-storage",
+	}
+}
+
+func GetOVNCapture(name types.NamespacedName) *ovnv1.OVNCapture {
+	return ovn.GetOVNCapture(name)
+}
+
+func OVNCaptureConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := ovn.GetOVNCapture(name)
+	return instance.Status.Conditions
+}
+
 func GetDefaultOVNDBClusterSpec() ovnv1.OVNDBClusterSpec {
 	return ovnv1.OVNDBClusterSpec{
 		OVNDBClusterSpecCore: ovnv1.OVNDBClusterSpecCore{
@@ -82,12 +167,14 @@ func GetDefaultOVNDBClusterSpec() ovnv1.OVNDBClusterSpec {
 
 func GetTLSOVNDBClusterSpec() ovnv1.OVNDBClusterSpec {
 	spec := GetDefaultOVNDBClusterSpec()
-	spec.TLS = tls.SimpleService{
-		Ca: tls.Ca{
-			CaBundleSecretName: CABundleSecretName,
-		},
-		GenericService: tls.GenericService{
-			SecretName: ptr.To(OvnDbCertSecretName),
+	spec.TLS = ovnv1.TLSSection{
+		SimpleService: tls.SimpleService{
+			Ca: tls.Ca{
+				CaBundleSecretName: CABundleSecretName,
+			},
+			GenericService: tls.GenericService{
+				SecretName: ptr.To(OvnDbCertSecretName),
+			},
 		},
 	}
 	return spec
@@ -172,6 +259,37 @@ func CreateOVNDBClusters(namespace string, nad map[string][]string, replicas int
 	return dbs
 }
 
+// CreateOVNICDBClusters Creates the OVN Interconnect NB and SB OVNDBClusters
+func CreateOVNICDBClusters(namespace string, replicas int32) []types.NamespacedName {
+	dbs := []types.NamespacedName{}
+	for _, db := range []string{ovnv1.ICNBDBType, ovnv1.ICSBDBType} {
+		spec := GetDefaultOVNDBClusterSpec()
+		spec.DBType = db
+		spec.Replicas = &replicas
+
+		instance := CreateOVNDBCluster(namespace, spec)
+		instanceName := types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+
+		statefulSetName := types.NamespacedName{
+			Namespace: instance.GetNamespace(),
+			Name:      "ovsdbserver-ic-" + strings.ToLower(strings.TrimPrefix(db, "IC")),
+		}
+		th.SimulateStatefulSetReplicaReadyWithPods(
+			statefulSetName,
+			map[string][]string{},
+		)
+		Eventually(func(g Gomega) {
+			endpoint, _ := ovn.GetOVNDBCluster(instanceName).GetInternalEndpoint()
+			g.Expect(endpoint).ToNot(BeEmpty())
+		}).Should(Succeed())
+
+		dbs = append(dbs, instanceName)
+	}
+
+	logger.Info("OVN Interconnect OVNDBClusters created", "OVNDBCluster", dbs)
+	return dbs
+}
+
 // DeleteOVNDBClusters Delete OVN DBClusters
 func DeleteOVNDBClusters(names []types.NamespacedName) {
 	for _, db := range names {
@@ -212,18 +330,58 @@ func SimulateDaemonsetNumberReady(name types.NamespacedName) {
 	logger.Info("Simulated daemonset success", "on", name)
 }
 
+// SimulateDaemonsetRolledOut - mark the current pod template of the
+// DaemonSet as rolled out to all its pods
+func SimulateDaemonsetRolledOut(name types.NamespacedName) {
+	Eventually(func(g Gomega) {
+		ds := GetDaemonSet(name)
+		ds.Status.ObservedGeneration = ds.Generation
+		ds.Status.NumberReady = 1
+		ds.Status.DesiredNumberScheduled = 1
+		ds.Status.UpdatedNumberScheduled = 1
+		g.Expect(k8sClient.Status().Update(ctx, ds)).To(Succeed())
+	}, timeout, interval).Should(Succeed())
+	logger.Info("Simulated daemonset rollout", "on", name)
+}
+
+func SimulateStatefulSetRolledOut(name types.NamespacedName) {
+	Eventually(func(g Gomega) {
+		ss := th.GetStatefulSet(name)
+		ss.Status.ObservedGeneration = ss.Generation
+		ss.Status.Replicas = *ss.Spec.Replicas
+		ss.Status.UpdatedReplicas = *ss.Spec.Replicas
+		ss.Status.ReadyReplicas = *ss.Spec.Replicas
+		g.Expect(k8sClient.Status().Update(ctx, ss)).To(Succeed())
+	}, timeout, interval).Should(Succeed())
+	logger.Info("Simulated statefulset rollout", "on", name)
+}
+
+func SimulateDeploymentRolledOut(name types.NamespacedName) {
+	Eventually(func(g Gomega) {
+		deployment := th.GetDeployment(name)
+		deployment.Status.ObservedGeneration = deployment.Generation
+		deployment.Status.Replicas = *deployment.Spec.Replicas
+		deployment.Status.UpdatedReplicas = *deployment.Spec.Replicas
+		deployment.Status.ReadyReplicas = *deployment.Spec.Replicas
+		g.Expect(k8sClient.Status().Update(ctx, deployment)).To(Succeed())
+	}, timeout, interval).Should(Succeed())
+	logger.Info("Simulated deployment rollout", "on", name)
+}
+
 func GetDefaultOVNControllerSpec() ovnv1.OVNControllerSpec {
 	return ovnv1.OVNControllerSpec{}
 }
 
 func GetTLSOVNControllerSpec() ovnv1.OVNControllerSpec {
 	spec := GetDefaultOVNControllerSpec()
-	spec.TLS = tls.SimpleService{
-		Ca: tls.Ca{
-			CaBundleSecretName: CABundleSecretName,
-		},
-		GenericService: tls.GenericService{
-			SecretName: ptr.To(OvnDbCertSecretName),
+	spec.TLS = ovnv1.TLSSection{
+		SimpleService: tls.SimpleService{
+			Ca: tls.Ca{
+				CaBundleSecretName: CABundleSecretName,
+			},
+			GenericService: tls.GenericService{
+				SecretName: ptr.To(OvnDbCertSecretName),
+			},
 		},
 	}
 	return spec
@@ -352,10 +510,33 @@ func GetPod(name types.NamespacedName) *corev1.Pod {
 	return pod
 }
 
+func GetPVC(name types.NamespacedName) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, pvc)).Should(Succeed())
+	}).Should(Succeed())
+
+	return pvc
+}
+
 func UpdatePod(pod *corev1.Pod) {
 	Expect(k8sClient.Update(ctx, pod)).Should(Succeed())
 }
 
+// GetEventReasons - reasons of the Events recorded on the named object
+func GetEventReasons(name types.NamespacedName) []string {
+	eventList := &corev1.EventList{}
+	Expect(k8sClient.List(ctx, eventList, client.InNamespace(name.Namespace))).Should(Succeed())
+
+	reasons := []string{}
+	for _, event := range eventList.Items {
+		if event.InvolvedObject.Name == name.Name {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+	return reasons
+}
+
 func GetServicesListWithLabel(namespace string, labelSelectorMap ...map[string]string) *corev1.ServiceList {
 	serviceList := &corev1.ServiceList{}
 	serviceListOpts := client.ListOptions{
@@ -0,0 +1,100 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	"github.com/google/uuid"
+	ovnapiv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("API version conversion", func() {
+
+	It("stores an OVNNorthd created with the v1 API as v1beta1", func() {
+		name := types.NamespacedName{Namespace: namespace, Name: "ovnnorthd-" + uuid.New().String()[:25]}
+		instance := &ovnapiv1.OVNNorthd{
+			ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+			Spec: ovnapiv1.OVNNorthdSpec{
+				Logging: ovnapiv1.OVNNorthdLogging{
+					Level:   "dbg",
+					Modules: []string{"northd:dbg"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, instance)).To(Succeed())
+		DeferCleanup(th.DeleteInstance, instance)
+
+		northd := GetOVNNorthd(name)
+		Expect(northd.Spec.LogLevel).To(Equal("dbg"))
+		Expect(northd.Spec.LogModules).To(ConsistOf("northd:dbg"))
+		// defaulted by the v1beta1 webhook
+		Expect(northd.Spec.ContainerImage).ToNot(BeEmpty())
+	})
+
+	It("serves an OVNDBCluster created with the v1beta1 API as v1", func() {
+		instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+		DeferCleanup(th.DeleteInstance, instance)
+
+		dbCluster := &ovnapiv1.OVNDBCluster{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: instance.GetNamespace(),
+				Name:      instance.GetName(),
+			}, dbCluster)).Should(Succeed())
+		}, timeout, interval).Should(Succeed())
+		Expect(dbCluster.Spec.DBType).To(Equal(ovnv1.NBDBType))
+		Expect(dbCluster.Spec.Storage.Request).To(Equal("1G"))
+		Expect(dbCluster.Spec.Storage.Class).To(Equal("local-storage"))
+		Expect(dbCluster.Spec.Storage.Retention).To(Equal(ovnv1.StorageRetentionDelete))
+		Expect(dbCluster.Spec.Logging.Level).To(Equal("info"))
+	})
+
+	It("applies the per container resources of an OVNController created with the v1 API", func() {
+		name := types.NamespacedName{Namespace: namespace, Name: "ovn-controller-" + uuid.New().String()[:25]}
+		vswitchdResources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		}
+		instance := &ovnapiv1.OVNController{
+			ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+			Spec: ovnapiv1.OVNControllerSpec{
+				Resources: ovnapiv1.OVNControllerResources{
+					OvsVswitchd: vswitchdResources,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, instance)).To(Succeed())
+		DeferCleanup(th.DeleteInstance, instance)
+
+		ovnController := GetOVNController(name)
+		Expect(ovnController.Spec.ContainerResources.OvsVswitchd).ToNot(BeNil())
+		Expect(ovnController.Spec.OvnControllerResources().Requests).To(BeEmpty())
+
+		Eventually(func(g Gomega) {
+			ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+			for _, container := range ds.Spec.Template.Spec.Containers {
+				if container.Name == "ovs-vswitchd" {
+					g.Expect(container.Resources.Requests.Cpu().String()).To(Equal("2"))
+				} else {
+					g.Expect(container.Resources.Requests).To(BeEmpty())
+				}
+			}
+		}, timeout, interval).Should(Succeed())
+	})
+})
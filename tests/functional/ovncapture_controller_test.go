@@ -0,0 +1,118 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("OVNCapture controller", func() {
+
+	When("A OVNCapture instance is created", func() {
+		var captureName types.NamespacedName
+		var captureJobName types.NamespacedName
+
+		BeforeEach(func() {
+			captureName = ovn.CreateOVNCapture(namespace, GetDefaultOVNCaptureSpec())
+			DeferCleanup(ovn.DeleteOVNCapture, captureName)
+			captureJobName = types.NamespacedName{Namespace: namespace, Name: captureName.Name + "-capture"}
+		})
+
+		It("should have the Spec fields initialized", func() {
+			capture := GetOVNCapture(captureName)
+			Expect(capture.Spec.Duration).Should(Equal(int32(60)))
+			Expect(capture.Spec.MaxSizeMB).Should(Equal(int32(100)))
+		})
+
+		It("waits for the OVNController", func() {
+			th.ExpectCondition(
+				captureName,
+				ConditionGetterFunc(OVNCaptureConditionGetter),
+				condition.InputReadyCondition,
+				corev1.ConditionFalse,
+			)
+		})
+
+		When("OVNController is available", func() {
+			BeforeEach(func() {
+				ovnController := CreateOVNController(namespace, GetDefaultOVNControllerSpec())
+				DeferCleanup(th.DeleteInstance, ovnController)
+			})
+
+			It("captures all the ports of the integration bridge on the node", func() {
+				th.ExpectConditionWithDetails(
+					captureName,
+					ConditionGetterFunc(OVNCaptureConditionGetter),
+					ovnv1.OVNCaptureReadyCondition,
+					corev1.ConditionFalse,
+					condition.RequestedReason,
+					"Capturing the packets of bridge br-int on node worker-0",
+				)
+
+				podSpec := th.GetJob(captureJobName).Spec.Template.Spec
+				Expect(podSpec.NodeName).Should(Equal("worker-0"))
+				Expect(podSpec.HostNetwork).Should(BeTrue())
+				Expect(podSpec.Containers[0].Env).Should(ContainElements(
+					corev1.EnvVar{Name: "OVS_BRIDGE", Value: "br-int"},
+					corev1.EnvVar{Name: "DURATION", Value: "60"},
+					corev1.EnvVar{Name: "CAPTURE_FILE", Value: captureName.Name + ".pcap"},
+				))
+				Expect(podSpec.Volumes).Should(ContainElement(HaveField("VolumeSource.PersistentVolumeClaim.ClaimName",
+					captureName.Name+"-capture")))
+			})
+
+			It("reports the capture file", func() {
+				SimulateTracePodTerminated(captureJobName, "12 packets captured\n12 packets received by filter\n0 packets dropped by kernel\n")
+				th.SimulateJobSuccess(captureJobName)
+
+				th.ExpectConditionWithDetails(
+					captureName,
+					ConditionGetterFunc(OVNCaptureConditionGetter),
+					ovnv1.OVNCaptureReadyCondition,
+					corev1.ConditionTrue,
+					condition.ReadyReason,
+					"Packets captured in "+captureName.Name+".pcap of PVC "+captureName.Name+"-capture: "+
+						"12 packets captured, 12 packets received by filter, 0 packets dropped by kernel",
+				)
+				Expect(GetOVNCapture(captureName).Status.CaptureFile).Should(Equal(captureName.Name + ".pcap"))
+			})
+
+			It("reports the error of ovs-tcpdump", func() {
+				SimulateTracePodTerminated(captureJobName, "ovs-tcpdump: error: unknown port vm1-port")
+				th.SimulateJobFailure(captureJobName)
+
+				th.ExpectConditionWithDetails(
+					captureName,
+					ConditionGetterFunc(OVNCaptureConditionGetter),
+					ovnv1.OVNCaptureReadyCondition,
+					corev1.ConditionFalse,
+					condition.ErrorReason,
+					"Capturing the packets failed: ovs-tcpdump: error: unknown port vm1-port",
+				)
+			})
+		})
+	})
+})
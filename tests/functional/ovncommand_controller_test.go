@@ -0,0 +1,158 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("OVNCommand controller", func() {
+
+	When("A OVNCommand instance is created for a DB command", func() {
+		var ovnCommandName types.NamespacedName
+		var commandJobName types.NamespacedName
+
+		BeforeEach(func() {
+			ovnCommandName = ovn.CreateOVNCommand(namespace, ovnv1.OVNCommandSpec{
+				Command: ovnv1.OVNCommandSBListChassis,
+			})
+			DeferCleanup(ovn.DeleteOVNCommand, ovnCommandName)
+			commandJobName = types.NamespacedName{Namespace: namespace, Name: ovnCommandName.Name + "-command"}
+		})
+
+		It("waits for the SB DB", func() {
+			th.ExpectCondition(
+				ovnCommandName,
+				ConditionGetterFunc(OVNCommandConditionGetter),
+				condition.InputReadyCondition,
+				corev1.ConditionFalse,
+			)
+		})
+
+		It("rejects updates of the spec", func() {
+			Eventually(func(g Gomega) {
+				instance := GetOVNCommand(ovnCommandName)
+				instance.Spec.Command = ovnv1.OVNCommandSBShow
+				err := k8sClient.Update(ctx, instance)
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(ContainSubstring("the spec is immutable"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		When("OVNDBCluster instances are available", func() {
+			BeforeEach(func() {
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+			})
+
+			It("runs the command against the SB DB", func() {
+				th.ExpectConditionWithDetails(
+					ovnCommandName,
+					ConditionGetterFunc(OVNCommandConditionGetter),
+					ovnv1.OVNCommandReadyCondition,
+					corev1.ConditionFalse,
+					condition.RequestedReason,
+					"Running ovn-sbctl list Chassis",
+				)
+				Expect(GetOVNCommand(ovnCommandName).Status.CommandLine).Should(Equal("ovn-sbctl list Chassis"))
+
+				container := th.GetJob(commandJobName).Spec.Template.Spec.Containers[0]
+				Expect(container.Env).Should(ContainElement(corev1.EnvVar{
+					Name:  "COMMAND",
+					Value: "ovn-sbctl --no-leader-only --db=tcp:ovsdbserver-sb-0." + namespace + ".svc.cluster.local:6642 list Chassis",
+				}))
+			})
+
+			It("stores the output of the command", func() {
+				SimulateTracePodTerminated(commandJobName, "hostname            : worker-0")
+				th.SimulateJobSuccess(commandJobName)
+
+				th.ExpectCondition(
+					ovnCommandName,
+					ConditionGetterFunc(OVNCommandConditionGetter),
+					condition.ReadyCondition,
+					corev1.ConditionTrue,
+				)
+				Expect(GetOVNCommand(ovnCommandName).Status.Output).Should(Equal("hostname            : worker-0"))
+			})
+
+			It("reports the error of the command", func() {
+				SimulateTracePodTerminated(commandJobName, "ovn-sbctl: database connection failed")
+				th.SimulateJobFailure(commandJobName)
+
+				th.ExpectConditionWithDetails(
+					ovnCommandName,
+					ConditionGetterFunc(OVNCommandConditionGetter),
+					ovnv1.OVNCommandReadyCondition,
+					corev1.ConditionFalse,
+					condition.ErrorReason,
+					"ovn-sbctl list Chassis failed: ovn-sbctl: database connection failed",
+				)
+			})
+		})
+	})
+
+	When("A OVNCommand instance is created for an OVS command", func() {
+		It("requires the node", func() {
+			instance := &ovnv1.OVNCommand{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovs-show",
+					Namespace: namespace,
+				},
+				Spec: ovnv1.OVNCommandSpec{
+					Command: ovnv1.OVNCommandOVSShow,
+				},
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("spec.node: Required value"))
+		})
+
+		It("runs the command on the node", func() {
+			ovnController := CreateOVNController(namespace, GetDefaultOVNControllerSpec())
+			DeferCleanup(th.DeleteInstance, ovnController)
+			ovnCommandName := ovn.CreateOVNCommand(namespace, ovnv1.OVNCommandSpec{
+				Command: ovnv1.OVNCommandOVSDumpFlows,
+				Node:    "worker-0",
+			})
+			DeferCleanup(ovn.DeleteOVNCommand, ovnCommandName)
+
+			th.ExpectConditionWithDetails(
+				ovnCommandName,
+				ConditionGetterFunc(OVNCommandConditionGetter),
+				ovnv1.OVNCommandReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				"Running ovs-ofctl dump-flows br-int",
+			)
+			podSpec := th.GetJob(types.NamespacedName{Namespace: namespace, Name: ovnCommandName.Name + "-command"}).Spec.Template.Spec
+			Expect(podSpec.NodeName).Should(Equal("worker-0"))
+			Expect(podSpec.Containers[0].Env).Should(ContainElement(
+				corev1.EnvVar{Name: "COMMAND", Value: "ovs-ofctl dump-flows br-int"}))
+		})
+	})
+})
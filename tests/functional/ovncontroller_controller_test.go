@@ -19,6 +19,7 @@ package functional_test
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
@@ -30,9 +31,16 @@ import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("OVNController controller", func() {
@@ -115,6 +123,434 @@ var _ = Describe("OVNController controller", func() {
 			th.AssertJobDoesNotExist(configJobOVS)
 		})
 
+		It("stops ovn-controller before removing the chassis on deletion", func() {
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			GetDaemonSet(daemonSetName)
+
+			th.DeleteInstance(GetOVNController(OVNControllerName))
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, daemonSetName, &appsv1.DaemonSet{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("updates the ovn-controller pods OnDelete during a canary rollout", func() {
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			SimulateDaemonsetRolledOut(daemonSetName)
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				g.Expect(ovnController.Status.OvnContainerImage).To(Equal(ovnController.Spec.OvnContainerImage))
+			}, timeout, interval).Should(Succeed())
+
+			newImage := "quay.io/test/ovn-controller:new"
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.Canary = &ovnv1.OVNControllerCanary{Percentage: 50}
+				ovnController.Spec.OvnContainerImage = newImage
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			th.SimulateJobSuccess(types.NamespacedName{Namespace: namespace, Name: OVNControllerName.Name + "-version-probe"})
+
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(daemonSetName)
+				g.Expect(ds.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteDaemonSetStrategyType))
+				g.Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal(newImage))
+				canary := GetOVNController(OVNControllerName).Status.Canary
+				g.Expect(canary).ToNot(BeNil())
+				g.Expect(canary.ContainerImage).To(Equal(newImage))
+				g.Expect(canary.Verified).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("drains the gateway ports before ovn-controller stops", func() {
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.GatewayDrain = true
+				ovnController.Spec.GatewayDrainTimeout = 60
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				podSpec := GetDaemonSet(daemonSetName).Spec.Template.Spec
+				g.Expect(podSpec.TerminationGracePeriodSeconds).ToNot(BeNil())
+				g.Expect(*podSpec.TerminationGracePeriodSeconds).To(Equal(int64(90)))
+				preStop := podSpec.Containers[0].Lifecycle.PreStop.Exec.Command
+				g.Expect(preStop[len(preStop)-1]).To(ContainSubstring("drain-gateway.sh drain"))
+				g.Expect(podSpec.Containers[0].Args[0]).To(ContainSubstring("drain-gateway.sh restore"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("keeps the fields set by other controllers on the DaemonSet", func() {
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			ds := GetDaemonSet(daemonSetName)
+			Expect(ds.ManagedFields).To(ContainElement(And(
+				HaveField("Manager", ovn_common.FieldManager),
+				HaveField("Operation", metav1.ManagedFieldsOperationApply))))
+
+			patch := client.MergeFrom(ds.DeepCopy())
+			ds.Spec.Template.Annotations["example.com/injected"] = "true"
+			Expect(k8sClient.Patch(ctx, ds, patch, client.FieldOwner("injector"))).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.GatewayDrain = true
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				template := GetDaemonSet(daemonSetName).Spec.Template
+				g.Expect(template.Spec.TerminationGracePeriodSeconds).ToNot(BeNil())
+				g.Expect(template.Annotations).To(HaveKeyWithValue("example.com/injected", "true"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("freezes the pods during a node maintenance", func() {
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			ds := GetDaemonSet(daemonSetName)
+
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "maintenance-" + namespace,
+					Annotations: map[string]string{"ovn.openstack.org/maintenance": "true"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, node)).Should(Succeed())
+			DeferCleanup(th.DeleteInstance, node)
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovn-controller-maintenance",
+					Namespace: namespace,
+					Labels:    ds.Spec.Template.Labels,
+				},
+				Spec: corev1.PodSpec{
+					NodeName: node.Name,
+					Containers: []corev1.Container{
+						{Name: "ovn-controller", Image: ds.Spec.Template.Spec.Containers[0].Image},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, pod)).Should(Succeed())
+			DeferCleanup(th.DeleteInstance, pod)
+
+			Eventually(func(g Gomega) {
+				g.Expect(GetDaemonSet(daemonSetName).Spec.UpdateStrategy.Type).To(
+					Equal(appsv1.OnDeleteDaemonSetStrategyType))
+				ovsDaemonSet := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+				g.Expect(ovsDaemonSet.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteDaemonSetStrategyType))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("runs on the control plane nodes of compact deployments", func() {
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.ControlPlane = true
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			toleration := corev1.Toleration{
+				Key:      "node-role.kubernetes.io/control-plane",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}
+			Eventually(func(g Gomega) {
+				ovnDaemonSet := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"})
+				g.Expect(ovnDaemonSet.Spec.Template.Spec.Tolerations).To(ContainElement(toleration))
+				ovsDaemonSet := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+				g.Expect(ovsDaemonSet.Spec.Template.Spec.Tolerations).To(ContainElement(toleration))
+				probe := ovsDaemonSet.Spec.Template.Spec.Containers[0].LivenessProbe
+				g.Expect(probe.TimeoutSeconds).To(Equal(int32(10)))
+				g.Expect(probe.FailureThreshold).To(Equal(int32(6)))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("runs the OVS pods with their own ServiceAccount only allowed to use the privileged SCC", func() {
+			ovsServiceAccount := "ovncontroller-" + OVNControllerName.Name + "-ovs"
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+				g.Expect(ds.Spec.Template.Spec.ServiceAccountName).To(Equal(ovsServiceAccount))
+				ds = GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"})
+				g.Expect(ds.Spec.Template.Spec.ServiceAccountName).To(Equal("ovncontroller-" + OVNControllerName.Name))
+			}, timeout, interval).Should(Succeed())
+
+			role := &rbacv1.Role{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ovsServiceAccount + "-role"}, role)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Expect(role.Rules).To(HaveLen(1))
+			Expect(role.Rules[0].Resources).To(Equal([]string{"securitycontextconstraints"}))
+			Expect(role.Rules[0].ResourceNames).To(Equal([]string{"privileged"}))
+		})
+
+		It("runs the pods with an externally managed ServiceAccount", func() {
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.ServiceAccount = "ovn-external"
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			th.ExpectConditionWithDetails(
+				OVNControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				condition.ServiceAccountReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				"ServiceAccount ovn-external not found",
+			)
+
+			sa := &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovn-external",
+					Namespace: namespace,
+				},
+			}
+			Expect(k8sClient.Create(ctx, sa)).Should(Succeed())
+			DeferCleanup(th.DeleteInstance, sa)
+
+			th.ExpectCondition(
+				OVNControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				condition.ServiceAccountReadyCondition,
+				corev1.ConditionTrue,
+			)
+			Eventually(func(g Gomega) {
+				for _, name := range []string{"ovn-controller", "ovn-controller-ovs"} {
+					ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: name})
+					g.Expect(ds.Spec.Template.Spec.ServiceAccountName).To(Equal("ovn-external"))
+				}
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("runs only on the nodes of the architectures of the images", func() {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "arm64-" + namespace,
+					Labels: map[string]string{"kubernetes.io/arch": "arm64"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, node)).Should(Succeed())
+			DeferCleanup(th.DeleteInstance, node)
+
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.Architectures = []string{"amd64"}
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				affinity := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"}).Spec.Template.Spec.Affinity
+				g.Expect(affinity).ToNot(BeNil())
+				terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				g.Expect(terms).To(HaveLen(1))
+				g.Expect(terms[0].MatchExpressions).To(ContainElement(corev1.NodeSelectorRequirement{
+					Key:      "kubernetes.io/arch",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"amd64"},
+				}))
+				g.Expect(GetOVNController(OVNControllerName).Status.UnsupportedArchNodes).To(ContainElement(node.Name))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("runs a DaemonSet of ovn-controller and OVS per zone", func() {
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.Zones = []string{"zone-a", "zone-b"}
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			for _, name := range []string{"ovn-controller", "ovn-controller-ovs"} {
+				for _, zone := range []string{"zone-a", "zone-b"} {
+					ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: name + "-" + zone})
+					Expect(ds.Spec.Selector.MatchLabels).To(Equal(map[string]string{
+						"service":                name,
+						"ovn.openstack.org/zone": zone,
+					}))
+					Expect(ds.Spec.Template.Labels).To(HaveKeyWithValue("ovn.openstack.org/zone", zone))
+					Expect(ds.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("topology.kubernetes.io/zone", zone))
+				}
+				// the nodes of the zones only run the DaemonSets of their zone
+				Eventually(func(g Gomega) {
+					ds := &appsv1.DaemonSet{}
+					err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, ds)
+					g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				}, timeout, interval).Should(Succeed())
+			}
+
+			// the readiness of the DaemonSets of the zones adds up
+			for _, zone := range []string{"zone-a", "zone-b"} {
+				SimulateDaemonsetNumberReady(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-" + zone})
+			}
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				g.Expect(ovnController.Status.DesiredNumberScheduled).To(Equal(int32(2)))
+				g.Expect(ovnController.Status.NumberReady).To(Equal(int32(2)))
+			}, timeout, interval).Should(Succeed())
+
+			// the DaemonSets of a zone removed from the list are deleted
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.Zones = []string{"zone-a"}
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				ds := &appsv1.DaemonSet{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "ovn-controller-zone-b"}, ds)
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				g.Expect(GetOVNController(OVNControllerName).Status.Rollout).ToNot(HaveKey("ovn-controller-zone-b"))
+			}, timeout, interval).Should(Succeed())
+			GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-zone-a"})
+
+			// without zones a single DaemonSet runs on all the nodes again
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.Zones = nil
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"})
+			Expect(ds.Spec.Template.Spec.NodeSelector).ToNot(HaveKey("topology.kubernetes.io/zone"))
+			Eventually(func(g Gomega) {
+				ds := &appsv1.DaemonSet{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "ovn-controller-zone-a"}, ds)
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("waits for the SB DB to delete the chassis of a decommissioned node", func() {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "decommission-" + namespace,
+					Annotations: map[string]string{"ovn.openstack.org/decommission": "true"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, node)).Should(Succeed())
+			DeferCleanup(th.DeleteInstance, node)
+
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNController(OVNControllerName).Status.Decommission).To(ContainElement(
+					ovnv1.OVNControllerDecommissionStatus{Node: node.Name, Phase: ovnv1.DecommissionPodsRemoved}))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: node.Name}, node)).Should(Succeed())
+				delete(node.Annotations, "ovn.openstack.org/decommission")
+				g.Expect(k8sClient.Update(ctx, node)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNController(OVNControllerName).Status.Decommission).To(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("tracks the rollout of the DaemonSets for an automatic rollback", func() {
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.AutoRollback = &ovnv1.OVNControllerAutoRollback{FailureTimeout: 600}
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				rollback := GetOVNController(OVNControllerName).Status.Rollback
+				g.Expect(rollback).To(HaveKey(daemonSetName.Name))
+				g.Expect(rollback[daemonSetName.Name].TemplateHash).ToNot(BeEmpty())
+				g.Expect(rollback[daemonSetName.Name].RolledBack).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+
+			SimulateDaemonsetRolledOut(daemonSetName)
+			Eventually(func(g Gomega) {
+				rollback := GetOVNController(OVNControllerName).Status.Rollback
+				g.Expect(rollback[daemonSetName.Name].RolledOut).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+			Expect(GetOVNController(OVNControllerName).Status.Conditions.Has(ovnv1.OVNControllerDegradedCondition)).To(BeFalse())
+		})
+
+		It("probes the version of a new ovn-controller image before rolling it out", func() {
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			SimulateDaemonsetRolledOut(daemonSetName)
+			var oldImage string
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				g.Expect(ovnController.Status.OvnContainerImage).To(Equal(ovnController.Spec.OvnContainerImage))
+				oldImage = ovnController.Status.OvnContainerImage
+			}, timeout, interval).Should(Succeed())
+
+			newImage := "quay.io/test/ovn-controller:new"
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.OvnContainerImage = newImage
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			probeJob := types.NamespacedName{Namespace: namespace, Name: OVNControllerName.Name + "-version-probe"}
+			Expect(th.GetJob(probeJob).Spec.Template.Spec.Containers[0].Image).To(Equal(newImage))
+			th.ExpectCondition(
+				OVNControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				ovnv1.OVNVersionSkewReadyCondition,
+				corev1.ConditionFalse,
+			)
+			Expect(GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Image).To(Equal(oldImage))
+
+			th.SimulateJobSuccess(probeJob)
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(daemonSetName)
+				g.Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal(newImage))
+				version := GetOVNController(OVNControllerName).Status.OvnVersion
+				g.Expect(version).ToNot(BeNil())
+				g.Expect(version.ContainerImage).To(Equal(newImage))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rolls out a new ovn-controller image without a SB DB to check its schema against", func() {
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			SimulateDaemonsetRolledOut(daemonSetName)
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				g.Expect(ovnController.Status.OvnContainerImage).To(Equal(ovnController.Spec.OvnContainerImage))
+			}, timeout, interval).Should(Succeed())
+
+			newImage := "quay.io/test/ovn-controller:new"
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.OvnContainerImage = newImage
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			probeJob := types.NamespacedName{Namespace: namespace, Name: OVNControllerName.Name + "-version-probe"}
+			Expect(th.GetJob(probeJob).Spec.Template.Spec.Containers[0].Image).To(Equal(newImage))
+			SimulateTracePodTerminated(probeJob, "ovn-controller 24.03.2\nSB DB Schema 20.33.0")
+			th.SimulateJobSuccess(probeJob)
+			Eventually(func(g Gomega) {
+				g.Expect(GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Image).To(Equal(newImage))
+				version := GetOVNController(OVNControllerName).Status.OvnVersion
+				g.Expect(version).ToNot(BeNil())
+				g.Expect(version.SBSchemaVersion).To(Equal("20.33.0"))
+			}, timeout, interval).Should(Succeed())
+		})
+
 		// TODO(ihar) introduce a new condition for the external config?
 		It("should be in input ready condition", func() {
 			th.ExpectCondition(
@@ -147,6 +583,43 @@ var _ = Describe("OVNController controller", func() {
 				}
 			})
 
+			It("reports the ready pods of both DaemonSets", func() {
+				th.ExpectConditionWithDetails(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					ovnv1.OVNControllerDaemonSetReadyCondition,
+					corev1.ConditionTrue,
+					condition.ReadyReason,
+					"1/1 ovn-controller pods ready",
+				)
+				th.ExpectConditionWithDetails(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					ovnv1.OVSDaemonSetReadyCondition,
+					corev1.ConditionTrue,
+					condition.ReadyReason,
+					"1/1 OVS pods ready",
+				)
+			})
+
+			It("reports the rollout of both DaemonSets", func() {
+				for _, name := range []string{"ovn-controller", "ovn-controller-ovs"} {
+					SimulateDaemonsetRolledOut(types.NamespacedName{Namespace: namespace, Name: name})
+				}
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					for _, name := range []string{"ovn-controller", "ovn-controller-ovs"} {
+						rollout, found := ovnController.Status.Rollout[name]
+						g.Expect(found).To(BeTrue())
+						g.Expect(rollout.ObservedGeneration).To(Equal(ovnController.Generation))
+						g.Expect(rollout.UpdatedNumberScheduled).To(Equal(int32(1)))
+						g.Expect(rollout.NumberReady).To(Equal(int32(1)))
+						g.Expect(rollout.PendingNodes).To(BeEmpty())
+						g.Expect(rollout.Complete).To(BeTrue())
+					}
+				}, timeout, interval).Should(Succeed())
+			})
+
 			It("should create a config job for ovn-controller and not for ovn-controller-ovs", func() {
 				daemonSetName := types.NamespacedName{
 					Namespace: namespace,
@@ -179,6 +652,268 @@ var _ = Describe("OVNController controller", func() {
 				th.AssertJobDoesNotExist(configJobOVS)
 			})
 
+			It("configures the gateway and the encap IP of the chassis from its node", func() {
+				daemonSetName := types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-controller",
+				}
+				node := &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						// the node of the pods of SimulateDaemonsetNumberReadyWithPods
+						Name:        daemonSetName.Name,
+						Annotations: map[string]string{"ovn.openstack.org/encap-ip": "172.19.0.100"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, node)).Should(Succeed())
+				DeferCleanup(th.DeleteInstance, node)
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExternalIDS.GatewayNodeSelector = map[string]string{"gateway": "true"}
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				SimulateDaemonsetNumberReadyWithPods(
+					daemonSetName,
+					map[string][]string{},
+				)
+				configJob := types.NamespacedName{
+					Namespace: OVNControllerName.Namespace,
+					Name:      daemonSetName.Name + "-config",
+				}
+				Eventually(func(g Gomega) {
+					job := &batchv1.Job{}
+					g.Expect(k8sClient.Get(ctx, configJob, job)).Should(Succeed())
+					envVars := job.Spec.Template.Spec.Containers[0].Env
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "EnableChassisAsGateway", Value: "false"}))
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNEncapIP", Value: "172.19.0.100"}))
+				}, timeout, interval).Should(Succeed())
+			})
+
+			It("lists the SB DB members in the ovn-remote by weight", func() {
+				daemonSetName := types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-controller",
+				}
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.SBRemote = &ovnv1.OVNControllerSBRemote{
+						Weights: map[string]int32{"ovsdbserver-sb-0": 3},
+					}
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				SimulateDaemonsetNumberReadyWithPods(
+					daemonSetName,
+					map[string][]string{},
+				)
+				configJob := types.NamespacedName{
+					Namespace: OVNControllerName.Namespace,
+					Name:      daemonSetName.Name + "-config",
+				}
+				Eventually(func(g Gomega) {
+					job := &batchv1.Job{}
+					g.Expect(k8sClient.Get(ctx, configJob, job)).Should(Succeed())
+					ovnRemote := ""
+					for _, envVar := range job.Spec.Template.Spec.Containers[0].Env {
+						if envVar.Name == "OVNRemote" {
+							ovnRemote = envVar.Value
+						}
+					}
+					remotes := strings.Split(ovnRemote, ",")
+					g.Expect(remotes).To(HaveLen(3))
+					g.Expect(remotes[0]).To(HavePrefix("tcp:ovsdbserver-sb-0."))
+					g.Expect(remotes).To(HaveEach(remotes[0]))
+				}, timeout, interval).Should(Succeed())
+			})
+
+			It("reconfigures the port of the tunnels of the chassis", func() {
+				daemonSetName := types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-controller",
+				}
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExternalIDS.OvnEncapType = "vxlan"
+					ovnController.Spec.ExternalIDS.OvnEncapDstPort = 4790
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				SimulateDaemonsetNumberReadyWithPods(
+					daemonSetName,
+					map[string][]string{},
+				)
+				configJob := types.NamespacedName{
+					Namespace: OVNControllerName.Namespace,
+					Name:      daemonSetName.Name + "-config",
+				}
+				Eventually(func(g Gomega) {
+					job := &batchv1.Job{}
+					g.Expect(k8sClient.Get(ctx, configJob, job)).Should(Succeed())
+					envVars := job.Spec.Template.Spec.Containers[0].Env
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNEncapType", Value: "vxlan"}))
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNEncapDstPort", Value: "4790"}))
+				}, timeout, interval).Should(Succeed())
+			})
+
+			It("configures the chassis with the values of the tuning profile", func() {
+				daemonSetName := types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-controller",
+				}
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.TuningProfile = ovnv1.TuningProfileLarge
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				SimulateDaemonsetNumberReadyWithPods(
+					daemonSetName,
+					map[string][]string{},
+				)
+				configJob := types.NamespacedName{
+					Namespace: OVNControllerName.Namespace,
+					Name:      daemonSetName.Name + "-config",
+				}
+				Eventually(func(g Gomega) {
+					job := &batchv1.Job{}
+					g.Expect(k8sClient.Get(ctx, configJob, job)).Should(Succeed())
+					envVars := job.Spec.Template.Spec.Containers[0].Env
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNRemoteProbeInterval", Value: "180000"}))
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNMonitorAll", Value: "true"}))
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNLflowCacheMemLimitKB", Value: "2097152"}))
+				}, timeout, interval).Should(Succeed())
+			})
+
+			It("applies the external-ids changes without rolling the pods out", func() {
+				daemonSetNames := []types.NamespacedName{
+					{Namespace: namespace, Name: "ovn-controller"},
+					{Namespace: namespace, Name: "ovn-controller-ovs"},
+				}
+				SimulateDaemonsetNumberReadyWithPods(daemonSetNames[0], map[string][]string{})
+				configJob := types.NamespacedName{
+					Namespace: OVNControllerName.Namespace,
+					Name:      daemonSetNames[0].Name + "-config",
+				}
+				Eventually(func(g Gomega) {
+					job := &batchv1.Job{}
+					g.Expect(k8sClient.Get(ctx, configJob, job)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+				generations := map[string]int64{}
+				for _, name := range daemonSetNames {
+					generations[name.Name] = GetDaemonSet(name).Generation
+				}
+
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExternalIDS.OvnAvailabilityZones = []string{"az0", "az1"}
+					ovnController.Spec.TuningProfile = ovnv1.TuningProfileLarge
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					job := &batchv1.Job{}
+					g.Expect(k8sClient.Get(ctx, configJob, job)).Should(Succeed())
+					envVars := job.Spec.Template.Spec.Containers[0].Env
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNAvailabilityZones", Value: "az0:az1"}))
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNRemoteProbeInterval", Value: "180000"}))
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNMonitorAll", Value: "true"}))
+					g.Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "OVNLflowCacheMemLimitKB", Value: "2097152"}))
+				}, timeout, interval).Should(Succeed())
+				Consistently(func(g Gomega) {
+					for _, name := range daemonSetNames {
+						g.Expect(GetDaemonSet(name).Generation).To(Equal(generations[name.Name]))
+					}
+				}, consistencyTimeout, interval).Should(Succeed())
+			})
+
+			It("applies the config of the chassis with the config agents instead of the Jobs", func() {
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ConfigAgent = true
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				daemonSetNameOVS := types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-controller-ovs",
+				}
+				Eventually(func(g Gomega) {
+					ds := GetDaemonSet(daemonSetNameOVS)
+					containers := ds.Spec.Template.Spec.Containers
+					g.Expect(containers).To(HaveLen(3))
+					g.Expect(containers[2].Name).To(Equal("config-agent"))
+					g.Expect(containers[2].Command).To(Equal([]string{"/usr/local/bin/container-scripts/config-agent.sh"}))
+					// the agent watches the ConfigMap of the node it runs on
+					g.Expect(containers[2].Env).To(ContainElement(corev1.EnvVar{
+						Name:  "NODE_CONFIG_MAP_PREFIX",
+						Value: OVNControllerName.Name + "-node-config-",
+					}))
+					g.Expect(containers[2].Env).To(ContainElement(HaveField("Name", "OVNHostName")))
+					g.Expect(ds.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", "node-config")))
+				}, timeout, interval).Should(Succeed())
+				Eventually(func(g Gomega) {
+					role := &rbacv1.Role{}
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{
+						Namespace: namespace,
+						Name:      "ovncontroller-" + OVNControllerName.Name + "-ovs-role",
+					}, role)).Should(Succeed())
+					g.Expect(role.Rules).To(ContainElement(rbacv1.PolicyRule{
+						APIGroups: []string{""},
+						Resources: []string{"configmaps"},
+						Verbs:     []string{"get", "list", "watch"},
+					}))
+				}, timeout, interval).Should(Succeed())
+				Eventually(func(g Gomega) {
+					cm := th.GetConfigMap(scriptsCM)
+					g.Expect(cm.Data).To(HaveKey("config-agent.sh"))
+				}, timeout, interval).Should(Succeed())
+
+				daemonSetName := types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-controller",
+				}
+				SimulateDaemonsetNumberReadyWithPods(daemonSetName, map[string][]string{})
+				// the simulated pods run on the node named after the DaemonSet
+				nodeConfigCM := types.NamespacedName{
+					Namespace: OVNControllerName.Namespace,
+					Name:      OVNControllerName.Name + "-node-config-" + daemonSetName.Name,
+				}
+				Eventually(func(g Gomega) {
+					cm := th.GetConfigMap(nodeConfigCM)
+					g.Expect(cm.Labels).To(HaveKeyWithValue("ovn.openstack.org/node-config", daemonSetName.Name))
+					g.Expect(string(cm.BinaryData["config"])).To(ContainSubstring("OVNEncapType='geneve'\n"))
+					g.Expect(string(cm.BinaryData["config"])).To(ContainSubstring("OVNRemote="))
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.TuningProfile = ovnv1.TuningProfileLarge
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+				Eventually(func(g Gomega) {
+					cm := th.GetConfigMap(nodeConfigCM)
+					g.Expect(string(cm.BinaryData["config"])).To(ContainSubstring("OVNMonitorAll='true'\n"))
+				}, timeout, interval).Should(Succeed())
+
+				configJob := types.NamespacedName{
+					Namespace: OVNControllerName.Namespace,
+					Name:      daemonSetName.Name + "-config",
+				}
+				Consistently(func(g Gomega) {
+					job := &batchv1.Job{}
+					err := k8sClient.Get(ctx, configJob, job)
+					g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				}, consistencyTimeout, interval).Should(Succeed())
+
+				// the node configs are removed with the agent
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ConfigAgent = false
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+				th.AssertConfigMapDoesNotExist(nodeConfigCM)
+			})
+
 			It("should create a ConfigMap for start-vswitchd.sh with eth0 as Interface Name", func() {
 				Eventually(func() corev1.ConfigMap {
 					return *th.GetConfigMap(scriptsCM)
@@ -271,6 +1006,36 @@ var _ = Describe("OVNController controller", func() {
 				}, timeout, interval).Should(Succeed())
 				th.AssertConfigMapDoesNotExist(configCM)
 			})
+
+			It("publishes the configuration of the external chassis", func() {
+				chassisCM := types.NamespacedName{
+					Namespace: OVNControllerName.Namespace,
+					Name:      OVNControllerName.Name + "-compute-0-config",
+				}
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExternalChassis = []ovnv1.OVNExternalChassis{
+						{Name: "compute-0", EncapIP: "172.19.0.10"},
+					}
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					config := th.GetConfigMap(chassisCM).Data["ovsdb-config"]
+					g.Expect(config).To(ContainSubstring("ovn-remote: tcp:"))
+					g.Expect(config).To(ContainSubstring("system-id: compute-0\n"))
+					g.Expect(config).To(ContainSubstring("ovn-encap-ip: 172.19.0.10\n"))
+					g.Expect(GetOVNController(OVNControllerName).Status.ExternalChassis).To(Equal(
+						[]ovnv1.OVNExternalChassisStatus{{Name: "compute-0", ConfigMap: chassisCM.Name}}))
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExternalChassis = nil
+					g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+				th.AssertConfigMapDoesNotExist(chassisCM)
+			})
 		})
 	})
 
@@ -728,109 +1493,544 @@ var _ = Describe("OVNController controller", func() {
 				HaveKeyWithValue("k8s.v1.cni.cncf.io/networks", string(expectedAnnotation)),
 			)
 
-			th.ExpectCondition(
-				OVNControllerName,
-				ConditionGetterFunc(OVNControllerConditionGetter),
-				condition.NetworkAttachmentsReadyCondition,
-				corev1.ConditionTrue,
-			)
+			th.ExpectCondition(
+				OVNControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				condition.NetworkAttachmentsReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("reports IP to not exist in Status for nic-configs", func() {
+			daemonSetNameOVS := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller-ovs",
+			}
+			SimulateDaemonsetNumberReadyWithPods(
+				daemonSetNameOVS,
+				map[string][]string{namespace + "/physnet1": {"10.0.0.1"}},
+			)
+
+			th.ExpectCondition(
+				OVNControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				condition.NetworkAttachmentsReadyCondition,
+				corev1.ConditionTrue,
+			)
+
+			Eventually(func(g Gomega) {
+				OVNController := GetOVNController(OVNControllerName)
+				g.Expect(OVNController.Status.NetworkAttachments).ToNot(
+					Equal(map[string][]string{namespace + "/physnet1": {"10.0.0.1"}}))
+
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNController is created with networkAttachment and nic configs", func() {
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNControllerSpec()
+			spec.NetworkAttachment = "internalapi"
+			spec.NicMappings = map[string]string{
+				"physnet1": "enp2s0.100",
+			}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("reports that daemonset have annotations for both Networkattachment and nic-configs", func() {
+			internalAPINADName := types.NamespacedName{Namespace: namespace, Name: "internalapi"}
+			nad := th.CreateNetworkAttachmentDefinition(internalAPINADName)
+			DeferCleanup(th.DeleteInstance, nad)
+
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			daemonSetNameOVS := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller-ovs",
+			}
+
+			ds := GetDaemonSet(daemonSetName)
+			Expect(ds.Spec.Template.ObjectMeta.Annotations).To(BeNil())
+
+			ds = GetDaemonSet(daemonSetNameOVS)
+			expectedAnnotation, err := json.Marshal(
+				[]networkv1.NetworkSelectionElement{
+					{
+						Name:             "internalapi",
+						Namespace:        namespace,
+						InterfaceRequest: "internalapi",
+					},
+					{
+						Name:             "physnet1",
+						Namespace:        namespace,
+						InterfaceRequest: "physnet1",
+					},
+				})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ds.Spec.Template.ObjectMeta.Annotations).To(
+				HaveKeyWithValue("k8s.v1.cni.cncf.io/networks", string(expectedAnnotation)),
+			)
+		})
+	})
+
+	When("OVNController is created with a tunnel MTU", func() {
+		var ovnControllerName types.NamespacedName
+
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNControllerSpec()
+			spec.NetworkAttachment = "internalapi"
+			spec.TunnelMTU = 1600
+			spec.TunnelMTUCheck = true
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+			ovnControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+		})
+
+		It("sets the MTU of the NIC of the tunnels", func() {
+			scriptsCM := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", ovnControllerName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(th.GetConfigMap(scriptsCM).Data["start-vswitchd.sh"]).Should(
+					ContainSubstring("ip link set dev internalapi mtu ${OVS_TUNNEL_MTU}"))
+			}, timeout, interval).Should(Succeed())
+			ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+			Expect(GetEnvVarValue(ds.Spec.Template.Spec.Containers[1].Env, "OVS_TUNNEL_MTU", "")).To(Equal("1600"))
+
+			th.ExpectCondition(
+				ovnControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				ovnv1.OVNTunnelMTUReadyCondition,
+				corev1.ConditionUnknown,
+			)
+		})
+	})
+
+	When("OVNController is created with extra args", func() {
+		var ovnControllerName types.NamespacedName
+
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNControllerSpec()
+			spec.ExtraArgs = ovnv1.OVNControllerExtraArgs{
+				OVNController: map[string]string{"-vreconnect:dbg": "", "--n-handler-threads": "4"},
+				OVSVswitchd:   map[string]string{"--no-mlockall": ""},
+			}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+			ovnControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+		})
+
+		It("appends them to the command lines", func() {
+			ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"})
+			Expect(ds.Spec.Template.Spec.Containers[0].Args[0]).To(HaveSuffix(" '--n-handler-threads=4' '-vreconnect:dbg'"))
+
+			scriptsCM := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", ovnControllerName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(th.GetConfigMap(scriptsCM).Data["start-vswitchd.sh"]).Should(
+					ContainSubstring(`eval "set -- ${OVS_VSWITCHD_EXTRA_ARGS}"`))
+				g.Expect(th.GetConfigMap(scriptsCM).Data["start-vswitchd.sh"]).Should(
+					ContainSubstring(`/usr/sbin/ovs-vswitchd --pidfile --mlockall --detach "$@"`))
+			}, timeout, interval).Should(Succeed())
+			ovsDS := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+			Expect(GetEnvVarValue(ovsDS.Spec.Template.Spec.Containers[1].Env, "OVS_VSWITCHD_EXTRA_ARGS", "")).To(Equal("'--no-mlockall'"))
+		})
+
+		It("only rolls the OVS pods out when the ovs-vswitchd args change", func() {
+			ovnDaemonSetName := types.NamespacedName{Namespace: namespace, Name: "ovn-controller"}
+			ovsDaemonSetName := types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"}
+			Eventually(func(g Gomega) {
+				rollout := GetOVNController(ovnControllerName).Status.Rollout
+				g.Expect(rollout).To(HaveKey("ovn-controller"))
+				g.Expect(rollout["ovn-controller-ovs"].InputHashes).To(HaveKey("ovs-tuning"))
+				g.Expect(rollout["ovn-controller-ovs"].InputHashes).To(HaveKey("scripts"))
+			}, timeout, interval).Should(Succeed())
+			ovnGeneration := GetDaemonSet(ovnDaemonSetName).Generation
+
+			Eventually(func(g Gomega) {
+				instance := GetOVNController(ovnControllerName)
+				instance.Spec.ExtraArgs.OVSVswitchd = map[string]string{"--n-handler-threads": "8"}
+				g.Expect(k8sClient.Update(ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(ovsDaemonSetName)
+				g.Expect(GetEnvVarValue(ds.Spec.Template.Spec.Containers[1].Env, "OVS_VSWITCHD_EXTRA_ARGS", "")).To(
+					Equal("'--n-handler-threads=8'"))
+				rollout := GetOVNController(ovnControllerName).Status.Rollout
+				g.Expect(rollout["ovn-controller-ovs"].ChangedInputs).To(Equal([]string{"ovs-tuning"}))
+			}, timeout, interval).Should(Succeed())
+			Consistently(func(g Gomega) {
+				g.Expect(GetDaemonSet(ovnDaemonSetName).Generation).To(Equal(ovnGeneration))
+			}, consistencyTimeout, interval).Should(Succeed())
+		})
+
+		It("rejects the values with shell special characters", func() {
+			Eventually(func(g Gomega) {
+				instance := &ovnv1.OVNController{}
+				g.Expect(k8sClient.Get(ctx, ovnControllerName, instance)).Should(Succeed())
+				instance.Spec.ExtraArgs.OVNController["--remote"] = "$(hostname)"
+				err := k8sClient.Update(ctx, instance)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("spec.extraArgs.ovnController[--remote]"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNController is created with pod namespaces", func() {
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNControllerSpec()
+			spec.PodNamespaces = ovnv1.OVNControllerPodNamespaces{
+				OVNController: ovnv1.PodNamespaces{ShareProcessNamespace: true},
+				OVS:           ovnv1.PodNamespaces{HostPID: true},
+			}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("shares them with the pods", func() {
+			ovnDS := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"})
+			Expect(ovnDS.Spec.Template.Spec.HostPID).To(BeFalse())
+			Expect(ovnDS.Spec.Template.Spec.HostIPC).To(BeFalse())
+			Expect(ovnDS.Spec.Template.Spec.ShareProcessNamespace).To(Equal(ptr.To(true)))
+
+			ovsDS := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+			Expect(ovsDS.Spec.Template.Spec.HostPID).To(BeTrue())
+			Expect(ovsDS.Spec.Template.Spec.HostIPC).To(BeFalse())
+			Expect(ovsDS.Spec.Template.Spec.ShareProcessNamespace).To(BeNil())
+		})
+	})
+
+	When("OVNController is created with empty spec", func() {
+		var ovnControllerName types.NamespacedName
+
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			instance := CreateOVNController(namespace, ovnv1.OVNControllerSpec{})
+			DeferCleanup(th.DeleteInstance, instance)
+
+			ovnControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+		})
+
+		It("applies meaningful defaults", func() {
+			ovnController := GetOVNController(ovnControllerName)
+			Expect(*ovnController.Spec.ExternalIDS.EnableChassisAsGateway).To(BeTrue())
+			Expect(ovnController.Spec.ExternalIDS.OvnEncapType).To(Equal("geneve"))
+			Expect(ovnController.Spec.ExternalIDS.OvnBridge).To(Equal("br-int"))
+			Expect(ovnController.Spec.ExternalIDS.SystemID).To(Equal("random"))
+			Expect(ovnController.Spec.ChassisStatusInterval).To(Equal(int32(60)))
+		})
+	})
+
+	When("OVNController is created with metrics", func() {
+		var ovnControllerName types.NamespacedName
+		var daemonSetName types.NamespacedName
+		var crbName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.Metrics.Enabled = true
+			// envtest has no ServiceMonitor CRD, the monitors are skipped
+			spec.Metrics.MonitorLabels = map[string]string{"release": "prometheus"}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			ovnControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			daemonSetName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			crbName = types.NamespacedName{
+				Name: namespace + "-ovncontroller-" + instance.GetName() + "-auth-delegator",
+			}
+		})
+
+		It("adds the exporter behind kube-rbac-proxy to the ovn-controller pods", func() {
+			ovnController := GetOVNController(ovnControllerName)
+			Expect(ovnController.Spec.ExporterContainerImage).To(Equal(ovnv1.OVNControllerExporterContainerImage))
+			Expect(ovnController.Spec.RbacProxyContainerImage).To(Equal(ovnv1.KubeRbacProxyContainerImage))
+
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(daemonSetName)
+				containers := ds.Spec.Template.Spec.Containers
+				g.Expect(containers).To(HaveLen(3))
+				g.Expect(containers[1].Name).To(Equal("openstack-network-exporter"))
+				g.Expect(containers[2].Name).To(Equal("kube-rbac-proxy"))
+				g.Expect(containers[2].Args).To(ContainElements(
+					"--secure-listen-address=0.0.0.0:1981",
+					"--upstream=http://127.0.0.1:1982/",
+				))
+				g.Expect(ds.Spec.Template.Annotations).To(HaveKey("ovn.openstack.org/metrics-config-hash"))
+			}, timeout, interval).Should(Succeed())
+
+			cm := th.GetConfigMap(types.NamespacedName{
+				Namespace: namespace,
+				Name:      ovnControllerName.Name + "-metrics-config",
+			})
+			Expect(cm.Data["openstack-network-exporter.yaml"]).To(ContainSubstring("http-listen: '127.0.0.1:1982'"))
+
+			svc := th.GetService(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-metrics"})
+			Expect(svc.Spec.ClusterIP).To(Equal("None"))
+			Expect(svc.Spec.Ports[0].Port).To(Equal(int32(1981)))
+
+			crb := &rbacv1.ClusterRoleBinding{}
+			Expect(k8sClient.Get(ctx, crbName, crb)).Should(Succeed())
+			Expect(crb.RoleRef.Name).To(Equal("system:auth-delegator"))
+			Expect(crb.Subjects[0].Name).To(Equal("ovncontroller-" + ovnControllerName.Name))
+		})
+
+		It("removes the exporter when the metrics get disabled", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, crbName, &rbacv1.ClusterRoleBinding{})).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(ovnControllerName)
+				ovnController.Spec.Metrics.Enabled = false
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers).To(HaveLen(1))
+				err := k8sClient.Get(ctx, crbName, &rbacv1.ClusterRoleBinding{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				err = k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "ovn-controller-metrics"}, &corev1.Service{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNController is created with BGP", func() {
+		var ovnControllerName types.NamespacedName
+		var daemonSetName types.NamespacedName
+		var bgpCM types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.BGP = &ovnv1.OVNControllerBGP{ExposeTenantNetworks: true}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			ovnControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			daemonSetName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			bgpCM = types.NamespacedName{
+				Namespace: namespace,
+				Name:      ovnControllerName.Name + "-bgp-config",
+			}
+		})
+
+		It("adds ovn-bgp-agent to the ovn-controller pods", func() {
+			ovnController := GetOVNController(ovnControllerName)
+			Expect(ovnController.Spec.BGPAgentContainerImage).To(Equal(ovnv1.OVNControllerBGPAgentContainerImage))
+			Expect(ovnController.Spec.BGP.ExposingMethod).To(Equal("underlay"))
+			Expect(ovnController.Spec.BGP.FRRSocketPath).To(Equal("/run/frr"))
+
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(daemonSetName)
+				containers := ds.Spec.Template.Spec.Containers
+				g.Expect(containers).To(HaveLen(2))
+				g.Expect(containers[1].Name).To(Equal("ovn-bgp-agent"))
+				g.Expect(containers[1].Image).To(Equal(ovnv1.OVNControllerBGPAgentContainerImage))
+				g.Expect(containers[1].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "frr-sockets",
+					MountPath: "/run/frr",
+				}))
+				g.Expect(ds.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("HostPath.Path", "/run/frr")))
+				g.Expect(ds.Spec.Template.Annotations).To(HaveKey("ovn.openstack.org/bgp-config-hash"))
+			}, timeout, interval).Should(Succeed())
+
+			config := th.GetConfigMap(bgpCM).Data["bgp-agent.conf"]
+			Expect(config).To(ContainSubstring("exposing_method = underlay\n"))
+			Expect(config).To(ContainSubstring("expose_tenant_networks = true\n"))
+			Expect(config).To(ContainSubstring("bgp_AS = 64999"))
+			Expect(config).NotTo(ContainSubstring("[ovn]"))
+		})
+
+		It("removes ovn-bgp-agent when BGP gets disabled", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers).To(HaveLen(2))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(ovnControllerName)
+				ovnController.Spec.BGP = nil
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(daemonSetName)
+				g.Expect(ds.Spec.Template.Spec.Containers).To(HaveLen(1))
+				g.Expect(ds.Spec.Template.Annotations).NotTo(HaveKey("ovn.openstack.org/bgp-config-hash"))
+			}, timeout, interval).Should(Succeed())
+			th.AssertConfigMapDoesNotExist(bgpCM)
 		})
+	})
 
-		It("reports IP to not exist in Status for nic-configs", func() {
-			daemonSetNameOVS := types.NamespacedName{
+	When("OVNController is created with the toolbox", func() {
+		var ovnControllerName types.NamespacedName
+		var toolboxName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.NodeSelector = map[string]string{"ovn": "a"}
+			spec.Toolbox = &ovnv1.OVNControllerToolbox{
+				NodeSelector: map[string]string{"debug": "true"},
+			}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			ovnControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			toolboxName = types.NamespacedName{
 				Namespace: namespace,
-				Name:      "ovn-controller-ovs",
+				Name:      "ovn-controller-toolbox",
 			}
-			SimulateDaemonsetNumberReadyWithPods(
-				daemonSetNameOVS,
-				map[string][]string{namespace + "/physnet1": {"10.0.0.1"}},
-			)
+		})
 
-			th.ExpectCondition(
-				OVNControllerName,
-				ConditionGetterFunc(OVNControllerConditionGetter),
-				condition.NetworkAttachmentsReadyCondition,
-				corev1.ConditionTrue,
-			)
+		It("runs the toolbox on the selected nodes of the OVNController", func() {
+			ovnController := GetOVNController(ovnControllerName)
+			Expect(ovnController.Spec.ToolboxContainerImage).To(Equal(ovnv1.OVNControllerToolboxContainerImage))
 
 			Eventually(func(g Gomega) {
-				OVNController := GetOVNController(OVNControllerName)
-				g.Expect(OVNController.Status.NetworkAttachments).ToNot(
-					Equal(map[string][]string{namespace + "/physnet1": {"10.0.0.1"}}))
+				ds := GetDaemonSet(toolboxName)
+				g.Expect(ds.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{
+					"ovn":   "a",
+					"debug": "true",
+				}))
+				g.Expect(ds.Spec.Template.Spec.HostNetwork).To(BeTrue())
+				containers := ds.Spec.Template.Spec.Containers
+				g.Expect(containers).To(HaveLen(1))
+				g.Expect(containers[0].Image).To(Equal(ovnv1.OVNControllerToolboxContainerImage))
+				g.Expect(*containers[0].SecurityContext.Privileged).To(BeTrue())
+				g.Expect(containers[0].VolumeMounts).To(ContainElements(
+					HaveField("MountPath", "/var/run/openvswitch"),
+					HaveField("MountPath", "/var/run/ovn"),
+				))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("removes the toolbox when it gets disabled", func() {
+			GetDaemonSet(toolboxName)
+
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(ovnControllerName)
+				ovnController.Spec.Toolbox = nil
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
 
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, toolboxName, &appsv1.DaemonSet{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
 			}, timeout, interval).Should(Succeed())
 		})
 	})
 
-	When("OVNController is created with networkAttachment and nic configs", func() {
+	When("OVNController is created with the memory watchdog", func() {
+		var ovnControllerName types.NamespacedName
+
 		BeforeEach(func() {
-			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
-			DeferCleanup(DeleteOVNDBClusters, dbs)
 			spec := GetDefaultOVNControllerSpec()
-			spec.NetworkAttachment = "internalapi"
-			spec.NicMappings = map[string]string{
-				"physnet1": "enp2s0.100",
+			spec.MemoryWatchdog = &ovnv1.OVNControllerMemoryWatchdog{
+				MaxRSSMB: 2048,
 			}
 			instance := CreateOVNController(namespace, spec)
 			DeferCleanup(th.DeleteInstance, instance)
+
+			ovnControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
 		})
 
-		It("reports that daemonset have annotations for both Networkattachment and nic-configs", func() {
-			internalAPINADName := types.NamespacedName{Namespace: namespace, Name: "internalapi"}
-			nad := th.CreateNetworkAttachmentDefinition(internalAPINADName)
-			DeferCleanup(th.DeleteInstance, nad)
+		It("checks the RSS every 5 minutes by default", func() {
+			ovnController := GetOVNController(ovnControllerName)
+			Expect(ovnController.Spec.MemoryWatchdog.MaxRSSMB).To(Equal(int32(2048)))
+			Expect(ovnController.Spec.MemoryWatchdog.Interval).To(Equal(int32(300)))
+		})
+	})
 
-			daemonSetName := types.NamespacedName{
+	When("OVNController is created with node capabilities", func() {
+		var daemonSetName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.NodeSelector = map[string]string{"ovn": "a"}
+			spec.NodeCapabilities = []string{ovnv1.NodeCapabilityDPDK}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			daemonSetName = types.NamespacedName{
 				Namespace: namespace,
 				Name:      "ovn-controller",
 			}
-			daemonSetNameOVS := types.NamespacedName{
-				Namespace: namespace,
-				Name:      "ovn-controller-ovs",
-			}
-
-			ds := GetDaemonSet(daemonSetName)
-			Expect(ds.Spec.Template.ObjectMeta.Annotations).To(BeNil())
+		})
 
-			ds = GetDaemonSet(daemonSetNameOVS)
-			expectedAnnotation, err := json.Marshal(
-				[]networkv1.NetworkSelectionElement{
-					{
-						Name:             "internalapi",
-						Namespace:        namespace,
-						InterfaceRequest: "internalapi",
-					},
-					{
-						Name:             "physnet1",
-						Namespace:        namespace,
-						InterfaceRequest: "physnet1",
-					},
-				})
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(ds.Spec.Template.ObjectMeta.Annotations).To(
-				HaveKeyWithValue("k8s.v1.cni.cncf.io/networks", string(expectedAnnotation)),
-			)
+		It("runs only on the nodes labeled with the capabilities", func() {
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(daemonSetName)
+				g.Expect(ds.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{
+					"ovn":                                 "a",
+					"feature.node.kubernetes.io/ovn-dpdk": "true",
+				}))
+			}, timeout, interval).Should(Succeed())
 		})
 	})
 
-	When("OVNController is created with empty spec", func() {
+	When("OVNController is created with OVS metrics", func() {
 		var ovnControllerName types.NamespacedName
+		var crbName types.NamespacedName
 
 		BeforeEach(func() {
-			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
-			DeferCleanup(DeleteOVNDBClusters, dbs)
-			instance := CreateOVNController(namespace, ovnv1.OVNControllerSpec{})
+			spec := GetDefaultOVNControllerSpec()
+			spec.Metrics.OVSEnabled = true
+			instance := CreateOVNController(namespace, spec)
 			DeferCleanup(th.DeleteInstance, instance)
 
 			ovnControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			crbName = types.NamespacedName{
+				Name: namespace + "-ovncontroller-" + instance.GetName() + "-auth-delegator",
+			}
 		})
 
-		It("applies meaningful defaults", func() {
-			ovnController := GetOVNController(ovnControllerName)
-			Expect(*ovnController.Spec.ExternalIDS.EnableChassisAsGateway).To(BeTrue())
-			Expect(ovnController.Spec.ExternalIDS.OvnEncapType).To(Equal("geneve"))
-			Expect(ovnController.Spec.ExternalIDS.OvnBridge).To(Equal("br-int"))
-			Expect(ovnController.Spec.ExternalIDS.SystemID).To(Equal("random"))
+		It("adds the exporter only to the OVS pods", func() {
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+				containers := ds.Spec.Template.Spec.Containers
+				g.Expect(containers[len(containers)-2].Name).To(Equal("openstack-network-exporter"))
+				g.Expect(containers[len(containers)-1].Name).To(Equal("kube-rbac-proxy"))
+				g.Expect(ds.Spec.Template.Annotations).To(HaveKey("ovn.openstack.org/metrics-config-hash"))
+			}, timeout, interval).Should(Succeed())
+
+			ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"})
+			Expect(ds.Spec.Template.Spec.Containers).To(HaveLen(1))
+			Expect(ds.Spec.Template.Annotations).ToNot(HaveKey("ovn.openstack.org/metrics-config-hash"))
+
+			cm := th.GetConfigMap(types.NamespacedName{
+				Namespace: namespace,
+				Name:      ovnControllerName.Name + "-ovs-metrics-config",
+			})
+			Expect(cm.Data["openstack-network-exporter.yaml"]).To(ContainSubstring("pmd-rxq"))
+
+			svc := th.GetService(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs-metrics"})
+			Expect(svc.Spec.ClusterIP).To(Equal("None"))
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "ovn-controller-metrics"}, &corev1.Service{})
+			Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+
+			Expect(k8sClient.Get(ctx, crbName, &rbacv1.ClusterRoleBinding{})).Should(Succeed())
 		})
 	})
 
@@ -850,13 +2050,10 @@ var _ = Describe("OVNController controller", func() {
 			th.ExpectConditionWithDetails(
 				ovnControllerName,
 				ConditionGetterFunc(OVNControllerConditionGetter),
-				condition.TLSInputReadyCondition,
+				condition.InputReadyCondition,
 				corev1.ConditionFalse,
-				condition.ErrorReason,
-				fmt.Sprintf(
-					"TLSInput error occured in TLS sources Secret %s/combined-ca-bundle not found",
-					namespace,
-				),
+				ovnv1.InputMissingReason,
+				"Waiting for the referenced objects to be created: Secret combined-ca-bundle",
 			)
 			th.ExpectCondition(
 				ovnControllerName,
@@ -874,13 +2071,10 @@ var _ = Describe("OVNController controller", func() {
 			th.ExpectConditionWithDetails(
 				ovnControllerName,
 				ConditionGetterFunc(OVNControllerConditionGetter),
-				condition.TLSInputReadyCondition,
+				condition.InputReadyCondition,
 				corev1.ConditionFalse,
-				condition.ErrorReason,
-				fmt.Sprintf(
-					"TLSInput error occured in TLS sources Secret %s/%s not found",
-					namespace, OvnDbCertSecretName,
-				),
+				ovnv1.InputMissingReason,
+				"Waiting for the referenced objects to be created: Secret "+OvnDbCertSecretName,
 			)
 			th.ExpectCondition(
 				ovnControllerName,
@@ -924,15 +2118,15 @@ var _ = Describe("OVNController controller", func() {
 
 			// check TLS volume mounts
 			th.AssertVolumeMountExists(CABundleSecretName, "tls-ca-bundle.pem", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovn-controller-tls-certs", "tls.key", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovn-controller-tls-certs", "tls.crt", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovn-controller-tls-certs", "ca.crt", svcC.VolumeMounts)
+			th.AssertVolumeMountExists("ovn-controller-tls-certs", "", svcC.VolumeMounts)
 
 			// check cli args
 			Expect(svcC.Args).To(And(
 				ContainElement(ContainSubstring(fmt.Sprintf("--private-key=%s", ovn_common.OVNDbKeyPath))),
 				ContainElement(ContainSubstring(fmt.Sprintf("--certificate=%s", ovn_common.OVNDbCertPath))),
 				ContainElement(ContainSubstring(fmt.Sprintf("--ca-cert=%s", ovn_common.OVNDbCaCertPath))),
+				ContainElement(HavePrefix(fmt.Sprintf("ovs-vsctl set-ssl %s %s %s && exec ovn-controller",
+					ovn_common.OVNDbKeyPath, ovn_common.OVNDbCertPath, ovn_common.OVNDbCaCertPath))),
 			))
 
 			th.ExpectCondition(
@@ -943,7 +2137,7 @@ var _ = Describe("OVNController controller", func() {
 			)
 		})
 
-		It("reconfigures the pods when CA bundle changes", func() {
+		It("restarts only the ovn-controller pods when CA bundle changes", func() {
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
 				Name:      CABundleSecretName,
 				Namespace: namespace,
@@ -958,21 +2152,28 @@ var _ = Describe("OVNController controller", func() {
 				Name:      "ovn-controller",
 			}
 
-			SimulateDaemonsetNumberReady(daemonSetName)
+			SimulateDaemonsetRolledOut(daemonSetName)
 
 			daemonSetNameOVS := types.NamespacedName{
 				Namespace: namespace,
 				Name:      "ovn-controller-ovs",
 			}
 
-			SimulateDaemonsetNumberReady(daemonSetNameOVS)
+			SimulateDaemonsetRolledOut(daemonSetNameOVS)
 
-			originalHash := GetEnvVarValue(
-				GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Env,
-				"CONFIG_HASH",
-				"",
-			)
+			originalHash := GetDaemonSet(daemonSetName).Spec.Template.Annotations[ovn_common.TLSCABundleHashAnnotation]
 			Expect(originalHash).NotTo(BeEmpty())
+			Eventually(func(g Gomega) {
+				tlsHashes := GetOVNController(ovnControllerName).Status.TLSHashes
+				g.Expect(tlsHashes).To(HaveKeyWithValue("ovn-controller", HaveKeyWithValue("ca-bundle", originalHash)))
+				g.Expect(tlsHashes).NotTo(HaveKey("ovn-controller-ovs"))
+			}, timeout, interval).Should(Succeed())
+
+			originalAnnotations := GetDaemonSet(daemonSetName).Spec.Template.Annotations
+			Expect(originalAnnotations).To(HaveKey(ovncontroller.ScriptsHashAnnotation))
+			originalOVSAnnotations := GetDaemonSet(daemonSetNameOVS).Spec.Template.Annotations
+			Expect(originalOVSAnnotations).To(HaveKey(ovncontroller.ScriptsHashAnnotation))
+			Expect(originalOVSAnnotations).NotTo(HaveKey(ovn_common.TLSCABundleHashAnnotation))
 
 			// Change the content of the CA secret
 			th.UpdateSecret(types.NamespacedName{
@@ -983,19 +2184,42 @@ var _ = Describe("OVNController controller", func() {
 				[]byte("DifferentCAData"),
 			)
 
-			// Assert that the pod is updated
+			// Assert that the ovn-controller pods are updated
+			var newHash string
 			Eventually(func(g Gomega) {
-				newHash := GetEnvVarValue(
-					GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Env,
-					"CONFIG_HASH",
-					"",
-				)
+				newHash = GetDaemonSet(daemonSetName).Spec.Template.Annotations[ovn_common.TLSCABundleHashAnnotation]
 				g.Expect(newHash).NotTo(BeEmpty())
 				g.Expect(newHash).NotTo(Equal(originalHash))
 			}, timeout, interval).Should(Succeed())
+			// only the CA bundle hash changed among the hashes of the inputs
+			newAnnotations := GetDaemonSet(daemonSetName).Spec.Template.Annotations
+			Expect(newAnnotations).To(HaveLen(len(originalAnnotations)))
+			for key, value := range originalAnnotations {
+				if key != ovn_common.TLSCABundleHashAnnotation {
+					Expect(newAnnotations).To(HaveKeyWithValue(key, value))
+				}
+			}
+
+			// the status keeps the old hash until the rollout completes
+			Expect(GetOVNController(ovnControllerName).Status.TLSHashes).To(
+				HaveKeyWithValue("ovn-controller", HaveKeyWithValue("ca-bundle", originalHash)))
+			SimulateDaemonsetRolledOut(daemonSetName)
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNController(ovnControllerName).Status.TLSHashes).To(
+					HaveKeyWithValue("ovn-controller", HaveKeyWithValue("ca-bundle", newHash)))
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() []string {
+				return GetEventReasons(ovnControllerName)
+			}, timeout, interval).Should(ContainElement(ovn_common.EventReasonTLSRolledOut))
+
+			// while the OVS pods don't use the CA bundle and are not restarted
+			Consistently(func(g Gomega) {
+				g.Expect(GetDaemonSet(daemonSetNameOVS).Spec.Template.Annotations).To(
+					Equal(originalOVSAnnotations))
+			}, consistencyTimeout, interval).Should(Succeed())
 		})
 
-		It("reconfigures the pods when cert changes", func() {
+		It("does not restart the pods when cert changes", func() {
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
 				Name:      CABundleSecretName,
 				Namespace: namespace,
@@ -1019,12 +2243,11 @@ var _ = Describe("OVNController controller", func() {
 
 			SimulateDaemonsetNumberReady(daemonSetNameOVS)
 
-			originalHash := GetEnvVarValue(
-				GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Env,
-				"CONFIG_HASH",
-				"",
-			)
-			Expect(originalHash).NotTo(BeEmpty())
+			originalAnnotations := GetDaemonSet(daemonSetName).Spec.Template.Annotations
+			Expect(originalAnnotations).To(HaveKey(ovncontroller.ScriptsHashAnnotation))
+			Expect(originalAnnotations).To(HaveKey(ovn_common.TLSCABundleHashAnnotation))
+			originalOVSAnnotations := GetDaemonSet(daemonSetNameOVS).Spec.Template.Annotations
+			Expect(originalOVSAnnotations).To(HaveKey(ovncontroller.ScriptsHashAnnotation))
 
 			// Change the content of the cert secret
 			th.UpdateSecret(types.NamespacedName{
@@ -1035,16 +2258,274 @@ var _ = Describe("OVNController controller", func() {
 				[]byte("DifferentCrtData"),
 			)
 
-			// Assert that the pod is updated
+			// The cert is reloaded in place: neither the CA bundle hash nor
+			// the hashes of the other inputs change, the pods are not updated
+			Consistently(func(g Gomega) {
+				g.Expect(GetDaemonSet(daemonSetName).Spec.Template.Annotations).To(
+					Equal(originalAnnotations))
+				g.Expect(GetDaemonSet(daemonSetNameOVS).Spec.Template.Annotations).To(
+					Equal(originalOVSAnnotations))
+			}, consistencyTimeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNController is created with FIPS", func() {
+		It("rejects FIPS without TLS", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.FIPS = true
+			instance := &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovn-controller-fips",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("FIPS mode requires TLS"))
+		})
+
+		It("restricts ovn-controller to FIPS approved TLS settings", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
+				Name:      CABundleSecretName,
+				Namespace: namespace,
+			}))
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(types.NamespacedName{
+				Name:      OvnDbCertSecretName,
+				Namespace: namespace,
+			}))
+			spec := GetTLSOVNControllerSpec()
+			spec.FIPS = true
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+			ovnControllerName := types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+
+			daemonSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+			SimulateDaemonsetNumberReady(daemonSetName)
+
 			Eventually(func(g Gomega) {
-				newHash := GetEnvVarValue(
-					GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Env,
-					"CONFIG_HASH",
-					"",
-				)
-				g.Expect(newHash).NotTo(BeEmpty())
-				g.Expect(newHash).NotTo(Equal(originalHash))
+				args := GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Args
+				g.Expect(args).To(HaveLen(1))
+				// the connections to the SB DB and the SSL table read by
+				// ovn-controller are both restricted
+				g.Expect(args[0]).To(HavePrefix(fmt.Sprintf("ovs-vsctl set-ssl %s %s %s %s %s && exec ovn-controller",
+					ovn_common.OVNDbKeyPath, ovn_common.OVNDbCertPath, ovn_common.OVNDbCaCertPath,
+					ovn_common.FIPSSSLProtocols, ovn_common.FIPSSSLCiphers)))
+				g.Expect(args[0]).To(ContainSubstring(" --ssl-protocols=" + ovn_common.FIPSSSLProtocols))
+				g.Expect(args[0]).To(ContainSubstring(" --ssl-ciphers=" + ovn_common.FIPSSSLCiphers))
+			}, timeout, interval).Should(Succeed())
+			// no pod is running to be checked
+			th.ExpectCondition(
+				ovnControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				ovnv1.OVNFIPSProviderReadyCondition,
+				corev1.ConditionUnknown,
+			)
+		})
+	})
+
+	When("OVNController is validated", func() {
+		It("rejects an OVNController selecting the nodes of another one", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.NodeSelector = map[string]string{"ovn": "a"}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			spec.NodeSelector = map[string]string{"ovn": "a", "zone": "z1"}
+			other := &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovn-controller-overlap",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, other)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(
+				fmt.Sprintf("nodes are also selected by OVNController %s/%s", namespace, instance.GetName())))
+
+			other.Spec.NodeSelector = map[string]string{"ovn": "b"}
+			Expect(k8sClient.Create(ctx, other)).To(Succeed())
+			DeferCleanup(th.DeleteInstance, other)
+		})
+
+		It("rejects a CA bundle without a service cert", func() {
+			spec := GetTLSOVNControllerSpec()
+			spec.TLS.SecretName = nil
+			instance := &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovn-controller-ca",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a CA bundle requires the service cert"))
+		})
+
+		It("rejects a tunnel MTU over the pod network", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.TunnelMTU = 1600
+			instance := &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovn-controller-mtu",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("set networkAttachment"))
+
+			instance.Spec.TunnelMTU = 0
+			instance.Spec.TunnelMTUCheck = true
+			err = k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("set tunnelMTU"))
+		})
+
+		It("rejects the default port of the other type of tunnels", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.ExternalIDS.OvnEncapType = "vxlan"
+			spec.ExternalIDS.OvnEncapDstPort = 6081
+			instance := &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovn-controller-encap-port",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("6081 is the default port of the geneve tunnels"))
+
+			instance.Spec.ExternalIDS.OvnEncapDstPort = 4790
+			Expect(k8sClient.Create(ctx, instance)).To(Succeed())
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("rejects the bridges and the Geneve port of ovn-kubernetes when coexisting with it", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.OVNKubernetesCoexistence = true
+			spec.NicMappings = map[string]string{"ex": "eth1"}
+			instance := &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovn-controller-coexistence",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("br-int is owned by ovn-kubernetes"))
+			Expect(err.Error()).To(ContainSubstring("the Geneve port 6081 is used by ovn-kubernetes"))
+			Expect(err.Error()).To(ContainSubstring("br-ex is owned by ovn-kubernetes"))
+
+			instance.Spec.ExternalIDS.OvnBridge = "br-osp"
+			instance.Spec.ExternalIDS.OvnEncapDstPort = 6082
+			instance.Spec.NicMappings = map[string]string{"datacentre": "eth1"}
+			Expect(k8sClient.Create(ctx, instance)).To(Succeed())
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+	})
+
+	When("OVNController is reconciled again without spec change", func() {
+		It("does not update the DaemonSets", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNControllerSpec()
+			spec.NicMappings = map[string]string{
+				"physnet1": "enp2s0.100",
+				"physnet2": "enp2s0.200",
+				"physnet3": "enp2s0.300",
+			}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+			OVNControllerName := types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			daemonSets := []types.NamespacedName{
+				{Namespace: namespace, Name: "ovn-controller"},
+				{Namespace: namespace, Name: "ovn-controller-ovs"},
+			}
+
+			// settled once two reads in a row return the same versions
+			versions := map[string]string{}
+			Eventually(func(g Gomega) {
+				settled := true
+				for _, name := range daemonSets {
+					version := GetDaemonSet(name).ResourceVersion
+					settled = settled && version == versions[name.Name]
+					versions[name.Name] = version
+				}
+				g.Expect(settled).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+
+			// any change of the instance triggers a reconcile
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Annotations = map[string]string{"reconcile": "again"}
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				for _, name := range daemonSets {
+					g.Expect(GetDaemonSet(name).ResourceVersion).To(Equal(versions[name.Name]))
+				}
+			}, consistencyTimeout, interval).Should(Succeed())
+		})
+	})
+
+	When("the ovn-controller DaemonSet is modified manually", func() {
+		var daemonSetName types.NamespacedName
+		BeforeEach(func() {
+			daemonSetName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-controller",
+			}
+		})
+
+		hotfix := func() {
+			ds := GetDaemonSet(daemonSetName)
+			patch := client.MergeFrom(ds.DeepCopy())
+			ds.Spec.Template.Spec.Containers[0].Image = "hotfix-image"
+			Expect(k8sClient.Patch(ctx, ds, patch, client.FieldOwner("kubectl-edit"))).Should(Succeed())
+		}
+
+		It("reverts the changes by default", func() {
+			instance := CreateOVNController(namespace, GetDefaultOVNControllerSpec())
+			DeferCleanup(th.DeleteInstance, instance)
+			hotfix()
+
+			Eventually(func(g Gomega) {
+				image := GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Image
+				g.Expect(image).ToNot(Equal("hotfix-image"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("keeps and reports the changes with the Report drift policy", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.DriftPolicy = ovnv1.DriftPolicyReport
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+			OVNControllerName := types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			hotfix()
+
+			Eventually(func(g Gomega) {
+				drifted := GetOVNController(OVNControllerName).Status.Conditions.Get(ovnv1.OVNDriftedCondition)
+				g.Expect(drifted).ToNot(BeNil())
+				g.Expect(drifted.Status).To(Equal(corev1.ConditionTrue))
+				g.Expect(drifted.Message).To(ContainSubstring(
+					`DaemonSet ovn-controller (.spec.template.spec.containers[name="ovn-controller"].image)`))
 			}, timeout, interval).Should(Succeed())
+			Consistently(func(g Gomega) {
+				image := GetDaemonSet(daemonSetName).Spec.Template.Spec.Containers[0].Image
+				g.Expect(image).To(Equal("hotfix-image"))
+			}, consistencyTimeout, interval).Should(Succeed())
 		})
 	})
 })
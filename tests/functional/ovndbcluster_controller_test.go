@@ -27,11 +27,19 @@ import (
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
 
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("OVNDBCluster controller", func() {
@@ -80,6 +88,24 @@ var _ = Describe("OVNDBCluster controller", func() {
 				Entry("DNS entry NB", "nb"),
 				Entry("DNS entry SB", "sb"),
 			)
+			It("should delete the dnsdata CR when the network attachment is removed", func() {
+				_ = th.CreateNetworkAttachmentDefinition(types.NamespacedName{Namespace: namespace, Name: "internalapi"})
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{namespace + "/internalapi": {"10.0.0.1"}}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+				dnsDataName := types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb"}
+				GetDNSData(dnsDataName)
+
+				Eventually(func(g Gomega) {
+					c := GetOVNDBCluster(dbs[0])
+					c.Spec.NetworkAttachment = ""
+					g.Expect(k8sClient.Update(ctx, c)).Should(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, dnsDataName, &infranetworkv1.DNSData{})
+					g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				}, timeout, interval).Should(Succeed())
+			})
 			DescribeTable("Should update DNSData IP if pod IP changes",
 				func(DNSEntryName string) {
 					var clusterName types.NamespacedName
@@ -145,6 +171,7 @@ var _ = Describe("OVNDBCluster controller", func() {
 			Expect(*(OVNDBCluster.Spec.Replicas)).Should(Equal(int32(1)))
 			Expect(OVNDBCluster.Spec.LogLevel).Should(Equal("info"))
 			Expect(OVNDBCluster.Spec.DBType).Should(Equal(ovnv1.NBDBType))
+			Expect(OVNDBCluster.Spec.ClusterStatusInterval).Should(Equal(int32(60)))
 		})
 
 		It("should have the StatefulSet with podManagementPolicy set to Parallel", func() {
@@ -157,10 +184,37 @@ var _ = Describe("OVNDBCluster controller", func() {
 			Expect(ss.Spec.PodManagementPolicy).Should(Equal(appsv1.ParallelPodManagement))
 		})
 
+		It("should render the StatefulSet for the restricted pod security profile", func() {
+			ss := th.GetStatefulSet(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			})
+
+			podSC := ss.Spec.Template.Spec.SecurityContext
+			Expect(*podSC.RunAsNonRoot).To(BeTrue())
+			Expect(podSC.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+			sc := ss.Spec.Template.Spec.Containers[0].SecurityContext
+			Expect(*sc.AllowPrivilegeEscalation).To(BeFalse())
+			Expect(sc.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+			Expect(sc.Privileged).To(BeNil())
+		})
+
+		It("should delete the PVCs with the StatefulSet by default", func() {
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			ss := th.GetStatefulSet(statefulSetName)
+
+			Expect(ss.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted).Should(
+				Equal(appsv1.DeletePersistentVolumeClaimRetentionPolicyType))
+		})
+
 		It("should have the Status fields initialized", func() {
 			OVNDBCluster := GetOVNDBCluster(OVNDBClusterName)
 			Expect(OVNDBCluster.Status.Hash).To(BeEmpty())
 			Expect(OVNDBCluster.Status.ReadyCount).To(Equal(int32(0)))
+			Expect(OVNDBCluster.Status.ClusterMembers).To(BeEmpty())
 		})
 
 		It("should have a finalizer", func() {
@@ -210,6 +264,630 @@ var _ = Describe("OVNDBCluster controller", func() {
 			Expect(th.GetConfigMap(cm).Data["setup.sh"]).Should(
 				ContainSubstring(fmt.Sprintf("NAMESPACE=\"%s\"", namespace)))
 		})
+
+		It("should publish the connection details in a ConfigMap", func() {
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			th.SimulateStatefulSetReplicaReadyWithPods(statefulSetName, map[string][]string{})
+
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb-connection",
+			}
+			Eventually(func(g Gomega) {
+				data := th.GetConfigMap(cm).Data
+				g.Expect(data[ovnv1.ConnectionInternalURLKey]).Should(
+					Equal(fmt.Sprintf("tcp:ovsdbserver-nb-0.%s.svc.cluster.local:6641", namespace)))
+				g.Expect(data[ovnv1.ConnectionTLSKey]).Should(Equal("false"))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(OVNDBClusterName).Status.ConnectionConfigMap).Should(Equal(cm.Name))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("the NB and SB OVNDBClusters are created", func() {
+		var dbs []types.NamespacedName
+		endpointsCM := types.NamespacedName{}
+
+		BeforeEach(func() {
+			dbs = CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			endpointsCM = types.NamespacedName{Namespace: namespace, Name: "ovn-endpoints"}
+		})
+
+		// The ConfigMap is consumed by other operators, the keys are spelled
+		// out instead of using the constants so that the schema can't change
+		// unnoticed
+		It("publishes the endpoints ConfigMap of schema version 1", func() {
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(endpointsCM)
+				g.Expect(cm.Data).To(Equal(map[string]string{
+					"schema_version":      "1",
+					"nb_internal_url":     fmt.Sprintf("tcp:ovsdbserver-nb-0.%s.svc.cluster.local:6641", namespace),
+					"nb_external_url":     "",
+					"nb_tls":              "false",
+					"nb_ca_bundle_secret": "",
+					"sb_internal_url":     fmt.Sprintf("tcp:ovsdbserver-sb-0.%s.svc.cluster.local:6642", namespace),
+					"sb_external_url":     "",
+					"sb_tls":              "false",
+					"sb_ca_bundle_secret": "",
+				}))
+				g.Expect(cm.OwnerReferences).To(HaveLen(2))
+			}, timeout, interval).Should(Succeed())
+
+			for _, db := range dbs {
+				Expect(GetOVNDBCluster(db).Status.EndpointsConfigMap).To(Equal("ovn-endpoints"))
+			}
+		})
+
+		It("removes the endpoints of a deleted OVNDBCluster", func() {
+			DeferCleanup(DeleteOVNDBClusters, dbs[1:])
+			th.DeleteInstance(GetOVNDBCluster(dbs[0]))
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(endpointsCM)
+				g.Expect(cm.Data).To(HaveKey("sb_internal_url"))
+				g.Expect(cm.Data).NotTo(HaveKey("nb_internal_url"))
+				g.Expect(cm.OwnerReferences).To(HaveLen(1))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created for the Octavia OVN provider", func() {
+		var dbClusterName types.NamespacedName
+		secretName := types.NamespacedName{}
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.OctaviaProvider = true
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+			dbClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			secretName = types.NamespacedName{Namespace: namespace, Name: "ovn-octavia-provider"}
+		})
+
+		It("publishes the NB DB connection in a Secret", func() {
+			Eventually(func(g Gomega) {
+				secret := th.GetSecret(secretName)
+				g.Expect(secret.Data).To(Equal(map[string][]byte{
+					"ovn_nb_connection": []byte(fmt.Sprintf("tcp:ovsdbserver-nb-0.%s.svc.cluster.local:6641", namespace)),
+				}))
+			}, timeout, interval).Should(Succeed())
+			Expect(GetOVNDBCluster(dbClusterName).Status.OctaviaProviderSecret).To(Equal(secretName.Name))
+		})
+
+		It("removes the Secret when disabled", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(dbClusterName).Status.OctaviaProviderSecret).To(Equal(secretName.Name))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				dbCluster := GetOVNDBCluster(dbClusterName)
+				dbCluster.Spec.OctaviaProvider = false
+				g.Expect(k8sClient.Update(ctx, dbCluster)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			th.AssertSecretDoesNotExist(secretName)
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(dbClusterName).Status.OctaviaProviderSecret).To(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects the Octavia OVN provider on the SB DB", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.DBType = ovnv1.SBDBType
+			spec.OctaviaProvider = true
+			instance := &ovnv1.OVNDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovndbcluster-sb-octavia",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("only connects to the NB DB"))
+		})
+	})
+
+	When("An OVN Interconnect OVNDBCluster is created", func() {
+		var OVNDBClusterName types.NamespacedName
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.DBType = ovnv1.ICNBDBType
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("should run the IC-NB database", func() {
+			scriptsCM := types.NamespacedName{
+				Namespace: OVNDBClusterName.Namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				data := th.GetConfigMap(scriptsCM).Data
+				g.Expect(data["functions"]).Should(ContainSubstring("DB_TYPE=\"ic_nb\""))
+				g.Expect(data["functions"]).Should(ContainSubstring("DB_NAME=\"OVN_IC_Northbound\""))
+				g.Expect(data["functions"]).Should(ContainSubstring("DB_FILE=/etc/ovn/ovn_ic_nb_db.db"))
+				g.Expect(data["setup.sh"]).Should(ContainSubstring("DB_PORT=\"6645\""))
+				g.Expect(data["setup.sh"]).Should(ContainSubstring("RAFT_PORT=\"6647\""))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should publish the IC-NB connection details", func() {
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-ic-nb",
+			}
+			th.SimulateStatefulSetReplicaReadyWithPods(statefulSetName, map[string][]string{})
+
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-ic-nb-connection",
+			}
+			Eventually(func(g Gomega) {
+				data := th.GetConfigMap(cm).Data
+				g.Expect(data[ovnv1.ConnectionInternalURLKey]).Should(
+					Equal(fmt.Sprintf("tcp:ovsdbserver-ic-nb-0.%s.svc.cluster.local:6645", namespace)))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with StorageRetention set to Retain", func() {
+		var OVNDBClusterName types.NamespacedName
+		var instance client.Object
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.StorageRetention = ovnv1.StorageRetentionRetain
+			instance = CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+		})
+
+		It("should retain the PVCs on StatefulSet deletion", func() {
+			DeferCleanup(th.DeleteInstance, instance)
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			ss := th.GetStatefulSet(statefulSetName)
+
+			Expect(ss.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted).Should(
+				Equal(appsv1.RetainPersistentVolumeClaimRetentionPolicyType))
+		})
+
+		It("should remove the owner references from the PVCs on delete", func() {
+			// there is no StatefulSet controller in envtest so simulate the
+			// PVC created from the volume claim template
+			ss := th.GetStatefulSet(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			})
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: ss.Spec.VolumeClaimTemplates[0].ObjectMeta,
+				Spec:       ss.Spec.VolumeClaimTemplates[0].Spec,
+			}
+			pvc.Name = pvc.Name + "-ovsdbserver-nb-0"
+			Expect(k8sClient.Create(ctx, pvc)).Should(Succeed())
+			pvcName := types.NamespacedName{Name: pvc.Name, Namespace: namespace}
+			DeferCleanup(k8sClient.Delete, ctx, pvc)
+
+			Expect(GetPVC(pvcName).OwnerReferences).ShouldNot(BeEmpty())
+
+			th.DeleteInstance(GetOVNDBCluster(OVNDBClusterName))
+
+			Eventually(func(g Gomega) {
+				g.Expect(GetPVC(pvcName).OwnerReferences).Should(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with file logging", func() {
+		var OVNDBClusterName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.LogModules = []string{"jsonrpc:dbg"}
+			spec.ExtraArgs = map[string]string{"--disable-file-column-diff": ""}
+			spec.LogFile = ovnv1.OVNDBClusterLogFile{
+				Enabled:   true,
+				MaxSizeMB: 10,
+				MaxFiles:  3,
+			}
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("should mount the log volume", func() {
+			ss := th.GetStatefulSet(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			})
+			Expect(ss.Spec.Template.Spec.Volumes).Should(ContainElement(
+				HaveField("Name", "var-log-ovn")))
+			Expect(ss.Spec.Template.Spec.Containers[0].VolumeMounts).Should(ContainElement(
+				HaveField("MountPath", "/var/log/ovn")))
+		})
+
+		It("should configure the log file in the scripts ConfigMap", func() {
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				setup := th.GetConfigMap(cm).Data["setup.sh"]
+				g.Expect(setup).Should(ContainSubstring("--ovn-${DB_OPT}-logfile=/var/log/ovn/ovsdb-server-nb.log"))
+				g.Expect(setup).Should(ContainSubstring("-vjsonrpc:dbg"))
+				g.Expect(setup).Should(ContainSubstring(`EXTRA_ARGS="${EXTRA_ARGS} "'--disable-file-column-diff'`))
+				g.Expect(setup).Should(ContainSubstring("rotate_log_file $! /var/log/ovn/ovsdb-server-nb.log 10485760 3"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with a tuning profile", func() {
+		var OVNDBClusterName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.TuningProfile = ovnv1.TuningProfileLarge
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("should configure the values of the profile in the scripts ConfigMap", func() {
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				setup := th.GetConfigMap(cm).Data["setup.sh"]
+				g.Expect(setup).Should(ContainSubstring("--db-${DB_OPT}-election-timer=16000"))
+				g.Expect(setup).Should(ContainSubstring("--db-${DB_OPT}-probe-interval-to-active=180000"))
+				g.Expect(setup).Should(ContainSubstring("--inactivity-probe=180000"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with the auto tuning profile", func() {
+		var OVNDBClusterName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.TuningProfile = ovnv1.TuningProfileAuto
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("should choose the profile of the number of chassis", func() {
+			Eventually(func(g Gomega) {
+				tuning := GetOVNDBCluster(OVNDBClusterName).Status.Tuning
+				g.Expect(tuning.Profile).To(Equal(ovnv1.TuningProfileSmall))
+				g.Expect(tuning.Chassis).To(Equal(int32(0)))
+				g.Expect(tuning.Values).To(HaveKeyWithValue("electionTimer", "5000"))
+			}, timeout, interval).Should(Succeed())
+
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				setup := th.GetConfigMap(cm).Data["setup.sh"]
+				g.Expect(setup).Should(ContainSubstring("--db-${DB_OPT}-election-timer=5000"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with a NetworkPolicy", func() {
+		var OVNDBClusterName types.NamespacedName
+		var npName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.NetworkPolicy = ovnv1.OVNDBClusterNetworkPolicy{
+				Enabled:         true,
+				AllowedCIDRs:    []string{"172.17.0.0/24"},
+				AllowedServices: []string{"neutron"},
+			}
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+			npName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			th.SimulateStatefulSetReplicaReadyWithPods(npName, map[string][]string{})
+		})
+
+		It("should restrict the DB and RAFT ports", func() {
+			Eventually(func(g Gomega) {
+				np := &networkingv1.NetworkPolicy{}
+				g.Expect(k8sClient.Get(ctx, npName, np)).Should(Succeed())
+				g.Expect(np.Spec.PodSelector.MatchLabels).To(HaveKeyWithValue("service", "ovsdbserver-nb"))
+				g.Expect(np.Spec.Ingress).To(HaveLen(2))
+
+				dbRule := np.Spec.Ingress[0]
+				g.Expect(dbRule.Ports[0].Port.IntValue()).To(Equal(6641))
+				g.Expect(dbRule.From).To(HaveLen(2))
+				g.Expect(dbRule.From[0].PodSelector.MatchExpressions[0].Values).To(ConsistOf(
+					"ovn-northd", "ovn-ic", "ovn-controller", "neutron"))
+				g.Expect(dbRule.From[1].IPBlock.CIDR).To(Equal("172.17.0.0/24"))
+
+				raftRule := np.Spec.Ingress[1]
+				g.Expect(raftRule.Ports[0].Port.IntValue()).To(Equal(6643))
+				g.Expect(raftRule.From[0].PodSelector.MatchLabels).To(HaveKeyWithValue("service", "ovsdbserver-nb"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should remove the NetworkPolicy when disabled", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, npName, &networkingv1.NetworkPolicy{})).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				cluster := GetOVNDBCluster(OVNDBClusterName)
+				cluster.Spec.NetworkPolicy.Enabled = false
+				g.Expect(k8sClient.Update(ctx, cluster)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, npName, &networkingv1.NetworkPolicy{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with a Services override", func() {
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Override.Services = &service.OverrideSpec{
+				EmbeddedLabelsAnnotations: &service.EmbeddedLabelsAnnotations{
+					Annotations: map[string]string{"metallb.universe.tf/address-pool": "internalapi"},
+					Labels:      map[string]string{"exposed": "true"},
+				},
+				Spec: &service.OverrideServiceSpec{
+					Type:                  corev1.ServiceTypeLoadBalancer,
+					ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+				},
+			}
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+			th.SimulateStatefulSetReplicaReadyWithPods(
+				types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb"},
+				map[string][]string{},
+			)
+		})
+
+		It("should override the Services of the members only", func() {
+			Eventually(func(g Gomega) {
+				svc := &corev1.Service{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb-0"}, svc)).Should(Succeed())
+				g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+				g.Expect(svc.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeLocal))
+				g.Expect(svc.Annotations).To(HaveKeyWithValue("metallb.universe.tf/address-pool", "internalapi"))
+				g.Expect(svc.Labels).To(HaveKeyWithValue("exposed", "true"))
+				g.Expect(svc.Labels).To(HaveKeyWithValue("type", "cluster"))
+				g.Expect(svc.Spec.Ports).To(HaveLen(2))
+
+				headless := &corev1.Service{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb"}, headless)).Should(Succeed())
+				g.Expect(headless.Spec.ClusterIP).To(Equal("None"))
+				g.Expect(headless.Labels).NotTo(HaveKey("exposed"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster pods become ready", func() {
+		var OVNDBClusterName types.NamespacedName
+
+		BeforeEach(func() {
+			instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("reports the ready RAFT cluster members", func() {
+			th.ExpectConditionWithDetails(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.OVNDBClusterReadyCondition(ovnv1.NBDBType),
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				"0/1 RAFT cluster members ready, waiting for a quorum",
+			)
+
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			th.SimulateStatefulSetReplicaReadyWithPods(statefulSetName, map[string][]string{})
+
+			th.ExpectConditionWithDetails(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.OVNDBClusterReadyCondition(ovnv1.NBDBType),
+				corev1.ConditionTrue,
+				condition.ReadyReason,
+				"1/1 RAFT cluster members ready",
+			)
+		})
+	})
+
+	When("OVNDBCluster pods fail the DB integrity check", func() {
+		var OVNDBClusterName types.NamespacedName
+
+		BeforeEach(func() {
+			instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("reports DBIntegrityReady", func() {
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			th.SimulateStatefulSetReplicaReadyWithPods(statefulSetName, map[string][]string{})
+
+			th.ExpectCondition(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.OVNDBClusterDBIntegrityReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("reports the corrupted pods", func() {
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			th.SimulateStatefulSetReplicaReadyWithPods(statefulSetName, map[string][]string{})
+
+			pod := GetPod(types.NamespacedName{Name: "ovsdbserver-nb-0", Namespace: namespace})
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name: "ovsdbserver-nb",
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  "OVSDB integrity check failed: /etc/ovn/ovnnb_db.db",
+						},
+					},
+				},
+			}
+			// the termination of the container alone triggers a reconcile
+			Expect(k8sClient.Status().Update(ctx, pod)).Should(Succeed())
+
+			th.ExpectConditionWithDetails(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.OVNDBClusterDBIntegrityReadyCondition,
+				corev1.ConditionFalse,
+				ovnv1.DBCorruptedReason,
+				"DB integrity check failed on pods: ovsdbserver-nb-0",
+			)
+		})
+	})
+
+	When("OVNDBCluster is created with consistency checks", func() {
+		var OVNDBClusterName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.ConsistencyCheckInterval = 3600
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("reports ConsistencyReady until the checks get disabled", func() {
+			th.ExpectConditionWithDetails(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.OVNDBClusterConsistencyReadyCondition,
+				corev1.ConditionUnknown,
+				condition.InitReason,
+				ovnv1.OVNDBClusterConsistencyReadyInitMessage,
+			)
+
+			Eventually(func(g Gomega) {
+				c := GetOVNDBCluster(OVNDBClusterName)
+				c.Spec.ConsistencyCheckInterval = 0
+				g.Expect(k8sClient.Update(ctx, c)).Should(Succeed())
+			}).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				c := GetOVNDBCluster(OVNDBClusterName)
+				g.Expect(c.Status.Conditions.Has(ovnv1.OVNDBClusterConsistencyReadyCondition)).To(BeFalse())
+				g.Expect(c.Status.ConsistencyCheck).To(BeNil())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is upgraded to a new image", func() {
+		var OVNDBClusterName types.NamespacedName
+		var statefulSetName types.NamespacedName
+		var jobName types.NamespacedName
+		var oldImage string
+		newImage := "quay.io/test/ovn-nb-db-server:new"
+
+		BeforeEach(func() {
+			instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+			statefulSetName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			jobName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      instance.GetName() + "-schema-upgrade",
+			}
+
+			th.SimulateStatefulSetReplicaReadyWithPods(statefulSetName, map[string][]string{})
+			SimulateStatefulSetRolledOut(statefulSetName)
+			Eventually(func(g Gomega) {
+				c := GetOVNDBCluster(OVNDBClusterName)
+				g.Expect(c.Status.ContainerImage).Should(Equal(c.Spec.ContainerImage))
+				g.Expect(c.Status.InternalDBAddress).ShouldNot(BeEmpty())
+				oldImage = c.Status.ContainerImage
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				c := GetOVNDBCluster(OVNDBClusterName)
+				c.Spec.ContainerImage = newImage
+				g.Expect(k8sClient.Update(ctx, c)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rolls out the new image once the schema upgrade Job succeeded", func() {
+			th.ExpectConditionWithDetails(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.OVNDBClusterSchemaUpgradeReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf("Validating the DB schema upgrade to %s", newImage),
+			)
+			Expect(th.GetJob(jobName).Spec.Template.Spec.Containers[0].Image).Should(Equal(newImage))
+			Expect(th.GetStatefulSet(statefulSetName).Spec.Template.Spec.Containers[0].Image).Should(Equal(oldImage))
+
+			th.SimulateJobSuccess(jobName)
+
+			Eventually(func(g Gomega) {
+				ss := th.GetStatefulSet(statefulSetName)
+				g.Expect(ss.Spec.Template.Spec.Containers[0].Image).Should(Equal(newImage))
+				schemaUpgrade := GetOVNDBCluster(OVNDBClusterName).Status.SchemaUpgrade
+				g.Expect(schemaUpgrade).ShouldNot(BeNil())
+				g.Expect(schemaUpgrade.ContainerImage).Should(Equal(newImage))
+				g.Expect(schemaUpgrade.Succeeded).Should(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("aborts the rollout when the schema conversion fails", func() {
+			th.SimulateJobFailure(jobName)
+
+			th.ExpectCondition(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.OVNDBClusterSchemaUpgradeReadyCondition,
+				corev1.ConditionFalse,
+			)
+			Eventually(func(g Gomega) {
+				schemaUpgrade := GetOVNDBCluster(OVNDBClusterName).Status.SchemaUpgrade
+				g.Expect(schemaUpgrade).ShouldNot(BeNil())
+				g.Expect(schemaUpgrade.Succeeded).Should(BeFalse())
+			}, timeout, interval).Should(Succeed())
+			Expect(th.GetStatefulSet(statefulSetName).Spec.Template.Spec.Containers[0].Image).Should(Equal(oldImage))
+		})
 	})
 
 	When("OVNDBClusters are created with networkAttachments", func() {
@@ -440,13 +1118,10 @@ var _ = Describe("OVNDBCluster controller", func() {
 			th.ExpectConditionWithDetails(
 				OVNDBClusterName,
 				ConditionGetterFunc(OVNDBClusterConditionGetter),
-				condition.TLSInputReadyCondition,
+				condition.InputReadyCondition,
 				corev1.ConditionFalse,
-				condition.ErrorReason,
-				fmt.Sprintf(
-					"TLSInput error occured in TLS sources Secret %s/combined-ca-bundle not found",
-					namespace,
-				),
+				ovnv1.InputMissingReason,
+				"Waiting for the referenced objects to be created: Secret combined-ca-bundle",
 			)
 			th.ExpectCondition(
 				OVNDBClusterName,
@@ -464,13 +1139,10 @@ var _ = Describe("OVNDBCluster controller", func() {
 			th.ExpectConditionWithDetails(
 				OVNDBClusterName,
 				ConditionGetterFunc(OVNDBClusterConditionGetter),
-				condition.TLSInputReadyCondition,
+				condition.InputReadyCondition,
 				corev1.ConditionFalse,
-				condition.ErrorReason,
-				fmt.Sprintf(
-					"TLSInput error occured in TLS sources Secret %s/%s not found",
-					namespace, OvnDbCertSecretName,
-				),
+				ovnv1.InputMissingReason,
+				"Waiting for the referenced objects to be created: Secret "+OvnDbCertSecretName,
 			)
 			th.ExpectCondition(
 				OVNDBClusterName,
@@ -508,9 +1180,7 @@ var _ = Describe("OVNDBCluster controller", func() {
 
 			// check TLS volume mounts
 			th.AssertVolumeMountExists(CABundleSecretName, "tls-ca-bundle.pem", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovsdbserver-sb-tls-certs", "tls.key", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovsdbserver-sb-tls-certs", "tls.crt", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovsdbserver-sb-tls-certs", "ca.crt", svcC.VolumeMounts)
+			th.AssertVolumeMountExists("ovsdbserver-sb-tls-certs", "", svcC.VolumeMounts)
 
 			// check DB url schema
 			Eventually(func(g Gomega) {
@@ -591,7 +1261,7 @@ var _ = Describe("OVNDBCluster controller", func() {
 			}, timeout, interval).Should(Succeed())
 		})
 
-		It("reconfigures the pods when cert changes", func() {
+		It("does not restart the pods when cert changes", func() {
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
 				Name:      CABundleSecretName,
 				Namespace: namespace,
@@ -625,17 +1295,157 @@ var _ = Describe("OVNDBCluster controller", func() {
 				[]byte("DifferentCrtData"),
 			)
 
-			// Assert that the pod is updated
-			Eventually(func(g Gomega) {
+			// The cert is reloaded in place, the pods are not updated
+			Consistently(func(g Gomega) {
 				newHash := GetEnvVarValue(
 					th.GetStatefulSet(statefulSetName).Spec.Template.Spec.Containers[0].Env,
 					"CONFIG_HASH",
 					"",
 				)
 				g.Expect(newHash).NotTo(BeEmpty())
-				g.Expect(newHash).NotTo(Equal(originalHash))
+				g.Expect(newHash).To(Equal(originalHash))
+			}, consistencyTimeout, interval).Should(Succeed())
+		})
+
+	})
+
+	When("OVNDBCluster is created with FIPS", func() {
+		It("rejects FIPS without TLS", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.FIPS = true
+			instance := &ovnv1.OVNDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovndbcluster-fips",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("FIPS mode requires TLS"))
+		})
+
+		It("restricts ovsdb-server to FIPS approved TLS settings", func() {
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
+				Name:      CABundleSecretName,
+				Namespace: namespace,
+			}))
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(types.NamespacedName{
+				Name:      OvnDbCertSecretName,
+				Namespace: namespace,
+			}))
+			spec := GetTLSOVNDBClusterSpec()
+			spec.FIPS = true
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+			OVNDBClusterName := types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+
+			scriptsCM := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(th.GetConfigMap(scriptsCM).Data["setup.sh"]).Should(ContainSubstring(
+					fmt.Sprintf("${CTLCMD} set-ssl %s %s %s %s %s",
+						ovn_common.OVNDbKeyPath, ovn_common.OVNDbCertPath, ovn_common.OVNDbCaCertPath,
+						ovn_common.FIPSSSLProtocols, ovn_common.FIPSSSLCiphers)))
+			}, timeout, interval).Should(Succeed())
+			// no pod is running to be checked
+			th.ExpectCondition(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.OVNFIPSProviderReadyCondition,
+				corev1.ConditionUnknown,
+			)
+		})
+
+		It("keeps the default TLS settings of ovsdb-server without FIPS", func() {
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
+				Name:      CABundleSecretName,
+				Namespace: namespace,
+			}))
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(types.NamespacedName{
+				Name:      OvnDbCertSecretName,
+				Namespace: namespace,
+			}))
+			instance := CreateOVNDBCluster(namespace, GetTLSOVNDBClusterSpec())
+			DeferCleanup(th.DeleteInstance, instance)
+			OVNDBClusterName := types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+
+			scriptsCM := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				setup := th.GetConfigMap(scriptsCM).Data["setup.sh"]
+				g.Expect(setup).Should(ContainSubstring(fmt.Sprintf("${CTLCMD} set-ssl %s %s %s\n",
+					ovn_common.OVNDbKeyPath, ovn_common.OVNDbCertPath, ovn_common.OVNDbCaCertPath)))
+				g.Expect(setup).ShouldNot(ContainSubstring(ovn_common.FIPSSSLCiphers))
+			}, timeout, interval).Should(Succeed())
+			Expect(GetOVNDBCluster(OVNDBClusterName).Status.Conditions.Has(ovnv1.OVNFIPSProviderReadyCondition)).To(BeFalse())
+		})
+	})
+
+	When("OVNDBCluster is updated", func() {
+		var dbClusterName types.NamespacedName
+
+		BeforeEach(func() {
+			instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+			DeferCleanup(th.DeleteInstance, instance)
+			dbClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+		})
+
+		It("rejects changing the dbType", func() {
+			Eventually(func(g Gomega) {
+				dbCluster := GetOVNDBCluster(dbClusterName)
+				dbCluster.Spec.DBType = ovnv1.SBDBType
+				err := k8sClient.Update(ctx, dbCluster)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("dbType is immutable"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects changing the storageClass", func() {
+			Eventually(func(g Gomega) {
+				dbCluster := GetOVNDBCluster(dbClusterName)
+				dbCluster.Spec.StorageClass = "other-storage"
+				err := k8sClient.Update(ctx, dbCluster)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("storageClass is immutable"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("allows changing the replicas", func() {
+			Eventually(func(g Gomega) {
+				dbCluster := GetOVNDBCluster(dbClusterName)
+				dbCluster.Spec.Replicas = ptr.To[int32](3)
+				g.Expect(k8sClient.Update(ctx, dbCluster)).To(Succeed())
 			}, timeout, interval).Should(Succeed())
 		})
+	})
 
+	When("OVNDBCluster is created with a minimal spec", func() {
+		It("defaults the image, the replicas and the raft settings", func() {
+			name := types.NamespacedName{Namespace: namespace, Name: "ovndbcluster-minimal"}
+			raw := map[string]interface{}{
+				"apiVersion": "ovn.openstack.org/v1beta1",
+				"kind":       "OVNDBCluster",
+				"metadata": map[string]interface{}{
+					"name":      name.Name,
+					"namespace": name.Namespace,
+				},
+				"spec": map[string]interface{}{
+					"storageRequest": "1G",
+				},
+			}
+			DeferCleanup(th.DeleteInstance, th.CreateUnstructured(raw))
+
+			dbCluster := GetOVNDBCluster(name)
+			Expect(dbCluster.Spec.DBType).To(Equal(ovnv1.NBDBType))
+			Expect(dbCluster.Spec.ContainerImage).To(Equal(ovnv1.OVNNBContainerImage))
+			Expect(*dbCluster.Spec.Replicas).To(Equal(int32(1)))
+			Expect(dbCluster.Spec.ElectionTimer).To(Equal(int32(10000)))
+			Expect(dbCluster.Spec.StorageRetention).To(Equal(ovnv1.StorageRetentionDelete))
+		})
 	})
 })
@@ -0,0 +1,161 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("OVNDiagnostics controller", func() {
+
+	When("A OVNDiagnostics instance is created", func() {
+		var diagnosticsName types.NamespacedName
+		var nbJobName types.NamespacedName
+		var sbJobName types.NamespacedName
+		var archiveJobName types.NamespacedName
+
+		BeforeEach(func() {
+			diagnosticsName = ovn.CreateOVNDiagnostics(namespace, GetDefaultOVNDiagnosticsSpec())
+			DeferCleanup(ovn.DeleteOVNDiagnostics, diagnosticsName)
+			nbJobName = types.NamespacedName{Namespace: namespace, Name: diagnosticsName.Name + "-nb"}
+			sbJobName = types.NamespacedName{Namespace: namespace, Name: diagnosticsName.Name + "-sb"}
+			archiveJobName = types.NamespacedName{Namespace: namespace, Name: diagnosticsName.Name + "-archive"}
+		})
+
+		It("should have the Spec fields initialized", func() {
+			Expect(GetOVNDiagnostics(diagnosticsName).Spec.StorageRequest).Should(Equal("1G"))
+		})
+
+		It("waits for the DBs", func() {
+			th.ExpectCondition(
+				diagnosticsName,
+				ConditionGetterFunc(OVNDiagnosticsConditionGetter),
+				condition.InputReadyCondition,
+				corev1.ConditionFalse,
+			)
+		})
+
+		When("OVNDBCluster instances are available", func() {
+			BeforeEach(func() {
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+			})
+
+			It("collects the DBs in the PVC", func() {
+				pvcName := types.NamespacedName{Namespace: namespace, Name: diagnosticsName.Name + "-diagnostics"}
+				Eventually(func(g Gomega) {
+					pvc := &corev1.PersistentVolumeClaim{}
+					g.Expect(k8sClient.Get(ctx, pvcName, pvc)).Should(Succeed())
+					g.Expect(*pvc.Spec.StorageClassName).Should(Equal("local-storage"))
+				}, timeout, interval).Should(Succeed())
+
+				container := th.GetJob(nbJobName).Spec.Template.Spec.Containers[0]
+				Expect(container.Env).Should(ContainElements(
+					corev1.EnvVar{Name: "DB_REMOTE", Value: "tcp:ovsdbserver-nb-0." + namespace + ".svc.cluster.local:6641"},
+					corev1.EnvVar{Name: "DB_NAME", Value: "OVN_Northbound"},
+				))
+				th.SimulateJobSuccess(nbJobName)
+
+				container = th.GetJob(sbJobName).Spec.Template.Spec.Containers[0]
+				Expect(container.Env).Should(ContainElement(
+					corev1.EnvVar{Name: "DB_REMOTE", Value: "tcp:ovsdbserver-sb-0." + namespace + ".svc.cluster.local:6642"},
+				))
+				th.SimulateJobSuccess(sbJobName)
+
+				th.ExpectConditionWithDetails(
+					diagnosticsName,
+					ConditionGetterFunc(OVNDiagnosticsConditionGetter),
+					ovnv1.OVNDiagnosticsReadyCondition,
+					corev1.ConditionFalse,
+					condition.RequestedReason,
+					"Collecting the diagnostics of the archive",
+				)
+				cm := th.GetConfigMap(types.NamespacedName{Namespace: namespace, Name: diagnosticsName.Name + "-operator"})
+				Expect(cm.Data).Should(HaveKey("ovndbclusters.yaml"))
+				Expect(cm.Data).Should(HaveKey("events.yaml"))
+				Expect(cm.Data["operator.log"]).Should(ContainSubstring("the operator pod is unknown"))
+				th.SimulateJobSuccess(archiveJobName)
+
+				th.ExpectCondition(
+					diagnosticsName,
+					ConditionGetterFunc(OVNDiagnosticsConditionGetter),
+					condition.ReadyCondition,
+					corev1.ConditionTrue,
+				)
+				diagnostics := GetOVNDiagnostics(diagnosticsName)
+				Expect(diagnostics.Status.Archive).Should(Equal(diagnosticsName.Name + ".tar.gz"))
+				Expect(diagnostics.Status.PersistentVolumeClaim).Should(Equal(pvcName.Name))
+			})
+
+			It("reports the error of a Job", func() {
+				SimulateTracePodTerminated(nbJobName, "ovsdb-client: tcp:ovsdbserver-nb-0:6641: Connection refused")
+				th.SimulateJobFailure(nbJobName)
+
+				Eventually(func(g Gomega) {
+					conditions := OVNDiagnosticsConditionGetter(diagnosticsName)
+					cond := conditions.Get(ovnv1.OVNDiagnosticsReadyCondition)
+					g.Expect(cond).ShouldNot(BeNil())
+					g.Expect(cond.Reason).Should(Equal(condition.ErrorReason))
+					g.Expect(cond.Message).Should(HaveSuffix(
+						"failed: ovsdb-client: tcp:ovsdbserver-nb-0:6641: Connection refused"))
+				}, timeout, interval).Should(Succeed())
+			})
+		})
+	})
+
+	When("A OVNDiagnostics instance is created for nodes", func() {
+		var diagnosticsName types.NamespacedName
+
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			ovnController := CreateOVNController(namespace, GetDefaultOVNControllerSpec())
+			DeferCleanup(th.DeleteInstance, ovnController)
+
+			spec := GetDefaultOVNDiagnosticsSpec()
+			spec.Nodes = []string{"worker-0"}
+			diagnosticsName = ovn.CreateOVNDiagnostics(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNDiagnostics, diagnosticsName)
+		})
+
+		It("collects the nodes first", func() {
+			th.ExpectConditionWithDetails(
+				diagnosticsName,
+				ConditionGetterFunc(OVNDiagnosticsConditionGetter),
+				ovnv1.OVNDiagnosticsReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				"Collecting the diagnostics of node worker-0",
+			)
+			podSpec := th.GetJob(types.NamespacedName{Namespace: namespace, Name: diagnosticsName.Name + "-node-0"}).Spec.Template.Spec
+			Expect(podSpec.NodeName).Should(Equal("worker-0"))
+			Expect(podSpec.Containers[0].Env).Should(ContainElements(
+				corev1.EnvVar{Name: "NODE", Value: "worker-0"},
+				corev1.EnvVar{Name: "OVS_BRIDGE", Value: "br-int"},
+			))
+		})
+	})
+})
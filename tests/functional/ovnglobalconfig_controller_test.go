@@ -0,0 +1,130 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("OVNGlobalConfig controller", func() {
+
+	When("A OVNGlobalConfig instance is created", func() {
+		var ovnGlobalConfigName types.NamespacedName
+
+		BeforeEach(func() {
+			ovnGlobalConfigName = ovn.CreateOVNGlobalConfig(namespace, ovnv1.OVNGlobalConfigSpec{
+				NBGlobalOptions: map[string]string{
+					"ignore_lsp_down": "true",
+					"debug_drop_mode": "false",
+				},
+			})
+			DeferCleanup(ovn.DeleteOVNGlobalConfig, ovnGlobalConfigName)
+		})
+
+		It("waits for the NB DB", func() {
+			th.ExpectCondition(
+				ovnGlobalConfigName,
+				ConditionGetterFunc(OVNGlobalConfigConditionGetter),
+				condition.InputReadyCondition,
+				corev1.ConditionFalse,
+			)
+		})
+
+		It("rejects the options set from the OVNNorthd", func() {
+			Eventually(func(g Gomega) {
+				instance := GetOVNGlobalConfig(ovnGlobalConfigName)
+				instance.Spec.NBGlobalOptions["northd_probe_interval"] = "60000"
+				err := k8sClient.Update(ctx, instance)
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(ContainSubstring("use its spec.probeInterval instead"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects the values with quotes", func() {
+			Eventually(func(g Gomega) {
+				instance := GetOVNGlobalConfig(ovnGlobalConfigName)
+				instance.Spec.NBGlobalOptions["ignore_lsp_down"] = `"true"`
+				err := k8sClient.Update(ctx, instance)
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(ContainSubstring("spec.nbGlobalOptions[ignore_lsp_down]"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		When("OVNDBCluster instances are available", func() {
+			BeforeEach(func() {
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+			})
+
+			It("waits for a running NB DB pod", func() {
+				th.ExpectCondition(
+					ovnGlobalConfigName,
+					ConditionGetterFunc(OVNGlobalConfigConditionGetter),
+					condition.InputReadyCondition,
+					corev1.ConditionTrue,
+				)
+				th.ExpectConditionWithDetails(
+					ovnGlobalConfigName,
+					ConditionGetterFunc(OVNGlobalConfigConditionGetter),
+					ovnv1.OVNGlobalConfigReadyCondition,
+					corev1.ConditionFalse,
+					condition.RequestedReason,
+					"Waiting for a running NB DB pod: no running NB DB pod to query",
+				)
+			})
+		})
+	})
+
+	When("A second OVNGlobalConfig instance is created in the namespace", func() {
+		var firstName types.NamespacedName
+		var secondName types.NamespacedName
+
+		BeforeEach(func() {
+			firstName = ovn.CreateOVNGlobalConfig(namespace, ovnv1.OVNGlobalConfigSpec{})
+			DeferCleanup(ovn.DeleteOVNGlobalConfig, firstName)
+			// the creation timestamps have a second resolution
+			Eventually(func(g Gomega) {
+				first := GetOVNGlobalConfig(firstName)
+				g.Expect(time.Since(first.CreationTimestamp.Time)).To(BeNumerically(">", time.Second))
+			}, timeout, interval).Should(Succeed())
+			secondName = ovn.CreateOVNGlobalConfig(namespace, ovnv1.OVNGlobalConfigSpec{})
+			DeferCleanup(ovn.DeleteOVNGlobalConfig, secondName)
+		})
+
+		It("leaves NB_Global to the oldest one", func() {
+			th.ExpectConditionWithDetails(
+				secondName,
+				ConditionGetterFunc(OVNGlobalConfigConditionGetter),
+				ovnv1.OVNGlobalConfigReadyCondition,
+				corev1.ConditionFalse,
+				condition.ErrorReason,
+				"The NB_Global options of the namespace are managed by OVNGlobalConfig "+firstName.Name,
+			)
+		})
+	})
+})
@@ -0,0 +1,108 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("OVNInterconnect controller", func() {
+
+	When("A OVNInterconnect instance is created", func() {
+		var ovnInterconnectName types.NamespacedName
+		BeforeEach(func() {
+			ovnInterconnectName = ovn.CreateOVNInterconnect(namespace, GetDefaultOVNInterconnectSpec())
+			DeferCleanup(ovn.DeleteOVNInterconnect, ovnInterconnectName)
+		})
+
+		It("should have the Spec fields initialized", func() {
+			OVNInterconnect := GetOVNInterconnect(ovnInterconnectName)
+			Expect(*(OVNInterconnect.Spec.Replicas)).Should(Equal(int32(1)))
+			Expect(OVNInterconnect.Spec.ContainerImage).ShouldNot(BeEmpty())
+		})
+
+		It("should have a finalizer", func() {
+			Eventually(func() []string {
+				return GetOVNInterconnect(ovnInterconnectName).Finalizers
+			}, timeout, interval).Should(ContainElement("openstack.org/ovninterconnect"))
+		})
+
+		It("should not create a Deployment without the interconnect databases", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+
+			th.ExpectCondition(
+				ovnInterconnectName,
+				ConditionGetterFunc(OVNInterconnectConditionGetter),
+				condition.DeploymentReadyCondition,
+				corev1.ConditionUnknown,
+			)
+			th.AssertDeploymentDoesNotExist(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-ic",
+			})
+		})
+
+		When("OVNDBCluster instances are available", func() {
+			BeforeEach(func() {
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+				icDBs := CreateOVNICDBClusters(namespace, 1)
+				DeferCleanup(DeleteOVNDBClusters, icDBs)
+			})
+
+			It("should create a Deployment connected to the local and interconnect databases", func() {
+				deplName := types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-ic",
+				}
+
+				container := th.GetDeployment(deplName).Spec.Template.Spec.Containers[0]
+				Expect(container.Command).To(Equal([]string{"/bin/bash", "-c"}))
+				Expect(container.Args[0]).To(And(
+					ContainSubstring("set NB_Global . name=az1"),
+					ContainSubstring("--ovnnb-db=tcp:ovsdbserver-nb-0."+namespace+".svc.cluster.local:6641"),
+					ContainSubstring("--ovnsb-db=tcp:ovsdbserver-sb-0."+namespace+".svc.cluster.local:6642"),
+					ContainSubstring("--ic-nb-db=tcp:ovsdbserver-ic-nb-0."+namespace+".svc.cluster.local:6645"),
+					ContainSubstring("--ic-sb-db=tcp:ovsdbserver-ic-sb-0."+namespace+".svc.cluster.local:6646"),
+				))
+			})
+
+			It("should be ready when the Deployment is ready", func() {
+				th.SimulateDeploymentReplicaReady(types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-ic",
+				})
+
+				th.ExpectCondition(
+					ovnInterconnectName,
+					ConditionGetterFunc(OVNInterconnectConditionGetter),
+					condition.ReadyCondition,
+					corev1.ConditionTrue,
+				)
+			})
+		})
+	})
+})
@@ -18,6 +18,8 @@ package functional_test
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
@@ -26,8 +28,13 @@ import (
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
 
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("OVNNorthd controller", func() {
@@ -47,6 +54,10 @@ var _ = Describe("OVNNorthd controller", func() {
 		It("should have the Status fields initialized", func() {
 			OVNNorthd := ovn.GetOVNNorthd(ovnNorthdName)
 			Expect(OVNNorthd.Status.ReadyCount).To(Equal(int32(0)))
+			Expect(OVNNorthd.Status.ActiveInstance).To(BeEmpty())
+			Expect(OVNNorthd.Status.Replicas).To(BeEmpty())
+			Expect(OVNNorthd.Status.NBCfg).To(Equal(int64(0)))
+			Expect(OVNNorthd.Status.HVCfg).To(Equal(int64(0)))
 		})
 
 		It("should have a finalizer", func() {
@@ -81,11 +92,43 @@ var _ = Describe("OVNNorthd controller", func() {
 				Expect(depl.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{
 					"-vfile:off",
 					"-vconsole:info",
+					"--pidfile",
 					fmt.Sprintf("--n-threads=%d", *OVNNorthd.Spec.NThreads),
 					"--ovnnb-db=tcp:ovsdbserver-nb-0." + namespace + ".svc.cluster.local:6641",
 					"--ovnsb-db=tcp:ovsdbserver-sb-0." + namespace + ".svc.cluster.local:6642",
 				}))
 			})
+
+			It("should probe ovn-northd through its control socket", func() {
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+
+				deplName := types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-northd",
+				}
+
+				container := th.GetDeployment(deplName).Spec.Template.Spec.Containers[0]
+				Expect(container.LivenessProbe.Exec.Command).To(Equal([]string{
+					"/usr/bin/ovn-appctl", "-t", "ovn-northd", "status",
+				}))
+				Expect(container.ReadinessProbe.Exec.Command[2]).To(
+					ContainSubstring("sb-connection-status | grep -q ^connected"))
+			})
+
+			It("should render the Deployment for the restricted pod security profile", func() {
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+
+				podSpec := th.GetDeployment(types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-northd",
+				}).Spec.Template.Spec
+				Expect(podSpec.SecurityContext.SeccompProfile.Type).To(
+					Equal(corev1.SeccompProfileTypeRuntimeDefault))
+				Expect(*podSpec.Containers[0].SecurityContext.RunAsNonRoot).To(BeTrue())
+				Expect(*podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+			})
 		})
 
 	})
@@ -129,6 +172,634 @@ var _ = Describe("OVNNorthd controller", func() {
 		})
 	})
 
+	When("A OVNNorthd instance is created with multiple threads", func() {
+		var ovnNorthdName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			nThreads := int32(4)
+			spec.NThreads = &nThreads
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("should pass the thread count to ovn-northd", func() {
+			deplName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}
+
+			depl := th.GetDeployment(deplName)
+			Expect(depl.Spec.Template.Spec.Containers[0].Args).Should(ContainElement("--n-threads=4"))
+		})
+	})
+
+	When("A OVNNorthd instance is created with extra args", func() {
+		var ovnNorthdName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.ExtraArgs = map[string]string{"--unixctl": "/tmp/ovn-northd.ctl", "-vjsonrpc:dbg": ""}
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("should append them to the ovn-northd args", func() {
+			depl := th.GetDeployment(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			})
+			args := depl.Spec.Template.Spec.Containers[0].Args
+			Expect(args[len(args)-2:]).To(Equal([]string{"--unixctl=/tmp/ovn-northd.ctl", "-vjsonrpc:dbg"}))
+		})
+	})
+
+	When("A OVNNorthd instance is created with sidecars", func() {
+		var ovnNorthdName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Sidecars = ovnv1.SidecarSection{
+				Containers: []ovnv1.Sidecar{
+					{
+						Name:  "log-shipper",
+						Image: "quay.io/example/log-shipper:latest",
+						VolumeMounts: []ovnv1.SidecarVolumeMount{
+							{Name: "config", MountPath: "/etc/log-shipper", ReadOnly: true},
+						},
+					},
+				},
+				ExtraVolumes: []ovnv1.ExtraVolume{
+					{
+						Name:      "config",
+						ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "log-shipper"}},
+					},
+				},
+			}
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("should run the sidecars with their extra volumes only", func() {
+			podSpec := th.GetDeployment(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}).Spec.Template.Spec
+			Expect(podSpec.Containers).To(HaveLen(2))
+			Expect(podSpec.Containers[0].Name).To(Equal("ovn-northd"))
+
+			sidecar := podSpec.Containers[1]
+			Expect(sidecar.Name).To(Equal("log-shipper"))
+			Expect(sidecar.Image).To(Equal("quay.io/example/log-shipper:latest"))
+			Expect(sidecar.VolumeMounts).To(Equal([]corev1.VolumeMount{
+				{Name: "extra-config", MountPath: "/etc/log-shipper", ReadOnly: true},
+			}))
+			Expect(*sidecar.SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+			Expect(podSpec.Volumes).To(ContainElement(HaveField("Name", "extra-config")))
+		})
+	})
+
+	When("OVNNorthd is created with invalid sidecars", func() {
+		It("rejects the mounts of undeclared volumes", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Sidecars.Containers = []ovnv1.Sidecar{
+				{
+					Name:  "agent",
+					Image: "quay.io/example/agent:latest",
+					VolumeMounts: []ovnv1.SidecarVolumeMount{
+						{Name: "ovn-northd-tls-certs", MountPath: "/certs"},
+					},
+				},
+			}
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-sidecars",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.sidecars.containers[0].volumeMounts[0].name: Not found"))
+		})
+
+		It("rejects a sidecar named after ovn-northd", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Sidecars.Containers = []ovnv1.Sidecar{
+				{Name: "ovn-northd", Image: "quay.io/example/agent:latest"},
+			}
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-sidecars",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.sidecars.containers[0].name: Duplicate value"))
+		})
+	})
+
+	When("A OVNNorthd instance is created with debug options", func() {
+		var ovnNorthdName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.LogLevel = "dbg"
+			spec.LogModules = []string{"northd:dbg", "jsonrpc:info"}
+			spec.DryRun = true
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("should pass the debug options to ovn-northd", func() {
+			deplName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}
+
+			depl := th.GetDeployment(deplName)
+			Expect(depl.Spec.Template.Spec.Containers[0].Args).Should(ContainElements(
+				"-vconsole:dbg",
+				"-vnorthd:dbg",
+				"-vjsonrpc:info",
+				"--dry-run",
+			))
+		})
+	})
+
+	When("A OVNNorthd instance is created with data retention", func() {
+		var ovnNorthdName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.DataRetention = ovnv1.OVNNorthdDataRetention{
+				MACBindingAgeThreshold: ptr.To(int32(300)),
+				FDBAgeThreshold:        ptr.To(int32(0)),
+			}
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("should keep the aging in the spec", func() {
+			OVNNorthd := GetOVNNorthd(ovnNorthdName)
+			Expect(*OVNNorthd.Spec.DataRetention.MACBindingAgeThreshold).To(Equal(int32(300)))
+			Expect(*OVNNorthd.Spec.DataRetention.FDBAgeThreshold).To(Equal(int32(0)))
+			Expect(OVNNorthd.Spec.DataRetention.MACBindingRemovalLimit).To(BeNil())
+		})
+
+		It("rejects negative thresholds", func() {
+			Eventually(func(g Gomega) {
+				OVNNorthd := GetOVNNorthd(ovnNorthdName)
+				OVNNorthd.Spec.DataRetention.FDBAgeThreshold = ptr.To(int32(-1))
+				err := k8sClient.Update(ctx, OVNNorthd)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("spec.dataRetention.fdbAgeThreshold"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("A OVNNorthd instance is created with connection tuning", func() {
+		var ovnNorthdName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.ProbeInterval = ptr.To(int32(60000))
+			spec.BackoffInterval = ptr.To(int32(200))
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("should keep the tuning in the spec", func() {
+			OVNNorthd := GetOVNNorthd(ovnNorthdName)
+			Expect(*OVNNorthd.Spec.ProbeInterval).To(Equal(int32(60000)))
+			Expect(*OVNNorthd.Spec.BackoffInterval).To(Equal(int32(200)))
+		})
+
+		It("should be in ready condition", func() {
+			th.SimulateDeploymentReplicaReady(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			})
+
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+	})
+
+	When("A OVNNorthd instance is created with multiple replicas", func() {
+		var ovnNorthdName types.NamespacedName
+		var pdbName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			replicas := int32(3)
+			spec.Replicas = &replicas
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+			pdbName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}
+		})
+
+		It("should create a PodDisruptionBudget", func() {
+			th.SimulateDeploymentReplicaReady(pdbName)
+
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).Should(Succeed())
+				g.Expect(pdb.Spec.MinAvailable.IntValue()).To(Equal(1))
+				g.Expect(pdb.Spec.Selector.MatchLabels).To(HaveKeyWithValue("service", "ovn-northd"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should remove the PodDisruptionBudget when scaled down", func() {
+			th.SimulateDeploymentReplicaReady(pdbName)
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, pdbName, &policyv1.PodDisruptionBudget{})).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ovnNorthd := GetOVNNorthd(ovnNorthdName)
+				replicas := int32(1)
+				ovnNorthd.Spec.Replicas = &replicas
+				g.Expect(k8sClient.Update(ctx, ovnNorthd)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, pdbName, &policyv1.PodDisruptionBudget{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("A OVNNorthd instance is paused", func() {
+		var ovnNorthdName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Paused = true
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("should report the paused condition", func() {
+			th.SimulateDeploymentReplicaReady(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			})
+
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.OVNNorthdPausedCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("should remove the paused condition when resumed", func() {
+			th.SimulateDeploymentReplicaReady(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			})
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.OVNNorthdPausedCondition,
+				corev1.ConditionTrue,
+			)
+
+			Eventually(func(g Gomega) {
+				ovnNorthd := GetOVNNorthd(ovnNorthdName)
+				ovnNorthd.Spec.Paused = false
+				g.Expect(k8sClient.Update(ctx, ovnNorthd)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ovnNorthd := GetOVNNorthd(ovnNorthdName)
+				g.Expect(ovnNorthd.Status.Conditions.Has(ovnv1.OVNNorthdPausedCondition)).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("A OVNNorthd instance is suspended", func() {
+		var ovnNorthdName types.NamespacedName
+		var deploymentName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, GetDefaultOVNNorthdSpec())
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+			deploymentName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}
+			th.GetDeployment(deploymentName)
+
+			Eventually(func(g Gomega) {
+				ovnNorthd := GetOVNNorthd(ovnNorthdName)
+				ovnNorthd.Spec.Suspend = true
+				ovnNorthd.Spec.Replicas = ptr.To[int32](3)
+				g.Expect(k8sClient.Update(ctx, ovnNorthd)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("does not modify the Deployment", func() {
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.OVNSuspendedCondition,
+				corev1.ConditionTrue,
+			)
+			Consistently(func(g Gomega) {
+				g.Expect(*th.GetDeployment(deploymentName).Spec.Replicas).To(Equal(int32(1)))
+			}, "3s", interval).Should(Succeed())
+		})
+
+		It("reconciles the Deployment once resumed", func() {
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.OVNSuspendedCondition,
+				corev1.ConditionTrue,
+			)
+			Eventually(func(g Gomega) {
+				ovnNorthd := GetOVNNorthd(ovnNorthdName)
+				ovnNorthd.Spec.Suspend = false
+				g.Expect(k8sClient.Update(ctx, ovnNorthd)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(*th.GetDeployment(deploymentName).Spec.Replicas).To(Equal(int32(3)))
+				g.Expect(GetOVNNorthd(ovnNorthdName).Status.Conditions.Has(ovnv1.OVNSuspendedCondition)).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("A OVNNorthd instance is upgraded", func() {
+		var ovnNorthdName types.NamespacedName
+		var deploymentName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, GetDefaultOVNNorthdSpec())
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+			deploymentName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}
+		})
+
+		It("keeps the deployed image until the OVN databases are upgraded", func() {
+			SimulateDeploymentRolledOut(deploymentName)
+			var oldImage string
+			Eventually(func(g Gomega) {
+				ovnNorthd := GetOVNNorthd(ovnNorthdName)
+				g.Expect(ovnNorthd.Status.ContainerImage).To(Equal(ovnNorthd.Spec.ContainerImage))
+				oldImage = ovnNorthd.Status.ContainerImage
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ovnNorthd := GetOVNNorthd(ovnNorthdName)
+				ovnNorthd.Spec.ContainerImage = "quay.io/test/ovn-northd:new"
+				g.Expect(k8sClient.Update(ctx, ovnNorthd)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			// the OVNDBClusters are not rolled out in envtest
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.OVNUpgradeReadyCondition,
+				corev1.ConditionFalse,
+			)
+			Expect(th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[0].Image).To(Equal(oldImage))
+			Expect(GetOVNNorthd(ovnNorthdName).Status.ContainerImage).To(Equal(oldImage))
+		})
+	})
+
+	When("OVNNorthd is created with a cert-manager Issuer", func() {
+		var ovnNorthdName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.TLS.Issuer = "ovn-issuer"
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("defaults the secret the cert is issued into", func() {
+			OVNNorthd := GetOVNNorthd(ovnNorthdName)
+			Expect(OVNNorthd.Spec.TLS.Enabled()).To(BeTrue())
+			Expect(*OVNNorthd.Spec.TLS.SecretName).To(Equal("cert-" + ovnNorthdName.Name + "-ovndbs"))
+			Expect(OVNNorthd.Spec.TLS.IssuerRefKind()).To(Equal(ovnv1.IssuerKindIssuer))
+		})
+
+		It("rejects an unknown kind of issuer", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.TLS.Issuer = "ovn-issuer"
+			spec.TLS.IssuerKind = "Vault"
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-issuer-kind",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.tls.issuerKind"))
+		})
+	})
+
+	When("OVNNorthd is created with the SANs and the lifetime of the cert", func() {
+		It("rejects them without an Issuer", func() {
+			spec := GetTLSOVNNorthdSpec()
+			spec.TLS.ExtraDNSNames = []string{"ovn-northd.example.com"}
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-sans",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.tls.extraDNSNames"))
+		})
+
+		It("rejects invalid IP addresses and a renewal longer than the lifetime", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.TLS.Issuer = "ovn-issuer"
+			spec.TLS.ExtraIPAddresses = []string{"192.0.2.10", "not-an-ip"}
+			spec.TLS.Duration = &metav1.Duration{Duration: 24 * time.Hour}
+			spec.TLS.RenewBefore = &metav1.Duration{Duration: 48 * time.Hour}
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-cert-lifetime",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.tls.extraIPAddresses[1]"))
+			Expect(err.Error()).To(ContainSubstring("must be shorter than tls.duration"))
+		})
+
+		It("accepts them with an Issuer", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.TLS.Issuer = "ovn-issuer"
+			spec.TLS.ExtraDNSNames = []string{"ovn-northd.example.com"}
+			spec.TLS.ExtraIPAddresses = []string{"192.0.2.10"}
+			spec.TLS.Duration = &metav1.Duration{Duration: 90 * 24 * time.Hour}
+			spec.TLS.RenewBefore = &metav1.Duration{Duration: 15 * 24 * time.Hour}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			OVNNorthd := GetOVNNorthd(ovnNorthdName)
+			Expect(OVNNorthd.Spec.TLS.ExtraIPAddresses).To(Equal([]string{"192.0.2.10"}))
+			Expect(OVNNorthd.Spec.TLS.Duration.Duration).To(Equal(90 * 24 * time.Hour))
+		})
+	})
+
+	When("OVNNorthd is created with a cert-manager ClusterIssuer", func() {
+		var ovnNorthdName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.TLS.Issuer = "ovn-cluster-issuer"
+			spec.TLS.IssuerKind = ovnv1.IssuerKindClusterIssuer
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("requests the cert from the ClusterIssuer", func() {
+			OVNNorthd := GetOVNNorthd(ovnNorthdName)
+			Expect(OVNNorthd.Spec.TLS.Enabled()).To(BeTrue())
+			Expect(OVNNorthd.Spec.TLS.IssuerRefKind()).To(Equal(ovnv1.IssuerKindClusterIssuer))
+		})
+	})
+
+	When("OVNNorthd is created with FIPS", func() {
+		It("rejects FIPS without TLS", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.FIPS = true
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-fips",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("FIPS mode requires TLS"))
+		})
+
+		It("restricts ovn-northd to FIPS approved TLS settings", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
+				Name:      CABundleSecretName,
+				Namespace: namespace,
+			}))
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(types.NamespacedName{
+				Name:      OvnDbCertSecretName,
+				Namespace: namespace,
+			}))
+			spec := GetTLSOVNNorthdSpec()
+			spec.FIPS = true
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			deploymentName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}
+			th.SimulateDeploymentReplicaReady(deploymentName)
+
+			Expect(th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+				"--ssl-protocols=TLSv1.2",
+				ContainSubstring("--ssl-ciphers=ECDHE-"),
+			))
+			// no pod is running to be checked
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.OVNFIPSProviderReadyCondition,
+				corev1.ConditionUnknown,
+			)
+		})
+	})
+
+	When("OVNNorthd without TLS connects to a TLS SB database", func() {
+		var ovnNorthdName types.NamespacedName
+
+		BeforeEach(func() {
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
+				Name:      CABundleSecretName,
+				Namespace: namespace,
+			}))
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(types.NamespacedName{
+				Name:      OvnDbCertSecretName,
+				Namespace: namespace,
+			}))
+
+			dbs := []types.NamespacedName{}
+			for _, db := range []string{ovnv1.NBDBType, ovnv1.SBDBType} {
+				spec := GetDefaultOVNDBClusterSpec()
+				if db == ovnv1.SBDBType {
+					spec = GetTLSOVNDBClusterSpec()
+				}
+				spec.DBType = db
+				instance := CreateOVNDBCluster(namespace, spec)
+				instanceName := types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+				dbs = append(dbs, instanceName)
+
+				th.SimulateStatefulSetReplicaReadyWithPods(
+					types.NamespacedName{
+						Namespace: namespace,
+						Name:      "ovsdbserver-" + strings.ToLower(db),
+					},
+					map[string][]string{},
+				)
+				Eventually(func(g Gomega) {
+					endpoint, _ := ovn.GetOVNDBCluster(instanceName).GetInternalEndpoint()
+					g.Expect(endpoint).ToNot(BeEmpty())
+				}, timeout, interval).Should(Succeed())
+			}
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+
+			ovnNorthdName = ovn.CreateOVNNorthd(namespace, GetDefaultOVNNorthdSpec())
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		})
+
+		It("reports that a cert is needed", func() {
+			Eventually(func(g Gomega) {
+				conditions := OVNNorthdConditionGetter(ovnNorthdName)
+				cond := conditions.Get(condition.TLSInputReadyCondition)
+				g.Expect(cond).ToNot(BeNil())
+				g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(cond.Message).To(ContainSubstring(
+					"ssl:ovsdbserver-sb-0." + namespace + ".svc.cluster.local:6642 uses TLS"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
 	When("OVNNorthd is created with TLS", func() {
 		var ovnNorthdName types.NamespacedName
 
@@ -144,13 +815,10 @@ var _ = Describe("OVNNorthd controller", func() {
 			th.ExpectConditionWithDetails(
 				ovnNorthdName,
 				ConditionGetterFunc(OVNNorthdConditionGetter),
-				condition.TLSInputReadyCondition,
+				condition.InputReadyCondition,
 				corev1.ConditionFalse,
-				condition.ErrorReason,
-				fmt.Sprintf(
-					"TLSInput error occured in TLS sources Secret %s/combined-ca-bundle not found",
-					namespace,
-				),
+				ovnv1.InputMissingReason,
+				"Waiting for the referenced objects to be created: Secret combined-ca-bundle",
 			)
 			th.ExpectCondition(
 				ovnNorthdName,
@@ -168,13 +836,10 @@ var _ = Describe("OVNNorthd controller", func() {
 			th.ExpectConditionWithDetails(
 				ovnNorthdName,
 				ConditionGetterFunc(OVNNorthdConditionGetter),
-				condition.TLSInputReadyCondition,
+				condition.InputReadyCondition,
 				corev1.ConditionFalse,
-				condition.ErrorReason,
-				fmt.Sprintf(
-					"TLSInput error occured in TLS sources Secret %s/%s not found",
-					namespace, OvnDbCertSecretName,
-				),
+				ovnv1.InputMissingReason,
+				"Waiting for the referenced objects to be created: Secret "+OvnDbCertSecretName,
 			)
 			th.ExpectCondition(
 				ovnNorthdName,
@@ -212,9 +877,7 @@ var _ = Describe("OVNNorthd controller", func() {
 
 			// check TLS volume mounts
 			th.AssertVolumeMountExists(CABundleSecretName, "tls-ca-bundle.pem", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovn-northd-tls-certs", "tls.key", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovn-northd-tls-certs", "tls.crt", svcC.VolumeMounts)
-			th.AssertVolumeMountExists("ovn-northd-tls-certs", "ca.crt", svcC.VolumeMounts)
+			th.AssertVolumeMountExists("ovn-northd-tls-certs", "", svcC.VolumeMounts)
 
 			// check cli args
 			Expect(svcC.Args).To(And(
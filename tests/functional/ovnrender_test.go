@@ -0,0 +1,135 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovnrender"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// renderLive - the objects rendered for the custom resource, the live
+// OVNDBClusters standing in for the cluster
+func renderLive(instance client.Object, opts ovnrender.Options) []client.Object {
+	dbs := &ovnv1.OVNDBClusterList{}
+	Expect(k8sClient.List(ctx, dbs, client.InNamespace(instance.GetNamespace()))).Should(Succeed())
+	objs := []client.Object{instance}
+	for i := range dbs.Items {
+		objs = append(objs, &dbs.Items[i])
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build()
+
+	r, err := ovnrender.Workloads(ctx, c, scheme.Scheme, instance, opts)
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(r.Notes).Should(BeEmpty())
+	return r.Objects
+}
+
+// renderedObject - the rendered object of the given type and name
+func renderedObject[T client.Object](objs []client.Object, name string) T {
+	for _, obj := range objs {
+		if o, ok := obj.(T); ok && obj.GetName() == name {
+			return o
+		}
+	}
+	Fail("no rendered object " + name)
+	var none T
+	return none
+}
+
+// ExpectSameTemplate - the rendered pod template matches the applied one.
+// The fields defaulted by the API server are left out.
+func ExpectSameTemplate(rendered corev1.PodTemplateSpec, applied corev1.PodTemplateSpec) {
+	Expect(rendered.Annotations).Should(Equal(applied.Annotations))
+	Expect(rendered.Labels).Should(Equal(applied.Labels))
+	Expect(rendered.Spec.Containers).Should(HaveLen(len(applied.Spec.Containers)))
+	for i, container := range rendered.Spec.Containers {
+		appliedContainer := applied.Spec.Containers[i]
+		Expect(container.Name).Should(Equal(appliedContainer.Name))
+		Expect(container.Image).Should(Equal(appliedContainer.Image))
+		Expect(container.Command).Should(Equal(appliedContainer.Command))
+		Expect(container.Args).Should(Equal(appliedContainer.Args))
+		Expect(envValues(container.Env)).Should(Equal(envValues(appliedContainer.Env)))
+	}
+}
+
+func envValues(envVars []corev1.EnvVar) map[string]string {
+	values := map[string]string{}
+	for _, envVar := range envVars {
+		values[envVar.Name] = envVar.Value
+	}
+	return values
+}
+
+var _ = Describe("render", func() {
+	var dbs []types.NamespacedName
+	BeforeEach(func() {
+		dbs = CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+		DeferCleanup(DeleteOVNDBClusters, dbs)
+	})
+
+	It("renders the DaemonSets the OVNController controller applies", func() {
+		spec := GetDefaultOVNControllerSpec()
+		spec.GatewayDrain = true
+		instance := CreateOVNController(namespace, spec)
+		DeferCleanup(th.DeleteInstance, instance)
+		ovnDaemonSetName := types.NamespacedName{Namespace: namespace, Name: "ovn-controller"}
+		ovsDaemonSetName := types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"}
+		Eventually(func(g Gomega) {
+			g.Expect(GetDaemonSet(ovnDaemonSetName).Spec.Template.Spec.Containers[0].Env).Should(
+				ContainElement(HaveField("Name", "NB_REMOTE")))
+		}, timeout, interval).Should(Succeed())
+		GetDaemonSet(ovsDaemonSetName)
+
+		objs := renderLive(GetOVNController(types.NamespacedName{
+			Namespace: instance.GetNamespace(), Name: instance.GetName()}), ovnrender.Options{})
+		ExpectSameTemplate(
+			renderedObject[*appsv1.DaemonSet](objs, ovnDaemonSetName.Name).Spec.Template,
+			GetDaemonSet(ovnDaemonSetName).Spec.Template)
+		ExpectSameTemplate(
+			renderedObject[*appsv1.DaemonSet](objs, ovsDaemonSetName.Name).Spec.Template,
+			GetDaemonSet(ovsDaemonSetName).Spec.Template)
+	})
+
+	It("renders the StatefulSet the OVNDBCluster controller applies", func() {
+		objs := renderLive(GetOVNDBCluster(dbs[0]), ovnrender.Options{RestrictedPodSecurity: true})
+		statefulSetName := types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb"}
+		ExpectSameTemplate(
+			renderedObject[*appsv1.StatefulSet](objs, statefulSetName.Name).Spec.Template,
+			th.GetStatefulSet(statefulSetName).Spec.Template)
+	})
+
+	It("renders the Deployment the OVNNorthd controller applies", func() {
+		ovnNorthdName := ovn.CreateOVNNorthd(namespace, GetDefaultOVNNorthdSpec())
+		DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+		deplName := types.NamespacedName{Namespace: namespace, Name: "ovn-northd"}
+		Eventually(func(g Gomega) {
+			g.Expect(th.GetDeployment(deplName).Spec.Template.Spec.Containers).ShouldNot(BeEmpty())
+		}, timeout, interval).Should(Succeed())
+
+		objs := renderLive(GetOVNNorthd(ovnNorthdName), ovnrender.Options{RestrictedPodSecurity: true})
+		ExpectSameTemplate(
+			renderedObject[*appsv1.Deployment](objs, deplName.Name).Spec.Template,
+			th.GetDeployment(deplName).Spec.Template)
+	})
+})
@@ -0,0 +1,215 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/controllers"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// janitorStaleRecords - SB DB records with a stale record of every kind
+const janitorStaleRecords = `Port_Binding,pb-vif-orphaned,port-gone,
+MAC_Binding,mb-stale,port-gone-too
+Chassis,chassis-edpm-1,edpm-compute-1
+Chassis_Private,chassis-edpm-1
+`
+
+// janitorExec - runs the commands of the janitor in the DB pods, listing
+// janitorStaleRecords and recording every command
+type janitorExec struct {
+	commands []string
+}
+
+func (e *janitorExec) exec(
+	_ context.Context,
+	_ *helper.Helper,
+	_ *rest.Config,
+	_ *corev1.Pod,
+	cmd []string,
+) (string, error) {
+	command := strings.Join(cmd, " ")
+	e.commands = append(e.commands, command)
+	if strings.Contains(command, "list Port_Binding") {
+		return janitorStaleRecords, nil
+	}
+	return "", nil
+}
+
+// deletes - the commands removing SB DB records
+func (e *janitorExec) deletes() []string {
+	deletes := []string{}
+	for _, command := range e.commands {
+		if strings.Contains(command, "destroy") || strings.Contains(command, "chassis-del") {
+			deletes = append(deletes, command)
+		}
+	}
+	return deletes
+}
+
+// NewSBJanitor - a janitor reconciled by the tests themselves, with the
+// commands run by fakeExec
+func NewSBJanitor(cleanup bool, fakeExec *janitorExec) (*controllers.OVNSBJanitorReconciler, *record.FakeRecorder) {
+	kclient, err := kubernetes.NewForConfig(cfg)
+	Expect(err).ToNot(HaveOccurred())
+	recorder := record.NewFakeRecorder(10)
+	return &controllers.OVNSBJanitorReconciler{
+		Client:   k8sClient,
+		Kclient:  kclient,
+		Scheme:   k8sClient.Scheme(),
+		Recorder: recorder,
+		Interval: time.Hour,
+		Cleanup:  cleanup,
+		Exec:     fakeExec.exec,
+	}, recorder
+}
+
+var _ = Describe("OVNSBJanitor controller", func() {
+
+	When("the SB OVNDBCluster is not ready", func() {
+		var sbName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.DBType = ovnv1.SBDBType
+			instance := CreateOVNDBCluster(namespace, spec)
+			sbName = types.NamespacedName{Name: instance.GetName(), Namespace: namespace}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("neither scans nor deletes anything", func() {
+			fakeExec := &janitorExec{}
+			janitor, recorder := NewSBJanitor(true, fakeExec)
+
+			result, err := janitor.Reconcile(ctx, ctrl.Request{NamespacedName: sbName})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Hour))
+			Expect(fakeExec.commands).To(BeEmpty())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	When("the SB OVNDBCluster is ready", func() {
+		var sbName types.NamespacedName
+
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			sbName = dbs[1]
+			th.ExpectCondition(
+				sbName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionTrue,
+			)
+
+			pod := GetPod(types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-sb-0"})
+			pod.Status.Phase = corev1.PodRunning
+			Expect(k8sClient.Status().Update(ctx, pod)).Should(Succeed())
+		})
+
+		It("only reports the stale records without the cleanup", func() {
+			fakeExec := &janitorExec{}
+			janitor, recorder := NewSBJanitor(false, fakeExec)
+
+			result, err := janitor.Reconcile(ctx, ctrl.Request{NamespacedName: sbName})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Hour))
+			Expect(fakeExec.commands).To(HaveLen(1))
+			Expect(fakeExec.deletes()).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(HavePrefix(
+				"Warning " + ovn_common.EventReasonStaleSBRecordsFound)))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("deletes the stale MAC bindings and chassis but leaves the port bindings to northd", func() {
+			fakeExec := &janitorExec{}
+			janitor, recorder := NewSBJanitor(true, fakeExec)
+
+			_, err := janitor.Reconcile(ctx, ctrl.Request{NamespacedName: sbName})
+
+			// the chassis may be registering, it is only remembered
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeExec.deletes()).To(HaveLen(1))
+			Expect(fakeExec.deletes()[0]).To(ContainSubstring("destroy MAC_Binding mb-stale"))
+			Expect(fakeExec.deletes()[0]).ToNot(ContainSubstring("chassis-del"))
+			Expect(fakeExec.deletes()[0]).ToNot(ContainSubstring("Port_Binding"))
+			Expect(GetOVNDBCluster(sbName).Status.StaleChassis).To(Equal([]string{"chassis-edpm-1"}))
+			Expect(recorder.Events).To(Receive(HavePrefix(
+				"Warning " + ovn_common.EventReasonStaleSBRecordsFound)))
+			Expect(recorder.Events).To(Receive(HavePrefix(
+				"Normal " + ovn_common.EventReasonStaleSBRecordsDeleted)))
+
+			fakeExec.commands = nil
+			_, err = janitor.Reconcile(ctx, ctrl.Request{NamespacedName: sbName})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeExec.deletes()).To(HaveLen(1))
+			Expect(fakeExec.deletes()[0]).To(ContainSubstring("chassis-del chassis-edpm-1"))
+		})
+
+		It("forgets the stale chassis no longer found half deleted", func() {
+			Eventually(func(g Gomega) {
+				instance := GetOVNDBCluster(sbName)
+				instance.Status.StaleChassis = []string{"chassis-registered"}
+				g.Expect(k8sClient.Status().Update(ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			fakeExec := &janitorExec{}
+			janitor, _ := NewSBJanitor(true, fakeExec)
+
+			_, err := janitor.Reconcile(ctx, ctrl.Request{NamespacedName: sbName})
+
+			Expect(err).ToNot(HaveOccurred())
+			for _, command := range fakeExec.deletes() {
+				Expect(command).ToNot(ContainSubstring("chassis-del"))
+			}
+			Expect(GetOVNDBCluster(sbName).Status.StaleChassis).To(Equal([]string{"chassis-edpm-1"}))
+		})
+
+		It("doesn't query a NB DB without a known RAFT leader", func() {
+			fakeExec := &janitorExec{}
+			janitor, _ := NewSBJanitor(true, fakeExec)
+
+			_, err := janitor.Reconcile(ctx, ctrl.Request{NamespacedName: sbName})
+
+			Expect(err).ToNot(HaveOccurred())
+			for _, command := range fakeExec.commands {
+				Expect(command).ToNot(ContainSubstring("ovn-nbctl"))
+			}
+		})
+	})
+})
@@ -0,0 +1,215 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SimulateTracePodTerminated - create a terminated pod of the trace Job
+// which wrote message to its termination log
+func SimulateTracePodTerminated(jobName types.NamespacedName, message string) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName.Name + "-pod",
+			Namespace: jobName.Namespace,
+			Labels:    map[string]string{"job-name": jobName.Name},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "trace", Image: "trace"}},
+		},
+	}
+	Expect(k8sClient.Create(ctx, pod)).Should(Succeed())
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: "trace",
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Message:    message,
+					FinishedAt: metav1.Now(),
+				},
+			},
+		},
+	}
+	Expect(k8sClient.Status().Update(ctx, pod)).Should(Succeed())
+}
+
+var _ = Describe("OVNTrace controller", func() {
+
+	When("A OVNTrace instance is created", func() {
+		var ovnTraceName types.NamespacedName
+		var traceJobName types.NamespacedName
+
+		BeforeEach(func() {
+			ovnTraceName = ovn.CreateOVNTrace(namespace, GetDefaultOVNTraceSpec())
+			DeferCleanup(ovn.DeleteOVNTrace, ovnTraceName)
+			traceJobName = types.NamespacedName{Namespace: namespace, Name: ovnTraceName.Name + "-ovn-trace"}
+		})
+
+		It("should have the Spec fields initialized", func() {
+			Expect(GetOVNTrace(ovnTraceName).Spec.Protocol).Should(Equal("icmp"))
+		})
+
+		It("waits for the SB DB", func() {
+			th.ExpectCondition(
+				ovnTraceName,
+				ConditionGetterFunc(OVNTraceConditionGetter),
+				condition.InputReadyCondition,
+				corev1.ConditionFalse,
+			)
+		})
+
+		When("OVNDBCluster instances are available", func() {
+			BeforeEach(func() {
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+			})
+
+			It("runs ovn-trace against the SB DB", func() {
+				th.ExpectConditionWithDetails(
+					ovnTraceName,
+					ConditionGetterFunc(OVNTraceConditionGetter),
+					ovnv1.OVNTraceReadyCondition,
+					corev1.ConditionFalse,
+					condition.RequestedReason,
+					"Tracing the packet from logical port vm1-port",
+				)
+
+				container := th.GetJob(traceJobName).Spec.Template.Spec.Containers[0]
+				Expect(container.Image).Should(Equal(ovnv1.OVNControllerContainerImage))
+				Expect(container.Env).Should(ContainElements(
+					corev1.EnvVar{Name: "SB_REMOTE", Value: "tcp:ovsdbserver-sb-0." + namespace + ".svc.cluster.local:6642"},
+					corev1.EnvVar{Name: "SOURCE_PORT", Value: "vm1-port"},
+					corev1.EnvVar{Name: "DESTINATION_PORT", Value: "vm2-port"},
+					corev1.EnvVar{Name: "PROTOCOL", Value: "icmp"},
+				))
+			})
+
+			It("stores the trace in the result ConfigMap", func() {
+				SimulateTracePodTerminated(traceJobName,
+					"chassis=worker-0\nflow=dl_src=fa:16:3e:00:00:01,dl_dst=fa:16:3e:00:00:02\n\n"+
+						"ingress(dp=\"net1\", inport=\"vm1-port\") {\n    output;\n};")
+				th.SimulateJobSuccess(traceJobName)
+
+				th.ExpectCondition(
+					ovnTraceName,
+					ConditionGetterFunc(OVNTraceConditionGetter),
+					condition.ReadyCondition,
+					corev1.ConditionTrue,
+				)
+				trace := GetOVNTrace(ovnTraceName)
+				Expect(trace.Status.Chassis).Should(Equal("worker-0"))
+				Expect(trace.Status.Flow).Should(Equal("dl_src=fa:16:3e:00:00:01,dl_dst=fa:16:3e:00:00:02"))
+				Expect(trace.Status.ResultConfigMap).Should(Equal(ovnTraceName.Name + "-result"))
+
+				cm := th.GetConfigMap(types.NamespacedName{Namespace: namespace, Name: trace.Status.ResultConfigMap})
+				Expect(cm.Data).Should(HaveKeyWithValue(ovnv1.OVNTraceResultKey,
+					"ingress(dp=\"net1\", inport=\"vm1-port\") {\n    output;\n};"))
+			})
+
+			It("reports the error of ovn-trace", func() {
+				SimulateTracePodTerminated(traceJobName, "logical port vm1-port not found")
+				th.SimulateJobFailure(traceJobName)
+
+				th.ExpectConditionWithDetails(
+					ovnTraceName,
+					ConditionGetterFunc(OVNTraceConditionGetter),
+					ovnv1.OVNTraceReadyCondition,
+					corev1.ConditionFalse,
+					condition.ErrorReason,
+					"ovn-trace failed: logical port vm1-port not found",
+				)
+			})
+		})
+	})
+
+	When("A OVNTrace instance is created with ofproto/trace", func() {
+		var ovnTraceName types.NamespacedName
+		var traceJobName types.NamespacedName
+		var ofprotoJobName types.NamespacedName
+
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			ovnController := CreateOVNController(namespace, GetDefaultOVNControllerSpec())
+			DeferCleanup(th.DeleteInstance, ovnController)
+
+			spec := GetDefaultOVNTraceSpec()
+			spec.OFProtoTrace = true
+			ovnTraceName = ovn.CreateOVNTrace(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNTrace, ovnTraceName)
+			traceJobName = types.NamespacedName{Namespace: namespace, Name: ovnTraceName.Name + "-ovn-trace"}
+			ofprotoJobName = types.NamespacedName{Namespace: namespace, Name: ovnTraceName.Name + "-ofproto-trace"}
+		})
+
+		It("traces the packet on the chassis of the source port", func() {
+			SimulateTracePodTerminated(traceJobName,
+				"chassis=worker-0\nflow=dl_src=fa:16:3e:00:00:01,dl_dst=fa:16:3e:00:00:02\n\noutput;")
+			th.SimulateJobSuccess(traceJobName)
+
+			th.ExpectConditionWithDetails(
+				ovnTraceName,
+				ConditionGetterFunc(OVNTraceConditionGetter),
+				ovnv1.OVNOFProtoTraceReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				"Tracing the packet on the chassis of node worker-0",
+			)
+			podSpec := th.GetJob(ofprotoJobName).Spec.Template.Spec
+			Expect(podSpec.NodeName).Should(Equal("worker-0"))
+			Expect(podSpec.Containers[0].Env).Should(ContainElements(
+				corev1.EnvVar{Name: "SOURCE_PORT", Value: "vm1-port"},
+				corev1.EnvVar{Name: "FLOW", Value: "dl_src=fa:16:3e:00:00:01,dl_dst=fa:16:3e:00:00:02"},
+			))
+
+			SimulateTracePodTerminated(ofprotoJobName, "Final flow: unchanged\nDatapath actions: 3")
+			th.SimulateJobSuccess(ofprotoJobName)
+
+			th.ExpectCondition(
+				ovnTraceName,
+				ConditionGetterFunc(OVNTraceConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionTrue,
+			)
+			Expect(GetOVNTrace(ovnTraceName).Status.DatapathActions).Should(Equal("3"))
+		})
+
+		It("reports an unbound source port", func() {
+			SimulateTracePodTerminated(traceJobName, "chassis=\nflow=dl_src=fa:16:3e:00:00:01\n\noutput;")
+			th.SimulateJobSuccess(traceJobName)
+
+			th.ExpectConditionWithDetails(
+				ovnTraceName,
+				ConditionGetterFunc(OVNTraceConditionGetter),
+				ovnv1.OVNOFProtoTraceReadyCondition,
+				corev1.ConditionFalse,
+				condition.ErrorReason,
+				"Logical port vm1-port is not bound to a chassis",
+			)
+		})
+	})
+})
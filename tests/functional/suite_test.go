@@ -50,6 +50,7 @@ import (
 
 	test "github.com/openstack-k8s-operators/lib-common/modules/test"
 	ovn_test "github.com/openstack-k8s-operators/ovn-operator/api/test/helpers"
+	ovnapiv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/ovn-operator/controllers"
 
@@ -116,6 +117,13 @@ var _ = BeforeSuite(func() {
 		},
 	}
 
+	// NOTE: the API versions need to be known before the CRDs are installed,
+	// envtest only enables the conversion webhook of convertible types
+	err = ovnv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+	err = ovnapiv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	// cfg is defined in this file globally.
 	cfg, err = testEnv.Start()
 	Expect(err).NotTo(HaveOccurred())
@@ -125,8 +133,6 @@ var _ = BeforeSuite(func() {
 	// Keep this in synch with OVNNorthdReconciler.SetupWithManager,
 	// otherwise the reconciler loop will silently not start
 	// in the test env.
-	err = ovnv1.AddToScheme(scheme.Scheme)
-	Expect(err).NotTo(HaveOccurred())
 	err = batchv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 	err = corev1.AddToScheme(scheme.Scheme)
@@ -170,23 +176,82 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred(), "failed to create kclient")
 
 	err = (&controllers.OVNNorthdReconciler{
-		Client:  k8sManager.GetClient(),
-		Scheme:  k8sManager.GetScheme(),
-		Kclient: kclient,
+		Client:                k8sManager.GetClient(),
+		Scheme:                k8sManager.GetScheme(),
+		Kclient:               kclient,
+		RestConfig:            cfg,
+		RestrictedPodSecurity: true,
+		Recorder:              k8sManager.GetEventRecorderFor("ovnnorthd-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&controllers.OVNDBClusterReconciler{
-		Client:  k8sManager.GetClient(),
-		Scheme:  k8sManager.GetScheme(),
-		Kclient: kclient,
+		Client:                k8sManager.GetClient(),
+		Scheme:                k8sManager.GetScheme(),
+		Kclient:               kclient,
+		RestConfig:            cfg,
+		RestrictedPodSecurity: true,
+		Recorder:              k8sManager.GetEventRecorderFor("ovndbcluster-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.OVNInterconnectReconciler{
+		Client:                k8sManager.GetClient(),
+		Scheme:                k8sManager.GetScheme(),
+		Kclient:               kclient,
+		RestConfig:            cfg,
+		RestrictedPodSecurity: true,
+		Recorder:              k8sManager.GetEventRecorderFor("ovninterconnect-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.OVNTraceReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Kclient:  kclient,
+		Recorder: k8sManager.GetEventRecorderFor("ovntrace-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.OVNCommandReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Kclient:  kclient,
+		Recorder: k8sManager.GetEventRecorderFor("ovncommand-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.OVNDiagnosticsReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Kclient:  kclient,
+		Recorder: k8sManager.GetEventRecorderFor("ovndiagnostics-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.OVNCaptureReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Kclient:  kclient,
+		Recorder: k8sManager.GetEventRecorderFor("ovncapture-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.OVNGlobalConfigReconciler{
+		Client:     k8sManager.GetClient(),
+		Scheme:     k8sManager.GetScheme(),
+		Kclient:    kclient,
+		RestConfig: cfg,
+		Recorder:   k8sManager.GetEventRecorderFor("ovnglobalconfig-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&controllers.OVNControllerReconciler{
-		Client:  k8sManager.GetClient(),
-		Scheme:  k8sManager.GetScheme(),
-		Kclient: kclient,
+		Client:     k8sManager.GetClient(),
+		Scheme:     k8sManager.GetScheme(),
+		Kclient:    kclient,
+		RestConfig: cfg,
+		Recorder:   k8sManager.GetEventRecorderFor("ovncontroller-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -202,6 +267,15 @@ var _ = BeforeSuite(func() {
 	err = (&ovnv1.OVNDBCluster{}).SetupWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&ovnv1.OVNInterconnect{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&ovnv1.OVNCommand{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&ovnv1.OVNGlobalConfig{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = k8sManager.Start(ctx)